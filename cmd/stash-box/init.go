@@ -75,6 +75,12 @@ Please ensure this database is created and available, or change the connection s
 		fmt.Printf("Autocert is enabled, but the following required settings are missing: %s\n", strings.Join(missingAutocert, ", "))
 		os.Exit(1)
 	}
+
+	missingOIDC := config.GetMissingOIDCSettings()
+	if len(missingOIDC) > 0 {
+		fmt.Printf("OIDC is enabled, but the following required settings are missing: %s\n", strings.Join(missingOIDC, ", "))
+		os.Exit(1)
+	}
 }
 
 func parseConfigFilePath(configFilePath string) (string, string) {
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/stashapp/stash-box/frontend"
 	"github.com/stashapp/stash-box/internal/api"
+	"github.com/stashapp/stash-box/internal/auth"
 	"github.com/stashapp/stash-box/internal/config"
 	"github.com/stashapp/stash-box/internal/cron"
 	"github.com/stashapp/stash-box/internal/database"
@@ -35,8 +36,6 @@ func main() {
 	//nolint:errcheck
 	defer cleanup(context.Background())
 
-	api.InitializeSession()
-
 	// Create email manager
 	emailMgr := email.NewManager()
 
@@ -44,6 +43,7 @@ func main() {
 	fac := service.NewFactory(db, emailMgr)
 	fac.User().CreateSystemUsers(context.Background())
 	bootstrapAdminFromEnv(context.Background(), fac)
+	api.InitializeSession(*fac)
 	api.Start(*fac, frontend.FS)
 	cron.Init(*fac)
 
@@ -86,6 +86,8 @@ func bootstrapAdminFromEnv(ctx context.Context, fac *service.Factory) {
 		emailAddr = username + "@bootstrap.local"
 	}
 
+	// the bootstrap runs with admin rights, which it grants
+	ctx = context.WithValue(ctx, auth.ContextRoles, []models.RoleEnum{models.RoleEnumAdmin})
 	if _, err := userSvc.Create(ctx, models.UserCreateInput{
 		Name:     username,
 		Password: password,
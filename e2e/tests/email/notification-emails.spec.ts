@@ -0,0 +1,149 @@
+// Email delivery of notifications. The e2e config sets
+// notification_email_interval to 2s, so pending notifications reach the
+// mock-smtp server within a few seconds of being created. Each test seeds its
+// own user so that parallel runs don't pick up each other's mail.
+
+import { request } from "@playwright/test";
+
+import { test, expect, TEST_PASSWORD } from "../../support/fixtures";
+import {
+  adminApi,
+  gql,
+  submitStudioCreateEdit,
+  uniq,
+} from "../../support/helpers/seed";
+import { graphqlAs } from "../../support/helpers/graphql";
+import { waitForEmailTo, extractLink } from "../../support/helpers/email";
+
+const UNSUBSCRIBE_LINK =
+  /https?:\/\/[^\s"<]*\/unsubscribe\/[0-9a-f-]+\?token=[0-9a-f]+/i;
+
+// Creates an editor with its own address and sets how COMMENT_OWN_EDIT
+// notifications are emailed to them.
+async function createSubscriber(frequency: string) {
+  const username = uniq("mail").toLowerCase().replace(/-/g, "_");
+  const email = `${username}-${Date.now()}@example.local`;
+
+  const admin = await adminApi();
+  await gql(
+    admin,
+    `mutation($input: UserCreateInput!) {
+       userCreate(input: $input) { id }
+     }`,
+    {
+      input: {
+        name: username,
+        password: TEST_PASSWORD,
+        email,
+        roles: ["READ", "EDIT"],
+      },
+    },
+  );
+  await admin.dispose();
+
+  const api = await graphqlAs(username);
+  await gql(
+    api,
+    `mutation($p: [NotificationEmailPreferenceInput!]!) {
+       updateNotificationEmailPreferences(preferences: $p)
+     }`,
+    { p: [{ type: "COMMENT_OWN_EDIT", frequency }] },
+  );
+
+  return { api, email };
+}
+
+async function commentOnEdit(editId: string) {
+  const admin = await adminApi();
+  await gql(
+    admin,
+    `mutation($input: EditCommentInput!) {
+       editComment(input: $input) { id }
+     }`,
+    { input: { id: editId, comment: `e2e ${uniq("comment")}` } },
+  );
+  await admin.dispose();
+}
+
+const emailPreferences = async (
+  api: import("@playwright/test").APIRequestContext,
+) =>
+  (
+    await gql<{
+      me: {
+        notification_email_preferences: { type: string; frequency: string }[];
+      };
+    }>(
+      api,
+      `query { me { notification_email_preferences { type frequency } } }`,
+    )
+  ).me.notification_email_preferences;
+
+test("immediate notification email links to the edit and unsubscribes", async () => {
+  const { api, email } = await createSubscriber("IMMEDIATE");
+  const prefs = await emailPreferences(api);
+  expect(prefs.find((p) => p.type === "COMMENT_OWN_EDIT")?.frequency).toBe(
+    "IMMEDIATE",
+  );
+  expect(prefs.find((p) => p.type === "DOWNVOTE_OWN_EDIT")?.frequency).toBe(
+    "OFF",
+  );
+
+  const startedAt = Date.now();
+  const { id: editId } = await submitStudioCreateEdit(api, uniq("Studio"));
+  await commentOnEdit(editId);
+
+  const mail = await waitForEmailTo(email, {
+    minReceivedAt: startedAt,
+    timeoutMs: 30_000,
+  });
+  expect(mail.subject).toMatch(/1 new notification/);
+  expect(mail.text).toContain("Comments on your edits");
+  expect(mail.text).toContain(`/edits/${editId}`);
+
+  const link = extractLink(mail, UNSUBSCRIBE_LINK);
+  expect(link, `no unsubscribe link in mail body:\n${mail.text}`).toBeTruthy();
+
+  const anonymous = await request.newContext();
+
+  // a tampered token is rejected
+  const forged = await anonymous.post(link!.replace(/token=.*/, "token=00"));
+  expect(forged.status()).toBe(403);
+
+  // visiting the link only asks for confirmation
+  const confirm = await anonymous.get(link!);
+  expect(confirm.ok()).toBe(true);
+  expect(await confirm.text()).toContain('<form method="post">');
+  expect(
+    (await emailPreferences(api)).find((p) => p.type === "COMMENT_OWN_EDIT")
+      ?.frequency,
+  ).toBe("IMMEDIATE");
+
+  const unsubscribe = await anonymous.post(link!);
+  expect(unsubscribe.ok()).toBe(true);
+  await anonymous.dispose();
+
+  for (const pref of await emailPreferences(api)) {
+    expect(pref.frequency).toBe("OFF");
+  }
+
+  await api.dispose();
+});
+
+test("daily digest is emailed for pending notifications", async () => {
+  const { api, email } = await createSubscriber("DAILY");
+
+  const startedAt = Date.now();
+  const { id: editId } = await submitStudioCreateEdit(api, uniq("Studio"));
+  await commentOnEdit(editId);
+
+  const mail = await waitForEmailTo(email, {
+    minReceivedAt: startedAt,
+    timeoutMs: 30_000,
+  });
+  expect(mail.subject).toMatch(/Your daily .* digest/);
+  expect(mail.text).toContain(`/edits/${editId}`);
+  expect(extractLink(mail, UNSUBSCRIBE_LINK)).toBeTruthy();
+
+  await api.dispose();
+});
@@ -0,0 +1,176 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/edit"
+	"github.com/stretchr/testify/assert"
+)
+
+type bulkEditTestRunner struct {
+	testRunner
+}
+
+func createBulkEditTestRunner(t *testing.T) *bulkEditTestRunner {
+	return &bulkEditTestRunner{
+		testRunner: *asModerate(t),
+	}
+}
+
+func (s *bulkEditTestRunner) createStudioScenes(studioID uuid.UUID, tagIDs []uuid.UUID, count int) []uuid.UUID {
+	var ids []uuid.UUID
+	for range count {
+		title := s.generateSceneName()
+		scene, err := asAdmin(s.t).createTestScene(&models.SceneCreateInput{
+			Title:    &title,
+			Date:     "2020-03-02",
+			StudioID: &studioID,
+			TagIds:   tagIDs,
+			Fingerprints: []models.FingerprintEditInput{
+				s.generateSceneFingerprint(nil),
+			},
+		})
+		assert.NoError(s.t, err)
+		ids = append(ids, scene.UUID())
+	}
+	return ids
+}
+
+func (s *bulkEditTestRunner) testBulkSceneEdit() {
+	studio, err := asAdmin(s.t).createTestStudio(nil)
+	assert.NoError(s.t, err)
+	existingTag, err := asAdmin(s.t).createTestTag(nil)
+	assert.NoError(s.t, err)
+	newTag, err := asAdmin(s.t).createTestTag(nil)
+	assert.NoError(s.t, err)
+
+	// one scene already has the tag and is not changed
+	sceneIDs := s.createStudioScenes(studio.UUID(), nil, 2)
+	s.createStudioScenes(studio.UUID(), []uuid.UUID{newTag.UUID()}, 1)
+
+	input := models.BulkSceneEditInput{
+		Filter: &models.SceneQueryInput{
+			Studios: &models.MultiIDCriterionInput{
+				Value:    []uuid.UUID{studio.UUID()},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		},
+		Changes: &models.BulkSceneChangesInput{
+			AddedTags: []uuid.UUID{newTag.UUID()},
+		},
+	}
+
+	preview, err := s.resolver.Query().BulkSceneEditPreview(s.ctx, input)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, preview, len(sceneIDs))
+
+	group, err := s.resolver.Mutation().BulkSceneEdit(s.ctx, input)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, models.TargetTypeEnumScene, group.TargetType)
+
+	edits, err := s.resolver.EditGroup().Edits(s.ctx, group)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, edits, len(sceneIDs))
+
+	// edits of a bulk edit can't be changed individually
+	_, err = s.resolver.Mutation().SceneEditUpdate(s.ctx, edits[0].ID, models.SceneEditInput{
+		Edit: &models.EditInput{Operation: models.OperationEnumModify, ID: &sceneIDs[0]},
+		Details: &models.SceneEditDetailsInput{
+			TagIds: []uuid.UUID{existingTag.UUID()},
+		},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrUpdateGroupedEdit)
+
+	// approving one edit applies the whole group
+	_, err = s.approveEdit(edits[0].ID)
+	assert.NoError(s.t, err)
+
+	for _, e := range edits {
+		applied, err := s.resolver.Query().FindEdit(s.ctx, e.ID)
+		assert.NoError(s.t, err)
+		s.verifyEditStatus(models.VoteStatusEnumImmediateAccepted.String(), applied)
+
+		editGroup, err := s.resolver.Edit().Group(s.ctx, applied)
+		assert.NoError(s.t, err)
+		if assert.NotNil(s.t, editGroup) {
+			assert.Equal(s.t, group.ID, editGroup.ID)
+		}
+	}
+
+	for _, id := range sceneIDs {
+		scene, err := s.client.findScene(id)
+		assert.NoError(s.t, err)
+		if assert.Len(s.t, scene.Tags, 1) {
+			assert.Equal(s.t, newTag.ID, scene.Tags[0].ID)
+		}
+	}
+
+	// every matching scene now has the tag
+	_, err = s.resolver.Mutation().BulkSceneEdit(s.ctx, input)
+	assert.ErrorIs(s.t, err, edit.ErrNoChanges)
+}
+
+func (s *bulkEditTestRunner) testBulkEditValidation() {
+	tag, err := asAdmin(s.t).createTestTag(nil)
+	assert.NoError(s.t, err)
+
+	_, err = s.resolver.Query().BulkSceneEditPreview(s.ctx, models.BulkSceneEditInput{
+		Filter:  &models.SceneQueryInput{},
+		Changes: &models.BulkSceneChangesInput{},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrEmptyBulkChanges)
+
+	_, err = s.resolver.Query().BulkSceneEditPreview(s.ctx, models.BulkSceneEditInput{
+		Filter: &models.SceneQueryInput{},
+		Changes: &models.BulkSceneChangesInput{
+			AddedTags:   []uuid.UUID{tag.UUID()},
+			RemovedTags: []uuid.UUID{tag.UUID()},
+		},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrBulkAddedAndRemoved)
+
+	// a broad filter is rejected without loading every match
+	prevLimit := config.C.BulkEditLimit
+	config.C.BulkEditLimit = 1
+	defer func() { config.C.BulkEditLimit = prevLimit }()
+
+	studio, err := asAdmin(s.t).createTestStudio(nil)
+	assert.NoError(s.t, err)
+	s.createStudioScenes(studio.UUID(), nil, 2)
+	filter := &models.SceneQueryInput{
+		Studios: &models.MultiIDCriterionInput{
+			Value:    []uuid.UUID{studio.UUID()},
+			Modifier: models.CriterionModifierIncludes,
+		},
+	}
+
+	_, err = s.resolver.Query().BulkSceneEditPreview(s.ctx, models.BulkSceneEditInput{
+		Filter:  filter,
+		Changes: &models.BulkSceneChangesInput{AddedTags: []uuid.UUID{tag.UUID()}},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrBulkEditLimit)
+
+	// repeated tags are only added once
+	config.C.BulkEditLimit = 2
+	preview, err := s.resolver.Query().BulkSceneEditPreview(s.ctx, models.BulkSceneEditInput{
+		Filter:  filter,
+		Changes: &models.BulkSceneChangesInput{AddedTags: []uuid.UUID{tag.UUID(), tag.UUID()}},
+	})
+	assert.NoError(s.t, err)
+	assert.Len(s.t, preview, 2)
+}
+
+func TestBulkSceneEdit(t *testing.T) {
+	pt := createBulkEditTestRunner(t)
+	pt.testBulkSceneEdit()
+}
+
+func TestBulkEditValidation(t *testing.T) {
+	pt := createBulkEditTestRunner(t)
+	pt.testBulkEditValidation()
+}
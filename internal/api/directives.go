@@ -16,8 +16,20 @@ func IsUserOwnerDirective(ctx context.Context, obj interface{}, next graphql.Res
 	return next(ctx)
 }
 
-func HasRoleDirective(ctx context.Context, obj interface{}, next graphql.Resolver, role models.RoleEnum) (interface{}, error) {
-	if err := auth.ValidateRole(ctx, role); err != nil {
+func HasPermissionDirective(ctx context.Context, obj interface{}, next graphql.Resolver, permission models.PermissionEnum) (interface{}, error) {
+	// users suspended to read-only access, and users that have yet to set up
+	// required two-factor authentication, keep read access only. Other
+	// suspensions withhold the permissions they cover.
+	if permission != models.PermissionEnumRead {
+		if err := auth.ValidateNotSuspended(ctx, permission); err != nil {
+			return nil, err
+		}
+		if err := auth.ValidateTwoFactorSetup(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := auth.ValidatePermission(ctx, permission); err != nil {
 		return nil, err
 	}
 
@@ -151,7 +151,7 @@ func (s *editCommentModerationTestRunner) testNonModeratorCannotModerate() {
 	editor := asEdit(s.t)
 	_, replyID := s.createEditWithComments(editor, "submission", "a reply")
 
-	// Updating via the client enforces the @hasRole(MODERATE) directive
+	// Updating via the client enforces the @hasPermission(MODERATE_COMMENTS) directive
 	_, err := editor.client.updateEditComment(models.UpdateEditCommentInput{
 		ID:      replyID,
 		Comment: "sneaky edit",
@@ -0,0 +1,158 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditCommentMentions(t *testing.T) {
+	s := asEdit(t)
+
+	edit, err := s.createTestTagEdit(models.OperationEnumCreate, nil, nil)
+	assert.NoError(t, err)
+
+	mentioned, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(t, err)
+
+	_, err = s.resolver.Mutation().EditComment(s.ctx, models.EditCommentInput{
+		ID:      edit.ID,
+		Comment: fmt.Sprintf("what do you think @%s? cc @nobody-by-this-name", mentioned.Name),
+	})
+	assert.NoError(t, err)
+
+	comments, err := s.resolver.Edit().Comments(s.ctx, edit)
+	assert.NoError(t, err)
+	stored := findCommentContaining(comments, "what do you think")
+	if !assert.NotNil(t, stored) {
+		return
+	}
+
+	// Mentions of existing users become links, unknown names stay bare
+	assert.Contains(t, stored.Text, fmt.Sprintf("[@%s](/users/%s)", mentioned.Name, mentioned.Name))
+	assert.Contains(t, stored.Text, "cc @nobody-by-this-name")
+
+	users, err := s.resolver.EditComment().Mentions(s.ctx, stored)
+	assert.NoError(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, mentioned.ID, users[0].ID)
+	}
+
+	// The mentioned user is subscribed to mentions by default
+	notifications := dbtest.Factory().Notification()
+	notifications.OnEditComment(s.ctx, stored)
+
+	mentionType := models.NotificationEnumMentionedInComment
+	found, err := notifications.GetNotifications(s.ctx, mentioned.ID, true, 1, 25, &mentionType)
+	assert.NoError(t, err)
+	if assert.Len(t, found, 1) {
+		assert.Equal(t, stored.ID, found[0].TargetID)
+	}
+}
+
+func TestEditCommentReplies(t *testing.T) {
+	s := asEdit(t)
+
+	edit, err := s.createTestTagEdit(models.OperationEnumCreate, nil, nil)
+	assert.NoError(t, err)
+	other, err := s.createTestTagEdit(models.OperationEnumCreate, nil, nil)
+	assert.NoError(t, err)
+
+	_, err = s.resolver.Mutation().EditComment(s.ctx, models.EditCommentInput{
+		ID:      edit.ID,
+		Comment: "a question",
+	})
+	assert.NoError(t, err)
+
+	comments, err := s.resolver.Edit().Comments(s.ctx, edit)
+	assert.NoError(t, err)
+	parent := findCommentContaining(comments, "a question")
+	if !assert.NotNil(t, parent) {
+		return
+	}
+
+	_, err = s.resolver.Mutation().EditComment(s.ctx, models.EditCommentInput{
+		ID:       edit.ID,
+		Comment:  "a reply",
+		ParentID: &parent.ID,
+	})
+	assert.NoError(t, err)
+
+	comments, err = s.resolver.Edit().Comments(s.ctx, edit)
+	assert.NoError(t, err)
+	reply := findCommentContaining(comments, "a reply")
+	if assert.NotNil(t, reply) {
+		found, err := s.resolver.EditComment().Parent(s.ctx, reply)
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, parent.ID, found.ID)
+		}
+	}
+
+	// Replies must be to a comment on the same edit
+	_, err = s.resolver.Mutation().EditComment(s.ctx, models.EditCommentInput{
+		ID:       other.ID,
+		Comment:  "a misplaced reply",
+		ParentID: &parent.ID,
+	})
+	assert.Error(t, err)
+}
+
+func TestEditCommentReactions(t *testing.T) {
+	s := asEdit(t)
+
+	edit, err := s.createTestTagEdit(models.OperationEnumCreate, nil, nil)
+	assert.NoError(t, err)
+	_, err = s.resolver.Mutation().EditComment(s.ctx, models.EditCommentInput{
+		ID:      edit.ID,
+		Comment: "looks good",
+	})
+	assert.NoError(t, err)
+
+	comments, err := s.resolver.Edit().Comments(s.ctx, edit)
+	assert.NoError(t, err)
+	stored := findCommentContaining(comments, "looks good")
+	if !assert.NotNil(t, stored) {
+		return
+	}
+	commentID := stored.ID
+
+	other, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit})
+	assert.NoError(t, err)
+	otherCtx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(other))
+
+	thumbsUp := models.EditCommentReactionInput{ID: commentID, Reaction: models.EditCommentReactionEnumThumbsUp}
+	for _, ctx := range []context.Context{s.ctx, otherCtx, otherCtx} {
+		_, err = s.resolver.Mutation().AddEditCommentReaction(ctx, thumbsUp)
+		assert.NoError(t, err)
+	}
+	comment, err := s.resolver.Mutation().AddEditCommentReaction(otherCtx, models.EditCommentReactionInput{
+		ID:       commentID,
+		Reaction: models.EditCommentReactionEnumHeart,
+	})
+	assert.NoError(t, err)
+
+	reactions, err := s.resolver.EditComment().Reactions(s.ctx, comment)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.EditCommentReaction{
+		{Reaction: models.EditCommentReactionEnumThumbsUp, Count: 2, Reacted: true},
+		{Reaction: models.EditCommentReactionEnumHeart, Count: 1, Reacted: false},
+	}, reactions)
+
+	_, err = s.resolver.Mutation().RemoveEditCommentReaction(s.ctx, thumbsUp)
+	assert.NoError(t, err)
+
+	reactions, err = s.resolver.EditComment().Reactions(s.ctx, comment)
+	assert.NoError(t, err)
+	if assert.Len(t, reactions, 2) {
+		assert.Equal(t, 1, reactions[0].Count)
+		assert.False(t, reactions[0].Reacted)
+	}
+}
@@ -0,0 +1,106 @@
+//go:build integration
+
+package api_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type editConflictTestRunner struct {
+	testRunner
+}
+
+func createEditConflictTestRunner(t *testing.T) *editConflictTestRunner {
+	return &editConflictTestRunner{
+		testRunner: *asModerate(t),
+	}
+}
+
+func (s *editConflictTestRunner) modifyPerformer(id uuid.UUID, details models.PerformerEditDetailsInput) *models.Edit {
+	s.t.Helper()
+
+	edit, err := s.createTestPerformerEdit(models.OperationEnumModify, &details, &models.EditInput{
+		Operation: models.OperationEnumModify,
+		ID:        &id,
+	}, nil)
+	assert.NoError(s.t, err)
+	return edit
+}
+
+func (s *editConflictTestRunner) conflictIDs(edit *models.Edit) []uuid.UUID {
+	s.t.Helper()
+
+	edits, err := s.resolver.Edit().ConflictingEdits(s.ctx, edit)
+	assert.NoError(s.t, err)
+
+	var ids []uuid.UUID
+	for _, e := range edits {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func (s *editConflictTestRunner) testConflictingEdits() {
+	performer, err := asAdmin(s.t).createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	performerID := performer.UUID()
+
+	first := s.generatePerformerName()
+	second := s.generatePerformerName()
+	rename := s.modifyPerformer(performerID, models.PerformerEditDetailsInput{Name: &first})
+	otherRename := s.modifyPerformer(performerID, models.PerformerEditDetailsInput{Name: &second})
+	aliases := s.modifyPerformer(performerID, models.PerformerEditDetailsInput{Name: &performer.Name, Aliases: []string{"alias"}})
+
+	assert.Equal(s.t, []uuid.UUID{otherRename.ID}, s.conflictIDs(rename))
+	assert.Equal(s.t, []uuid.UUID{rename.ID}, s.conflictIDs(otherRename))
+	assert.Empty(s.t, s.conflictIDs(aliases))
+
+	// updating an edit to change other fields removes the conflict
+	disambiguation := "updated"
+	updated, err := s.resolver.Mutation().PerformerEditUpdate(s.ctx, otherRename.ID, models.PerformerEditInput{
+		Edit: &models.EditInput{
+			Operation: models.OperationEnumModify,
+			ID:        &performerID,
+		},
+		Details: &models.PerformerEditDetailsInput{Name: &performer.Name, Disambiguation: &disambiguation},
+	})
+	assert.NoError(s.t, err)
+	assert.Empty(s.t, s.conflictIDs(updated))
+	assert.Empty(s.t, s.conflictIDs(rename))
+
+	// a merge conflicts with every edit of the merged performers
+	target, err := asAdmin(s.t).createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	targetID := target.UUID()
+	merge, err := s.createTestPerformerEdit(models.OperationEnumMerge, nil, &models.EditInput{
+		Operation:      models.OperationEnumMerge,
+		ID:             &targetID,
+		MergeSourceIds: []uuid.UUID{performerID},
+	}, nil)
+	assert.NoError(s.t, err)
+	assert.ElementsMatch(s.t, []uuid.UUID{rename.ID, updated.ID, aliases.ID}, s.conflictIDs(merge))
+
+	// voters on pending conflicting edits are warned once one is applied
+	_, err = s.approveEdit(rename.ID)
+	assert.NoError(s.t, err)
+
+	comments, err := s.resolver.Edit().Comments(s.ctx, merge)
+	assert.NoError(s.t, err)
+	warned := false
+	for _, comment := range comments {
+		if strings.Contains(comment.Text, rename.ID.String()) {
+			warned = true
+		}
+	}
+	assert.True(s.t, warned, "conflicting edit was not warned")
+}
+
+func TestConflictingEdits(t *testing.T) {
+	pt := createEditConflictTestRunner(t)
+	pt.testConflictingEdits()
+}
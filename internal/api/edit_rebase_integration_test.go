@@ -0,0 +1,140 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/edit"
+	"github.com/stretchr/testify/assert"
+)
+
+type editRebaseTestRunner struct {
+	testRunner
+}
+
+func createEditRebaseTestRunner(t *testing.T) *editRebaseTestRunner {
+	return &editRebaseTestRunner{
+		testRunner: *asModerate(t),
+	}
+}
+
+func (s *editRebaseTestRunner) testRebaseEdit() {
+	performer, err := asAdmin(s.t).createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	performerID := performer.UUID()
+
+	editInput := &models.EditInput{
+		Operation: models.OperationEnumModify,
+		ID:        &performerID,
+	}
+
+	first := s.generatePerformerName()
+	rename, err := s.createTestPerformerEdit(models.OperationEnumModify, &models.PerformerEditDetailsInput{
+		Name: &first,
+	}, editInput, nil)
+	assert.NoError(s.t, err)
+
+	second := s.generatePerformerName()
+	country := "DE"
+	conflicting, err := s.createTestPerformerEdit(models.OperationEnumModify, &models.PerformerEditDetailsInput{
+		Name:    &second,
+		Country: &country,
+	}, editInput, nil)
+	assert.NoError(s.t, err)
+
+	// nothing to rebase onto until the conflicting edit is applied
+	_, err = s.resolver.Mutation().RebaseEdit(s.ctx, models.RebaseEditInput{ID: conflicting.ID})
+	assert.ErrorIs(s.t, err, edit.ErrRebaseStatus)
+
+	_, err = s.approveEdit(rename.ID)
+	assert.NoError(s.t, err)
+
+	rebased, err := s.resolver.Mutation().RebaseEdit(s.ctx, models.RebaseEditInput{ID: conflicting.ID})
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, models.VoteStatusEnumPending.String(), rebased.Status)
+
+	// the name has since been changed, the country has not
+	data, err := rebased.GetPerformerData()
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, data.New.Name)
+	if assert.NotNil(s.t, data.New.Country) {
+		assert.Equal(s.t, country, *data.New.Country)
+	}
+
+	from, err := s.resolver.Edit().RebasedFrom(s.ctx, rebased)
+	assert.NoError(s.t, err)
+	if assert.NotNil(s.t, from) {
+		assert.Equal(s.t, conflicting.ID, from.ID)
+		assert.Equal(s.t, models.VoteStatusEnumCanceled.String(), from.Status)
+	}
+
+	to, err := s.resolver.Edit().RebasedTo(s.ctx, conflicting)
+	assert.NoError(s.t, err)
+	if assert.NotNil(s.t, to) {
+		assert.Equal(s.t, rebased.ID, to.ID)
+	}
+
+	// an edit is only rebased once
+	_, err = s.resolver.Mutation().RebaseEdit(s.ctx, models.RebaseEditInput{ID: conflicting.ID})
+	assert.Error(s.t, err)
+
+	// a pending edit without conflicts can't be rebased
+	_, err = s.resolver.Mutation().RebaseEdit(s.ctx, models.RebaseEditInput{ID: rebased.ID})
+	assert.ErrorIs(s.t, err, edit.ErrRebaseStatus)
+}
+
+func (s *editRebaseTestRunner) testRebaseValidatesEdit() {
+	performer, err := asAdmin(s.t).createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	performerID := performer.UUID()
+	related, err := asAdmin(s.t).createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	relatedID := related.UUID()
+
+	editInput := &models.EditInput{
+		Operation: models.OperationEnumModify,
+		ID:        &performerID,
+	}
+
+	first := s.generatePerformerName()
+	rename, err := s.createTestPerformerEdit(models.OperationEnumModify, &models.PerformerEditDetailsInput{
+		Name: &first,
+	}, editInput, nil)
+	assert.NoError(s.t, err)
+
+	second := s.generatePerformerName()
+	conflicting, err := s.createTestPerformerEdit(models.OperationEnumModify, &models.PerformerEditDetailsInput{
+		Name: &second,
+		Relationships: []models.PerformerRelationshipInput{
+			{PerformerID: relatedID, Type: models.PerformerRelationshipTypeEnumSibling},
+		},
+	}, editInput, nil)
+	assert.NoError(s.t, err)
+
+	destroy, err := s.createTestPerformerEdit(models.OperationEnumDestroy, &models.PerformerEditDetailsInput{}, &models.EditInput{
+		Operation: models.OperationEnumDestroy,
+		ID:        &relatedID,
+	}, nil)
+	assert.NoError(s.t, err)
+	_, err = s.approveEdit(destroy.ID)
+	assert.NoError(s.t, err)
+
+	_, err = s.approveEdit(rename.ID)
+	assert.NoError(s.t, err)
+
+	// the surviving relationship refers to a deleted performer
+	_, err = s.resolver.Mutation().RebaseEdit(s.ctx, models.RebaseEditInput{ID: conflicting.ID})
+	assert.ErrorIs(s.t, err, edit.ErrEntityDeleted)
+}
+
+func TestRebaseEdit(t *testing.T) {
+	pt := createEditRebaseTestRunner(t)
+	pt.testRebaseEdit()
+}
+
+func TestRebaseValidatesEdit(t *testing.T) {
+	pt := createEditRebaseTestRunner(t)
+	pt.testRebaseValidatesEdit()
+}
@@ -0,0 +1,214 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/edit"
+	"github.com/stretchr/testify/assert"
+)
+
+type entityLockTestRunner struct {
+	testRunner
+}
+
+func createEntityLockTestRunner(t *testing.T) *entityLockTestRunner {
+	return &entityLockTestRunner{
+		testRunner: *asModerate(t),
+	}
+}
+
+func (s *entityLockTestRunner) createPerformer() uuid.UUID {
+	s.t.Helper()
+
+	performer, err := asAdmin(s.t).createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	return performer.UUID()
+}
+
+func (s *entityLockTestRunner) lock(id uuid.UUID, level models.EntityLockLevelEnum, fields ...string) *models.EntityLock {
+	s.t.Helper()
+
+	lock, err := s.resolver.Mutation().EntityLock(s.ctx, models.EntityLockInput{
+		TargetType: models.TargetTypeEnumPerformer,
+		TargetID:   id,
+		Fields:     fields,
+		Level:      level,
+		Reason:     "edit war",
+	})
+	assert.NoError(s.t, err)
+	return lock
+}
+
+func (s *entityLockTestRunner) modifyEdit(ctx context.Context, id uuid.UUID, details models.PerformerEditDetailsInput) (*models.Edit, error) {
+	return s.resolver.Mutation().PerformerEdit(ctx, models.PerformerEditInput{
+		Edit: &models.EditInput{
+			Operation: models.OperationEnumModify,
+			ID:        &id,
+		},
+		Details: &details,
+	})
+}
+
+func (s *entityLockTestRunner) testLockedFields() {
+	performerID := s.createPerformer()
+	lock := s.lock(performerID, models.EntityLockLevelEnumLocked, "name", "name")
+	assert.Equal(s.t, []string{"name"}, lock.Fields)
+
+	performer, err := s.resolver.Query().FindPerformer(s.ctx, performerID)
+	assert.NoError(s.t, err)
+	locks, err := s.resolver.Performer().Locks(s.ctx, performer)
+	assert.NoError(s.t, err)
+	if assert.Len(s.t, locks, 1) {
+		assert.Equal(s.t, lock.ID, locks[0].ID)
+	}
+
+	editor := asEdit(s.t)
+	name := s.generatePerformerName()
+	_, err = s.modifyEdit(editor.ctx, performerID, models.PerformerEditDetailsInput{Name: &name})
+	assert.ErrorIs(s.t, err, edit.ErrEntityLocked)
+
+	// fields that are not locked can still be edited
+	disambiguation := "unlocked"
+	_, err = s.modifyEdit(editor.ctx, performerID, models.PerformerEditDetailsInput{Disambiguation: &disambiguation})
+	assert.NoError(s.t, err)
+
+	// as can locked fields by moderators
+	_, err = s.modifyEdit(s.ctx, performerID, models.PerformerEditDetailsInput{Name: &name})
+	assert.NoError(s.t, err)
+
+	unlocked, err := s.resolver.Mutation().EntityUnlock(s.ctx, models.EntityUnlockInput{ID: lock.ID})
+	assert.NoError(s.t, err)
+	assert.True(s.t, unlocked)
+
+	_, err = s.modifyEdit(editor.ctx, performerID, models.PerformerEditDetailsInput{Name: &name})
+	assert.NoError(s.t, err)
+
+	result, err := s.resolver.Query().QueryModAudits(s.ctx, models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  10,
+		TargetID: &performerID,
+	})
+	assert.NoError(s.t, err)
+	audits, err := s.resolver.QueryModAuditsResultType().Audits(s.ctx, result)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, audits, 2)
+}
+
+func (s *entityLockTestRunner) testInvalidLock() {
+	performerID := s.createPerformer()
+
+	_, err := s.resolver.Mutation().EntityLock(s.ctx, models.EntityLockInput{
+		TargetType: models.TargetTypeEnumPerformer,
+		TargetID:   performerID,
+		Fields:     []string{"title"},
+		Level:      models.EntityLockLevelEnumLocked,
+		Reason:     "edit war",
+	})
+	assert.ErrorIs(s.t, err, edit.ErrInvalidLockField)
+
+	_, err = s.resolver.Mutation().EntityLock(s.ctx, models.EntityLockInput{
+		TargetType: models.TargetTypeEnumPerformer,
+		TargetID:   performerID,
+		Level:      models.EntityLockLevelEnumLocked,
+		Reason:     " ",
+	})
+	assert.ErrorIs(s.t, err, edit.ErrEmptyLockReason)
+
+	_, err = s.resolver.Mutation().EntityLock(s.ctx, models.EntityLockInput{
+		TargetType: models.TargetTypeEnumScene,
+		TargetID:   performerID,
+		Level:      models.EntityLockLevelEnumLocked,
+		Reason:     "edit war",
+	})
+	assert.ErrorIs(s.t, err, edit.ErrEntityNotFound)
+}
+
+func (s *entityLockTestRunner) testProtectedEntity() {
+	performerID := s.createPerformer()
+	s.lock(performerID, models.EntityLockLevelEnumProtected)
+
+	name := s.generatePerformerName()
+	protectedEdit, err := s.modifyEdit(asEdit(s.t).ctx, performerID, models.PerformerEditDetailsInput{Name: &name})
+	assert.NoError(s.t, err)
+
+	// votes alone do not apply the edit, but queue it for a moderator once
+	for range 4 {
+		voter, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumVote})
+		assert.NoError(s.t, err)
+		ctx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(voter))
+		ctx = context.WithValue(ctx, auth.ContextRoles, []models.RoleEnum{models.RoleEnumVote})
+		_, err = s.resolver.Mutation().EditVote(ctx, models.EditVoteInput{
+			ID:   protectedEdit.ID,
+			Vote: models.VoteTypeEnumAccept,
+		})
+		assert.NoError(s.t, err)
+	}
+
+	pending, err := s.resolver.Query().FindEdit(s.ctx, protectedEdit.ID)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, models.VoteStatusEnumPending.String(), pending.Status)
+	assert.True(s.t, s.awaitingModerator(performerID, protectedEdit.ID))
+
+	comments, err := s.resolver.Edit().Comments(s.ctx, pending)
+	assert.NoError(s.t, err)
+	queuedComments := 0
+	for _, comment := range comments {
+		if strings.Contains(comment.Text, "Awaiting moderator") {
+			queuedComments++
+		}
+	}
+	assert.Equal(s.t, 1, queuedComments)
+
+	approved, err := s.resolver.Mutation().ApproveEdit(s.ctx, models.ApproveEditInput{ID: protectedEdit.ID})
+	assert.NoError(s.t, err)
+	assert.True(s.t, approved.Applied)
+	assert.False(s.t, s.awaitingModerator(performerID, protectedEdit.ID))
+}
+
+func (s *entityLockTestRunner) awaitingModerator(performerID uuid.UUID, editID uuid.UUID) bool {
+	s.t.Helper()
+
+	awaiting := true
+	targetType := models.TargetTypeEnumPerformer
+	result, err := s.resolver.Query().QueryEdits(s.ctx, models.EditQueryInput{
+		TargetType:        &targetType,
+		TargetID:          &performerID,
+		AwaitingModerator: &awaiting,
+		Page:              1,
+		PerPage:           25,
+		Direction:         models.SortDirectionEnumDesc,
+		Sort:              models.EditSortEnumCreatedAt,
+	})
+	assert.NoError(s.t, err)
+
+	edits, err := s.resolver.QueryEditsResultType().Edits(s.ctx, result)
+	assert.NoError(s.t, err)
+	for _, edit := range edits {
+		if edit.ID == editID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEntityLockedFields(t *testing.T) {
+	pt := createEntityLockTestRunner(t)
+	pt.testLockedFields()
+}
+
+func TestEntityLockInvalid(t *testing.T) {
+	pt := createEntityLockTestRunner(t)
+	pt.testInvalidLock()
+}
+
+func TestEntityLockProtected(t *testing.T) {
+	pt := createEntityLockTestRunner(t)
+	pt.testProtectedEntity()
+}
@@ -42,7 +42,8 @@ type userPopulator struct {
 var userDB *userPopulator
 
 func (p *userPopulator) PopulateDB(factory *service.Factory) error {
-	ctx := context.TODO()
+	// the test users are created with the rights of an admin
+	ctx := context.WithValue(context.TODO(), auth.ContextRoles, []models.RoleEnum{models.RoleEnumAdmin})
 	userService := factory.User()
 
 	// create admin user
@@ -182,8 +183,8 @@ func createTestRunner(t *testing.T, u *models.User, roles []models.RoleEnum) *te
 	gqlHandler := handler.NewDefaultServer(models.NewExecutableSchema(models.Config{
 		Resolvers: resolver,
 		Directives: models.DirectiveRoot{
-			IsUserOwner: api.IsUserOwnerDirective,
-			HasRole:     api.HasRoleDirective,
+			IsUserOwner:   api.IsUserOwnerDirective,
+			HasPermission: api.HasPermissionDirective,
 		},
 	}))
 	var handlerFunc http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
@@ -473,7 +474,9 @@ func (s *testRunner) createTestUser(input *models.UserCreateInput, roles []model
 		}
 	}
 
-	createdUser, err := s.resolver.Mutation().UserCreate(s.ctx, *input)
+	// test users are created by an admin, whatever the runner may grant
+	admin := asAdmin(s.t)
+	createdUser, err := admin.resolver.Mutation().UserCreate(admin.ctx, *input)
 
 	if err != nil {
 		s.t.Errorf("Error creating user: %s", err.Error())
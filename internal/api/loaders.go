@@ -30,6 +30,28 @@ func tagList(ctx context.Context, tagIDs []uuid.UUID) ([]models.Tag, error) {
 	return tags, nil
 }
 
+func userList(ctx context.Context, userIDs []uuid.UUID) ([]models.User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	ret, errors := dataloader.For(ctx).UserByID.LoadAll(userIDs)
+	for _, err := range errors {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var users []models.User
+	for _, user := range ret {
+		if user != nil {
+			users = append(users, *user)
+		}
+	}
+
+	return users, nil
+}
+
 func imageList(ctx context.Context, imageIDs []uuid.UUID) ([]models.Image, error) {
 	if len(imageIDs) == 0 {
 		return nil, nil
@@ -0,0 +1,225 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stretchr/testify/assert"
+)
+
+type lockoutTestRunner struct {
+	testRunner
+}
+
+func createLockoutTestRunner(t *testing.T) *lockoutTestRunner {
+	prevAttempts := config.C.LockoutAttempts
+	config.C.LockoutAttempts = 3
+	t.Cleanup(func() { config.C.LockoutAttempts = prevAttempts })
+
+	return &lockoutTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *lockoutTestRunner) findLockout(scope models.LockoutScopeEnum, key string) *models.Lockout {
+	s.t.Helper()
+
+	lockouts, err := s.resolver.Query().QueryLockouts(s.ctx, true)
+	assert.NoError(s.t, err)
+	for _, l := range lockouts {
+		if l.Scope == scope && l.Key == key {
+			return &l
+		}
+	}
+	return nil
+}
+
+func (s *lockoutTestRunner) testLoginLockout() {
+	users := dbtest.Factory().User()
+	ctx := context.WithValue(s.ctx, auth.ContextClientIP, "198.51.100.1")
+
+	name := s.generateUserName()
+	password := "password" + name
+	u, err := s.createTestUser(&models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: password,
+		Roles:    []models.RoleEnum{models.RoleEnumRead},
+	}, nil)
+	assert.NoError(s.t, err)
+
+	// a successful login resets the failures
+	_, err = users.Authenticate(ctx, name, "wrong password")
+	assert.ErrorIs(s.t, err, user.ErrAccessDenied)
+	id, err := users.Authenticate(ctx, name, password)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, u.ID.String(), id)
+
+	for range 3 {
+		_, err = users.Authenticate(ctx, name, "wrong password")
+		assert.ErrorIs(s.t, err, user.ErrAccessDenied)
+	}
+
+	// the correct password is rejected during the lockout
+	_, err = users.Authenticate(ctx, name, password)
+	assert.ErrorIs(s.t, err, user.ErrLockedOut)
+
+	lockout := s.findLockout(models.LockoutScopeEnumLoginAccount, name)
+	if assert.NotNil(s.t, lockout) {
+		assert.Equal(s.t, 3, lockout.Attempts)
+		assert.NotNil(s.t, lockout.LockedUntil)
+	}
+	ipLockout := s.findLockout(models.LockoutScopeEnumLoginIP, "198.51.100.1")
+	assert.Nil(s.t, ipLockout)
+
+	cleared, err := s.resolver.Mutation().ClearLockout(s.ctx, models.ClearLockoutInput{
+		Scope: models.LockoutScopeEnumLoginAccount,
+		Key:   name,
+	})
+	assert.NoError(s.t, err)
+	assert.True(s.t, cleared)
+
+	_, err = users.Authenticate(ctx, name, password)
+	assert.NoError(s.t, err)
+
+	action := models.ModAuditActionEnumLockoutClear
+	result, err := s.resolver.Query().QueryModAudits(s.ctx, models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  25,
+		Action:   &action,
+		TargetID: &lockout.ID,
+	})
+	assert.NoError(s.t, err)
+	audits, err := s.resolver.QueryModAuditsResultType().Audits(s.ctx, result)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, audits, 1)
+}
+
+func (s *lockoutTestRunner) testTwoFactorLockout() {
+	users := dbtest.Factory().User()
+	ctx := context.WithValue(s.ctx, auth.ContextClientIP, "198.51.100.4")
+
+	name := s.generateUserName()
+	password := "password" + name
+	u, err := s.createTestUser(&models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: password,
+		Roles:    []models.RoleEnum{models.RoleEnumRead},
+	}, nil)
+	assert.NoError(s.t, err)
+
+	userCtx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(u))
+	setup, err := s.resolver.Mutation().SetupTwoFactor(userCtx)
+	assert.NoError(s.t, err)
+	_, err = s.resolver.Mutation().EnableTwoFactor(userCtx, currentTOTPCode(s.t, setup.Secret, 0))
+	assert.NoError(s.t, err)
+
+	// the correct password does not reset the wrong codes
+	for range 3 {
+		_, err = users.Authenticate(ctx, name, password)
+		assert.NoError(s.t, err)
+		assert.ErrorIs(s.t, users.VerifyTwoFactor(ctx, u.ID, "000000"), user.ErrInvalidTwoFactorCode)
+	}
+
+	_, err = users.Authenticate(ctx, name, password)
+	assert.ErrorIs(s.t, err, user.ErrLockedOut)
+	assert.ErrorIs(s.t, users.VerifyTwoFactor(ctx, u.ID, currentTOTPCode(s.t, setup.Secret, 0)), user.ErrLockedOut)
+
+	lockout := s.findLockout(models.LockoutScopeEnumLoginAccount, name)
+	if assert.NotNil(s.t, lockout) {
+		assert.Equal(s.t, 3, lockout.Attempts)
+	}
+}
+
+func (s *lockoutTestRunner) testUnknownUserLockout() {
+	users := dbtest.Factory().User()
+	name := s.generateUserName()
+
+	for range 3 {
+		_, err := users.Authenticate(s.ctx, name, "password")
+		assert.ErrorIs(s.t, err, user.ErrAccessDenied)
+	}
+
+	_, err := users.Authenticate(s.ctx, name, "password")
+	assert.ErrorIs(s.t, err, user.ErrLockedOut)
+}
+
+func (s *lockoutTestRunner) testNewUserLockout() {
+	emailAddr := s.generateUserName() + "@example.com"
+	ctx := context.WithValue(s.ctx, auth.ContextClientIP, "198.51.100.2")
+
+	// requests are counted whether or not they succeed
+	for range 3 {
+		_, err := s.resolver.Mutation().NewUser(ctx, models.NewUserInput{Email: emailAddr})
+		assert.NotErrorIs(s.t, err, user.ErrLockedOut)
+	}
+
+	_, err := s.resolver.Mutation().NewUser(ctx, models.NewUserInput{Email: emailAddr})
+	assert.ErrorIs(s.t, err, user.ErrLockedOut)
+}
+
+func (s *lockoutTestRunner) testIPCountsFailures() {
+	prevIPAttempts := config.C.LockoutIPAttempts
+	prevRequireInvite := config.C.RequireInvite
+	config.C.LockoutIPAttempts = 3
+	defer func() {
+		config.C.LockoutIPAttempts = prevIPAttempts
+		config.C.RequireInvite = prevRequireInvite
+	}()
+
+	ip := "198.51.100.3"
+	ctx := context.WithValue(s.ctx, auth.ContextClientIP, ip)
+
+	// successful registrations don't count against the address
+	config.C.RequireInvite = false
+	for range 4 {
+		_, err := s.resolver.Mutation().NewUser(ctx, models.NewUserInput{Email: s.generateUserName() + "@example.com"})
+		assert.NoError(s.t, err)
+	}
+
+	// failed registrations do
+	config.C.RequireInvite = true
+	for range 3 {
+		_, err := s.resolver.Mutation().NewUser(ctx, models.NewUserInput{Email: s.generateUserName() + "@example.com"})
+		assert.Error(s.t, err)
+		assert.NotErrorIs(s.t, err, user.ErrLockedOut)
+	}
+
+	config.C.RequireInvite = false
+	_, err := s.resolver.Mutation().NewUser(ctx, models.NewUserInput{Email: s.generateUserName() + "@example.com"})
+	assert.ErrorIs(s.t, err, user.ErrLockedOut)
+	assert.NotNil(s.t, s.findLockout(models.LockoutScopeEnumNewUserIP, ip))
+}
+
+func TestLoginLockout(t *testing.T) {
+	pt := createLockoutTestRunner(t)
+	pt.testLoginLockout()
+}
+
+func TestTwoFactorLockout(t *testing.T) {
+	pt := createLockoutTestRunner(t)
+	pt.testTwoFactorLockout()
+}
+
+func TestUnknownUserLockout(t *testing.T) {
+	pt := createLockoutTestRunner(t)
+	pt.testUnknownUserLockout()
+}
+
+func TestNewUserLockout(t *testing.T) {
+	pt := createLockoutTestRunner(t)
+	pt.testNewUserLockout()
+}
+
+func TestIPLockoutCountsFailures(t *testing.T) {
+	pt := createLockoutTestRunner(t)
+	pt.testIPCountsFailures()
+}
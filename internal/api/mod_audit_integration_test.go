@@ -0,0 +1,225 @@
+//go:build integration
+
+package api_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+	"github.com/stretchr/testify/assert"
+)
+
+type modAuditTestRunner struct {
+	testRunner
+}
+
+func createModAuditTestRunner(t *testing.T) *modAuditTestRunner {
+	return &modAuditTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *modAuditTestRunner) queryTargetAudits(targetID uuid.UUID, action *models.ModAuditActionEnum) []models.ModAudit {
+	s.t.Helper()
+
+	input := models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  25,
+		Action:   action,
+		TargetID: &targetID,
+	}
+	result, err := s.resolver.Query().QueryModAudits(s.ctx, input)
+	assert.NoError(s.t, err)
+
+	audits, err := s.resolver.QueryModAuditsResultType().Audits(s.ctx, result)
+	assert.NoError(s.t, err)
+	return audits
+}
+
+func (s *modAuditTestRunner) testUserRoleChangeAudit() {
+	user, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+
+	_, err = s.resolver.Mutation().UserUpdate(s.ctx, models.UserUpdateInput{
+		ID:    user.ID,
+		Roles: []models.RoleEnum{models.RoleEnumModerate},
+	})
+	assert.NoError(s.t, err)
+
+	action := models.ModAuditActionEnumUserUpdate
+	audits := s.queryTargetAudits(user.ID, &action)
+	if !assert.Len(s.t, audits, 1) {
+		return
+	}
+
+	audit := audits[0]
+	assert.Equal(s.t, "USER", audit.TargetType)
+	assert.Equal(s.t, userDB.admin.ID, audit.UserID.UUID)
+
+	var data struct {
+		Before struct{ Roles []string } `json:"before"`
+		After  struct{ Roles []string } `json:"after"`
+	}
+	assert.NoError(s.t, json.Unmarshal([]byte(audit.Data), &data))
+	assert.Equal(s.t, []string{"READ"}, data.Before.Roles)
+	assert.Equal(s.t, []string{"MODERATE"}, data.After.Roles)
+
+	// the email address is not logged
+	assert.NotContains(s.t, audit.Data, user.Email)
+}
+
+func (s *modAuditTestRunner) testInviteGrantAudit() {
+	user, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+
+	_, err = s.resolver.Mutation().GrantInvite(s.ctx, models.GrantInviteInput{
+		UserID: user.ID,
+		Amount: 2,
+	})
+	assert.NoError(s.t, err)
+
+	action := models.ModAuditActionEnumInviteGrant
+	audits := s.queryTargetAudits(user.ID, &action)
+	if assert.Len(s.t, audits, 1) {
+		assert.JSONEq(s.t, `{"before":{"invite_tokens":0},"after":{"invite_tokens":2}}`, audits[0].Data)
+	}
+}
+
+func (s *modAuditTestRunner) testAuditFilters() {
+	user, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+
+	// USER_CREATE and USER_DESTROY are both recorded against the user
+	_, err = s.resolver.Mutation().UserDestroy(s.ctx, models.UserDestroyInput{ID: user.ID})
+	assert.NoError(s.t, err)
+	assert.Len(s.t, s.queryTargetAudits(user.ID, nil), 2)
+
+	targetType := "USER"
+	past := time.Now().Add(-time.Hour)
+	input := models.ModAuditQueryInput{
+		Page:       1,
+		PerPage:    25,
+		TargetType: &targetType,
+		TargetID:   &user.ID,
+		EndDate:    &past,
+	}
+	count, err := s.resolver.QueryModAuditsResultType().Count(s.ctx, &models.ModAuditQuery{Filter: input})
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 0, count)
+
+	input.EndDate = nil
+	input.StartDate = &past
+	count, err = s.resolver.QueryModAuditsResultType().Count(s.ctx, &models.ModAuditQuery{Filter: input})
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 2, count)
+}
+
+func (s *modAuditTestRunner) testExportModAudits() {
+	user, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+
+	input := models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  1,
+		TargetID: &user.ID,
+	}
+
+	out, err := s.resolver.Query().ExportModAudits(s.ctx, input, models.ModAuditExportFormatEnumCSV)
+	assert.NoError(s.t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if assert.Len(s.t, lines, 2) {
+		assert.True(s.t, strings.HasPrefix(lines[0], "id,created_at,action"))
+		assert.Contains(s.t, lines[1], "USER_CREATE")
+	}
+
+	out, err = s.resolver.Query().ExportModAudits(s.ctx, input, models.ModAuditExportFormatEnumJSON)
+	assert.NoError(s.t, err)
+	var entries []map[string]any
+	assert.NoError(s.t, json.Unmarshal([]byte(out), &entries))
+	if assert.Len(s.t, entries, 1) {
+		assert.Equal(s.t, user.ID.String(), entries[0]["target_id"])
+	}
+
+	// exports are paginated like queries
+	input.Page = 2
+	out, err = s.resolver.Query().ExportModAudits(s.ctx, input, models.ModAuditExportFormatEnumJSON)
+	assert.NoError(s.t, err)
+	entries = nil
+	assert.NoError(s.t, json.Unmarshal([]byte(out), &entries))
+	assert.Empty(s.t, entries)
+
+	input.PerPage = 10001
+	_, err = s.resolver.Query().ExportModAudits(s.ctx, input, models.ModAuditExportFormatEnumCSV)
+	assert.ErrorIs(s.t, err, mod_audit.ErrExportPageSize)
+}
+
+func (s *modAuditTestRunner) testEditApproveAudit() {
+	tag, err := s.createTestTag(nil)
+	assert.NoError(s.t, err)
+	tagID := tag.UUID()
+
+	editInput := &models.EditInput{
+		Operation: models.OperationEnumModify,
+		ID:        &tagID,
+	}
+	first := s.generateTagName()
+	rename, err := s.createTestTagEdit(models.OperationEnumModify, &models.TagEditDetailsInput{Name: &first}, editInput)
+	assert.NoError(s.t, err)
+	second := s.generateTagName()
+	conflicting, err := s.createTestTagEdit(models.OperationEnumModify, &models.TagEditDetailsInput{Name: &second}, editInput)
+	assert.NoError(s.t, err)
+
+	_, err = s.approveEdit(rename.ID)
+	assert.NoError(s.t, err)
+
+	action := models.ModAuditActionEnumEditApprove
+	audits := s.queryTargetAudits(rename.ID, &action)
+	if assert.Len(s.t, audits, 1) {
+		var data struct {
+			Before struct{ Status string } `json:"before"`
+			After  struct {
+				Status  string
+				Applied bool
+			} `json:"after"`
+		}
+		assert.NoError(s.t, json.Unmarshal([]byte(audits[0].Data), &data))
+		assert.Equal(s.t, models.VoteStatusEnumPending.String(), data.Before.Status)
+		assert.Equal(s.t, models.VoteStatusEnumImmediateAccepted.String(), data.After.Status)
+		assert.True(s.t, data.After.Applied)
+	}
+
+	// an approval that fails to apply is not logged
+	_, err = s.resolver.Mutation().ApproveEdit(s.ctx, models.ApproveEditInput{ID: conflicting.ID})
+	assert.Error(s.t, err)
+	assert.Empty(s.t, s.queryTargetAudits(conflicting.ID, &action))
+}
+
+func TestUserRoleChangeAudit(t *testing.T) {
+	pt := createModAuditTestRunner(t)
+	pt.testUserRoleChangeAudit()
+}
+
+func TestInviteGrantAudit(t *testing.T) {
+	pt := createModAuditTestRunner(t)
+	pt.testInviteGrantAudit()
+}
+
+func TestModAuditFilters(t *testing.T) {
+	pt := createModAuditTestRunner(t)
+	pt.testAuditFilters()
+}
+
+func TestExportModAudits(t *testing.T) {
+	pt := createModAuditTestRunner(t)
+	pt.testExportModAudits()
+}
+
+func TestEditApproveAudit(t *testing.T) {
+	pt := createModAuditTestRunner(t)
+	pt.testEditApproveAudit()
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash-box/internal/auth"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -370,6 +371,7 @@ var generalSubscriptions = []models.NotificationEnum{
 	models.NotificationEnumFavoriteStudioScene,
 	models.NotificationEnumFavoriteStudioEdit,
 	models.NotificationEnumFingerprintedSceneEdit,
+	models.NotificationEnumMentionedInComment,
 }
 
 // Voting notification types require the VOTE role.
@@ -704,3 +706,49 @@ func TestNotificationOnFavoriteStudioScene(t *testing.T) {
 	pt := createNotificationTestRunner(t)
 	pt.testNotificationOnFavoriteStudioScene()
 }
+
+func (s *notificationTestRunner) testNotificationEmailPreferences() {
+	user, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit})
+	assert.NoError(s.t, err)
+	userCtx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(user))
+
+	frequencies := func() map[models.NotificationEnum]models.NotificationEmailFrequency {
+		preferences, err := s.resolver.User().NotificationEmailPreferences(userCtx, user)
+		assert.NoError(s.t, err)
+		assert.Len(s.t, preferences, len(models.AllNotificationEnum))
+
+		ret := make(map[models.NotificationEnum]models.NotificationEmailFrequency)
+		for _, p := range preferences {
+			ret[p.Type] = p.Frequency
+		}
+		return ret
+	}
+
+	// emails are off by default
+	for _, frequency := range frequencies() {
+		assert.Equal(s.t, models.NotificationEmailFrequencyOff, frequency)
+	}
+
+	_, err = s.resolver.Mutation().UpdateNotificationEmailPreferences(userCtx, []models.NotificationEmailPreferenceInput{
+		{Type: models.NotificationEnumCommentOwnEdit, Frequency: models.NotificationEmailFrequencyImmediate},
+		{Type: models.NotificationEnumFavoriteStudioScene, Frequency: models.NotificationEmailFrequencyWeekly},
+	})
+	assert.NoError(s.t, err)
+
+	updated := frequencies()
+	assert.Equal(s.t, models.NotificationEmailFrequencyImmediate, updated[models.NotificationEnumCommentOwnEdit])
+	assert.Equal(s.t, models.NotificationEmailFrequencyWeekly, updated[models.NotificationEnumFavoriteStudioScene])
+	assert.Equal(s.t, models.NotificationEmailFrequencyOff, updated[models.NotificationEnumDownvoteOwnEdit])
+
+	// unsubscribing turns every email off
+	err = dbtest.Factory().Notification().UnsubscribeEmails(s.ctx, user.ID)
+	assert.NoError(s.t, err)
+	for _, frequency := range frequencies() {
+		assert.Equal(s.t, models.NotificationEmailFrequencyOff, frequency)
+	}
+}
+
+func TestNotificationEmailPreferences(t *testing.T) {
+	pt := createNotificationTestRunner(t)
+	pt.testNotificationEmailPreferences()
+}
@@ -0,0 +1,138 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/edit"
+	"github.com/stretchr/testify/assert"
+)
+
+type performerCareerTestRunner struct {
+	testRunner
+}
+
+func createPerformerCareerTestRunner(t *testing.T) *performerCareerTestRunner {
+	return &performerCareerTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *performerCareerTestRunner) modifyCareerPeriods(performerID uuid.UUID, periods []models.PerformerCareerPeriodInput) (*models.Edit, error) {
+	s.t.Helper()
+
+	return s.createTestPerformerEdit(models.OperationEnumModify, &models.PerformerEditDetailsInput{
+		CareerPeriods: periods,
+	}, &models.EditInput{
+		Operation: models.OperationEnumModify,
+		ID:        &performerID,
+	}, nil)
+}
+
+func (s *performerCareerTestRunner) countActive(name string, active bool) int {
+	s.t.Helper()
+
+	count, err := s.resolver.QueryPerformersResultType().Count(s.ctx, &models.PerformerQuery{
+		Filter: models.PerformerQueryInput{
+			Name:    &name,
+			Active:  &active,
+			Page:    1,
+			PerPage: 10,
+		},
+	})
+	assert.NoError(s.t, err)
+	return count
+}
+
+func (s *performerCareerTestRunner) testCareerPeriods() {
+	studio, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+	studioID := studio.UUID()
+
+	startYear := 2008
+	endYear := 2014
+	performer, err := s.createTestPerformer(&models.PerformerCreateInput{
+		Name:            s.generatePerformerName(),
+		CareerStartYear: &startYear,
+		CareerEndYear:   &endYear,
+	})
+	assert.NoError(s.t, err)
+	performerID := performer.UUID()
+
+	// the career years alone mark the performer as retired
+	assert.Equal(s.t, 0, s.countActive(performer.Name, true))
+	assert.Equal(s.t, 1, s.countActive(performer.Name, false))
+
+	end := "2014-06"
+	note := "Comeback"
+	createdEdit, err := s.modifyCareerPeriods(performerID, []models.PerformerCareerPeriodInput{
+		{StartDate: "2008", EndDate: &end, StudioID: &studioID},
+		{StartDate: "2020-02-01", Note: &note},
+	})
+	assert.NoError(s.t, err)
+
+	_, err = s.approveEdit(createdEdit.ID)
+	assert.NoError(s.t, err)
+
+	found, err := s.resolver.Query().FindPerformer(s.ctx, performerID)
+	assert.NoError(s.t, err)
+
+	periods, err := s.resolver.Performer().CareerPeriods(s.ctx, found)
+	assert.NoError(s.t, err)
+	if assert.Len(s.t, periods, 2) {
+		assert.Equal(s.t, "2008", periods[0].StartDate)
+		assert.Equal(s.t, end, *periods[0].EndDate)
+		assert.Equal(s.t, studioID, *periods[0].StudioID)
+		assert.Equal(s.t, "2020-02-01", periods[1].StartDate)
+		assert.Nil(s.t, periods[1].EndDate)
+		assert.Equal(s.t, note, *periods[1].Note)
+	}
+
+	// the open comeback period takes precedence over the career end year
+	assert.Equal(s.t, 1, s.countActive(performer.Name, true))
+	assert.Equal(s.t, 0, s.countActive(performer.Name, false))
+
+	// removing a period is recorded in the edit details
+	createdEdit, err = s.modifyCareerPeriods(performerID, []models.PerformerCareerPeriodInput{
+		{StartDate: "2008", EndDate: &end, StudioID: &studioID},
+	})
+	assert.NoError(s.t, err)
+
+	details := s.getEditPerformerDetails(createdEdit)
+	assert.Empty(s.t, details.AddedCareerPeriods)
+	if assert.Len(s.t, details.RemovedCareerPeriods, 1) {
+		assert.Equal(s.t, "2020-02-01", details.RemovedCareerPeriods[0].StartDate)
+	}
+}
+
+func (s *performerCareerTestRunner) testInvalidCareerPeriods() {
+	performer, err := s.createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	performerID := performer.UUID()
+
+	end := "2012"
+	_, err = s.modifyCareerPeriods(performerID, []models.PerformerCareerPeriodInput{
+		{StartDate: "2015", EndDate: &end},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrInvalidCareerPeriod)
+
+	end = "2016"
+	_, err = s.modifyCareerPeriods(performerID, []models.PerformerCareerPeriodInput{
+		{StartDate: "2010", EndDate: &end},
+		{StartDate: "2014"},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrCareerPeriodOverlap)
+}
+
+func TestPerformerCareerPeriods(t *testing.T) {
+	pt := createPerformerCareerTestRunner(t)
+	pt.testCareerPeriods()
+}
+
+func TestInvalidPerformerCareerPeriods(t *testing.T) {
+	pt := createPerformerCareerTestRunner(t)
+	pt.testInvalidCareerPeriods()
+}
@@ -0,0 +1,143 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/edit"
+	"github.com/stretchr/testify/assert"
+)
+
+type performerRelationshipTestRunner struct {
+	testRunner
+}
+
+func createPerformerRelationshipTestRunner(t *testing.T) *performerRelationshipTestRunner {
+	return &performerRelationshipTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *performerRelationshipTestRunner) modifyRelationships(performerID uuid.UUID, relationships []models.PerformerRelationshipInput) (*models.Edit, error) {
+	s.t.Helper()
+
+	return s.createTestPerformerEdit(models.OperationEnumModify, &models.PerformerEditDetailsInput{
+		Relationships: relationships,
+	}, &models.EditInput{
+		Operation: models.OperationEnumModify,
+		ID:        &performerID,
+	}, nil)
+}
+
+func (s *performerRelationshipTestRunner) getRelationships(performerID uuid.UUID) []models.PerformerRelationship {
+	s.t.Helper()
+
+	performer, err := s.resolver.Query().FindPerformer(s.ctx, performerID)
+	assert.NoError(s.t, err)
+
+	relationships, err := s.resolver.Performer().Relationships(s.ctx, performer)
+	assert.NoError(s.t, err)
+	return relationships
+}
+
+func (s *performerRelationshipTestRunner) testAddRelationship() {
+	performers := s.createPerformers(2)
+	a, b := performers[0], performers[1]
+
+	createdEdit, err := s.modifyRelationships(a, []models.PerformerRelationshipInput{
+		{PerformerID: b, Type: models.PerformerRelationshipTypeEnumSibling},
+	})
+	assert.NoError(s.t, err)
+
+	_, err = s.approveEdit(createdEdit.ID)
+	assert.NoError(s.t, err)
+
+	// relationships are symmetric
+	relationships := s.getRelationships(a)
+	if assert.Len(s.t, relationships, 1) {
+		assert.Equal(s.t, b, relationships[0].Performer.ID)
+		assert.Equal(s.t, models.PerformerRelationshipTypeEnumSibling, relationships[0].Type)
+	}
+
+	relationships = s.getRelationships(b)
+	if assert.Len(s.t, relationships, 1) {
+		assert.Equal(s.t, a, relationships[0].Performer.ID)
+	}
+
+	// an empty list removes the relationship from both sides
+	createdEdit, err = s.modifyRelationships(b, []models.PerformerRelationshipInput{})
+	assert.NoError(s.t, err)
+
+	_, err = s.approveEdit(createdEdit.ID)
+	assert.NoError(s.t, err)
+
+	assert.Empty(s.t, s.getRelationships(a))
+	assert.Empty(s.t, s.getRelationships(b))
+}
+
+func (s *performerRelationshipTestRunner) testInvalidRelationship() {
+	performers := s.createPerformers(2)
+	a, b := performers[0], performers[1]
+
+	_, err := s.modifyRelationships(a, []models.PerformerRelationshipInput{
+		{PerformerID: a, Type: models.PerformerRelationshipTypeEnumAlterEgo},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrSelfRelationship)
+
+	destroyEdit, err := s.createTestPerformerEdit(models.OperationEnumDestroy, &models.PerformerEditDetailsInput{}, &models.EditInput{
+		Operation: models.OperationEnumDestroy,
+		ID:        &b,
+	}, nil)
+	assert.NoError(s.t, err)
+	_, err = s.approveEdit(destroyEdit.ID)
+	assert.NoError(s.t, err)
+
+	_, err = s.modifyRelationships(a, []models.PerformerRelationshipInput{
+		{PerformerID: b, Type: models.PerformerRelationshipTypeEnumPartner},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrEntityDeleted)
+}
+
+func (s *performerRelationshipTestRunner) testMergeRelationships() {
+	performers := s.createPerformers(3)
+	target, source, partner := performers[0], performers[1], performers[2]
+
+	createdEdit, err := s.modifyRelationships(source, []models.PerformerRelationshipInput{
+		{PerformerID: partner, Type: models.PerformerRelationshipTypeEnumPartner},
+	})
+	assert.NoError(s.t, err)
+	_, err = s.approveEdit(createdEdit.ID)
+	assert.NoError(s.t, err)
+
+	mergeEdit, err := s.createTestPerformerEdit(models.OperationEnumMerge, &models.PerformerEditDetailsInput{}, &models.EditInput{
+		Operation:      models.OperationEnumMerge,
+		ID:             &target,
+		MergeSourceIds: []uuid.UUID{source},
+	}, nil)
+	assert.NoError(s.t, err)
+	_, err = s.approveEdit(mergeEdit.ID)
+	assert.NoError(s.t, err)
+
+	relationships := s.getRelationships(partner)
+	if assert.Len(s.t, relationships, 1) {
+		assert.Equal(s.t, target, relationships[0].Performer.ID)
+	}
+}
+
+func TestPerformerRelationships(t *testing.T) {
+	pt := createPerformerRelationshipTestRunner(t)
+	pt.testAddRelationship()
+}
+
+func TestInvalidPerformerRelationships(t *testing.T) {
+	pt := createPerformerRelationshipTestRunner(t)
+	pt.testInvalidRelationship()
+}
+
+func TestMergePerformerRelationships(t *testing.T) {
+	pt := createPerformerRelationshipTestRunner(t)
+	pt.testMergeRelationships()
+}
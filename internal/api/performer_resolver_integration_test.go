@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -477,6 +478,133 @@ func (s *performerResolverTestRunner) testPerformerTattoosAndPiercings() {
 	assertBodyMods(s.t, piercings, retrievedPiercings, "Piercings should match")
 }
 
+// createCostarScene creates a scene featuring all of the given performers
+func (s *performerResolverTestRunner) createCostarScene(date string, studioID *uuid.UUID, performerIDs ...uuid.UUID) {
+	s.t.Helper()
+
+	var appearances []models.PerformerAppearanceInput
+	for _, id := range performerIDs {
+		appearances = append(appearances, models.PerformerAppearanceInput{PerformerID: id})
+	}
+
+	_, err := s.resolver.Mutation().SceneCreate(s.ctx, models.SceneCreateInput{
+		Date:       date,
+		StudioID:   studioID,
+		Performers: appearances,
+	})
+	assert.NoError(s.t, err)
+}
+
+func (s *testRunner) createPerformers(n int) []uuid.UUID {
+	s.t.Helper()
+
+	var ids []uuid.UUID
+	for range n {
+		performer, err := s.resolver.Mutation().PerformerCreate(s.ctx, models.PerformerCreateInput{
+			Name: s.generatePerformerName(),
+		})
+		assert.NoError(s.t, err)
+		ids = append(ids, performer.ID)
+	}
+	return ids
+}
+
+// testPerformerCostars tests the costars resolver field
+func (s *performerResolverTestRunner) testPerformerCostars() {
+	ids := s.createPerformers(3)
+
+	studio, err := s.resolver.Mutation().StudioCreate(s.ctx, models.StudioCreateInput{
+		Name: s.generateStudioName(),
+	})
+	assert.NoError(s.t, err)
+
+	s.createCostarScene("2019-01-01", nil, ids[0], ids[1])
+	s.createCostarScene("2021-06-01", &studio.ID, ids[0], ids[1])
+	s.createCostarScene("2020-03-01", &studio.ID, ids[0], ids[2])
+
+	performer, err := s.resolver.Query().FindPerformer(s.ctx, ids[0])
+	assert.NoError(s.t, err)
+
+	costars, err := s.resolver.Performer().Costars(s.ctx, performer, nil, nil)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, costars, 2)
+
+	// ordered by shared scene count
+	assert.Equal(s.t, ids[1], costars[0].Performer.ID)
+	assert.Equal(s.t, 2, costars[0].SceneCount)
+	assert.Equal(s.t, "2019-01-01", *costars[0].FirstSceneDate)
+	assert.Equal(s.t, "2021-06-01", *costars[0].LastSceneDate)
+	assert.Equal(s.t, ids[2], costars[1].Performer.ID)
+	assert.Equal(s.t, 1, costars[1].SceneCount)
+
+	limit := 1
+	costars, err = s.resolver.Performer().Costars(s.ctx, performer, &limit, nil)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, costars, 1)
+
+	// out of range limits are clamped
+	limit = 0
+	costars, err = s.resolver.Performer().Costars(s.ctx, performer, &limit, nil)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, costars, 1)
+
+	limit = 1000000
+	costars, err = s.resolver.Performer().Costars(s.ctx, performer, &limit, nil)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, costars, 2)
+
+	costars, err = s.resolver.Performer().Costars(s.ctx, performer, nil, &studio.ID)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, costars, 2)
+	for _, costar := range costars {
+		assert.Equal(s.t, 1, costar.SceneCount)
+	}
+}
+
+// testPerformerPath tests the performerPath query
+func (s *performerResolverTestRunner) testPerformerPath() {
+	ids := s.createPerformers(5)
+
+	// chain 0 - 1 - 2 - 3, with a dead end 0 - 4
+	s.createCostarScene("2020-01-01", nil, ids[0], ids[1])
+	s.createCostarScene("2020-01-02", nil, ids[1], ids[2])
+	s.createCostarScene("2020-01-03", nil, ids[2], ids[3])
+	s.createCostarScene("2020-01-04", nil, ids[0], ids[4])
+
+	path, err := s.resolver.Query().PerformerPath(s.ctx, ids[0], ids[3])
+	assert.NoError(s.t, err)
+
+	var pathIDs []uuid.UUID
+	for _, p := range path {
+		pathIDs = append(pathIDs, p.ID)
+	}
+	assert.Equal(s.t, []uuid.UUID{ids[0], ids[1], ids[2], ids[3]}, pathIDs)
+
+	// a direct co-appearance shortens the chain
+	s.createCostarScene("2020-01-05", nil, ids[4], ids[3])
+	path, err = s.resolver.Query().PerformerPath(s.ctx, ids[0], ids[3])
+	assert.NoError(s.t, err)
+	assert.Len(s.t, path, 3)
+
+	unconnected := s.createPerformers(1)
+	path, err = s.resolver.Query().PerformerPath(s.ctx, ids[0], unconnected[0])
+	assert.NoError(s.t, err)
+	assert.Empty(s.t, path)
+
+	// paths do not pass through deleted performers
+	destroy, err := s.createTestPerformerEdit(models.OperationEnumDestroy, &models.PerformerEditDetailsInput{}, &models.EditInput{
+		Operation: models.OperationEnumDestroy,
+		ID:        &ids[4],
+	}, nil)
+	assert.NoError(s.t, err)
+	_, err = s.approveEdit(destroy.ID)
+	assert.NoError(s.t, err)
+
+	path, err = s.resolver.Query().PerformerPath(s.ctx, ids[0], ids[3])
+	assert.NoError(s.t, err)
+	assert.Len(s.t, path, 4)
+}
+
 func TestPerformerImages(t *testing.T) {
 	pt := createPerformerResolverTestRunner(t)
 	pt.testPerformerImages()
@@ -536,3 +664,13 @@ func TestPerformerTattoosAndPiercings(t *testing.T) {
 	pt := createPerformerResolverTestRunner(t)
 	pt.testPerformerTattoosAndPiercings()
 }
+
+func TestPerformerCostars(t *testing.T) {
+	pt := createPerformerResolverTestRunner(t)
+	pt.testPerformerCostars()
+}
+
+func TestPerformerPath(t *testing.T) {
+	pt := createPerformerResolverTestRunner(t)
+	pt.testPerformerPath()
+}
@@ -33,6 +33,9 @@ func (r *Resolver) EditVote() models.EditVoteResolver {
 func (r *Resolver) Performer() models.PerformerResolver {
 	return &performerResolver{r}
 }
+func (r *Resolver) PerformerCareerPeriod() models.PerformerCareerPeriodResolver {
+	return &performerCareerPeriodResolver{r}
+}
 func (r *Resolver) PerformerEdit() models.PerformerEditResolver {
 	return &performerEditResolver{r}
 }
@@ -60,6 +63,18 @@ func (r *Resolver) Studio() models.StudioResolver {
 func (r *Resolver) Scene() models.SceneResolver {
 	return &sceneResolver{r}
 }
+func (r *Resolver) ScenePerformerFacet() models.ScenePerformerFacetResolver {
+	return &scenePerformerFacetResolver{r}
+}
+func (r *Resolver) SceneStudioFacet() models.SceneStudioFacetResolver {
+	return &sceneStudioFacetResolver{r}
+}
+func (r *Resolver) SceneTagFacet() models.SceneTagFacetResolver {
+	return &sceneTagFacetResolver{r}
+}
+func (r *Resolver) SearchHit() models.SearchHitResolver {
+	return &searchHitResolver{r}
+}
 func (r *Resolver) Site() models.SiteResolver {
 	return &siteResolver{r}
 }
@@ -69,6 +84,25 @@ func (r *Resolver) URL() models.URLResolver {
 func (r *Resolver) User() models.UserResolver {
 	return &userResolver{r}
 }
+func (r *Resolver) UserSession() models.UserSessionResolver {
+	return &userSessionResolver{r}
+}
+func (r *Resolver) UserSuspension() models.UserSuspensionResolver {
+	return &userSuspensionResolver{r}
+}
+func (r *Resolver) InviteTreeNode() models.InviteTreeNodeResolver {
+	return &inviteTreeNodeResolver{r}
+}
+func (r *Resolver) Role() models.RoleResolver {
+	return &roleResolver{r}
+}
+func (r *Resolver) EntityLock() models.EntityLockResolver {
+	return &entityLockResolver{r}
+}
+
+func (r *Resolver) EditGroup() models.EditGroupResolver {
+	return &editGroupResolver{r}
+}
 func (r *Resolver) Query() models.QueryResolver {
 	return &queryResolver{r}
 }
@@ -90,6 +124,9 @@ func (r *Resolver) PerformerDraft() models.PerformerDraftResolver {
 func (r *Resolver) SceneDraft() models.SceneDraftResolver {
 	return &sceneDraftResolver{r}
 }
+func (r *Resolver) SavedSearch() models.SavedSearchResolver {
+	return &savedSearchResolver{r}
+}
 func (r *Resolver) QueryExistingSceneResult() models.QueryExistingSceneResultResolver {
 	return &queryExistingSceneResolver{r}
 }
@@ -141,5 +178,7 @@ func (r *queryResolver) GetConfig(ctx context.Context) (*models.StashBoxConfig,
 		RequireSceneDraft:          config.GetRequireSceneDraft(),
 		EditUpdateLimit:            config.GetEditUpdateLimit(),
 		RequireTagRole:             config.GetRequireTagRole(),
+		OidcEnabled:                config.GetOIDCConfig() != nil,
+		DisableLocalRegistration:   config.GetDisableLocalRegistration(),
 	}, nil
 }
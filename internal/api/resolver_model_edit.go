@@ -221,6 +221,22 @@ func (r *editResolver) Destructive(ctx context.Context, obj *models.Edit) (bool,
 	return obj.IsDestructive(), nil
 }
 
+func (r *editResolver) ConflictingEdits(ctx context.Context, obj *models.Edit) ([]models.Edit, error) {
+	return r.services.Edit().GetConflictingEdits(ctx, obj.ID)
+}
+
+func (r *editResolver) RebasedFrom(ctx context.Context, obj *models.Edit) (*models.Edit, error) {
+	return r.services.Edit().GetRebasedFrom(ctx, obj.ID)
+}
+
+func (r *editResolver) RebasedTo(ctx context.Context, obj *models.Edit) (*models.Edit, error) {
+	return r.services.Edit().GetRebasedTo(ctx, obj.ID)
+}
+
+func (r *editResolver) Group(ctx context.Context, obj *models.Edit) (*models.EditGroup, error) {
+	return r.services.Edit().GetEditGroup(ctx, obj.ID)
+}
+
 func (r *editResolver) Updatable(ctx context.Context, obj *models.Edit) (bool, error) {
 	user := auth.GetCurrentUser(ctx)
 
@@ -41,3 +41,24 @@ func (r *editCommentResolver) User(ctx context.Context, obj *models.EditComment)
 func (r *editCommentResolver) Edit(ctx context.Context, obj *models.EditComment) (*models.Edit, error) {
 	return r.services.Edit().FindByID(ctx, obj.EditID)
 }
+
+func (r *editCommentResolver) Parent(ctx context.Context, obj *models.EditComment) (*models.EditComment, error) {
+	if !obj.ParentID.Valid {
+		return nil, nil
+	}
+
+	return dataloader.For(ctx).EditCommentByID.Load(obj.ParentID.UUID)
+}
+
+func (r *editCommentResolver) Mentions(ctx context.Context, obj *models.EditComment) ([]models.User, error) {
+	ids, err := r.services.Edit().GetCommentMentions(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return userList(ctx, ids)
+}
+
+func (r *editCommentResolver) Reactions(ctx context.Context, obj *models.EditComment) ([]models.EditCommentReaction, error) {
+	return r.services.Edit().GetCommentReactions(ctx, obj.ID)
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/dataloader"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type editGroupResolver struct{ *Resolver }
+
+func (r *editGroupResolver) User(ctx context.Context, obj *models.EditGroup) (*models.User, error) {
+	if !obj.UserID.Valid {
+		return nil, nil
+	}
+	return dataloader.For(ctx).UserByID.Load(obj.UserID.UUID)
+}
+
+func (r *editGroupResolver) Edits(ctx context.Context, obj *models.EditGroup) ([]models.Edit, error) {
+	return r.services.Edit().GetEditGroupEdits(ctx, obj.ID)
+}
@@ -26,7 +26,7 @@ func (r *editVoteResolver) Date(ctx context.Context, obj *models.EditVote) (*tim
 
 func (r *editVoteResolver) User(ctx context.Context, obj *models.EditVote) (*models.User, error) {
 	// User votes only available to users with vote permission
-	if err := auth.ValidateRole(ctx, models.RoleEnumVote); err != nil {
+	if err := auth.ValidatePermission(ctx, models.PermissionEnumVote); err != nil {
 		return nil, nil
 	}
 
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/dataloader"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type entityLockResolver struct{ *Resolver }
+
+func (r *entityLockResolver) CreatedBy(ctx context.Context, obj *models.EntityLock) (*models.User, error) {
+	if !obj.CreatedByID.Valid {
+		return nil, nil
+	}
+	return dataloader.For(ctx).UserByID.Load(obj.CreatedByID.UUID)
+}
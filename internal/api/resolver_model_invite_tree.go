@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/dataloader"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type inviteTreeNodeResolver struct{ *Resolver }
+
+func (r *inviteTreeNodeResolver) User(ctx context.Context, obj *models.InviteTreeNode) (*models.User, error) {
+	return dataloader.For(ctx).UserByID.Load(obj.UserID)
+}
+
+func (r *inviteTreeNodeResolver) InvitedBy(ctx context.Context, obj *models.InviteTreeNode) (*models.User, error) {
+	if !obj.InvitedByID.Valid || obj.Depth == 0 {
+		return nil, nil
+	}
+	return dataloader.For(ctx).UserByID.Load(obj.InvitedByID.UUID)
+}
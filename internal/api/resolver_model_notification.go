@@ -32,6 +32,8 @@ func (r *notificationResolver) Data(ctx context.Context, obj *models.Notificatio
 	case models.NotificationEnumCommentOwnEdit:
 		fallthrough
 	case models.NotificationEnumCommentVotedEdit:
+		fallthrough
+	case models.NotificationEnumMentionedInComment:
 		comment, err := dataloader.For(ctx).EditCommentByID.Load(obj.TargetID)
 		if err != nil {
 			return nil, err
@@ -42,6 +44,8 @@ func (r *notificationResolver) Data(ctx context.Context, obj *models.Notificatio
 			return &models.CommentCommentedEdit{Comment: comment}, nil
 		case models.NotificationEnumCommentOwnEdit:
 			return &models.CommentOwnEdit{Comment: comment}, nil
+		case models.NotificationEnumMentionedInComment:
+			return &models.MentionedInComment{Comment: comment}, nil
 		default:
 			return &models.CommentVotedEdit{Comment: comment}, nil
 		}
@@ -49,15 +53,21 @@ func (r *notificationResolver) Data(ctx context.Context, obj *models.Notificatio
 	case models.NotificationEnumFavoritePerformerScene:
 		fallthrough
 	case models.NotificationEnumFavoriteStudioScene:
+		fallthrough
+	case models.NotificationEnumFavoriteSceneReleased:
 		scene, err := dataloader.For(ctx).SceneByID.Load(obj.TargetID)
 		if err != nil {
 			return nil, err
 		}
 
-		if obj.Type == models.NotificationEnumFavoritePerformerScene {
+		switch obj.Type {
+		case models.NotificationEnumFavoritePerformerScene:
 			return &models.FavoritePerformerScene{Scene: scene}, nil
+		case models.NotificationEnumFavoriteSceneReleased:
+			return &models.FavoriteSceneReleased{Scene: scene}, nil
+		default:
+			return &models.FavoriteStudioScene{Scene: scene}, nil
 		}
-		return &models.FavoriteStudioScene{Scene: scene}, nil
 
 	case models.NotificationEnumFavoritePerformerEdit:
 		fallthrough
@@ -114,6 +124,65 @@ func (r *notificationResolver) Data(ctx context.Context, obj *models.Notificatio
 			TargetScene:     targetScene,
 			FingerprintHash: models.FingerprintHash(moveData.FingerprintHash),
 		}, nil
+	case models.NotificationEnumSavedSearchMatch:
+		var matchData struct {
+			IDs   []uuid.UUID `json:"ids"`
+			Count int         `json:"count"`
+		}
+		if obj.Data == nil {
+			return nil, nil
+		}
+		if err := json.Unmarshal(*obj.Data, &matchData); err != nil {
+			return nil, err
+		}
+		savedSearch, err := r.services.SavedSearch().FindByID(ctx, obj.TargetID)
+		if err != nil || savedSearch == nil {
+			return nil, err
+		}
+
+		ret := &models.SavedSearchMatch{
+			SavedSearch: savedSearch,
+			Count:       matchData.Count,
+			Scenes:      []models.Scene{},
+			Performers:  []models.Performer{},
+		}
+
+		// matches that have since been deleted are skipped
+		switch savedSearch.Type {
+		case models.SavedSearchTypeEnumScene:
+			scenes, errs := dataloader.For(ctx).SceneByID.LoadAll(matchData.IDs)
+			for i, scene := range scenes {
+				if errs[i] != nil {
+					return nil, errs[i]
+				}
+				if scene != nil {
+					ret.Scenes = append(ret.Scenes, *scene)
+				}
+			}
+		case models.SavedSearchTypeEnumPerformer:
+			performers, errs := dataloader.For(ctx).PerformerByID.LoadAll(matchData.IDs)
+			for i, performer := range performers {
+				if errs[i] != nil {
+					return nil, errs[i]
+				}
+				if performer != nil {
+					ret.Performers = append(ret.Performers, *performer)
+				}
+			}
+		}
+		return ret, nil
+	case models.NotificationEnumUserSuspended:
+		fallthrough
+	case models.NotificationEnumUserSuspensionLifted:
+		suspension, err := r.services.User().FindSuspensionByID(ctx, obj.TargetID)
+		if err != nil || suspension == nil {
+			return nil, err
+		}
+
+		if obj.Type == models.NotificationEnumUserSuspended {
+			return &models.UserSuspended{Suspension: suspension}, nil
+		}
+		return &models.UserSuspensionLifted{Suspension: suspension}, nil
 	}
 	return nil, nil
 }
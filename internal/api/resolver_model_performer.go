@@ -100,6 +100,10 @@ func (r *performerResolver) Edits(ctx context.Context, obj *models.Performer) ([
 	return r.services.Edit().FindByPerformerID(ctx, obj.ID)
 }
 
+func (r *performerResolver) Locks(ctx context.Context, obj *models.Performer) ([]models.EntityLock, error) {
+	return r.services.Edit().GetEntityLocks(ctx, obj.ID)
+}
+
 func (r *performerResolver) SceneCount(ctx context.Context, obj *models.Performer) (int, error) {
 	return r.services.Scene().CountByPerformer(ctx, obj.ID)
 }
@@ -159,6 +163,22 @@ func (r *performerResolver) Studios(ctx context.Context, obj *models.Performer,
 	return r.services.Studio().CountByPerformer(ctx, obj.ID, studioID)
 }
 
+func (r *performerResolver) Costars(ctx context.Context, obj *models.Performer, limit *int, studioID *uuid.UUID) ([]models.PerformerCostar, error) {
+	l := 25
+	if limit != nil {
+		l = *limit
+	}
+	return r.services.Performer().Costars(ctx, obj.ID, l, studioID)
+}
+
+func (r *performerResolver) Relationships(ctx context.Context, obj *models.Performer) ([]models.PerformerRelationship, error) {
+	return r.services.Performer().GetRelationships(ctx, obj.ID)
+}
+
+func (r *performerResolver) CareerPeriods(ctx context.Context, obj *models.Performer) ([]models.PerformerCareerPeriod, error) {
+	return r.services.Performer().GetCareerPeriods(ctx, obj.ID)
+}
+
 func (r *performerResolver) IsFavorite(ctx context.Context, obj *models.Performer) (bool, error) {
 	return dataloader.For(ctx).PerformerIsFavoriteByID.Load(obj.ID)
 }
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/dataloader"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type performerCareerPeriodResolver struct{ *Resolver }
+
+func (r *performerCareerPeriodResolver) Studio(ctx context.Context, obj *models.PerformerCareerPeriod) (*models.Studio, error) {
+	if obj.StudioID == nil {
+		return nil, nil
+	}
+
+	return dataloader.For(ctx).StudioByID.Load(*obj.StudioID)
+}
@@ -3,6 +3,8 @@ package api
 import (
 	"context"
 
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/dataloader"
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/pkg/utils"
 )
@@ -81,3 +83,46 @@ func (r *performerEditResolver) Tattoos(ctx context.Context, obj *models.Perform
 func (r *performerEditResolver) Piercings(ctx context.Context, obj *models.PerformerEdit) ([]models.BodyModification, error) {
 	return r.services.Edit().GetMergedPerformerPiercings(ctx, obj.EditID)
 }
+
+func (r *performerEditResolver) CareerPeriods(ctx context.Context, obj *models.PerformerEdit) ([]models.PerformerCareerPeriod, error) {
+	return r.services.Edit().GetMergedPerformerCareerPeriods(ctx, obj.EditID)
+}
+
+func (r *performerEditResolver) relationshipList(ctx context.Context, relationships []models.PerformerRelationshipInput) ([]models.PerformerRelationship, error) {
+	if len(relationships) == 0 {
+		return nil, nil
+	}
+
+	var uuids []uuid.UUID
+	for _, rel := range relationships {
+		uuids = append(uuids, rel.PerformerID)
+	}
+	loadedPerformers, errors := dataloader.For(ctx).PerformerByID.LoadAll(uuids)
+	for _, err := range errors {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var ret []models.PerformerRelationship
+	for i, rel := range relationships {
+		ret = append(ret, models.PerformerRelationship{
+			Performer: loadedPerformers[i],
+			Type:      rel.Type,
+		})
+	}
+
+	return ret, nil
+}
+
+func (r *performerEditResolver) AddedRelationships(ctx context.Context, obj *models.PerformerEdit) ([]models.PerformerRelationship, error) {
+	return r.relationshipList(ctx, obj.AddedRelationships)
+}
+
+func (r *performerEditResolver) RemovedRelationships(ctx context.Context, obj *models.PerformerEdit) ([]models.PerformerRelationship, error) {
+	return r.relationshipList(ctx, obj.RemovedRelationships)
+}
+
+func (r *performerEditResolver) Relationships(ctx context.Context, obj *models.PerformerEdit) ([]models.PerformerRelationship, error) {
+	return r.services.Edit().GetMergedPerformerRelationships(ctx, obj.EditID)
+}
@@ -0,0 +1,13 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type roleResolver struct{ *Resolver }
+
+func (r *roleResolver) UserCount(ctx context.Context, obj *models.Role) (int, error) {
+	return r.services.User().CountRoleUsers(ctx, obj.ID)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type savedSearchResolver struct{ *Resolver }
+
+func (r *savedSearchResolver) Filter(ctx context.Context, obj *models.SavedSearch) (string, error) {
+	return string(obj.Filter), nil
+}
+
+func (r *savedSearchResolver) LastChecked(ctx context.Context, obj *models.SavedSearch) (*time.Time, error) {
+	return &obj.LastCheckedAt, nil
+}
+
+func (r *savedSearchResolver) Scenes(ctx context.Context, obj *models.SavedSearch) (*models.SceneQuery, error) {
+	if obj.Type != models.SavedSearchTypeEnumScene {
+		return nil, nil
+	}
+
+	filter, err := obj.SceneFilter()
+	if err != nil {
+		return nil, err
+	}
+	return &models.SceneQuery{Filter: filter}, nil
+}
+
+func (r *savedSearchResolver) Performers(ctx context.Context, obj *models.SavedSearch) (*models.PerformerQuery, error) {
+	if obj.Type != models.SavedSearchTypeEnumPerformer {
+		return nil, nil
+	}
+
+	filter, err := obj.PerformerFilter()
+	if err != nil {
+		return nil, err
+	}
+	return &models.PerformerQuery{Filter: filter}, nil
+}
+
+func (r *savedSearchResolver) Created(ctx context.Context, obj *models.SavedSearch) (*time.Time, error) {
+	return &obj.CreatedAt, nil
+}
+
+func (r *savedSearchResolver) Updated(ctx context.Context, obj *models.SavedSearch) (*time.Time, error) {
+	return &obj.UpdatedAt, nil
+}
@@ -98,6 +98,10 @@ func (r *sceneResolver) Edits(ctx context.Context, obj *models.Scene) ([]models.
 	return r.services.Edit().FindBySceneID(ctx, obj.ID)
 }
 
+func (r *sceneResolver) Locks(ctx context.Context, obj *models.Scene) ([]models.EntityLock, error) {
+	return r.services.Edit().GetEntityLocks(ctx, obj.ID)
+}
+
 func (r *sceneResolver) Created(ctx context.Context, obj *models.Scene) (*time.Time, error) {
 	return &obj.CreatedAt, nil
 }
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash-box/internal/dataloader"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type sceneStudioFacetResolver struct{ *Resolver }
+
+func (r *sceneStudioFacetResolver) Studio(ctx context.Context, obj *models.SceneStudioFacet) (*models.Studio, error) {
+	studio, err := dataloader.For(ctx).StudioByID.Load(obj.StudioID)
+	if err == nil && studio == nil {
+		err = fmt.Errorf("studio %s not found", obj.StudioID)
+	}
+	return studio, err
+}
+
+type sceneTagFacetResolver struct{ *Resolver }
+
+func (r *sceneTagFacetResolver) Tag(ctx context.Context, obj *models.SceneTagFacet) (*models.Tag, error) {
+	tag, err := dataloader.For(ctx).TagByID.Load(obj.TagID)
+	if err == nil && tag == nil {
+		err = fmt.Errorf("tag %s not found", obj.TagID)
+	}
+	return tag, err
+}
+
+type scenePerformerFacetResolver struct{ *Resolver }
+
+func (r *scenePerformerFacetResolver) Performer(ctx context.Context, obj *models.ScenePerformerFacet) (*models.Performer, error) {
+	performer, err := dataloader.For(ctx).PerformerByID.Load(obj.PerformerID)
+	if err == nil && performer == nil {
+		err = fmt.Errorf("performer %s not found", obj.PerformerID)
+	}
+	return performer, err
+}
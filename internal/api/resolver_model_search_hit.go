@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash-box/internal/dataloader"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type searchHitResolver struct{ *Resolver }
+
+func (r *searchHitResolver) Result(ctx context.Context, obj *models.SearchHit) (models.SearchResult, error) {
+	var result models.SearchResult
+	var err error
+
+	loaders := dataloader.For(ctx)
+	switch obj.Type {
+	case models.SearchTypeEnumPerformer:
+		var performer *models.Performer
+		performer, err = loaders.PerformerByID.Load(obj.ID)
+		if performer != nil {
+			result = performer
+		}
+	case models.SearchTypeEnumScene:
+		var scene *models.Scene
+		scene, err = loaders.SceneByID.Load(obj.ID)
+		if scene != nil {
+			result = scene
+		}
+	case models.SearchTypeEnumStudio:
+		var studio *models.Studio
+		studio, err = loaders.StudioByID.Load(obj.ID)
+		if studio != nil {
+			result = studio
+		}
+	case models.SearchTypeEnumTag:
+		var tag *models.Tag
+		tag, err = loaders.TagByID.Load(obj.ID)
+		if tag != nil {
+			result = tag
+		}
+	}
+
+	if err == nil && result == nil {
+		err = fmt.Errorf("%s %s not found", obj.Type, obj.ID)
+	}
+	return result, err
+}
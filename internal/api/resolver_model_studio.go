@@ -56,6 +56,10 @@ func (r *studioResolver) IsFavorite(ctx context.Context, obj *models.Studio) (bo
 	return dataloader.For(ctx).StudioIsFavoriteByID.Load(obj.ID)
 }
 
+func (r *studioResolver) Locks(ctx context.Context, obj *models.Studio) ([]models.EntityLock, error) {
+	return r.services.Edit().GetEntityLocks(ctx, obj.ID)
+}
+
 func (r *studioResolver) Created(ctx context.Context, obj *models.Studio) (*time.Time, error) {
 	return &obj.CreatedAt, nil
 }
@@ -71,6 +75,10 @@ func (r *studioResolver) Performers(ctx context.Context, obj *models.Studio, inp
 	}, nil
 }
 
+func (r *studioResolver) NetworkStats(ctx context.Context, obj *models.Studio) (*models.StudioNetworkStats, error) {
+	return r.services.Studio().NetworkStats(ctx, obj.ID)
+}
+
 func (r *studioResolver) Aliases(ctx context.Context, obj *models.Studio) ([]string, error) {
 	aliases, err := dataloader.For(ctx).StudioAliasesByID.Load(obj.ID)
 	if err != nil {
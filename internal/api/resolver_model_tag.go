@@ -32,6 +32,10 @@ func (r *tagResolver) Edits(ctx context.Context, obj *models.Tag) ([]models.Edit
 	return r.services.Edit().FindByTagID(ctx, obj.ID)
 }
 
+func (r *tagResolver) Locks(ctx context.Context, obj *models.Tag) ([]models.EntityLock, error) {
+	return r.services.Edit().GetEntityLocks(ctx, obj.ID)
+}
+
 func (r *tagResolver) Category(ctx context.Context, obj *models.Tag) (*models.TagCategory, error) {
 	if obj.CategoryID.Valid {
 		return dataloader.For(ctx).TagCategoryByID.Load(obj.CategoryID.UUID)
@@ -2,10 +2,13 @@ package api
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
 	"github.com/stashapp/stash-box/internal/dataloader"
 	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/user"
 )
 
 type userResolver struct{ *Resolver }
@@ -15,9 +18,9 @@ func (r *userResolver) ID(ctx context.Context, user *models.User) (string, error
 }
 
 func (r *userResolver) Roles(ctx context.Context, user *models.User) ([]models.RoleEnum, error) {
-	// Limit user role visibility to admins and user themself
+	// Limit user role visibility to user managers and user themself
 	if err := auth.ValidateOwner(ctx, user.ID); err != nil {
-		if err := auth.ValidateRole(ctx, models.RoleEnumAdmin); err != nil {
+		if err := auth.ValidateManageUsers(ctx); err != nil {
 			return nil, nil
 		}
 	}
@@ -25,6 +28,14 @@ func (r *userResolver) Roles(ctx context.Context, user *models.User) ([]models.R
 	return r.services.User().GetRoles(ctx, user.ID)
 }
 
+func (r *userResolver) CustomRoles(ctx context.Context, user *models.User) ([]models.Role, error) {
+	return r.services.User().GetCustomRoles(ctx, user.ID)
+}
+
+func (r *userResolver) Permissions(ctx context.Context, user *models.User) ([]models.PermissionEnum, error) {
+	return r.services.User().GetPermissions(ctx, user.ID)
+}
+
 func (r *userResolver) VoteCount(ctx context.Context, obj *models.User) (*models.UserVoteCount, error) {
 	return r.services.User().CountVotesByType(ctx, obj.ID)
 }
@@ -33,6 +44,22 @@ func (r *userResolver) EditCount(ctx context.Context, obj *models.User) (*models
 	return r.services.User().CountEditsByStatus(ctx, obj.ID)
 }
 
+func (r *userResolver) Reputation(ctx context.Context, obj *models.User) (*models.UserReputation, error) {
+	return r.services.User().GetReputation(ctx, obj.ID)
+}
+
+func (r *userResolver) Suspension(ctx context.Context, obj *models.User) (*models.UserSuspension, error) {
+	return r.services.User().FindActiveSuspension(ctx, obj.ID)
+}
+
+func (r *userResolver) Suspensions(ctx context.Context, obj *models.User) ([]models.UserSuspension, error) {
+	return r.services.User().GetSuspensions(ctx, obj.ID)
+}
+
+func (r *userResolver) TwoFactor(ctx context.Context, obj *models.User) (*models.TwoFactorStatus, error) {
+	return r.services.User().GetTwoFactorStatus(ctx, obj.ID)
+}
+
 func (r *userResolver) InvitedBy(ctx context.Context, user *models.User) (*models.User, error) {
 	if !user.InvitedByID.Valid {
 		return nil, nil
@@ -46,7 +73,7 @@ func (r *userResolver) ActiveInviteCodes(ctx context.Context, user *models.User)
 	currentUser := auth.GetCurrentUser(ctx)
 
 	if currentUser.ID != user.ID {
-		if err := auth.ValidateRole(ctx, models.RoleEnumManageInvites); err != nil {
+		if err := auth.ValidateManageInvites(ctx); err != nil {
 			return nil, nil
 		}
 	}
@@ -68,7 +95,7 @@ func (r *userResolver) InviteCodes(ctx context.Context, user *models.User) ([]mo
 	currentUser := auth.GetCurrentUser(ctx)
 
 	if currentUser.ID != user.ID {
-		if err := auth.ValidateRole(ctx, models.RoleEnumManageInvites); err != nil {
+		if err := auth.ValidateManageInvites(ctx); err != nil {
 			return nil, nil
 		}
 	}
@@ -79,3 +106,12 @@ func (r *userResolver) InviteCodes(ctx context.Context, user *models.User) ([]mo
 func (r *userResolver) NotificationSubscriptions(ctx context.Context, user *models.User) ([]models.NotificationEnum, error) {
 	return r.services.User().GetNotificationSubscriptions(ctx, user.ID)
 }
+
+func (r *userResolver) NotificationEmailPreferences(ctx context.Context, user *models.User) ([]models.NotificationEmailPreference, error) {
+	return r.services.Notification().GetEmailPreferences(ctx, user.ID)
+}
+
+func (r *userResolver) CalendarURL(ctx context.Context, obj *models.User) (*string, error) {
+	url := fmt.Sprintf("%s/calendar/%s?token=%s", config.GetHostURL(), obj.ID, user.CalendarToken(obj))
+	return &url, nil
+}
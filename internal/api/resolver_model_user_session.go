@@ -0,0 +1,15 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type userSessionResolver struct{ *Resolver }
+
+func (r *userSessionResolver) Current(ctx context.Context, obj *models.UserSession) (bool, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+	return currentUser != nil && currentUser.SessionID != nil && *currentUser.SessionID == obj.ID, nil
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/dataloader"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type userSuspensionResolver struct{ *Resolver }
+
+func (r *userSuspensionResolver) User(ctx context.Context, obj *models.UserSuspension) (*models.User, error) {
+	return dataloader.For(ctx).UserByID.Load(obj.UserID)
+}
+
+func (r *userSuspensionResolver) CreatedBy(ctx context.Context, obj *models.UserSuspension) (*models.User, error) {
+	if !obj.CreatedByID.Valid {
+		return nil, nil
+	}
+	return dataloader.For(ctx).UserByID.Load(obj.CreatedByID.UUID)
+}
+
+func (r *userSuspensionResolver) LiftedBy(ctx context.Context, obj *models.UserSuspension) (*models.User, error) {
+	if !obj.LiftedByID.Valid {
+		return nil, nil
+	}
+	return dataloader.For(ctx).UserByID.Load(obj.LiftedByID.UUID)
+}
@@ -111,6 +111,14 @@ func (r *mutationResolver) HideEditComment(ctx context.Context, input models.Hid
 	return r.services.Edit().HideComment(ctx, input)
 }
 
+func (r *mutationResolver) AddEditCommentReaction(ctx context.Context, input models.EditCommentReactionInput) (*models.EditComment, error) {
+	return r.services.Edit().AddCommentReaction(ctx, input)
+}
+
+func (r *mutationResolver) RemoveEditCommentReaction(ctx context.Context, input models.EditCommentReactionInput) (*models.EditComment, error) {
+	return r.services.Edit().RemoveCommentReaction(ctx, input)
+}
+
 func (r *mutationResolver) CancelEdit(ctx context.Context, input models.CancelEditInput) (*models.Edit, error) {
 	edit, err := r.services.Edit().Cancel(ctx, input)
 	if err == nil {
@@ -120,6 +128,22 @@ func (r *mutationResolver) CancelEdit(ctx context.Context, input models.CancelEd
 	return edit, err
 }
 
+func (r *mutationResolver) BulkSceneEdit(ctx context.Context, input models.BulkSceneEditInput) (*models.EditGroup, error) {
+	return r.services.Edit().CreateBulkSceneEdit(ctx, input)
+}
+
+func (r *mutationResolver) BulkPerformerEdit(ctx context.Context, input models.BulkPerformerEditInput) (*models.EditGroup, error) {
+	return r.services.Edit().CreateBulkPerformerEdit(ctx, input)
+}
+
+func (r *mutationResolver) RebaseEdit(ctx context.Context, input models.RebaseEditInput) (*models.Edit, error) {
+	edit, err := r.services.Edit().RebaseEdit(ctx, input)
+	if err == nil {
+		go r.services.Notification().OnCreateEdit(context.Background(), edit)
+	}
+	return edit, err
+}
+
 func (r *mutationResolver) ApproveEdit(ctx context.Context, input models.ApproveEditInput) (*models.Edit, error) {
 	edit, err := r.services.Edit().Apply(ctx, input)
 	if err == nil {
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func (r *mutationResolver) EntityLock(ctx context.Context, input models.EntityLockInput) (*models.EntityLock, error) {
+	return r.services.Edit().LockEntity(ctx, input)
+}
+
+func (r *mutationResolver) EntityUnlock(ctx context.Context, input models.EntityUnlockInput) (bool, error) {
+	err := r.services.Edit().UnlockEntity(ctx, input)
+
+	return err == nil, err
+}
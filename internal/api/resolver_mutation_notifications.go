@@ -28,16 +28,18 @@ func (r *mutationResolver) UpdateNotificationSubscriptions(ctx context.Context,
 		models.NotificationEnumFavoritePerformerEdit:  true,
 		models.NotificationEnumFavoriteStudioScene:    true,
 		models.NotificationEnumFavoriteStudioEdit:     true,
+		models.NotificationEnumFavoriteSceneReleased:  true,
 		models.NotificationEnumFingerprintedSceneEdit: true,
 		models.NotificationEnumFingerprintMoved:       true,
+		models.NotificationEnumMentionedInComment:     true,
 	}
 
-	if auth.IsRole(ctx, models.RoleEnumVote) {
+	if auth.HasPermission(ctx, models.PermissionEnumVote) {
 		allowed[models.NotificationEnumUpdatedEdit] = true
 		allowed[models.NotificationEnumCommentVotedEdit] = true
 	}
 
-	if auth.IsRole(ctx, models.RoleEnumEdit) {
+	if auth.HasPermission(ctx, models.PermissionEnumEdit) {
 		allowed[models.NotificationEnumCommentOwnEdit] = true
 		allowed[models.NotificationEnumDownvoteOwnEdit] = true
 		allowed[models.NotificationEnumFailedOwnEdit] = true
@@ -54,3 +56,9 @@ func (r *mutationResolver) UpdateNotificationSubscriptions(ctx context.Context,
 	err := r.services.Notification().UpdateNotificationSubscriptions(ctx, user.ID, filteredSubscriptions)
 	return err == nil, err
 }
+
+func (r *mutationResolver) UpdateNotificationEmailPreferences(ctx context.Context, preferences []models.NotificationEmailPreferenceInput) (bool, error) {
+	user := auth.GetCurrentUser(ctx)
+	err := r.services.Notification().UpdateEmailPreferences(ctx, user.ID, preferences)
+	return err == nil, err
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func (r *mutationResolver) RoleCreate(ctx context.Context, input models.RoleCreateInput) (*models.Role, error) {
+	return r.services.User().CreateRole(ctx, input)
+}
+
+func (r *mutationResolver) RoleUpdate(ctx context.Context, input models.RoleUpdateInput) (*models.Role, error) {
+	return r.services.User().UpdateRole(ctx, input)
+}
+
+func (r *mutationResolver) RoleDestroy(ctx context.Context, input models.RoleDestroyInput) (bool, error) {
+	err := r.services.User().DestroyRole(ctx, input.ID)
+
+	return err == nil, err
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func (r *mutationResolver) SavedSearchCreate(ctx context.Context, input models.SavedSearchCreateInput) (*models.SavedSearch, error) {
+	user := auth.GetCurrentUser(ctx)
+	return r.services.SavedSearch().Create(ctx, user.ID, input)
+}
+
+func (r *mutationResolver) SavedSearchUpdate(ctx context.Context, input models.SavedSearchUpdateInput) (*models.SavedSearch, error) {
+	user := auth.GetCurrentUser(ctx)
+	return r.services.SavedSearch().Update(ctx, user.ID, input)
+}
+
+func (r *mutationResolver) SavedSearchDestroy(ctx context.Context, input models.SavedSearchDestroyInput) (bool, error) {
+	user := auth.GetCurrentUser(ctx)
+	if err := r.services.SavedSearch().Destroy(ctx, user.ID, input.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -21,6 +21,15 @@ func (r *mutationResolver) UserDestroy(ctx context.Context, input models.UserDes
 	return err == nil, err
 }
 
+func (r *mutationResolver) UserSuspend(ctx context.Context, input models.UserSuspendInput) (*models.UserSuspension, error) {
+	return r.services.User().Suspend(ctx, input)
+}
+
+func (r *mutationResolver) UserLiftSuspension(ctx context.Context, input models.UserLiftSuspensionInput) (bool, error) {
+	err := r.services.User().LiftSuspension(ctx, input)
+	return err == nil, err
+}
+
 func (r *mutationResolver) RegenerateAPIKey(ctx context.Context, userID *uuid.UUID) (string, error) {
 	return r.services.User().RegenerateAPIKey(ctx, userID)
 }
@@ -35,6 +44,46 @@ func (r *mutationResolver) ChangePassword(ctx context.Context, input models.User
 	return err == nil, err
 }
 
+func (r *mutationResolver) SetupTwoFactor(ctx context.Context) (*models.TwoFactorSetup, error) {
+	return r.services.User().SetupTwoFactor(ctx)
+}
+
+func (r *mutationResolver) EnableTwoFactor(ctx context.Context, code string) ([]string, error) {
+	return r.services.User().EnableTwoFactor(ctx, code)
+}
+
+func (r *mutationResolver) DisableTwoFactor(ctx context.Context, code string) (bool, error) {
+	err := r.services.User().DisableTwoFactor(ctx, code)
+	return err == nil, err
+}
+
+func (r *mutationResolver) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	return r.services.User().RegenerateRecoveryCodes(ctx, code)
+}
+
+func (r *mutationResolver) ResetTwoFactor(ctx context.Context, userID uuid.UUID) (bool, error) {
+	err := r.services.User().ResetTwoFactor(ctx, userID)
+	return err == nil, err
+}
+
+func (r *mutationResolver) RevokeSession(ctx context.Context, id uuid.UUID) (bool, error) {
+	err := r.services.User().RevokeSession(ctx, id)
+	return err == nil, err
+}
+
+func (r *mutationResolver) RevokeOtherSessions(ctx context.Context) (int, error) {
+	return r.services.User().RevokeOtherSessions(ctx)
+}
+
+func (r *mutationResolver) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	return r.services.User().RevokeUserSessions(ctx, userID)
+}
+
+func (r *mutationResolver) ClearLockout(ctx context.Context, input models.ClearLockoutInput) (bool, error) {
+	err := r.services.User().ClearLockout(ctx, input)
+	return err == nil, err
+}
+
 func (r *mutationResolver) NewUser(ctx context.Context, input models.NewUserInput) (*uuid.UUID, error) {
 	return r.services.User().NewUser(ctx, input.Email, input.InviteKey)
 }
@@ -64,6 +113,10 @@ func (r *mutationResolver) RevokeInvite(ctx context.Context, input models.Revoke
 	return r.services.User().RevokeInvite(ctx, input)
 }
 
+func (r *mutationResolver) RevokeInviteTree(ctx context.Context, input models.InviteTreeRevokeInput) (*models.InviteTreeRevokeResult, error) {
+	return r.services.User().RevokeInviteTree(ctx, input)
+}
+
 func (r *mutationResolver) RequestChangeEmail(ctx context.Context) (models.UserChangeEmailStatus, error) {
 	return r.services.User().RequestChangeEmail(ctx)
 }
@@ -75,3 +128,17 @@ func (r *mutationResolver) ValidateChangeEmail(ctx context.Context, tokenID uuid
 func (r *mutationResolver) ConfirmChangeEmail(ctx context.Context, tokenID uuid.UUID) (models.UserChangeEmailStatus, error) {
 	return r.services.User().ConfirmChangeEmail(ctx, tokenID)
 }
+
+func (r *mutationResolver) ExportMyData(ctx context.Context) (*models.UserDataExport, error) {
+	return r.services.User().RequestDataExport(ctx)
+}
+
+func (r *mutationResolver) DeleteMyAccount(ctx context.Context) (bool, error) {
+	err := r.services.User().RequestDeleteAccount(ctx)
+	return err == nil, err
+}
+
+func (r *mutationResolver) ConfirmDeleteMyAccount(ctx context.Context, token uuid.UUID) (bool, error) {
+	err := r.services.User().ConfirmDeleteAccount(ctx, token)
+	return err == nil, err
+}
@@ -11,6 +11,18 @@ func (r *queryResolver) FindEdit(ctx context.Context, id uuid.UUID) (*models.Edi
 	return r.services.Edit().FindByID(ctx, id)
 }
 
+func (r *queryResolver) FindEditGroup(ctx context.Context, id uuid.UUID) (*models.EditGroup, error) {
+	return r.services.Edit().FindEditGroup(ctx, id)
+}
+
+func (r *queryResolver) BulkSceneEditPreview(ctx context.Context, input models.BulkSceneEditInput) ([]models.Scene, error) {
+	return r.services.Edit().BulkSceneEditPreview(ctx, input)
+}
+
+func (r *queryResolver) BulkPerformerEditPreview(ctx context.Context, input models.BulkPerformerEditInput) ([]models.Performer, error) {
+	return r.services.Edit().BulkPerformerEditPreview(ctx, input)
+}
+
 func (r *queryResolver) QueryEdits(ctx context.Context, input models.EditQueryInput) (*models.EditQuery, error) {
 	return &models.EditQuery{
 		Filter: input,
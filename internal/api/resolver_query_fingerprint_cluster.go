@@ -19,7 +19,7 @@ func (r *queryResolver) FingerprintClusters(ctx context.Context, input models.Fi
 	if input.Distance < distanceMin || input.Distance > distanceMax {
 		return nil, gqlerror.Errorf("distance must be between %d and %d", distanceMin, distanceMax)
 	}
-	if input.Distance > distanceModerator && !auth.IsRole(ctx, models.RoleEnumModerate) {
+	if input.Distance > distanceModerator && !auth.HasPermission(ctx, models.PermissionEnumModerateFingerprints) {
 		return nil, gqlerror.Errorf("distance > %d is restricted to moderators", distanceModerator)
 	}
 	return r.services.Fingerprint().ClusterScenes(ctx, input.SceneID, input.Distance)
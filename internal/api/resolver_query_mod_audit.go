@@ -13,6 +13,10 @@ func (r *queryResolver) QueryModAudits(ctx context.Context, input models.ModAudi
 	}, nil
 }
 
+func (r *queryResolver) ExportModAudits(ctx context.Context, input models.ModAuditQueryInput, format models.ModAuditExportFormatEnum) (string, error) {
+	return r.services.ModAudit().Export(ctx, input, format)
+}
+
 type queryModAuditResolver struct{ *Resolver }
 
 func (r *queryModAuditResolver) Count(ctx context.Context, obj *models.ModAuditQuery) (int, error) {
@@ -18,6 +18,10 @@ func (r *queryResolver) QueryPerformers(ctx context.Context, input models.Perfor
 	}, nil
 }
 
+func (r *queryResolver) PerformerPath(ctx context.Context, from uuid.UUID, to uuid.UUID) ([]models.Performer, error) {
+	return r.services.Performer().FindPath(ctx, from, to)
+}
+
 type queryPerformerResolver struct{ *Resolver }
 
 func (r *queryPerformerResolver) Count(ctx context.Context, obj *models.PerformerQuery) (int, error) {
@@ -0,0 +1,15 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func (r *queryResolver) QueryRoles(ctx context.Context) ([]models.Role, error) {
+	return r.services.User().GetAllRoles(ctx)
+}
+
+func (r *queryResolver) RolePresets(ctx context.Context) ([]models.RolePreset, error) {
+	return r.services.User().RolePresets(), nil
+}
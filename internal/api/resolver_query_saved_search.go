@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func (r *queryResolver) FindSavedSearches(ctx context.Context) ([]models.SavedSearch, error) {
+	user := auth.GetCurrentUser(ctx)
+	return r.services.SavedSearch().FindByUser(ctx, user.ID)
+}
+
+func (r *queryResolver) FindSavedSearch(ctx context.Context, id uuid.UUID) (*models.SavedSearch, error) {
+	savedSearch, err := r.services.SavedSearch().FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if savedSearch == nil {
+		return nil, nil
+	}
+
+	user := auth.GetCurrentUser(ctx)
+	if user.ID != savedSearch.UserID {
+		return nil, nil
+	}
+
+	return savedSearch, nil
+}
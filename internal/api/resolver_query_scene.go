@@ -38,6 +38,25 @@ func (r *querySceneResolver) Count(ctx context.Context, obj *models.SceneQuery)
 	return r.services.Scene().QueryCount(ctx, obj.Filter)
 }
 
+func (r *querySceneResolver) Facets(ctx context.Context, obj *models.SceneQuery, limit *int) (*models.SceneSearchFacets, error) {
+	facetLimit := 0
+	if limit != nil {
+		facetLimit = *limit
+	}
+
+	if obj.Search != nil {
+		return r.services.Scene().SearchFacets(ctx, *obj.Search, facetLimit)
+	}
+	if obj.SearchResults != nil {
+		ids := make([]uuid.UUID, len(obj.SearchResults.Scenes))
+		for i, scene := range obj.SearchResults.Scenes {
+			ids[i] = scene.ID
+		}
+		return r.services.Scene().FacetsByIDs(ctx, ids, facetLimit)
+	}
+	return r.services.Scene().QueryFacets(ctx, obj.Filter, facetLimit)
+}
+
 func (r *querySceneResolver) Scenes(ctx context.Context, obj *models.SceneQuery) ([]models.Scene, error) {
 	if obj.SearchResults != nil {
 		return obj.SearchResults.Scenes, nil
@@ -64,7 +83,7 @@ func (r *queryExistingSceneResolver) Scenes(ctx context.Context, obj *models.Que
 
 // Deprecated: Use SearchScenes instead
 func (r *queryResolver) SearchScene(ctx context.Context, term string, limit *int) ([]models.Scene, error) {
-	result, err := r.searchScenes(ctx, term, limit, nil, nil)
+	result, err := r.searchScenes(ctx, term, limit, nil, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -74,11 +93,11 @@ func (r *queryResolver) SearchScene(ctx context.Context, term string, limit *int
 	return nil, nil
 }
 
-func (r *queryResolver) SearchScenes(ctx context.Context, term string, limit *int, page *int, perPage *int) (*models.SceneQuery, error) {
-	return r.searchScenes(ctx, term, limit, page, perPage)
+func (r *queryResolver) SearchScenes(ctx context.Context, term string, limit *int, page *int, perPage *int, filter *models.SceneSearchFilterInput) (*models.SceneQuery, error) {
+	return r.searchScenes(ctx, term, limit, page, perPage, filter)
 }
 
-func (r *queryResolver) searchScenes(ctx context.Context, term string, limit *int, page *int, perPage *int) (*models.SceneQuery, error) {
+func (r *queryResolver) searchScenes(ctx context.Context, term string, limit *int, page *int, perPage *int, filter *models.SceneSearchFilterInput) (*models.SceneQuery, error) {
 	trimmedQuery := strings.TrimSpace(term)
 	sceneID, err := uuid.FromString(trimmedQuery)
 	if err == nil {
@@ -118,5 +137,9 @@ func (r *queryResolver) searchScenes(ctx context.Context, term string, limit *in
 		}, err
 	}
 
-	return r.services.Scene().SearchScenesWithCount(ctx, trimmedQuery, searchLimit, searchOffset)
+	params := models.SceneSearchParams{
+		Term:   trimmedQuery,
+		Filter: filter,
+	}
+	return r.services.Scene().SearchScenesWithCount(ctx, params, searchLimit, searchOffset)
 }
@@ -0,0 +1,16 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func (r *queryResolver) Search(ctx context.Context, term string, types []models.SearchTypeEnum, limit *int) (*models.SearchResultType, error) {
+	searchLimit := 0
+	if limit != nil {
+		searchLimit = *limit
+	}
+
+	return r.services.Search().Search(ctx, term, types, searchLimit)
+}
@@ -33,3 +33,20 @@ func (r *queryResolver) Me(ctx context.Context) (*models.User, error) {
 
 	return r.services.User().FindByID(ctx, currentUser.ID)
 }
+
+func (r *queryResolver) MySessions(ctx context.Context) ([]models.UserSession, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return nil, auth.ErrUnauthorized
+	}
+
+	return r.services.User().GetSessions(ctx, currentUser.ID)
+}
+
+func (r *queryResolver) QueryLockouts(ctx context.Context, lockedOnly bool) ([]models.Lockout, error) {
+	return r.services.User().QueryLockouts(ctx, lockedOnly)
+}
+
+func (r *queryResolver) InviteTree(ctx context.Context, userID uuid.UUID) ([]models.InviteTreeNode, error) {
+	return r.services.User().GetInviteTree(ctx, userID)
+}
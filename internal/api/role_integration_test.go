@@ -0,0 +1,264 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stretchr/testify/assert"
+)
+
+type roleTestRunner struct {
+	testRunner
+}
+
+func createRoleTestRunner(t *testing.T) *roleTestRunner {
+	return &roleTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *roleTestRunner) createRole(name string, permissions ...models.PermissionEnum) *models.Role {
+	s.t.Helper()
+
+	role, err := s.resolver.Mutation().RoleCreate(s.ctx, models.RoleCreateInput{
+		Name:        name,
+		Permissions: permissions,
+	})
+	assert.NoError(s.t, err)
+	return role
+}
+
+// userContext returns a context acting as the user, with the permissions of
+// their custom roles loaded as on login.
+func (s *roleTestRunner) userContext(u *models.User, roles []models.RoleEnum) context.Context {
+	s.t.Helper()
+
+	au := auth.FromUser(u)
+	permissions, err := dbtest.Factory().User().GetCustomPermissions(s.ctx, u.ID)
+	assert.NoError(s.t, err)
+	au.Permissions = permissions
+
+	ctx := context.WithValue(s.ctx, auth.ContextUser, au)
+	return context.WithValue(ctx, auth.ContextRoles, roles)
+}
+
+func (s *roleTestRunner) testRoleCRUD() {
+	name := "Curator " + s.generateUserName()
+	role := s.createRole(name, models.PermissionEnumEditTags, models.PermissionEnumRead, models.PermissionEnumEditTags)
+	assert.Equal(s.t, name, role.Name)
+	assert.Equal(s.t, []models.PermissionEnum{models.PermissionEnumRead, models.PermissionEnumEditTags}, role.Permissions)
+
+	_, err := s.resolver.Mutation().RoleCreate(s.ctx, models.RoleCreateInput{
+		Name:        "admin",
+		Permissions: []models.PermissionEnum{models.PermissionEnumRead},
+	})
+	assert.ErrorIs(s.t, err, user.ErrBuiltinRoleName)
+
+	_, err = s.resolver.Mutation().RoleCreate(s.ctx, models.RoleCreateInput{
+		Name:        name,
+		Permissions: []models.PermissionEnum{models.PermissionEnumRead},
+	})
+	assert.ErrorIs(s.t, err, user.ErrRoleNameTaken)
+
+	_, err = s.resolver.Mutation().RoleCreate(s.ctx, models.RoleCreateInput{
+		Name: "Empty " + name,
+	})
+	assert.ErrorIs(s.t, err, user.ErrEmptyRolePermissions)
+
+	description := "Curates tags"
+	updated, err := s.resolver.Mutation().RoleUpdate(s.ctx, models.RoleUpdateInput{
+		ID:          role.ID,
+		Description: &description,
+		Permissions: []models.PermissionEnum{models.PermissionEnumRead, models.PermissionEnumVote, models.PermissionEnumEditTags},
+	})
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, name, updated.Name)
+	assert.Equal(s.t, description, updated.Description)
+	assert.Contains(s.t, updated.Permissions, models.PermissionEnumVote)
+
+	roles, err := s.resolver.Query().QueryRoles(s.ctx)
+	assert.NoError(s.t, err)
+	assert.True(s.t, containsRole(roles, role.ID))
+
+	destroyed, err := s.resolver.Mutation().RoleDestroy(s.ctx, models.RoleDestroyInput{ID: role.ID})
+	assert.NoError(s.t, err)
+	assert.True(s.t, destroyed)
+
+	roles, err = s.resolver.Query().QueryRoles(s.ctx)
+	assert.NoError(s.t, err)
+	assert.False(s.t, containsRole(roles, role.ID))
+}
+
+func containsRole(roles []models.Role, id uuid.UUID) bool {
+	for _, r := range roles {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *roleTestRunner) testCustomRolePermissions() {
+	config.C.RequireTagRole = true
+	defer func() { config.C.RequireTagRole = false }()
+
+	curator := s.createRole("Tag curator "+s.generateUserName(), models.PermissionEnumRead, models.PermissionEnumEdit, models.PermissionEnumEditTags)
+	builtin := []models.RoleEnum{models.RoleEnumRead, models.RoleEnumEdit}
+	u, err := s.createTestUser(nil, builtin)
+	assert.NoError(s.t, err)
+
+	name := s.generateTagName()
+	input := models.TagEditInput{
+		Edit:    &models.EditInput{Operation: models.OperationEnumCreate},
+		Details: &models.TagEditDetailsInput{Name: &name},
+	}
+
+	// the edit role does not grant editing tags when the tag role is required
+	_, err = s.resolver.Mutation().TagEdit(s.userContext(u, builtin), input)
+	assert.ErrorIs(s.t, err, auth.ErrUnauthorized)
+
+	_, err = s.resolver.Mutation().UserUpdate(s.ctx, models.UserUpdateInput{
+		ID:            u.ID,
+		Roles:         builtin,
+		CustomRoleIds: []uuid.UUID{curator.ID},
+	})
+	assert.NoError(s.t, err)
+
+	customRoles, err := s.resolver.User().CustomRoles(s.ctx, u)
+	assert.NoError(s.t, err)
+	if assert.Len(s.t, customRoles, 1) {
+		assert.Equal(s.t, curator.ID, customRoles[0].ID)
+	}
+
+	permissions, err := s.resolver.User().Permissions(s.ctx, u)
+	assert.NoError(s.t, err)
+	assert.Contains(s.t, permissions, models.PermissionEnumEditTags)
+
+	_, err = s.resolver.Mutation().TagEdit(s.userContext(u, builtin), input)
+	assert.NoError(s.t, err)
+
+	count, err := s.resolver.Role().UserCount(s.ctx, curator)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 1, count)
+
+	// unknown roles are rejected
+	_, err = s.resolver.Mutation().UserUpdate(s.ctx, models.UserUpdateInput{
+		ID:            u.ID,
+		Roles:         builtin,
+		CustomRoleIds: []uuid.UUID{uuid.Must(uuid.NewV4())},
+	})
+	assert.ErrorIs(s.t, err, user.ErrRoleNotFound)
+
+	// deleting the role removes it from the user
+	_, err = s.resolver.Mutation().RoleDestroy(s.ctx, models.RoleDestroyInput{ID: curator.ID})
+	assert.NoError(s.t, err)
+	customRoles, err = s.resolver.User().CustomRoles(s.ctx, u)
+	assert.NoError(s.t, err)
+	assert.Empty(s.t, customRoles)
+}
+
+func (s *roleTestRunner) testRoleSelfEscalation() {
+	manager := s.createRole("Role manager "+s.generateUserName(), models.PermissionEnumRead, models.PermissionEnumManageRoles)
+	builtin := []models.RoleEnum{models.RoleEnumRead}
+	u, err := s.createTestUser(nil, builtin)
+	assert.NoError(s.t, err)
+	_, err = s.resolver.Mutation().UserUpdate(s.ctx, models.UserUpdateInput{
+		ID:            u.ID,
+		Roles:         builtin,
+		CustomRoleIds: []uuid.UUID{manager.ID},
+	})
+	assert.NoError(s.t, err)
+	ctx := s.userContext(u, builtin)
+
+	// a role manager can't add permissions they don't hold to their own role
+	_, err = s.resolver.Mutation().RoleUpdate(ctx, models.RoleUpdateInput{
+		ID:          manager.ID,
+		Permissions: []models.PermissionEnum{models.PermissionEnumRead, models.PermissionEnumManageRoles, models.PermissionEnumManageUsers},
+	})
+	assert.ErrorIs(s.t, err, auth.ErrGrantNotHeld)
+
+	_, err = s.resolver.Mutation().RoleCreate(ctx, models.RoleCreateInput{
+		Name:        "Escalated " + s.generateUserName(),
+		Permissions: models.RoleEnumAdmin.Permissions(),
+	})
+	assert.ErrorIs(s.t, err, auth.ErrGrantNotHeld)
+
+	// roles with permissions they hold can be managed
+	description := "Manages roles"
+	_, err = s.resolver.Mutation().RoleUpdate(ctx, models.RoleUpdateInput{
+		ID:          manager.ID,
+		Description: &description,
+		Permissions: []models.PermissionEnum{models.PermissionEnumRead, models.PermissionEnumManageRoles},
+	})
+	assert.NoError(s.t, err)
+
+	userManager := s.createRole("User manager "+s.generateUserName(), models.PermissionEnumRead, models.PermissionEnumManageUsers)
+	v, err := s.createTestUser(nil, builtin)
+	assert.NoError(s.t, err)
+	_, err = s.resolver.Mutation().UserUpdate(s.ctx, models.UserUpdateInput{
+		ID:            v.ID,
+		Roles:         builtin,
+		CustomRoleIds: []uuid.UUID{userManager.ID},
+	})
+	assert.NoError(s.t, err)
+	ctx = s.userContext(v, builtin)
+
+	// a user manager can't give themselves the admin role or other custom roles
+	_, err = s.resolver.Mutation().UserUpdate(ctx, models.UserUpdateInput{
+		ID:            v.ID,
+		Roles:         []models.RoleEnum{models.RoleEnumRead, models.RoleEnumAdmin},
+		CustomRoleIds: []uuid.UUID{userManager.ID},
+	})
+	assert.ErrorIs(s.t, err, auth.ErrGrantNotHeld)
+
+	_, err = s.resolver.Mutation().UserUpdate(ctx, models.UserUpdateInput{
+		ID:            v.ID,
+		Roles:         builtin,
+		CustomRoleIds: []uuid.UUID{userManager.ID, manager.ID},
+	})
+	assert.ErrorIs(s.t, err, auth.ErrGrantNotHeld)
+
+	name := s.generateUserName()
+	_, err = s.resolver.Mutation().UserCreate(ctx, models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: "password" + name,
+		Roles:    []models.RoleEnum{models.RoleEnumAdmin},
+	})
+	assert.ErrorIs(s.t, err, auth.ErrGrantNotHeld)
+
+	roles, err := s.resolver.User().Roles(s.ctx, v)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, builtin, roles)
+
+	// roles they already hold are kept
+	_, err = s.resolver.Mutation().UserUpdate(ctx, models.UserUpdateInput{
+		ID:            v.ID,
+		Roles:         builtin,
+		CustomRoleIds: []uuid.UUID{userManager.ID},
+	})
+	assert.NoError(s.t, err)
+}
+
+func TestRoleCRUD(t *testing.T) {
+	pt := createRoleTestRunner(t)
+	pt.testRoleCRUD()
+}
+
+func TestCustomRolePermissions(t *testing.T) {
+	pt := createRoleTestRunner(t)
+	pt.testCustomRolePermissions()
+}
+
+func TestRoleSelfEscalation(t *testing.T) {
+	pt := createRoleTestRunner(t)
+	pt.testRoleSelfEscalation()
+}
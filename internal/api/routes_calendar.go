@@ -0,0 +1,119 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/calendar"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service"
+	"github.com/stashapp/stash-box/internal/service/user"
+)
+
+const (
+	calendarWindowDays = 180
+	calendarMaxEvents  = 500
+)
+
+type calendarRoutes struct {
+	fac service.Factory
+}
+
+func (rs calendarRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{uuid}", rs.calendar)
+
+	return r
+}
+
+// calendar serves an iCalendar feed of upcoming releases from the user's
+// favorite performers and studios. Calendar clients cannot send session
+// cookies or API keys, so access is granted by the token in the feed URL.
+func (rs calendarRoutes) calendar(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "uuid"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	u, err := rs.fac.User().FindByID(ctx, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if u == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !user.ValidateCalendarToken(u, r.URL.Query().Get("token")) {
+		http.Error(w, "invalid token", http.StatusForbidden)
+		return
+	}
+
+	today := time.Now()
+	scenes, err := rs.fac.Scene().FindUpcomingFavorites(ctx, u.ID, today, today.AddDate(0, 0, calendarWindowDays), calendarMaxEvents)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cal := calendar.Calendar{
+		Name: fmt.Sprintf("%s upcoming releases", config.GetTitle()),
+	}
+	for _, scene := range scenes {
+		event, err := rs.sceneEvent(r, scene)
+		if err != nil {
+			continue
+		}
+		cal.Events = append(cal.Events, *event)
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	_ = cal.Write(w)
+}
+
+func (rs calendarRoutes) sceneEvent(r *http.Request, scene models.Scene) (*calendar.Event, error) {
+	if scene.Date == nil {
+		return nil, fmt.Errorf("scene %s has no release date", scene.ID)
+	}
+	date, err := time.Parse(time.DateOnly, *scene.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	hostURL := config.GetHostURL()
+	host := hostURL
+	if u, err := url.Parse(hostURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	event := &calendar.Event{
+		UID:     fmt.Sprintf("scene-%s@%s", scene.ID, host),
+		Date:    date,
+		Summary: "Untitled scene",
+		URL:     fmt.Sprintf("%s/scenes/%s", hostURL, scene.ID),
+	}
+	if scene.Title != nil && *scene.Title != "" {
+		event.Summary = *scene.Title
+	}
+
+	if scene.StudioID.Valid {
+		studio, err := rs.fac.Studio().FindByID(r.Context(), scene.StudioID.UUID)
+		if err == nil && studio != nil {
+			event.Description = studio.Name
+			event.Summary = fmt.Sprintf("%s: %s", studio.Name, event.Summary)
+		}
+	}
+
+	return event, nil
+}
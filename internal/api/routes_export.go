@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/service"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stashapp/stash-box/pkg/logger"
+)
+
+type exportRoutes struct {
+	fac service.Factory
+}
+
+func (rs exportRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{token}", rs.export)
+
+	return r
+}
+
+// export streams a zip archive of the data held about a user. The link is
+// created by the exportMyData mutation, and access is granted by the
+// single-use token in the URL, so that it can be opened by the browser.
+func (rs exportRoutes) export(w http.ResponseWriter, r *http.Request) {
+	tokenID, err := uuid.FromString(chi.URLParam(r, "token"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	filename := fmt.Sprintf("stash-box-export-%s.zip", time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Cache-Control", "no-store")
+
+	// the headers are only sent with the first write of the archive, so
+	// errors reading the data can still be reported with a status
+	ew := &exportWriter{ResponseWriter: w}
+	err = rs.fac.User().ExportData(r.Context(), tokenID, ew)
+	if err == nil || ew.written {
+		if err != nil {
+			logger.Errorf("Error exporting user data: %s", err)
+		}
+		return
+	}
+
+	w.Header().Del("Content-Disposition")
+	if errors.Is(err, user.ErrInvalidActivationKey) {
+		http.Error(w, "export link is invalid or expired", http.StatusNotFound)
+		return
+	}
+	logger.Errorf("Error exporting user data: %s", err)
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// exportWriter records whether writing the archive has started
+type exportWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportRequiresToken(t *testing.T) {
+	for _, path := range []string{"/", "/invalid"} {
+		w := httptest.NewRecorder()
+		exportRoutes{}.Routes().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+		assert.Empty(t, w.Header().Get("Content-Disposition"), path)
+	}
+}
+
+func TestExportWriter(t *testing.T) {
+	w := &exportWriter{ResponseWriter: httptest.NewRecorder()}
+	assert.False(t, w.written)
+
+	_, err := w.Write([]byte("PK"))
+	assert.NoError(t, err)
+	assert.True(t, w.written)
+}
@@ -24,12 +24,27 @@ func (rr rootRoutes) Routes(fac service.Factory) chi.Router {
 
 	// session handlers
 	r.Post("/login", handleLogin(fac))
+	r.Post("/login/two-factor", handleLoginTwoFactor(fac))
 	r.HandleFunc("/logout", handleLogout)
+	r.Get("/oidc/login", handleOIDCLogin)
+	r.Get("/oidc/callback", handleOIDCCallback(fac))
 
 	r.Mount("/images", imageRoutes{
 		fac: fac,
 	}.Routes())
 
+	r.Mount("/calendar", calendarRoutes{
+		fac: fac,
+	}.Routes())
+
+	r.Mount("/unsubscribe", unsubscribeRoutes{
+		fac: fac,
+	}.Routes())
+
+	r.Mount("/export", exportRoutes{
+		fac: fac,
+	}.Routes())
+
 	// Serve static assets
 	r.HandleFunc("/assets/*", rr.assets)
 	r.HandleFunc("/favicon.ico", rr.assets)
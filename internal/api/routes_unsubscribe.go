@@ -0,0 +1,101 @@
+package api
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/email"
+	"github.com/stashapp/stash-box/internal/service"
+)
+
+var unsubscribeTemplate = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+  <head>
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>{{ .Title }}</title>
+  </head>
+  <body>
+    <h1>{{ .Title }}</h1>
+    {{ if .Done }}
+    <p>You will no longer receive notification emails. Email preferences can be changed again from your user page.</p>
+    {{ else }}
+    <form method="post">
+      <p>Stop receiving notification emails from {{ .Title }}?</p>
+      <button type="submit">Unsubscribe</button>
+    </form>
+    {{ end }}
+  </body>
+</html>
+`))
+
+type unsubscribeRoutes struct {
+	fac service.Factory
+}
+
+func (rs unsubscribeRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{uuid}", rs.confirm)
+	r.Post("/{uuid}", rs.unsubscribe)
+
+	return r
+}
+
+// userID returns the user unsubscribing, if the token in the URL is valid.
+// Unsubscribe links must work without logging in, so access is granted by
+// the token alone.
+func (rs unsubscribeRoutes) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.FromString(chi.URLParam(r, "uuid"))
+	if err != nil {
+		http.NotFound(w, r)
+		return uuid.Nil, false
+	}
+
+	if !email.ValidateUnsubscribeToken(userID, r.URL.Query().Get("token")) {
+		http.Error(w, "invalid token", http.StatusForbidden)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// confirm asks for confirmation rather than unsubscribing straight away, so
+// that link scanners following the URL do not unsubscribe the user.
+func (rs unsubscribeRoutes) confirm(w http.ResponseWriter, r *http.Request) {
+	if _, ok := rs.userID(w, r); !ok {
+		return
+	}
+
+	rs.render(w, false)
+}
+
+// unsubscribe handles both the confirmation form and one-click unsubscribe
+// requests from mail clients.
+func (rs unsubscribeRoutes) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := rs.userID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := rs.fac.Notification().UnsubscribeEmails(r.Context(), userID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rs.render(w, true)
+}
+
+func (rs unsubscribeRoutes) render(w http.ResponseWriter, done bool) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = unsubscribeTemplate.Execute(w, struct {
+		Title string
+		Done  bool
+	}{
+		Title: config.GetTitle(),
+		Done:  done,
+	})
+}
@@ -0,0 +1,195 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/auth"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type savedSearchTestRunner struct {
+	testRunner
+}
+
+func createSavedSearchTestRunner(t *testing.T) *savedSearchTestRunner {
+	return &savedSearchTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *savedSearchTestRunner) createStudioSearch(studioID uuid.UUID) *models.SavedSearch {
+	savedSearch, err := s.resolver.Mutation().SavedSearchCreate(s.ctx, models.SavedSearchCreateInput{
+		Name: "Studio scenes",
+		SceneFilter: &models.SceneQueryInput{
+			Studios: &models.MultiIDCriterionInput{
+				Value:    []uuid.UUID{studioID},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		},
+		Notify: true,
+	})
+	assert.NoError(s.t, err)
+	return savedSearch
+}
+
+func (s *savedSearchTestRunner) testSavedSearchCrud() {
+	studio, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+
+	savedSearch := s.createStudioSearch(studio.UUID())
+	assert.Equal(s.t, models.SavedSearchTypeEnumScene, savedSearch.Type)
+
+	// both or neither filter is rejected
+	_, err = s.resolver.Mutation().SavedSearchCreate(s.ctx, models.SavedSearchCreateInput{
+		Name: "Invalid",
+	})
+	assert.Error(s.t, err)
+
+	found, err := s.resolver.Query().FindSavedSearch(s.ctx, savedSearch.ID)
+	assert.NoError(s.t, err)
+	if assert.NotNil(s.t, found) {
+		assert.Equal(s.t, "Studio scenes", found.Name)
+	}
+
+	query, err := s.resolver.SavedSearch().Scenes(s.ctx, found)
+	assert.NoError(s.t, err)
+	if assert.NotNil(s.t, query) {
+		assert.Equal(s.t, []uuid.UUID{studio.UUID()}, query.Filter.Studios.Value)
+	}
+	performers, err := s.resolver.SavedSearch().Performers(s.ctx, found)
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, performers)
+
+	name := "Renamed"
+	notify := false
+	updated, err := s.resolver.Mutation().SavedSearchUpdate(s.ctx, models.SavedSearchUpdateInput{
+		ID:              savedSearch.ID,
+		Name:            &name,
+		Notify:          &notify,
+		PerformerFilter: &models.PerformerQueryInput{Name: &name},
+	})
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, name, updated.Name)
+	assert.False(s.t, updated.Notify)
+	assert.Equal(s.t, models.SavedSearchTypeEnumPerformer, updated.Type)
+
+	destroyed, err := s.resolver.Mutation().SavedSearchDestroy(s.ctx, models.SavedSearchDestroyInput{ID: savedSearch.ID})
+	assert.NoError(s.t, err)
+	assert.True(s.t, destroyed)
+
+	found, err = s.resolver.Query().FindSavedSearch(s.ctx, savedSearch.ID)
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, found)
+}
+
+func (s *savedSearchTestRunner) testSavedSearchOwnership() {
+	studio, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+	savedSearch := s.createStudioSearch(studio.UUID())
+
+	otherUser, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+	otherCtx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(otherUser))
+
+	found, err := s.resolver.Query().FindSavedSearch(otherCtx, savedSearch.ID)
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, found)
+
+	searches, err := s.resolver.Query().FindSavedSearches(otherCtx)
+	assert.NoError(s.t, err)
+	assert.Empty(s.t, searches)
+
+	name := "Hijacked"
+	_, err = s.resolver.Mutation().SavedSearchUpdate(otherCtx, models.SavedSearchUpdateInput{
+		ID:   savedSearch.ID,
+		Name: &name,
+	})
+	assert.Error(s.t, err)
+
+	_, err = s.resolver.Mutation().SavedSearchDestroy(otherCtx, models.SavedSearchDestroyInput{ID: savedSearch.ID})
+	assert.Error(s.t, err)
+}
+
+func (s *savedSearchTestRunner) findMatchNotification(savedSearchID uuid.UUID) *models.SavedSearchMatch {
+	notificationType := models.NotificationEnumSavedSearchMatch
+	notifications, err := s.resolver.QueryNotificationsResult().Notifications(s.ctx, &models.QueryNotificationsResult{
+		Input: models.QueryNotificationsInput{
+			Page:    1,
+			PerPage: 100,
+			Type:    &notificationType,
+		},
+	})
+	assert.NoError(s.t, err)
+
+	for i := range notifications {
+		data, err := s.resolver.Notification().Data(s.ctx, &notifications[i])
+		assert.NoError(s.t, err)
+		if match, ok := data.(*models.SavedSearchMatch); ok && match.SavedSearch.ID == savedSearchID {
+			return match
+		}
+	}
+	return nil
+}
+
+func (s *savedSearchTestRunner) testSavedSearchMatchNotification() {
+	studio, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+	studioID := studio.UUID()
+
+	savedSearch := s.createStudioSearch(studioID)
+
+	// nothing has been created since the search was saved
+	savedSearches := dbtest.Factory().SavedSearch()
+	assert.NoError(s.t, savedSearches.CheckMatches(s.ctx))
+	assert.Nil(s.t, s.findMatchNotification(savedSearch.ID))
+
+	title := s.generateSceneName()
+	scene, err := s.createTestScene(&models.SceneCreateInput{
+		Title:    &title,
+		Date:     "2020-01-01",
+		StudioID: &studioID,
+	})
+	assert.NoError(s.t, err)
+
+	assert.NoError(s.t, savedSearches.CheckMatches(s.ctx))
+	match := s.findMatchNotification(savedSearch.ID)
+	if assert.NotNil(s.t, match) {
+		assert.Equal(s.t, 1, match.Count)
+		if assert.Len(s.t, match.Scenes, 1) {
+			assert.Equal(s.t, scene.UUID(), match.Scenes[0].ID)
+		}
+	}
+
+	// the scene is only reported once
+	assert.NoError(s.t, savedSearches.CheckMatches(s.ctx))
+	match = s.findMatchNotification(savedSearch.ID)
+	if assert.NotNil(s.t, match) {
+		assert.Equal(s.t, 1, match.Count)
+	}
+
+	// destroying the saved search removes its notifications
+	_, err = s.resolver.Mutation().SavedSearchDestroy(s.ctx, models.SavedSearchDestroyInput{ID: savedSearch.ID})
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, s.findMatchNotification(savedSearch.ID))
+}
+
+func TestSavedSearchCrud(t *testing.T) {
+	pt := createSavedSearchTestRunner(t)
+	pt.testSavedSearchCrud()
+}
+
+func TestSavedSearchOwnership(t *testing.T) {
+	pt := createSavedSearchTestRunner(t)
+	pt.testSavedSearchOwnership()
+}
+
+func TestSavedSearchMatchNotification(t *testing.T) {
+	pt := createSavedSearchTestRunner(t)
+	pt.testSavedSearchMatchNotification()
+}
@@ -0,0 +1,168 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type sceneFacetTestRunner struct {
+	testRunner
+	studioID    uuid.UUID
+	tagID       uuid.UUID
+	performerID uuid.UUID
+}
+
+func createSceneFacetTestRunner(t *testing.T) *sceneFacetTestRunner {
+	return &sceneFacetTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+// createScenes creates three scenes in a new studio, titled with term. All
+// three share a performer, two share a tag, and they span two release years
+// and three duration buckets.
+func (s *sceneFacetTestRunner) createScenes(term string) {
+	studio, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+	s.studioID = studio.UUID()
+
+	tag, err := s.createTestTag(nil)
+	assert.NoError(s.t, err)
+	s.tagID = tag.UUID()
+
+	performer, err := s.createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	s.performerID = performer.UUID()
+
+	scenes := []struct {
+		date     string
+		duration int
+		tagged   bool
+	}{
+		{"2019-02-03", 5 * 60, true},
+		{"2019-06", 20 * 60, true},
+		{"2021-01-01", 90 * 60, false},
+	}
+	for _, scene := range scenes {
+		title := term + " " + s.generateSceneName()
+		input := models.SceneCreateInput{
+			Title:    &title,
+			Date:     scene.date,
+			Duration: &scene.duration,
+			StudioID: &s.studioID,
+			Performers: []models.PerformerAppearanceInput{
+				{PerformerID: s.performerID},
+			},
+		}
+		if scene.tagged {
+			input.TagIds = []uuid.UUID{s.tagID}
+		}
+		_, err := s.createTestScene(&input)
+		assert.NoError(s.t, err)
+	}
+}
+
+func (s *sceneFacetTestRunner) verifyFacets(facets *models.SceneSearchFacets) {
+	if assert.Len(s.t, facets.Studios, 1) {
+		assert.Equal(s.t, s.studioID, facets.Studios[0].StudioID)
+		assert.Equal(s.t, 3, facets.Studios[0].Count)
+	}
+	if assert.Len(s.t, facets.Tags, 1) {
+		assert.Equal(s.t, s.tagID, facets.Tags[0].TagID)
+		assert.Equal(s.t, 2, facets.Tags[0].Count)
+	}
+	if assert.Len(s.t, facets.Performers, 1) {
+		assert.Equal(s.t, s.performerID, facets.Performers[0].PerformerID)
+		assert.Equal(s.t, 3, facets.Performers[0].Count)
+	}
+	assert.Equal(s.t, []models.SceneYearFacet{
+		{Year: 2021, Count: 1},
+		{Year: 2019, Count: 2},
+	}, facets.Years)
+	assert.Equal(s.t, []models.SceneDurationFacet{
+		{Bucket: models.SceneDurationBucketEnumUnder10Minutes, Count: 1},
+		{Bucket: models.SceneDurationBucketEnumFrom10To30Minutes, Count: 1},
+		{Bucket: models.SceneDurationBucketEnumOver60Minutes, Count: 1},
+	}, facets.Durations)
+}
+
+func (s *sceneFacetTestRunner) testQueryFacets() {
+	s.createScenes(s.generateSceneName())
+
+	input := models.SceneQueryInput{
+		Studios: &models.MultiIDCriterionInput{
+			Value:    []uuid.UUID{s.studioID},
+			Modifier: models.CriterionModifierIncludes,
+		},
+		Page:    1,
+		PerPage: 1,
+	}
+	result, err := s.resolver.Query().QueryScenes(s.ctx, input)
+	assert.NoError(s.t, err)
+
+	// facets cover every match, not just the page
+	facets, err := s.resolver.QueryScenesResultType().Facets(s.ctx, result, nil)
+	assert.NoError(s.t, err)
+	s.verifyFacets(facets)
+
+	// drill down on facet values
+	input.Years = []int{2019}
+	input.Durations = []models.SceneDurationBucketEnum{models.SceneDurationBucketEnumFrom10To30Minutes}
+	input.PerPage = 25
+	result, err = s.resolver.Query().QueryScenes(s.ctx, input)
+	assert.NoError(s.t, err)
+
+	count, err := s.resolver.QueryScenesResultType().Count(s.ctx, result)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 1, count)
+}
+
+func (s *sceneFacetTestRunner) testSearchFacets() {
+	term := "facetsearch"
+	s.createScenes(term)
+
+	perPage := 1
+	result, err := s.resolver.Query().SearchScenes(s.ctx, term, nil, nil, &perPage, nil)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 3, result.SearchResults.Count)
+	assert.Len(s.t, result.SearchResults.Scenes, 1)
+
+	facets, err := s.resolver.QueryScenesResultType().Facets(s.ctx, result, nil)
+	assert.NoError(s.t, err)
+	s.verifyFacets(facets)
+
+	filter := &models.SceneSearchFilterInput{
+		Tags: &models.MultiIDCriterionInput{
+			Value:    []uuid.UUID{s.tagID},
+			Modifier: models.CriterionModifierIncludes,
+		},
+		Years: []int{2019},
+	}
+	result, err = s.resolver.Query().SearchScenes(s.ctx, term, nil, nil, &perPage, filter)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 2, result.SearchResults.Count)
+	assert.Len(s.t, result.SearchResults.Scenes, 1)
+
+	limit := 1
+	facets, err = s.resolver.QueryScenesResultType().Facets(s.ctx, result, &limit)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, []models.SceneYearFacet{{Year: 2019, Count: 2}}, facets.Years)
+	if assert.Len(s.t, facets.Tags, 1) {
+		assert.Equal(s.t, 2, facets.Tags[0].Count)
+	}
+}
+
+func TestSceneQueryFacets(t *testing.T) {
+	pt := createSceneFacetTestRunner(t)
+	pt.testQueryFacets()
+}
+
+func TestSceneSearchFacets(t *testing.T) {
+	pt := createSceneFacetTestRunner(t)
+	pt.testSearchFacets()
+}
@@ -0,0 +1,129 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const calendarTestWindow = 180
+
+type sceneReleaseTestRunner struct {
+	testRunner
+}
+
+func createSceneReleaseTestRunner(t *testing.T) *sceneReleaseTestRunner {
+	return &sceneReleaseTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *sceneReleaseTestRunner) createStudioScene(studioID uuid.UUID, date time.Time) uuid.UUID {
+	s.t.Helper()
+
+	title := s.generateSceneName()
+	scene, err := s.createTestScene(&models.SceneCreateInput{
+		Title:    &title,
+		Date:     date.Format(time.DateOnly),
+		StudioID: &studioID,
+	})
+	assert.NoError(s.t, err)
+	return scene.UUID()
+}
+
+// createSubscriber creates a user who favorites the studio and subscribes
+// to release notifications.
+func (s *sceneReleaseTestRunner) createSubscriber(studioID uuid.UUID) (*models.User, *testRunner) {
+	s.t.Helper()
+
+	roles := []models.RoleEnum{models.RoleEnumRead}
+	user, err := s.createTestUser(nil, roles)
+	assert.NoError(s.t, err)
+
+	runner := createTestRunner(s.t, user, roles)
+	_, err = runner.client.favoriteStudio(studioID, true)
+	assert.NoError(s.t, err)
+
+	_, err = runner.client.updateNotificationSubscriptions([]models.NotificationEnum{
+		models.NotificationEnumFavoriteSceneReleased,
+	})
+	assert.NoError(s.t, err)
+
+	return user, runner
+}
+
+func (s *sceneReleaseTestRunner) testUpcomingFavoriteScenes() {
+	studio, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+	studioID := studio.UUID()
+
+	today := time.Now()
+	upcomingID := s.createStudioScene(studioID, today.AddDate(0, 0, 14))
+	s.createStudioScene(studioID, today.AddDate(0, 0, -14))
+	s.createStudioScene(studioID, today.AddDate(0, 0, calendarTestWindow+30))
+
+	user, runner := s.createSubscriber(studioID)
+
+	favorites := models.FavoriteFilterStudio
+	result, err := runner.client.queryScenes(models.SceneQueryInput{
+		Favorites: &favorites,
+		Upcoming:  pointerTo(true),
+		Page:      1,
+		PerPage:   25,
+		Direction: models.SortDirectionEnumAsc,
+		Sort:      models.SceneSortEnumDate,
+	})
+	assert.NoError(s.t, err)
+	if assert.Equal(s.t, 2, result.Count) {
+		assert.Equal(s.t, upcomingID, result.Scenes[0].UUID())
+	}
+
+	scenes, err := dbtest.Factory().Scene().FindUpcomingFavorites(s.ctx, user.ID, today, today.AddDate(0, 0, calendarTestWindow), 10)
+	assert.NoError(s.t, err)
+	if assert.Len(s.t, scenes, 1) {
+		assert.Equal(s.t, upcomingID, scenes[0].ID)
+	}
+}
+
+func (s *sceneReleaseTestRunner) testSceneReleaseNotification() {
+	studio, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+	studioID := studio.UUID()
+
+	releaseDate := time.Now().AddDate(0, 0, 1)
+	s.createStudioScene(studioID, releaseDate)
+
+	_, runner := s.createSubscriber(studioID)
+
+	// run the trigger twice to ensure notifications are not duplicated
+	notifications := dbtest.Factory().Notification()
+	for range 2 {
+		err = notifications.TriggerSceneReleaseNotifications(s.ctx, releaseDate.Format(time.DateOnly))
+		assert.NoError(s.t, err)
+	}
+
+	notificationType := models.NotificationEnumFavoriteSceneReleased
+	result, err := runner.client.queryNotifications(models.QueryNotificationsInput{
+		Page:    1,
+		PerPage: 25,
+		Type:    &notificationType,
+	})
+	assert.NoError(s.t, err)
+	assert.Len(s.t, result.Notifications, 1)
+}
+
+func TestUpcomingFavoriteScenes(t *testing.T) {
+	pt := createSceneReleaseTestRunner(t)
+	pt.testUpcomingFavoriteScenes()
+}
+
+func TestSceneReleaseNotification(t *testing.T) {
+	pt := createSceneReleaseTestRunner(t)
+	pt.testSceneReleaseNotification()
+}
@@ -177,7 +177,7 @@ func TestSceneSearchRankingRegressions(t *testing.T) {
 	for _, q := range fixture.Queries {
 		q := q
 		t.Run(q.Name, func(t *testing.T) {
-			result, err := runner.resolver.Query().SearchScenes(runner.ctx, q.Term, nil, nil, nil)
+			result, err := runner.resolver.Query().SearchScenes(runner.ctx, q.Term, nil, nil, nil, nil)
 			assert.NoError(t, err, "running query %q", q.Term)
 
 			expectedID, ok := sceneIDs[q.Expect]
@@ -75,7 +75,7 @@ func (s *searchTestRunner) testSearchSceneByTerm() {
 	createdScene, err := s.createTestScene(&input)
 	assert.NoError(s.t, err)
 
-	result, err := s.resolver.Query().SearchScenes(s.ctx, *createdScene.Title+" "+*createdScene.Date, nil, nil, nil)
+	result, err := s.resolver.Query().SearchScenes(s.ctx, *createdScene.Title+" "+*createdScene.Date, nil, nil, nil, nil)
 	assert.NoError(s.t, err, "Error finding scene")
 
 	scenes := result.SearchResults.Scenes
@@ -90,7 +90,7 @@ func (s *searchTestRunner) testSearchSceneByID() {
 	createdScene, err := s.createTestScene(nil)
 	assert.NoError(s.t, err)
 
-	result, err := s.resolver.Query().SearchScenes(s.ctx, "   "+createdScene.ID, nil, nil, nil)
+	result, err := s.resolver.Query().SearchScenes(s.ctx, "   "+createdScene.ID, nil, nil, nil, nil)
 	assert.NoError(s.t, err, "Error finding scene")
 
 	scenes := result.SearchResults.Scenes
@@ -222,3 +222,80 @@ func TestQueryPerformerNoFacets(t *testing.T) {
 	pt := createSearchTestRunner(t)
 	pt.testQueryPerformerNoFacets()
 }
+
+func (s *searchTestRunner) testUnifiedSearch() {
+	name := s.generatePerformerName()
+	alias := name + " Alias"
+	createdPerformer, err := s.createTestPerformer(&models.PerformerCreateInput{
+		Name:    name,
+		Aliases: []string{alias},
+	})
+	assert.NoError(s.t, err)
+
+	createdTag, err := s.createTestTag(&models.TagCreateInput{
+		Name: name,
+	})
+	assert.NoError(s.t, err)
+
+	result, err := s.resolver.Query().Search(s.ctx, name, nil, nil)
+	assert.NoError(s.t, err, "Error searching")
+
+	// every type is counted when no types are requested
+	assert.Len(s.t, result.Counts, 4)
+
+	hits := make(map[models.SearchTypeEnum]models.SearchHit)
+	for _, hit := range result.Hits {
+		assert.True(s.t, hit.Score >= 0 && hit.Score <= 1, "Score should be normalized")
+		if _, found := hits[hit.Type]; !found {
+			hits[hit.Type] = hit
+		}
+	}
+
+	performerHit, found := hits[models.SearchTypeEnumPerformer]
+	if assert.True(s.t, found, "Did not find performer") {
+		assert.Equal(s.t, createdPerformer.UUID(), performerHit.ID)
+		if assert.NotEmpty(s.t, performerHit.Highlights) {
+			assert.Equal(s.t, "name", performerHit.Highlights[0].Field)
+			assert.Equal(s.t, name, performerHit.Highlights[0].Value)
+		}
+
+		result, err := s.resolver.SearchHit().Result(s.ctx, &performerHit)
+		assert.NoError(s.t, err)
+		performer, ok := result.(*models.Performer)
+		if assert.True(s.t, ok, "Result should be a performer") {
+			assert.Equal(s.t, name, performer.Name)
+		}
+	}
+
+	tagHit, found := hits[models.SearchTypeEnumTag]
+	if assert.True(s.t, found, "Did not find tag") {
+		assert.Equal(s.t, createdTag.UUID(), tagHit.ID)
+	}
+
+	// restricting the types only searches those types
+	result, err = s.resolver.Query().Search(s.ctx, alias, []models.SearchTypeEnum{models.SearchTypeEnumPerformer}, nil)
+	assert.NoError(s.t, err, "Error searching")
+
+	if assert.Len(s.t, result.Counts, 1) {
+		assert.Equal(s.t, models.SearchTypeEnumPerformer, result.Counts[0].Type)
+		assert.True(s.t, result.Counts[0].Count > 0)
+	}
+	for _, hit := range result.Hits {
+		assert.Equal(s.t, models.SearchTypeEnumPerformer, hit.Type)
+	}
+	if assert.NotEmpty(s.t, result.Hits) && assert.NotEmpty(s.t, result.Hits[0].Highlights) {
+		assert.Equal(s.t, "alias", result.Hits[0].Highlights[0].Field)
+		assert.Equal(s.t, alias, result.Hits[0].Highlights[0].Value)
+	}
+
+	// the limit is capped
+	limit := 1000000
+	result, err = s.resolver.Query().Search(s.ctx, name, nil, &limit)
+	assert.NoError(s.t, err, "Error searching")
+	assert.LessOrEqual(s.t, len(result.Hits), 100)
+}
+
+func TestUnifiedSearch(t *testing.T) {
+	pt := createSearchTestRunner(t)
+	pt.testUnifiedSearch()
+}
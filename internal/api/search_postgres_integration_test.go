@@ -0,0 +1,135 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// The search tables and document functions exist regardless of whether
+// pg_search is installed, so the postgres backend can be exercised against
+// the test database.
+func usePostgresSearch(t *testing.T) {
+	prevBackend := config.C.SearchBackend
+	config.C.SearchBackend = string(config.PostgresSearch)
+	t.Cleanup(func() { config.C.SearchBackend = prevBackend })
+}
+
+func (s *searchTestRunner) testPostgresSearchPerformerFilterAndFacets() {
+	female := models.GenderEnumFemale
+	male := models.GenderEnumMale
+
+	_, err := s.createTestPerformer(&models.PerformerCreateInput{
+		Name:   "Postgres Facet Performer Female",
+		Gender: &female,
+	})
+	assert.NoError(s.t, err)
+
+	_, err = s.createTestPerformer(&models.PerformerCreateInput{
+		Name:   "Postgres Facet Performer Male",
+		Gender: &male,
+	})
+	assert.NoError(s.t, err)
+
+	result, err := s.resolver.Query().SearchPerformers(s.ctx, "Postgres Facet Performer", nil, nil, nil, nil)
+	assert.NoError(s.t, err)
+
+	count, err := s.resolver.QueryPerformersResultType().Count(s.ctx, result)
+	assert.NoError(s.t, err)
+	assert.True(s.t, count >= 2, "Should find at least 2 performers")
+
+	facets, err := s.resolver.QueryPerformersResultType().Facets(s.ctx, result)
+	assert.NoError(s.t, err)
+	if assert.NotNil(s.t, facets) {
+		genders := make(map[models.GenderEnum]int)
+		for _, facet := range facets.Genders {
+			genders[facet.Gender] = facet.Count
+		}
+		assert.True(s.t, genders[models.GenderEnumFemale] >= 1)
+		assert.True(s.t, genders[models.GenderEnumMale] >= 1)
+	}
+
+	result, err = s.resolver.Query().SearchPerformers(s.ctx, "Postgres Facet Performer", nil, nil, nil, &models.PerformerSearchFilter{
+		Gender: &female,
+	})
+	assert.NoError(s.t, err)
+
+	performers, err := s.resolver.QueryPerformersResultType().Performers(s.ctx, result)
+	assert.NoError(s.t, err)
+	assert.NotEmpty(s.t, performers)
+	for _, performer := range performers {
+		if assert.NotNil(s.t, performer.Gender) {
+			assert.Equal(s.t, female, *performer.Gender)
+		}
+	}
+}
+
+func (s *searchTestRunner) testPostgresSearchRanking() {
+	name := s.generatePerformerName()
+	createdPerformer, err := s.createTestPerformer(&models.PerformerCreateInput{
+		Name: name,
+	})
+	assert.NoError(s.t, err)
+
+	// the exact name ranks first
+	result, err := s.resolver.Query().SearchPerformers(s.ctx, name, nil, nil, nil, nil)
+	assert.NoError(s.t, err)
+	performers, err := s.resolver.QueryPerformersResultType().Performers(s.ctx, result)
+	assert.NoError(s.t, err)
+	if assert.NotEmpty(s.t, performers) {
+		assert.Equal(s.t, createdPerformer.UUID(), performers[0].ID)
+	}
+
+	// operators in the search term are ignored
+	_, err = s.resolver.Query().SearchPerformers(s.ctx, "!&|:*()", nil, nil, nil, nil)
+	assert.NoError(s.t, err)
+}
+
+func (s *searchTestRunner) testPostgresSearchByTerm() {
+	s.testSearchSceneByTerm()
+	s.testSearchTagByTerm()
+	s.testUnifiedSearch()
+
+	createdStudio, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+
+	studios, err := s.resolver.Query().SearchStudio(s.ctx, createdStudio.Name, nil)
+	assert.NoError(s.t, err)
+	if assert.NotEmpty(s.t, studios) {
+		assert.Equal(s.t, createdStudio.UUID(), studios[0].ID)
+	}
+
+	// tags match on word prefixes
+	createdTag, err := s.createTestTag(&models.TagCreateInput{
+		Name: "Postgres Prefix Tag",
+	})
+	assert.NoError(s.t, err)
+
+	tags, err := s.resolver.Query().SearchTag(s.ctx, "postgres prefix ta", nil)
+	assert.NoError(s.t, err)
+	if assert.NotEmpty(s.t, tags) {
+		assert.Equal(s.t, createdTag.UUID(), tags[0].ID)
+	}
+}
+
+func TestPostgresSearchPerformerFilterAndFacets(t *testing.T) {
+	usePostgresSearch(t)
+	pt := createSearchTestRunner(t)
+	pt.testPostgresSearchPerformerFilterAndFacets()
+}
+
+func TestPostgresSearchRanking(t *testing.T) {
+	usePostgresSearch(t)
+	pt := createSearchTestRunner(t)
+	pt.testPostgresSearchRanking()
+}
+
+func TestPostgresSearchByTerm(t *testing.T) {
+	usePostgresSearch(t)
+	pt := createSearchTestRunner(t)
+	pt.testPostgresSearchByTerm()
+}
@@ -59,10 +59,31 @@ func getUserAndRoles(ctx context.Context, fac service.Factory, userID string) (*
 		return u, roles, nil
 	}
 	u, roles, err := fac.User().FindWithRoles(ctx, id)
+	if err != nil || u == nil {
+		return nil, nil, err
+	}
+	suspension, err := fac.User().FindActiveSuspension(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	twoFactorEnabled, err := fac.User().IsTwoFactorEnabled(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	permissions, err := fac.User().GetCustomPermissions(ctx, id)
 	if err != nil {
 		return nil, nil, err
 	}
 	au := auth.FromUser(u)
+	au.TwoFactorEnabled = twoFactorEnabled
+	au.Permissions = permissions
+	if suspension != nil {
+		au.Suspension = &auth.Suspension{
+			Reason:      suspension.Reason,
+			Restriction: suspension.Restriction,
+			Expires:     suspension.Expires,
+		}
+	}
 	auth.CacheSet(au, roles)
 	return au, roles, nil
 }
@@ -80,13 +101,14 @@ func authenticateHandler(fac service.Factory) func(http.Handler) http.Handler {
 
 			// translate api key into current user, if present
 			userID := ""
+			var sessionID *uuid.UUID
 			apiKey := r.Header.Get(APIKeyHeader)
 			var err error
 			if apiKey != "" {
 				userID, err = user.GetUserIDFromAPIKey(apiKey)
 			} else {
 				// handle session
-				userID, err = getSessionUserID(w, r)
+				userID, sessionID, err = getSessionUserID(w, r)
 			}
 
 			var u *auth.AuthUser
@@ -109,8 +131,18 @@ func authenticateHandler(fac service.Factory) func(http.Handler) http.Handler {
 
 			// TODO - increment api key counters
 
+			if u != nil {
+				u.SessionID = sessionID
+			}
+
+			// api keys are not subject to two-factor authentication
+			if apiKey == "" && u != nil && !u.TwoFactorEnabled && user.TwoFactorRequired(roles, u.Permissions) {
+				u.TwoFactorSetupRequired = true
+			}
+
+			ctx = context.WithValue(ctx, auth.ContextClientIP, remoteIP(r))
 			ctx = context.WithValue(ctx, auth.ContextUser, u)
-			ctx = context.WithValue(ctx, auth.ContextRoles, roles)
+			ctx = context.WithValue(ctx, auth.ContextRoles, auth.EffectiveRoles(u, roles))
 
 			span := trace.SpanFromContext(ctx)
 			if span.SpanContext().IsValid() && u != nil {
@@ -170,8 +202,8 @@ func Start(fac service.Factory, ui embed.FS) {
 	gqlConfig := models.Config{
 		Resolvers: NewResolver(fac),
 		Directives: models.DirectiveRoot{
-			IsUserOwner: IsUserOwnerDirective,
-			HasRole:     HasRoleDirective,
+			IsUserOwner:   IsUserOwnerDirective,
+			HasPermission: HasPermissionDirective,
 		},
 	}
 	gqlSrv := gqlHandler.New(models.NewExecutableSchema(gqlConfig))
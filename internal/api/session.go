@@ -3,6 +3,9 @@ package api
 import (
 	"errors"
 	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
 
 	"github.com/stashapp/stash-box/internal/config"
 	"github.com/stashapp/stash-box/internal/service"
@@ -14,13 +17,25 @@ import (
 const cookieName = "stashbox"
 const usernameFormKey = "username"
 const passwordFormKey = "password"
+const twoFactorFormKey = "code"
 const userIDKey = "userID"
 const maxCookieAge = 60 * 60 * 24 * 30 // 1 month
 
-var sessionStore *sessions.CookieStore
+// Users with two-factor authentication have to complete the second login
+// step within this time of entering their password
+const pendingUserIDKey = "pendingUserID"
+const pendingExpiresKey = "pendingExpires"
+const twoFactorTimeout = 5 * time.Minute
+
+// Sessions are stored again, updating their last seen time and expiry, at
+// most once within this time rather than on every request
+const lastSeenKey = "lastSeen"
+const sessionRefreshInterval = time.Minute
 
-func InitializeSession() {
-	sessionStore = sessions.NewCookieStore(config.GetSessionStoreKey())
+var sessionStore *sessionDBStore
+
+func InitializeSession(fac service.Factory) {
+	sessionStore = newSessionDBStore(fac, config.GetSessionStoreKey())
 }
 
 func handleLogin(fac service.Factory) func(http.ResponseWriter, *http.Request) {
@@ -41,29 +56,128 @@ func handleLogin(fac service.Factory) func(http.ResponseWriter, *http.Request) {
 		if errors.Is(err, user.ErrAccessDenied) {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
+		} else if errors.Is(err, user.ErrLockedOut) {
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
 		} else if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
+		id, err := uuid.FromString(userID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		twoFactorEnabled, err := fac.User().IsTwoFactorEnabled(r.Context(), id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if twoFactorEnabled {
+			code := r.FormValue(twoFactorFormKey)
+			if code == "" {
+				startTwoFactorLogin(w, r, newSession, userID)
+				return
+			}
+
+			if !verifyTwoFactor(w, r, fac, id, code) {
+				return
+			}
+		}
+
+		if err := sessionStore.renew(r, newSession); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		newSession.Values[userIDKey] = userID
-		newSession.Options.MaxAge = maxCookieAge
-		newSession.Options.HttpOnly = true
-		if config.GetIsProduction() {
-			newSession.Options.Secure = true
-		} else {
-			newSession.Options.Secure = false
-			newSession.Options.SameSite = http.SameSiteLaxMode
+		if err := saveSession(w, r, newSession); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
+	}
+}
 
-		err = newSession.Save(r, w)
+// startTwoFactorLogin marks the session as waiting for the second login step
+// of the user. The session is logged in once handleLoginTwoFactor succeeds.
+func startTwoFactorLogin(w http.ResponseWriter, r *http.Request, session *sessions.Session, userID string) {
+	delete(session.Values, userIDKey)
+	session.Values[pendingUserIDKey] = userID
+	session.Values[pendingExpiresKey] = time.Now().Add(twoFactorTimeout).Unix()
+	if err := saveSession(w, r, session); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleLoginTwoFactor completes the login of a user with two-factor
+// authentication, after their password was accepted by handleLogin or they
+// returned from the single sign-on provider.
+func handleLoginTwoFactor(fac service.Factory) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session, err := sessionStore.Get(r, cookieName)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+
+		userID, _ := session.Values[pendingUserIDKey].(string)
+		expires, _ := session.Values[pendingExpiresKey].(int64)
+		id, err := uuid.FromString(userID)
+		if err != nil || time.Now().Unix() > expires {
+			http.Error(w, "two-factor login expired", http.StatusUnauthorized)
+			return
+		}
+
+		if !verifyTwoFactor(w, r, fac, id, r.FormValue(twoFactorFormKey)) {
+			return
+		}
+
+		if err := sessionStore.renew(r, session); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		delete(session.Values, pendingUserIDKey)
+		delete(session.Values, pendingExpiresKey)
+		session.Values[userIDKey] = userID
+		if err := saveSession(w, r, session); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 	}
 }
 
+func verifyTwoFactor(w http.ResponseWriter, r *http.Request, fac service.Factory, userID uuid.UUID, code string) bool {
+	err := fac.User().VerifyTwoFactor(r.Context(), userID, code)
+	if errors.Is(err, user.ErrInvalidTwoFactorCode) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return false
+	} else if errors.Is(err, user.ErrLockedOut) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return false
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+func saveSession(w http.ResponseWriter, r *http.Request, session *sessions.Session) error {
+	session.Options.MaxAge = maxCookieAge
+	session.Options.HttpOnly = true
+	if config.GetIsProduction() {
+		session.Options.Secure = true
+	} else {
+		session.Options.Secure = false
+		session.Options.SameSite = http.SameSiteLaxMode
+	}
+
+	return session.Save(r, w)
+}
+
 func handleLogout(w http.ResponseWriter, r *http.Request) {
 	session, err := sessionStore.Get(r, cookieName)
 	if err != nil {
@@ -91,14 +205,16 @@ func handleLogout(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/", http.StatusFound)
 }
 
-func getSessionUserID(w http.ResponseWriter, r *http.Request) (string, error) {
+// getSessionUserID returns the id of the logged in user and the id of their
+// session, if the request has a session.
+func getSessionUserID(w http.ResponseWriter, r *http.Request) (string, *uuid.UUID, error) {
 	session, err := sessionStore.Get(r, cookieName)
 	if err != nil {
 		session.Options.MaxAge = -1
 		if err = session.Save(r, w); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
-		return "", nil
+		return "", nil, nil
 	}
 
 	if !session.IsNew {
@@ -106,13 +222,27 @@ func getSessionUserID(w http.ResponseWriter, r *http.Request) (string, error) {
 		userID, _ := userIDInt.(string)
 
 		// refresh the cookie
-		err = session.Save(r, w)
+		if sessionNeedsRefresh(session, time.Now()) {
+			err = session.Save(r, w)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
+		sessionID, err := uuid.FromString(session.ID)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 
-		return userID, nil
+		return userID, &sessionID, nil
 	}
 
-	return "", nil
+	return "", nil, nil
+}
+
+// sessionNeedsRefresh returns whether a stored session was last seen longer
+// than sessionRefreshInterval ago.
+func sessionNeedsRefresh(session *sessions.Session, now time.Time) bool {
+	lastSeen, ok := session.Values[lastSeenKey].(time.Time)
+	return !ok || now.Sub(lastSeen) >= sessionRefreshInterval
 }
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/oidc"
+	"github.com/stashapp/stash-box/internal/service"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stashapp/stash-box/pkg/logger"
+)
+
+const oidcStateKey = "oidcState"
+const oidcNonceKey = "oidcNonce"
+const oidcVerifierKey = "oidcVerifier"
+
+// Set when the single sign-on login was started from a logged in session,
+// which may link the provider account to the logged in user
+const oidcLinkUserIDKey = "oidcLinkUserID"
+
+// Users have to return from the provider within this time of starting the
+// single sign-on login
+const oidcExpiresKey = "oidcExpires"
+const oidcTimeout = 10 * time.Minute
+
+var (
+	oidcProvider      *oidc.Provider
+	oidcProviderMutex sync.Mutex
+)
+
+// getOIDCProvider returns the configured provider, discovering it on first
+// use. Returns nil if single sign-on is disabled.
+func getOIDCProvider(r *http.Request) (*oidc.Provider, error) {
+	c := config.GetOIDCConfig()
+	if c == nil {
+		return nil, nil
+	}
+
+	oidcProviderMutex.Lock()
+	defer oidcProviderMutex.Unlock()
+
+	if oidcProvider == nil {
+		provider, err := oidc.Discover(r.Context(), oidc.Config{
+			Issuer:        c.Issuer,
+			ClientID:      c.ClientID,
+			ClientSecret:  c.ClientSecret,
+			RedirectURL:   strings.TrimSuffix(config.GetHostURL(), "/") + "/oidc/callback",
+			Scopes:        c.Scopes,
+			UsernameClaim: c.UsernameClaim,
+			RolesClaim:    c.RolesClaim,
+		})
+		if err != nil {
+			return nil, err
+		}
+		oidcProvider = provider
+	}
+
+	return oidcProvider, nil
+}
+
+// handleOIDCLogin redirects to the provider to sign in.
+func handleOIDCLogin(w http.ResponseWriter, r *http.Request) {
+	provider, err := getOIDCProvider(r)
+	if err != nil {
+		logger.Errorf("Error discovering OIDC provider: %s", err)
+		http.Error(w, "single sign-on is unavailable", http.StatusBadGateway)
+		return
+	} else if provider == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	session, err := sessionStore.Get(r, cookieName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var values [3]string
+	for i := range values {
+		if values[i], err = oidc.RandomString(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	state, nonce, verifier := values[0], values[1], values[2]
+
+	session.Values[oidcStateKey] = state
+	session.Values[oidcNonceKey] = nonce
+	session.Values[oidcVerifierKey] = verifier
+	session.Values[oidcExpiresKey] = time.Now().Add(oidcTimeout).Unix()
+	if userID, ok := session.Values[userIDKey].(string); ok {
+		session.Values[oidcLinkUserIDKey] = userID
+	} else {
+		delete(session.Values, oidcLinkUserIDKey)
+	}
+	if err := saveSession(w, r, session); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, provider.AuthCodeURL(state, nonce, verifier), http.StatusFound)
+}
+
+// handleOIDCCallback logs in the user returning from the provider. The
+// provider account is only linked to an existing user when the login was
+// started from that user's session. Users that enabled two-factor
+// authentication after linking still have to complete the second login
+// step, like password logins.
+func handleOIDCCallback(fac service.Factory) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider, err := getOIDCProvider(r)
+		if err != nil {
+			logger.Errorf("Error discovering OIDC provider: %s", err)
+			http.Error(w, "single sign-on is unavailable", http.StatusBadGateway)
+			return
+		} else if provider == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		session, err := sessionStore.Get(r, cookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		state, _ := session.Values[oidcStateKey].(string)
+		nonce, _ := session.Values[oidcNonceKey].(string)
+		verifier, _ := session.Values[oidcVerifierKey].(string)
+		expires, _ := session.Values[oidcExpiresKey].(int64)
+		linkUserID, _ := session.Values[oidcLinkUserIDKey].(string)
+		delete(session.Values, oidcStateKey)
+		delete(session.Values, oidcNonceKey)
+		delete(session.Values, oidcVerifierKey)
+		delete(session.Values, oidcExpiresKey)
+		delete(session.Values, oidcLinkUserIDKey)
+
+		if state == "" || r.FormValue("state") != state || time.Now().Unix() > expires {
+			http.Error(w, "single sign-on login expired", http.StatusUnauthorized)
+			return
+		}
+		if errMsg := r.FormValue("error"); errMsg != "" {
+			http.Error(w, "single sign-on login failed: "+errMsg, http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := provider.Exchange(r.Context(), r.FormValue("code"), verifier, nonce)
+		if err != nil {
+			logger.Warnf("OIDC login failed: %s", err)
+			http.Error(w, "single sign-on login failed", http.StatusUnauthorized)
+			return
+		}
+
+		var sessionUserID *uuid.UUID
+		if id, err := uuid.FromString(linkUserID); err == nil {
+			sessionUserID = &id
+		}
+
+		userID, err := fac.User().LoginSSO(r.Context(), *claims, sessionUserID)
+		if errors.Is(err, user.ErrSSOMissingEmail) || errors.Is(err, user.ErrSSOEmailNotVerified) || errors.Is(err, user.ErrSSOUsernameTaken) ||
+			errors.Is(err, user.ErrSSOLinkRequiresLogin) || errors.Is(err, user.ErrSSOLinkProtected) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		twoFactorEnabled, err := fac.User().IsTwoFactorEnabled(r.Context(), userID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if twoFactorEnabled {
+			startTwoFactorLogin(w, r, session, userID.String())
+			return
+		}
+
+		if err := sessionStore.renew(r, session); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		session.Values[userIDKey] = userID.String()
+		if err := saveSession(w, r, session); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, "/", http.StatusFound)
+	}
+}
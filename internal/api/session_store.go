@@ -0,0 +1,218 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service"
+)
+
+// sessionDBStore is a sessions.Store keeping the session values in the
+// database. The cookie only holds the signed session id, so that sessions
+// can be listed and logged out remotely.
+type sessionDBStore struct {
+	fac     service.Factory
+	codecs  []securecookie.Codec
+	options sessions.Options
+}
+
+func newSessionDBStore(fac service.Factory, keyPairs ...[]byte) *sessionDBStore {
+	s := &sessionDBStore{
+		fac:    fac,
+		codecs: securecookie.CodecsFromPairs(keyPairs...),
+		options: sessions.Options{
+			Path:   "/",
+			MaxAge: maxCookieAge,
+		},
+	}
+
+	for _, codec := range s.codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(maxCookieAge)
+		}
+	}
+
+	return s
+}
+
+// Get returns the session of the request after adding it to the registry.
+func (s *sessionDBStore) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New returns the session of the request. A new session is returned if the
+// stored session expired or was revoked.
+func (s *sessionDBStore) New(r *http.Request, name string) (*sessions.Session, error) {
+	session := sessions.NewSession(s, name)
+	opts := s.options
+	session.Options = &opts
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+
+	var id string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &id, s.codecs...); err != nil {
+		return session, err
+	}
+	sessionID, err := uuid.FromString(id)
+	if err != nil {
+		return session, err
+	}
+
+	stored, err := s.fac.User().FindSession(r.Context(), sessionID)
+	if err != nil || stored == nil {
+		return session, err
+	}
+
+	if err := (securecookie.GobEncoder{}).Deserialize(stored.Data, &session.Values); err != nil {
+		return session, err
+	}
+	session.Values[lastSeenKey] = stored.LastSeen
+	session.ID = id
+	session.IsNew = false
+
+	return session, nil
+}
+
+// Save stores the session and sets its cookie. A session with a MaxAge of
+// zero or less is deleted.
+func (s *sessionDBStore) Save(r *http.Request, w http.ResponseWriter, session *sessions.Session) error {
+	ctx := r.Context()
+
+	if session.Options.MaxAge <= 0 {
+		if session.ID != "" {
+			id, err := uuid.FromString(session.ID)
+			if err != nil {
+				return err
+			}
+			if err := s.fac.User().DeleteSession(ctx, id); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	// the last seen time is kept in its own column
+	delete(session.Values, lastSeenKey)
+	data, err := (securecookie.GobEncoder{}).Serialize(session.Values)
+	if err != nil {
+		return err
+	}
+
+	stored := models.UserSession{
+		Data:      data,
+		UserAgent: r.UserAgent(),
+		IPAddress: remoteIP(r),
+		Expires:   time.Now().Add(time.Duration(session.Options.MaxAge) * time.Second),
+	}
+	if userID, ok := session.Values[userIDKey].(string); ok {
+		if id, err := uuid.FromString(userID); err == nil {
+			stored.UserID = uuid.NullUUID{UUID: id, Valid: true}
+		}
+	}
+
+	if session.ID == "" {
+		stored.ID, err = uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		if err := s.fac.User().CreateSession(ctx, stored); err != nil {
+			return err
+		}
+		session.ID = stored.ID.String()
+	} else {
+		stored.ID, err = uuid.FromString(session.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.fac.User().UpdateSession(ctx, stored); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
+
+	return nil
+}
+
+// renew discards the stored session, so that it is saved under a new id.
+// Called on login, so that a session id known before the login cannot be
+// used to take over the logged in session.
+func (s *sessionDBStore) renew(r *http.Request, session *sessions.Session) error {
+	if session.ID == "" {
+		return nil
+	}
+
+	id, err := uuid.FromString(session.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.fac.User().DeleteSession(r.Context(), id); err != nil {
+		return err
+	}
+
+	session.ID = ""
+	return nil
+}
+
+// remoteIP returns the IP address of the client. Requests from trusted proxies
+// are attributed to the last address in X-Forwarded-For that is not a trusted
+// proxy, since the addresses before it can be set by the client.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	proxies := config.GetTrustedProxies()
+	if !isTrustedProxy(host, proxies) {
+		return host
+	}
+
+	var forwarded []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			break
+		}
+		if !isTrustedProxy(addr.String(), proxies) {
+			return addr.String()
+		}
+		host = addr.String()
+	}
+	return host
+}
+
+func isTrustedProxy(ip string, proxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range proxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
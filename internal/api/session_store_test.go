@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash-box/internal/config"
+)
+
+func TestRemoteIP(t *testing.T) {
+	prevProxies := config.C.TrustedProxies
+	config.C.TrustedProxies = []string{"10.0.0.0/8", "192.0.2.1"}
+	defer func() { config.C.TrustedProxies = prevProxies }()
+
+	request := func(remoteAddr string, forwarded ...string) string {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = remoteAddr
+		for _, f := range forwarded {
+			r.Header.Add("X-Forwarded-For", f)
+		}
+		return remoteIP(r)
+	}
+
+	// direct requests ignore the header
+	assert.Equal(t, "198.51.100.1", request("198.51.100.1:1234"))
+	assert.Equal(t, "198.51.100.1", request("198.51.100.1:1234", "203.0.113.1"))
+
+	// proxied requests use the last untrusted address
+	assert.Equal(t, "203.0.113.1", request("10.1.2.3:1234", "203.0.113.1"))
+	assert.Equal(t, "203.0.113.1", request("192.0.2.1:1234", "203.0.113.9, 203.0.113.1, 10.0.0.2"))
+	assert.Equal(t, "203.0.113.1", request("10.1.2.3:1234", "203.0.113.9", "203.0.113.1"))
+	assert.Equal(t, "2001:db8::1", request("10.1.2.3:1234", "2001:db8::1"))
+
+	// without a usable header the proxy is the client
+	assert.Equal(t, "10.1.2.3", request("10.1.2.3:1234"))
+	assert.Equal(t, "10.1.2.3", request("10.1.2.3:1234", "unknown"))
+	assert.Equal(t, "10.0.0.2", request("10.1.2.3:1234", "10.0.0.2"))
+}
+
+func TestSessionNeedsRefresh(t *testing.T) {
+	now := time.Now()
+	session := sessions.NewSession(nil, cookieName)
+
+	// sessions without a last seen time are always stored
+	assert.True(t, sessionNeedsRefresh(session, now))
+
+	session.Values[lastSeenKey] = now.Add(-30 * time.Second)
+	assert.False(t, sessionNeedsRefresh(session, now))
+
+	session.Values[lastSeenKey] = now.Add(-sessionRefreshInterval)
+	assert.True(t, sessionNeedsRefresh(session, now))
+}
@@ -0,0 +1,216 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/oidc"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIssuer = "https://sso.example.com"
+
+type ssoTestRunner struct {
+	testRunner
+}
+
+func createSSOTestRunner(t *testing.T) *ssoTestRunner {
+	prev := config.C.OIDC.OIDCConfig
+	config.C.OIDC.OIDCConfig = config.OIDCConfig{
+		Enabled:      true,
+		Issuer:       testIssuer,
+		ClientID:     "stash-box",
+		ClientSecret: "secret",
+		RoleMappings: []config.OIDCRoleMapping{
+			{Value: "moderators", Roles: []string{"MODERATE"}},
+			{Value: "admins", Roles: []string{"ADMIN"}},
+		},
+	}
+	t.Cleanup(func() { config.C.OIDC.OIDCConfig = prev })
+
+	return &ssoTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *ssoTestRunner) claims(name string, roles ...string) oidc.Claims {
+	return oidc.Claims{
+		Issuer:        testIssuer,
+		Subject:       "subject-" + name,
+		Email:         name + "@example.com",
+		EmailVerified: true,
+		Username:      name,
+		Roles:         roles,
+	}
+}
+
+func (s *ssoTestRunner) testCreateUser() {
+	users := dbtest.Factory().User()
+	name := s.generateUserName()
+
+	id, err := users.LoginSSO(s.ctx, s.claims(name, "moderators", "unmapped"), nil)
+	assert.NoError(s.t, err)
+
+	created, roles, err := users.FindWithRoles(s.ctx, id)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, name, created.Name)
+	assert.Equal(s.t, name+"@example.com", created.Email)
+	assert.Contains(s.t, roles, models.RoleEnumModerate)
+	assert.NotContains(s.t, roles, models.RoleEnumAdmin)
+
+	// later logins use the linked account, and sync the mapped roles
+	again, err := users.LoginSSO(s.ctx, s.claims(name, "admins"), nil)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, id, again)
+
+	roles, err = users.GetRoles(s.ctx, id)
+	assert.NoError(s.t, err)
+	assert.Contains(s.t, roles, models.RoleEnumAdmin)
+	assert.NotContains(s.t, roles, models.RoleEnumModerate)
+	assert.Contains(s.t, roles, models.RoleEnumRead)
+}
+
+func (s *ssoTestRunner) testLinkExistingUser() {
+	users := dbtest.Factory().User()
+	name := s.generateUserName()
+
+	existing, err := s.createTestUser(&models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: "password" + name,
+		Roles:    []models.RoleEnum{models.RoleEnumRead, models.RoleEnumEdit},
+	}, nil)
+	assert.NoError(s.t, err)
+
+	// an unverified email address is not linked
+	claims := s.claims(name)
+	claims.EmailVerified = false
+	_, err = users.LoginSSO(s.ctx, claims, &existing.ID)
+	assert.ErrorIs(s.t, err, user.ErrSSOEmailNotVerified)
+
+	// linking requires the login to be started from the user's session
+	_, err = users.LoginSSO(s.ctx, s.claims(name), nil)
+	assert.ErrorIs(s.t, err, user.ErrSSOLinkRequiresLogin)
+
+	other, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit})
+	assert.NoError(s.t, err)
+	_, err = users.LoginSSO(s.ctx, s.claims(name), &other.ID)
+	assert.ErrorIs(s.t, err, user.ErrSSOLinkRequiresLogin)
+
+	id, err := users.LoginSSO(s.ctx, s.claims(name), &existing.ID)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, existing.ID, id)
+
+	// unmapped roles are kept
+	roles, err := users.GetRoles(s.ctx, id)
+	assert.NoError(s.t, err)
+	assert.ElementsMatch(s.t, []models.RoleEnum{models.RoleEnumRead, models.RoleEnumEdit}, roles)
+
+	// once linked, the email address of the account no longer matters
+	claims = s.claims(name)
+	claims.Email = "changed-" + name + "@example.com"
+	claims.EmailVerified = false
+	again, err := users.LoginSSO(s.ctx, claims, nil)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, id, again)
+}
+
+func (s *ssoTestRunner) testLinkProtectedUser() {
+	users := dbtest.Factory().User()
+
+	// staff accounts are never linked
+	name := s.generateUserName()
+	moderator, err := s.createTestUser(&models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: "password" + name,
+		Roles:    []models.RoleEnum{models.RoleEnumRead, models.RoleEnumModerate},
+	}, nil)
+	assert.NoError(s.t, err)
+
+	_, err = users.LoginSSO(s.ctx, s.claims(name), &moderator.ID)
+	assert.ErrorIs(s.t, err, user.ErrSSOLinkProtected)
+
+	// neither are accounts with two-factor authentication
+	name = s.generateUserName()
+	u, err := s.createTestUser(&models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: "password" + name,
+		Roles:    []models.RoleEnum{models.RoleEnumRead, models.RoleEnumEdit},
+	}, nil)
+	assert.NoError(s.t, err)
+
+	userCtx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(u))
+	setup, err := s.resolver.Mutation().SetupTwoFactor(userCtx)
+	assert.NoError(s.t, err)
+	_, err = s.resolver.Mutation().EnableTwoFactor(userCtx, currentTOTPCode(s.t, setup.Secret, 0))
+	assert.NoError(s.t, err)
+
+	_, err = users.LoginSSO(s.ctx, s.claims(name), &u.ID)
+	assert.ErrorIs(s.t, err, user.ErrSSOLinkProtected)
+}
+
+func (s *ssoTestRunner) testUsernameTaken() {
+	users := dbtest.Factory().User()
+	name := s.generateUserName()
+
+	_, err := s.createTestUser(&models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: "password" + name,
+	}, nil)
+	assert.NoError(s.t, err)
+
+	claims := s.claims(name)
+	claims.Subject = "other-" + name
+	claims.Email = "other-" + name + "@example.com"
+	_, err = users.LoginSSO(s.ctx, claims, nil)
+	assert.ErrorIs(s.t, err, user.ErrSSOUsernameTaken)
+}
+
+func (s *ssoTestRunner) testLocalRegistrationDisabled() {
+	config.C.DisableLocalRegistration = true
+	defer func() { config.C.DisableLocalRegistration = false }()
+
+	_, err := s.resolver.Mutation().NewUser(s.ctx, models.NewUserInput{
+		Email: s.generateUserName() + "@example.com",
+	})
+	assert.ErrorIs(s.t, err, user.ErrLocalRegistrationDisabled)
+
+	// users can still register through single sign-on
+	_, err = dbtest.Factory().User().LoginSSO(s.ctx, s.claims(s.generateUserName()), nil)
+	assert.NoError(s.t, err)
+}
+
+func TestSSOCreateUser(t *testing.T) {
+	pt := createSSOTestRunner(t)
+	pt.testCreateUser()
+}
+
+func TestSSOLinkExistingUser(t *testing.T) {
+	pt := createSSOTestRunner(t)
+	pt.testLinkExistingUser()
+}
+
+func TestSSOLinkProtectedUser(t *testing.T) {
+	pt := createSSOTestRunner(t)
+	pt.testLinkProtectedUser()
+}
+
+func TestSSOUsernameTaken(t *testing.T) {
+	pt := createSSOTestRunner(t)
+	pt.testUsernameTaken()
+}
+
+func TestLocalRegistrationDisabled(t *testing.T) {
+	pt := createSSOTestRunner(t)
+	pt.testLocalRegistrationDisabled()
+}
@@ -0,0 +1,251 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/edit"
+	"github.com/stashapp/stash-box/internal/service/studio"
+	"github.com/stretchr/testify/assert"
+)
+
+type studioNetworkTestRunner struct {
+	testRunner
+}
+
+func createStudioNetworkTestRunner(t *testing.T) *studioNetworkTestRunner {
+	return &studioNetworkTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+// createNetwork creates a three level network: network -> site -> sub-site
+func (s *studioNetworkTestRunner) createNetwork() (network, site, subSite uuid.UUID) {
+	s.t.Helper()
+
+	root, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+	network = root.UUID()
+
+	siteInput := models.StudioCreateInput{
+		Name:     s.generateStudioName(),
+		ParentID: &network,
+	}
+	child, err := s.createTestStudio(&siteInput)
+	assert.NoError(s.t, err)
+	site = child.UUID()
+
+	subSiteInput := models.StudioCreateInput{
+		Name:     s.generateStudioName(),
+		ParentID: &site,
+	}
+	grandchild, err := s.createTestStudio(&subSiteInput)
+	assert.NoError(s.t, err)
+	subSite = grandchild.UUID()
+
+	return network, site, subSite
+}
+
+func (s *studioNetworkTestRunner) createNetworkScene(studioID uuid.UUID, date string, performerID *uuid.UUID) uuid.UUID {
+	s.t.Helper()
+
+	title := s.generateSceneName()
+	input := models.SceneCreateInput{
+		Title:    &title,
+		StudioID: &studioID,
+		Date:     date,
+	}
+	if performerID != nil {
+		input.Performers = []models.PerformerAppearanceInput{
+			{PerformerID: *performerID},
+		}
+	}
+
+	scene, err := s.createTestScene(&input)
+	assert.NoError(s.t, err)
+	return scene.UUID()
+}
+
+func (s *studioNetworkTestRunner) testRecursiveSceneQuery() {
+	network, site, subSite := s.createNetwork()
+	networkScene := s.createNetworkScene(network, "2020-01-01", nil)
+	siteScene := s.createNetworkScene(site, "2021-01-01", nil)
+	subSiteScene := s.createNetworkScene(subSite, "2022-01-01", nil)
+
+	parent := network.String()
+	query, err := s.resolver.Query().QueryScenes(s.ctx, models.SceneQueryInput{
+		ParentStudio: &parent,
+		Page:         1,
+		PerPage:      10,
+	})
+	assert.NoError(s.t, err)
+
+	scenes, err := s.resolver.QueryScenesResultType().Scenes(s.ctx, query)
+	assert.NoError(s.t, err)
+
+	var ids []uuid.UUID
+	for _, scene := range scenes {
+		ids = append(ids, scene.ID)
+	}
+	assert.ElementsMatch(s.t, []uuid.UUID{networkScene, siteScene, subSiteScene}, ids)
+
+	// querying from the middle of the network excludes the root
+	parent = site.String()
+	query, err = s.resolver.Query().QueryScenes(s.ctx, models.SceneQueryInput{
+		ParentStudio: &parent,
+		Page:         1,
+		PerPage:      10,
+	})
+	assert.NoError(s.t, err)
+
+	count, err := s.resolver.QueryScenesResultType().Count(s.ctx, query)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 2, count)
+}
+
+func (s *studioNetworkTestRunner) testRecursivePerformerQuery() {
+	network, _, subSite := s.createNetwork()
+
+	performer, err := s.createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	performerID := performer.UUID()
+	s.createNetworkScene(subSite, "2022-01-01", &performerID)
+
+	input := models.PerformerQueryInput{
+		StudioID: &network,
+		Page:     1,
+		PerPage:  10,
+	}
+	count, err := s.resolver.QueryPerformersResultType().Count(s.ctx, &models.PerformerQuery{Filter: input})
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 0, count)
+
+	includeSubStudios := true
+	input.IncludeSubStudios = &includeSubStudios
+	count, err = s.resolver.QueryPerformersResultType().Count(s.ctx, &models.PerformerQuery{Filter: input})
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 1, count)
+}
+
+func (s *studioNetworkTestRunner) testNetworkStats() {
+	network, site, subSite := s.createNetwork()
+
+	performer, err := s.createTestPerformer(nil)
+	assert.NoError(s.t, err)
+	performerID := performer.UUID()
+
+	s.createNetworkScene(network, "2019-05-01", &performerID)
+	s.createNetworkScene(site, "2021-01-01", &performerID)
+	s.createNetworkScene(subSite, "2023-11-20", nil)
+
+	root, err := s.resolver.Query().FindStudio(s.ctx, &network, nil)
+	assert.NoError(s.t, err)
+
+	stats, err := s.resolver.Studio().NetworkStats(s.ctx, root)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 3, stats.StudioCount)
+	assert.Equal(s.t, 3, stats.SceneCount)
+	assert.Equal(s.t, 1, stats.PerformerCount)
+	assert.Equal(s.t, "2019-05-01", *stats.FirstSceneDate)
+	assert.Equal(s.t, "2023-11-20", *stats.LastSceneDate)
+
+	root, err = s.resolver.Query().FindStudio(s.ctx, &subSite, nil)
+	assert.NoError(s.t, err)
+
+	stats, err = s.resolver.Studio().NetworkStats(s.ctx, root)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 1, stats.StudioCount)
+	assert.Equal(s.t, 1, stats.SceneCount)
+	assert.Equal(s.t, 0, stats.PerformerCount)
+}
+
+func (s *studioNetworkTestRunner) testUpdateParentCycle() {
+	network, _, subSite := s.createNetwork()
+
+	_, err := s.resolver.Mutation().StudioUpdate(s.ctx, models.StudioUpdateInput{
+		ID:       network,
+		ParentID: &subSite,
+	})
+	assert.ErrorIs(s.t, err, studio.ErrParentCycle)
+
+	_, err = s.resolver.Mutation().StudioUpdate(s.ctx, models.StudioUpdateInput{
+		ID:       network,
+		ParentID: &network,
+	})
+	assert.ErrorIs(s.t, err, studio.ErrParentCycle)
+}
+
+func (s *studioNetworkTestRunner) testEditParentCycle() {
+	network, site, subSite := s.createNetwork()
+
+	_, err := s.resolver.Mutation().StudioEdit(s.ctx, models.StudioEditInput{
+		Edit: &models.EditInput{
+			Operation: models.OperationEnumModify,
+			ID:        &network,
+		},
+		Details: &models.StudioEditDetailsInput{
+			ParentID: &subSite,
+		},
+	})
+	assert.ErrorIs(s.t, err, edit.ErrStudioParentCycle)
+
+	// moving a leaf elsewhere in the network is fine
+	_, err = s.createTestStudioEdit(models.OperationEnumModify, &models.StudioEditDetailsInput{
+		ParentID: &network,
+	}, &models.EditInput{
+		Operation: models.OperationEnumModify,
+		ID:        &subSite,
+	})
+	assert.NoError(s.t, err)
+
+	// a pending edit that becomes cyclic fails when applied
+	other, err := s.createTestStudio(nil)
+	assert.NoError(s.t, err)
+	otherID := other.UUID()
+
+	pending, err := s.createTestStudioEdit(models.OperationEnumModify, &models.StudioEditDetailsInput{
+		ParentID: &otherID,
+	}, &models.EditInput{
+		Operation: models.OperationEnumModify,
+		ID:        &site,
+	})
+	assert.NoError(s.t, err)
+
+	_, err = s.resolver.Mutation().StudioUpdate(s.ctx, models.StudioUpdateInput{
+		ID:       otherID,
+		ParentID: &site,
+	})
+	assert.NoError(s.t, err)
+
+	applied, err := s.approveEdit(pending.ID)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, models.VoteStatusEnumFailed.String(), applied.Status)
+}
+
+func TestRecursiveSceneQuery(t *testing.T) {
+	pt := createStudioNetworkTestRunner(t)
+	pt.testRecursiveSceneQuery()
+}
+
+func TestRecursivePerformerQuery(t *testing.T) {
+	pt := createStudioNetworkTestRunner(t)
+	pt.testRecursivePerformerQuery()
+}
+
+func TestStudioNetworkStats(t *testing.T) {
+	pt := createStudioNetworkTestRunner(t)
+	pt.testNetworkStats()
+}
+
+func TestStudioUpdateParentCycle(t *testing.T) {
+	pt := createStudioNetworkTestRunner(t)
+	pt.testUpdateParentCycle()
+}
+
+func TestStudioEditParentCycle(t *testing.T) {
+	pt := createStudioNetworkTestRunner(t)
+	pt.testEditParentCycle()
+}
@@ -0,0 +1,144 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// currentTOTPCode computes the code an authenticator app would show for the
+// secret, offset by the given number of periods
+func currentTOTPCode(t *testing.T, secret string, offset int64) string {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	assert.NoError(t, err)
+
+	mac := hmac.New(sha1.New, key)
+	_ = binary.Write(mac, binary.BigEndian, time.Now().Unix()/30+offset)
+	sum := mac.Sum(nil)
+	o := sum[len(sum)-1] & 0x0f
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[o:o+4])&0x7fffffff)%1000000)
+}
+
+func TestTwoFactorAuthentication(t *testing.T) {
+	s := asAdmin(t)
+	users := dbtest.Factory().User()
+
+	u, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit})
+	assert.NoError(t, err)
+	userCtx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(u))
+
+	setup, err := s.resolver.Mutation().SetupTwoFactor(userCtx)
+	assert.NoError(t, err)
+	assert.Contains(t, setup.URI, "secret="+setup.Secret)
+
+	// the secret is only enabled once confirmed
+	enabled, err := users.IsTwoFactorEnabled(s.ctx, u.ID)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+
+	_, err = s.resolver.Mutation().EnableTwoFactor(userCtx, "000000")
+	assert.ErrorIs(t, err, user.ErrInvalidTwoFactorCode)
+
+	code := currentTOTPCode(t, setup.Secret, 0)
+	recoveryCodes, err := s.resolver.Mutation().EnableTwoFactor(userCtx, code)
+	assert.NoError(t, err)
+	assert.Len(t, recoveryCodes, 10)
+
+	status, err := s.resolver.User().TwoFactor(userCtx, u)
+	assert.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.False(t, status.Required)
+	assert.Equal(t, 10, status.RecoveryCodesRemaining)
+
+	_, err = s.resolver.Mutation().SetupTwoFactor(userCtx)
+	assert.ErrorIs(t, err, user.ErrTwoFactorEnabled)
+
+	// codes can not be replayed
+	assert.ErrorIs(t, users.VerifyTwoFactor(s.ctx, u.ID, code), user.ErrInvalidTwoFactorCode)
+	assert.NoError(t, users.VerifyTwoFactor(s.ctx, u.ID, currentTOTPCode(t, setup.Secret, 1)))
+
+	// recovery codes are single use
+	assert.NoError(t, users.VerifyTwoFactor(s.ctx, u.ID, recoveryCodes[0]))
+	assert.ErrorIs(t, users.VerifyTwoFactor(s.ctx, u.ID, recoveryCodes[0]), user.ErrInvalidTwoFactorCode)
+
+	status, err = s.resolver.User().TwoFactor(userCtx, u)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, status.RecoveryCodesRemaining)
+
+	// two-factor authentication can not be disabled when required
+	prevRoles := config.C.TwoFactorRequiredRoles
+	config.C.TwoFactorRequiredRoles = []string{string(models.RoleEnumEdit)}
+	t.Cleanup(func() { config.C.TwoFactorRequiredRoles = prevRoles })
+
+	assert.True(t, user.TwoFactorRequired([]models.RoleEnum{models.RoleEnumAdmin}, nil))
+	assert.False(t, user.TwoFactorRequired([]models.RoleEnum{models.RoleEnumVote}, nil))
+	// custom roles holding permissions of the required roles require it too
+	assert.True(t, user.TwoFactorRequired([]models.RoleEnum{models.RoleEnumVote}, []models.PermissionEnum{models.PermissionEnumEditScenes}))
+
+	_, err = s.resolver.Mutation().DisableTwoFactor(userCtx, recoveryCodes[1])
+	assert.ErrorIs(t, err, user.ErrTwoFactorRequired)
+
+	// admins can remove it when a user lost access
+	reset, err := s.resolver.Mutation().ResetTwoFactor(s.ctx, u.ID)
+	assert.NoError(t, err)
+	assert.True(t, reset)
+
+	enabled, err = users.IsTwoFactorEnabled(s.ctx, u.ID)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+
+	action := models.ModAuditActionEnumUserTwoFactorReset
+	result, err := s.resolver.Query().QueryModAudits(s.ctx, models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  25,
+		Action:   &action,
+		TargetID: &u.ID,
+	})
+	assert.NoError(t, err)
+	audits, err := s.resolver.QueryModAuditsResultType().Audits(s.ctx, result)
+	assert.NoError(t, err)
+	assert.Len(t, audits, 1)
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	s := asAdmin(t)
+
+	u, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(t, err)
+	userCtx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(u))
+
+	setup, err := s.resolver.Mutation().SetupTwoFactor(userCtx)
+	assert.NoError(t, err)
+	recoveryCodes, err := s.resolver.Mutation().EnableTwoFactor(userCtx, currentTOTPCode(t, setup.Secret, 0))
+	assert.NoError(t, err)
+
+	newCodes, err := s.resolver.Mutation().RegenerateRecoveryCodes(userCtx, recoveryCodes[0])
+	assert.NoError(t, err)
+	assert.Len(t, newCodes, 10)
+
+	// the old recovery codes were replaced
+	_, err = s.resolver.Mutation().DisableTwoFactor(userCtx, recoveryCodes[1])
+	assert.ErrorIs(t, err, user.ErrInvalidTwoFactorCode)
+
+	disabled, err := s.resolver.Mutation().DisableTwoFactor(userCtx, newCodes[0])
+	assert.NoError(t, err)
+	assert.True(t, disabled)
+
+	status, err := s.resolver.User().TwoFactor(userCtx, u)
+	assert.NoError(t, err)
+	assert.False(t, status.Enabled)
+}
@@ -0,0 +1,209 @@
+//go:build integration
+
+package api_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/converter"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stretchr/testify/assert"
+)
+
+type userAccountTestRunner struct {
+	testRunner
+}
+
+func createUserAccountTestRunner(t *testing.T) *userAccountTestRunner {
+	return &userAccountTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+// createEditor creates a user with an edit carrying a comment, returning a
+// runner acting as the user and the edit.
+func (s *userAccountTestRunner) createEditor(comment string) (*models.User, *testRunner, *models.Edit) {
+	s.t.Helper()
+
+	name := s.generateUserName()
+	roles := []models.RoleEnum{models.RoleEnumRead, models.RoleEnumEdit}
+	u, err := s.createTestUser(&models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: "password" + name,
+		Roles:    roles,
+	}, roles)
+	assert.NoError(s.t, err)
+
+	editor := createTestRunner(s.t, u, roles)
+	edit, err := editor.createTestTagEdit(models.OperationEnumCreate, nil, &models.EditInput{
+		Operation: models.OperationEnumCreate,
+		Comment:   &comment,
+	})
+	assert.NoError(s.t, err)
+
+	return u, editor, edit
+}
+
+// deleteAccountToken creates the token emailed to confirm the deletion of
+// the account.
+func (s *userAccountTestRunner) deleteAccountToken(userID uuid.UUID) uuid.UUID {
+	s.t.Helper()
+
+	var id uuid.UUID
+	err := dbtest.Factory().User().WithTxn(func(tx *queries.Queries) error {
+		param, err := converter.CreateUserTokenParamsFromData(models.UserTokenTypeDeleteAccount, models.UserTokenData{
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+		token, err := tx.CreateUserToken(s.ctx, param)
+		id = token.ID
+		return err
+	})
+	assert.NoError(s.t, err)
+	return id
+}
+
+func readExportFile(t *testing.T, archive *zip.Reader, name string, v any) {
+	t.Helper()
+
+	f, err := archive.Open(name)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, v))
+}
+
+func (s *userAccountTestRunner) testExportMyData() {
+	u, editor, edit := s.createEditor("export comment")
+
+	users := dbtest.Factory().User()
+
+	// the export is only available to logged in users
+	_, err := users.RequestDataExport(context.Background())
+	assert.ErrorIs(s.t, err, auth.ErrUnauthorized)
+
+	export, err := editor.resolver.Mutation().ExportMyData(editor.ctx)
+	assert.NoError(s.t, err)
+	assert.True(s.t, export.Expires.After(time.Now()))
+
+	token, err := uuid.FromString(path.Base(export.URL))
+	if !assert.NoError(s.t, err) {
+		return
+	}
+
+	// other tokens can't be used to export data
+	var buf bytes.Buffer
+	err = users.ExportData(s.ctx, s.deleteAccountToken(u.ID), &buf)
+	assert.ErrorIs(s.t, err, user.ErrInvalidActivationKey)
+	assert.Zero(s.t, buf.Len())
+
+	err = users.ExportData(s.ctx, token, &buf)
+	assert.NoError(s.t, err)
+
+	// the link can be used once
+	var again bytes.Buffer
+	err = users.ExportData(s.ctx, token, &again)
+	assert.ErrorIs(s.t, err, user.ErrInvalidActivationKey)
+
+	data := buf.Bytes()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if !assert.NoError(s.t, err) {
+		return
+	}
+
+	var profile struct {
+		ID    uuid.UUID `json:"id"`
+		Name  string    `json:"name"`
+		Email string    `json:"email"`
+	}
+	readExportFile(s.t, archive, "profile.json", &profile)
+	assert.Equal(s.t, u.ID, profile.ID)
+	assert.Equal(s.t, u.Name, profile.Name)
+	assert.Equal(s.t, u.Email, profile.Email)
+
+	var edits []struct {
+		ID uuid.UUID `json:"id"`
+	}
+	readExportFile(s.t, archive, "edits.json", &edits)
+	if assert.Len(s.t, edits, 1) {
+		assert.Equal(s.t, edit.ID, edits[0].ID)
+	}
+
+	var comments []struct {
+		EditID uuid.UUID `json:"edit_id"`
+		Text   string    `json:"text"`
+	}
+	readExportFile(s.t, archive, "comments.json", &comments)
+	if assert.Len(s.t, comments, 1) {
+		assert.Equal(s.t, edit.ID, comments[0].EditID)
+		assert.Equal(s.t, "export comment", comments[0].Text)
+	}
+
+	for _, name := range []string{"votes.json", "fingerprints.json", "favorites.json", "notifications.json"} {
+		_, err := archive.Open(name)
+		assert.NoError(s.t, err, name)
+	}
+}
+
+func (s *userAccountTestRunner) testDeleteMyAccount() {
+	u, editor, edit := s.createEditor("kept comment")
+	token := s.deleteAccountToken(u.ID)
+
+	// the token only deletes the account it was issued for
+	other := asEdit(s.t)
+	_, err := other.resolver.Mutation().ConfirmDeleteMyAccount(other.ctx, token)
+	assert.ErrorIs(s.t, err, user.ErrInvalidActivationKey)
+
+	deleted, err := editor.resolver.Mutation().ConfirmDeleteMyAccount(editor.ctx, token)
+	assert.NoError(s.t, err)
+	assert.True(s.t, deleted)
+
+	found, err := dbtest.Factory().User().FindByID(s.ctx, u.ID)
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, found)
+
+	// authored content is kept without the user
+	keptEdit, err := s.resolver.Query().FindEdit(s.ctx, edit.ID)
+	assert.NoError(s.t, err)
+	assert.False(s.t, keptEdit.UserID.Valid)
+
+	comments, err := s.resolver.Edit().Comments(s.ctx, keptEdit)
+	assert.NoError(s.t, err)
+	comment := findComment(comments, "kept comment")
+	if assert.NotNil(s.t, comment) {
+		assert.False(s.t, comment.UserID.Valid)
+	}
+
+	// the token cannot be used again
+	_, err = editor.resolver.Mutation().ConfirmDeleteMyAccount(editor.ctx, token)
+	assert.Error(s.t, err)
+}
+
+func TestExportMyData(t *testing.T) {
+	pt := createUserAccountTestRunner(t)
+	pt.testExportMyData()
+}
+
+func TestDeleteMyAccount(t *testing.T) {
+	pt := createUserAccountTestRunner(t)
+	pt.testDeleteMyAccount()
+}
@@ -0,0 +1,153 @@
+//go:build integration
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type inviteTreeTestRunner struct {
+	testRunner
+}
+
+func createInviteTreeTestRunner(t *testing.T) *inviteTreeTestRunner {
+	return &inviteTreeTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *inviteTreeTestRunner) createInvitedUser(invitedBy *uuid.UUID) *models.User {
+	s.t.Helper()
+
+	name := s.generateUserName()
+	roles := []models.RoleEnum{models.RoleEnumRead, models.RoleEnumEdit}
+	u, err := s.createTestUser(&models.UserCreateInput{
+		Name:        name,
+		Email:       name + "@example.com",
+		Password:    "password" + name,
+		Roles:       roles,
+		InvitedByID: invitedBy,
+	}, roles)
+	assert.NoError(s.t, err)
+	return u
+}
+
+// createTree creates a root user, a user invited by the root and a user
+// invited by that user, who has a pending edit.
+func (s *inviteTreeTestRunner) createTree() (root, child, grandchild *models.User) {
+	s.t.Helper()
+
+	root = s.createInvitedUser(nil)
+	child = s.createInvitedUser(&root.ID)
+	grandchild = s.createInvitedUser(&child.ID)
+
+	editor := createTestRunner(s.t, grandchild, []models.RoleEnum{models.RoleEnumRead, models.RoleEnumEdit})
+	_, err := editor.createTestTagEdit(models.OperationEnumCreate, nil, nil)
+	assert.NoError(s.t, err)
+
+	return root, child, grandchild
+}
+
+func (s *inviteTreeTestRunner) testInviteTree() {
+	root, child, grandchild := s.createTree()
+
+	nodes, err := s.resolver.Query().InviteTree(s.ctx, root.ID)
+	assert.NoError(s.t, err)
+	if !assert.Len(s.t, nodes, 3) {
+		return
+	}
+
+	assert.Equal(s.t, root.ID, nodes[0].UserID)
+	assert.Equal(s.t, 0, nodes[0].Depth)
+	assert.Equal(s.t, 2, nodes[0].Descendants)
+	assert.Equal(s.t, 1, nodes[0].BranchEdits.Pending)
+	assert.Equal(s.t, 0, nodes[0].Edits.Pending)
+
+	assert.Equal(s.t, child.ID, nodes[1].UserID)
+	assert.Equal(s.t, 1, nodes[1].Depth)
+	assert.Equal(s.t, 1, nodes[1].BranchEdits.Pending)
+
+	assert.Equal(s.t, grandchild.ID, nodes[2].UserID)
+	assert.Equal(s.t, 2, nodes[2].Depth)
+	assert.Equal(s.t, 1, nodes[2].Edits.Pending)
+
+	invitedBy, err := s.resolver.InviteTreeNode().InvitedBy(s.ctx, &nodes[2])
+	assert.NoError(s.t, err)
+	if assert.NotNil(s.t, invitedBy) {
+		assert.Equal(s.t, child.ID, invitedBy.ID)
+	}
+
+	// a subtree starts at the given user
+	nodes, err = s.resolver.Query().InviteTree(s.ctx, child.ID)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, nodes, 2)
+}
+
+func (s *inviteTreeTestRunner) testRevokeInviteTree() {
+	root, child, grandchild := s.createTree()
+	users := dbtest.Factory().User()
+
+	_, err := s.resolver.Mutation().GrantInvite(s.ctx, models.GrantInviteInput{UserID: child.ID, Amount: 3})
+	assert.NoError(s.t, err)
+
+	result, err := s.resolver.Mutation().RevokeInviteTree(s.ctx, models.InviteTreeRevokeInput{
+		UserID:        root.ID,
+		IncludeRoot:   false,
+		Suspend:       true,
+		RevokeInvites: true,
+		Reason:        "spam ring",
+	})
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 2, result.Users)
+	assert.Equal(s.t, 2, result.Suspended)
+	assert.Equal(s.t, 3, result.RevokedTokens)
+
+	suspension, err := users.FindActiveSuspension(s.ctx, root.ID)
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, suspension)
+
+	for _, u := range []*models.User{child, grandchild} {
+		suspension, err := users.FindActiveSuspension(s.ctx, u.ID)
+		assert.NoError(s.t, err)
+		if assert.NotNil(s.t, suspension) {
+			assert.Equal(s.t, "spam ring", suspension.Reason)
+		}
+	}
+
+	found, err := users.FindByID(s.ctx, child.ID)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 0, found.InviteTokens)
+
+	action := models.ModAuditActionEnumInviteTreeRevoke
+	query, err := s.resolver.Query().QueryModAudits(s.ctx, models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  25,
+		Action:   &action,
+		TargetID: &root.ID,
+	})
+	assert.NoError(s.t, err)
+	audits, err := s.resolver.QueryModAuditsResultType().Audits(s.ctx, query)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, audits, 1)
+
+	_, err = s.resolver.Mutation().RevokeInviteTree(s.ctx, models.InviteTreeRevokeInput{
+		UserID: root.ID,
+		Reason: "nothing to do",
+	})
+	assert.Error(s.t, err)
+}
+
+func TestInviteTree(t *testing.T) {
+	pt := createInviteTreeTestRunner(t)
+	pt.testInviteTree()
+}
+
+func TestRevokeInviteTree(t *testing.T) {
+	pt := createInviteTreeTestRunner(t)
+	pt.testRevokeInviteTree()
+}
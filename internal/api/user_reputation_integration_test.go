@@ -0,0 +1,101 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserReputation(t *testing.T) {
+	s := asAdmin(t)
+
+	user, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit})
+	assert.NoError(t, err)
+
+	// A user without any history starts in the middle
+	reputation, err := s.resolver.User().Reputation(s.ctx, user)
+	assert.NoError(t, err)
+	assert.Equal(t, 50, reputation.Score)
+
+	userCtx := context.WithValue(s.ctx, auth.ContextUser, auth.FromUser(user))
+	name := s.generateTagName()
+	edit, err := s.resolver.Mutation().TagEdit(userCtx, models.TagEditInput{
+		Edit:    &models.EditInput{Operation: models.OperationEnumCreate},
+		Details: &models.TagEditDetailsInput{Name: &name},
+	})
+	assert.NoError(t, err)
+
+	_, err = s.resolver.Mutation().ApproveEdit(s.ctx, models.ApproveEditInput{ID: edit.ID})
+	assert.NoError(t, err)
+
+	reputation, err = s.resolver.User().Reputation(s.ctx, user)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reputation.AcceptedEdits)
+	assert.Greater(t, reputation.Score, 50)
+
+	prevRules := config.C.ReputationRules
+	config.C.ReputationRules = []config.ReputationRule{
+		{Role: string(models.RoleEnumManageInvites), MinScore: reputation.Score, MinEdits: 1},
+	}
+	t.Cleanup(func() { config.C.ReputationRules = prevRules })
+
+	// The cached roles of the user are dropped when they change
+	auth.CacheSet(auth.FromUser(user), []models.RoleEnum{models.RoleEnumEdit})
+	_, _, cached := auth.CacheGet(user.ID)
+	assert.True(t, cached)
+
+	assert.NoError(t, dbtest.Factory().User().ApplyReputationRules(s.ctx))
+
+	_, _, cached = auth.CacheGet(user.ID)
+	assert.False(t, cached)
+
+	roles, err := s.resolver.User().Roles(s.ctx, user)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumManageInvites}, roles)
+
+	action := models.ModAuditActionEnumUserReputationUpdate
+	result, err := s.resolver.Query().QueryModAudits(s.ctx, models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  25,
+		Action:   &action,
+		TargetID: &user.ID,
+	})
+	assert.NoError(t, err)
+	audits, err := s.resolver.QueryModAuditsResultType().Audits(s.ctx, result)
+	assert.NoError(t, err)
+	if !assert.Len(t, audits, 1) {
+		return
+	}
+	if assert.NotNil(t, audits[0].Reason) {
+		assert.Contains(t, *audits[0].Reason, "granted MANAGE_INVITES")
+	}
+
+	var data struct {
+		Before struct{ Roles []string } `json:"before"`
+		After  struct{ Roles []string } `json:"after"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(audits[0].Data), &data))
+	assert.NotContains(t, data.Before.Roles, "MANAGE_INVITES")
+	assert.Contains(t, data.After.Roles, "MANAGE_INVITES")
+
+	// Applying the rules again changes nothing
+	assert.NoError(t, dbtest.Factory().User().ApplyReputationRules(s.ctx))
+	result, err = s.resolver.Query().QueryModAudits(s.ctx, models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  25,
+		Action:   &action,
+		TargetID: &user.ID,
+	})
+	assert.NoError(t, err)
+	audits, err = s.resolver.QueryModAuditsResultType().Audits(s.ctx, result)
+	assert.NoError(t, err)
+	assert.Len(t, audits, 1)
+}
@@ -0,0 +1,212 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/auth"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stretchr/testify/assert"
+)
+
+type userSessionTestRunner struct {
+	testRunner
+}
+
+func createUserSessionTestRunner(t *testing.T) *userSessionTestRunner {
+	return &userSessionTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+func (s *userSessionTestRunner) createSession(userID uuid.UUID) uuid.UUID {
+	s.t.Helper()
+
+	id, err := uuid.NewV4()
+	assert.NoError(s.t, err)
+	err = dbtest.Factory().User().CreateSession(s.ctx, models.UserSession{
+		ID:        id,
+		UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+		Data:      []byte{},
+		UserAgent: "test",
+		IPAddress: "127.0.0.1",
+		Expires:   time.Now().Add(time.Hour),
+	})
+	assert.NoError(s.t, err)
+	return id
+}
+
+func (s *userSessionTestRunner) sessionContext(u *models.User, sessionID uuid.UUID) context.Context {
+	au := auth.FromUser(u)
+	au.SessionID = &sessionID
+	return context.WithValue(s.ctx, auth.ContextUser, au)
+}
+
+func (s *userSessionTestRunner) testMySessions() {
+	u, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+	other, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+
+	current := s.createSession(u.ID)
+	second := s.createSession(u.ID)
+	otherSession := s.createSession(other.ID)
+	ctx := s.sessionContext(u, current)
+
+	sessions, err := s.resolver.Query().MySessions(ctx)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, sessions, 2)
+
+	for _, session := range sessions {
+		isCurrent, err := s.resolver.UserSession().Current(ctx, &session)
+		assert.NoError(s.t, err)
+		assert.Equal(s.t, session.ID == current, isCurrent)
+		assert.Equal(s.t, "127.0.0.1", session.IPAddress)
+	}
+
+	// sessions of other users cannot be revoked
+	_, err = s.resolver.Mutation().RevokeSession(ctx, otherSession)
+	assert.ErrorIs(s.t, err, user.ErrSessionNotFound)
+
+	revoked, err := s.resolver.Mutation().RevokeSession(ctx, second)
+	assert.NoError(s.t, err)
+	assert.True(s.t, revoked)
+
+	found, err := dbtest.Factory().User().FindSession(s.ctx, second)
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, found)
+}
+
+func (s *userSessionTestRunner) testRevokeOtherSessions() {
+	u, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+
+	current := s.createSession(u.ID)
+	s.createSession(u.ID)
+	s.createSession(u.ID)
+	ctx := s.sessionContext(u, current)
+
+	count, err := s.resolver.Mutation().RevokeOtherSessions(ctx)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 2, count)
+
+	sessions, err := s.resolver.Query().MySessions(ctx)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, sessions, 1)
+	assert.Equal(s.t, current, sessions[0].ID)
+}
+
+func (s *userSessionTestRunner) testChangePasswordRevokesSessions() {
+	name := s.generateUserName()
+	password := "password" + name
+	u, err := s.createTestUser(&models.UserCreateInput{
+		Name:     name,
+		Email:    name + "@example.com",
+		Password: password,
+		Roles:    []models.RoleEnum{models.RoleEnumRead},
+	}, nil)
+	assert.NoError(s.t, err)
+
+	current := s.createSession(u.ID)
+	s.createSession(u.ID)
+	ctx := s.sessionContext(u, current)
+
+	_, err = s.resolver.Mutation().ChangePassword(ctx, models.UserChangePasswordInput{
+		ExistingPassword: &password,
+		NewPassword:      name + "newpassword",
+	})
+	assert.NoError(s.t, err)
+
+	// the session changing the password stays logged in
+	sessions, err := s.resolver.Query().MySessions(ctx)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, sessions, 1)
+	assert.Equal(s.t, current, sessions[0].ID)
+}
+
+func (s *userSessionTestRunner) testUpdateRolesRevokesSessions() {
+	u, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit})
+	assert.NoError(s.t, err)
+	s.createSession(u.ID)
+
+	// unrelated changes keep the sessions
+	name := s.generateUserName()
+	_, err = s.resolver.Mutation().UserUpdate(s.ctx, models.UserUpdateInput{
+		ID:    u.ID,
+		Name:  &name,
+		Roles: []models.RoleEnum{models.RoleEnumEdit},
+	})
+	assert.NoError(s.t, err)
+
+	sessions, err := dbtest.Factory().User().GetSessions(s.ctx, u.ID)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, sessions, 1)
+
+	_, err = s.resolver.Mutation().UserUpdate(s.ctx, models.UserUpdateInput{
+		ID:    u.ID,
+		Roles: []models.RoleEnum{models.RoleEnumRead},
+	})
+	assert.NoError(s.t, err)
+
+	sessions, err = dbtest.Factory().User().GetSessions(s.ctx, u.ID)
+	assert.NoError(s.t, err)
+	assert.Empty(s.t, sessions)
+}
+
+func (s *userSessionTestRunner) testRevokeUserSessions() {
+	u, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumRead})
+	assert.NoError(s.t, err)
+	s.createSession(u.ID)
+	s.createSession(u.ID)
+
+	count, err := s.resolver.Mutation().RevokeUserSessions(s.ctx, u.ID)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, 2, count)
+
+	sessions, err := dbtest.Factory().User().GetSessions(s.ctx, u.ID)
+	assert.NoError(s.t, err)
+	assert.Empty(s.t, sessions)
+
+	action := models.ModAuditActionEnumUserRevokeSessions
+	result, err := s.resolver.Query().QueryModAudits(s.ctx, models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  25,
+		Action:   &action,
+		TargetID: &u.ID,
+	})
+	assert.NoError(s.t, err)
+	audits, err := s.resolver.QueryModAuditsResultType().Audits(s.ctx, result)
+	assert.NoError(s.t, err)
+	assert.Len(s.t, audits, 1)
+}
+
+func TestMySessions(t *testing.T) {
+	pt := createUserSessionTestRunner(t)
+	pt.testMySessions()
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	pt := createUserSessionTestRunner(t)
+	pt.testRevokeOtherSessions()
+}
+
+func TestChangePasswordRevokesSessions(t *testing.T) {
+	pt := createUserSessionTestRunner(t)
+	pt.testChangePasswordRevokesSessions()
+}
+
+func TestUpdateRolesRevokesSessions(t *testing.T) {
+	pt := createUserSessionTestRunner(t)
+	pt.testUpdateRolesRevokesSessions()
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	pt := createUserSessionTestRunner(t)
+	pt.testRevokeUserSessions()
+}
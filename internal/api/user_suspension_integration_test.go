@@ -0,0 +1,309 @@
+//go:build integration
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/api"
+	"github.com/stashapp/stash-box/internal/auth"
+	dbtest "github.com/stashapp/stash-box/internal/database/testutil"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/service/user"
+	"github.com/stretchr/testify/assert"
+)
+
+type userSuspensionTestRunner struct {
+	testRunner
+}
+
+func createUserSuspensionTestRunner(t *testing.T) *userSuspensionTestRunner {
+	return &userSuspensionTestRunner{
+		testRunner: *asAdmin(t),
+	}
+}
+
+// suspensionNotification returns the data of the latest notification of the
+// given type sent to the user
+func (s *userSuspensionTestRunner) suspensionNotification(userID uuid.UUID, notificationType models.NotificationEnum) models.NotificationData {
+	s.t.Helper()
+
+	notifications, err := dbtest.Factory().Notification().GetNotifications(s.ctx, userID, false, 1, 25, &notificationType)
+	assert.NoError(s.t, err)
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	data, err := s.resolver.Notification().Data(s.ctx, &notifications[0])
+	assert.NoError(s.t, err)
+	return data
+}
+
+func (s *userSuspensionTestRunner) testSuspendUser() {
+	suspended, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumVote})
+	assert.NoError(s.t, err)
+
+	expires := time.Now().Add(24 * time.Hour)
+	suspension, err := s.resolver.Mutation().UserSuspend(s.ctx, models.UserSuspendInput{
+		UserID:  suspended.ID,
+		Reason:  "spamming comments",
+		Expires: &expires,
+	})
+	assert.NoError(s.t, err)
+	assert.True(s.t, suspension.Active())
+	assert.Equal(s.t, userDB.admin.ID, suspension.CreatedByID.UUID)
+
+	active, err := s.resolver.User().Suspension(s.ctx, suspended)
+	assert.NoError(s.t, err)
+	if assert.NotNil(s.t, active) {
+		assert.Equal(s.t, suspension.ID, active.ID)
+	}
+
+	// the user is notified with the reason
+	data := s.suspensionNotification(suspended.ID, models.NotificationEnumUserSuspended)
+	if notification, ok := data.(*models.UserSuspended); assert.True(s.t, ok) {
+		assert.Equal(s.t, "spamming comments", notification.Suspension.Reason)
+	}
+
+	// voting and commenting are rejected, reading is not
+	ctx := context.WithValue(s.ctx, auth.ContextUser, &auth.AuthUser{
+		ID:   suspended.ID,
+		Name: suspended.Name,
+		Suspension: &auth.Suspension{
+			Reason:  suspension.Reason,
+			Expires: suspension.Expires,
+		},
+	})
+	ctx = context.WithValue(ctx, auth.ContextRoles, []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumVote})
+	next := func(ctx context.Context) (any, error) { return true, nil }
+	for _, permission := range []models.PermissionEnum{models.PermissionEnumVote, models.PermissionEnumEdit} {
+		_, err = api.HasPermissionDirective(ctx, nil, graphql.Resolver(next), permission)
+		assert.ErrorIs(s.t, err, auth.ErrSuspended)
+	}
+	_, err = api.HasPermissionDirective(ctx, nil, graphql.Resolver(next), models.PermissionEnumRead)
+	assert.NoError(s.t, err)
+
+	// a new suspension replaces the active one
+	ban, err := s.resolver.Mutation().UserSuspend(s.ctx, models.UserSuspendInput{
+		UserID: suspended.ID,
+		Reason: "kept spamming",
+	})
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, ban.Expires)
+
+	history, err := s.resolver.User().Suspensions(s.ctx, suspended)
+	assert.NoError(s.t, err)
+	if assert.Len(s.t, history, 2) {
+		assert.Equal(s.t, ban.ID, history[0].ID)
+		assert.True(s.t, history[0].Active())
+		assert.Equal(s.t, suspension.ID, history[1].ID)
+		assert.False(s.t, history[1].Active())
+	}
+
+	action := models.ModAuditActionEnumUserSuspend
+	result, err := s.resolver.Query().QueryModAudits(s.ctx, models.ModAuditQueryInput{
+		Page:     1,
+		PerPage:  25,
+		Action:   &action,
+		TargetID: &suspended.ID,
+	})
+	assert.NoError(s.t, err)
+	audits, err := s.resolver.QueryModAuditsResultType().Audits(s.ctx, result)
+	assert.NoError(s.t, err)
+	if assert.Len(s.t, audits, 2) {
+		var data struct {
+			Before *struct{ Reason string } `json:"before"`
+			After  *struct{ Reason string } `json:"after"`
+		}
+		assert.NoError(s.t, json.Unmarshal([]byte(audits[0].Data), &data))
+		if assert.NotNil(s.t, data.Before) && assert.NotNil(s.t, data.After) {
+			assert.Equal(s.t, "spamming comments", data.Before.Reason)
+			assert.Equal(s.t, "kept spamming", data.After.Reason)
+		}
+	}
+
+	lifted, err := s.resolver.Mutation().UserLiftSuspension(s.ctx, models.UserLiftSuspensionInput{
+		UserID: suspended.ID,
+	})
+	assert.NoError(s.t, err)
+	assert.True(s.t, lifted)
+
+	active, err = s.resolver.User().Suspension(s.ctx, suspended)
+	assert.NoError(s.t, err)
+	assert.Nil(s.t, active)
+
+	data = s.suspensionNotification(suspended.ID, models.NotificationEnumUserSuspensionLifted)
+	if notification, ok := data.(*models.UserSuspensionLifted); assert.True(s.t, ok) {
+		assert.Equal(s.t, ban.ID, notification.Suspension.ID)
+		assert.Equal(s.t, userDB.admin.ID, notification.Suspension.LiftedByID.UUID)
+	}
+
+	_, err = s.resolver.Mutation().UserLiftSuspension(s.ctx, models.UserLiftSuspensionInput{
+		UserID: suspended.ID,
+	})
+	assert.ErrorIs(s.t, err, user.ErrNotSuspended)
+}
+
+func (s *userSuspensionTestRunner) testSuspendValidation() {
+	target, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit})
+	assert.NoError(s.t, err)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = s.resolver.Mutation().UserSuspend(s.ctx, models.UserSuspendInput{
+		UserID:  target.ID,
+		Reason:  "too late",
+		Expires: &past,
+	})
+	assert.ErrorIs(s.t, err, user.ErrSuspensionExpired)
+
+	_, err = s.resolver.Mutation().UserSuspend(s.ctx, models.UserSuspendInput{
+		UserID: target.ID,
+		Reason: "  ",
+	})
+	assert.ErrorIs(s.t, err, user.ErrEmptySuspensionReason)
+
+	_, err = s.resolver.Mutation().UserSuspend(s.ctx, models.UserSuspendInput{
+		UserID: userDB.admin.ID,
+		Reason: "self",
+	})
+	assert.ErrorIs(s.t, err, user.ErrSuspendSelf)
+
+	admin, err := s.createTestUser(nil, nil)
+	assert.NoError(s.t, err)
+	_, err = s.resolver.Mutation().UserSuspend(s.ctx, models.UserSuspendInput{
+		UserID: admin.ID,
+		Reason: "admins are not suspended",
+	})
+	assert.ErrorIs(s.t, err, user.ErrSuspendAdmin)
+}
+
+func (s *userSuspensionTestRunner) testLiftExpiredSuspensions() {
+	suspended, err := s.createTestUser(nil, []models.RoleEnum{models.RoleEnumEdit})
+	assert.NoError(s.t, err)
+
+	expires := time.Now().Add(time.Second)
+	suspension, err := s.resolver.Mutation().UserSuspend(s.ctx, models.UserSuspendInput{
+		UserID:  suspended.ID,
+		Reason:  "cool down",
+		Expires: &expires,
+	})
+	assert.NoError(s.t, err)
+
+	time.Sleep(1100 * time.Millisecond)
+	assert.NoError(s.t, dbtest.Factory().User().LiftExpiredSuspensions(s.ctx))
+
+	lifted, err := dbtest.Factory().User().FindSuspensionByID(s.ctx, suspension.ID)
+	assert.NoError(s.t, err)
+	if assert.NotNil(s.t, lifted) {
+		assert.NotNil(s.t, lifted.Lifted)
+		assert.False(s.t, lifted.LiftedByID.Valid)
+	}
+
+	data := s.suspensionNotification(suspended.ID, models.NotificationEnumUserSuspensionLifted)
+	_, ok := data.(*models.UserSuspensionLifted)
+	assert.True(s.t, ok)
+}
+
+// suspendedContext returns a context acting as the user with their active
+// suspension, as set up by the authentication handler
+func (s *userSuspensionTestRunner) suspendedContext(u *models.User, roles []models.RoleEnum) context.Context {
+	s.t.Helper()
+
+	suspension, err := dbtest.Factory().User().FindActiveSuspension(s.ctx, u.ID)
+	assert.NoError(s.t, err)
+	authUser := &auth.AuthUser{ID: u.ID, Name: u.Name}
+	if suspension != nil {
+		authUser.Suspension = &auth.Suspension{
+			Reason:      suspension.Reason,
+			Restriction: suspension.Restriction,
+			Expires:     suspension.Expires,
+		}
+	}
+
+	ctx := context.WithValue(s.ctx, auth.ContextUser, authUser)
+	return context.WithValue(ctx, auth.ContextRoles, auth.EffectiveRoles(authUser, roles))
+}
+
+func (s *userSuspensionTestRunner) testSuspensionRestrictions() {
+	roles := []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumVote}
+	next := func(ctx context.Context) (any, error) { return true, nil }
+	edit, err := s.createTestTagEdit(models.OperationEnumCreate, nil, nil)
+	assert.NoError(s.t, err)
+
+	suspend := func(restriction models.SuspensionRestrictionEnum) context.Context {
+		u, err := s.createTestUser(nil, roles)
+		assert.NoError(s.t, err)
+
+		expires := time.Now().Add(time.Hour)
+		suspension, err := s.resolver.Mutation().UserSuspend(s.ctx, models.UserSuspendInput{
+			UserID:      u.ID,
+			Reason:      "restricted",
+			Restriction: restriction,
+			Expires:     &expires,
+		})
+		assert.NoError(s.t, err)
+		assert.Equal(s.t, restriction, suspension.Restriction)
+
+		return s.suspendedContext(u, roles)
+	}
+
+	// voting is withheld, editing and commenting are not
+	ctx := suspend(models.SuspensionRestrictionEnumNoVoting)
+	_, err = api.HasPermissionDirective(ctx, nil, graphql.Resolver(next), models.PermissionEnumVote)
+	assert.ErrorIs(s.t, err, auth.ErrSuspended)
+	_, err = api.HasPermissionDirective(ctx, nil, graphql.Resolver(next), models.PermissionEnumEdit)
+	assert.NoError(s.t, err)
+	_, err = s.resolver.Mutation().EditComment(ctx, models.EditCommentInput{ID: edit.ID, Comment: "still commenting"})
+	assert.NoError(s.t, err)
+
+	// commenting is withheld, editing and voting are not
+	ctx = suspend(models.SuspensionRestrictionEnumNoCommenting)
+	for _, permission := range []models.PermissionEnum{models.PermissionEnumVote, models.PermissionEnumEdit} {
+		_, err = api.HasPermissionDirective(ctx, nil, graphql.Resolver(next), permission)
+		assert.NoError(s.t, err)
+	}
+	_, err = s.resolver.Mutation().EditComment(ctx, models.EditCommentInput{ID: edit.ID, Comment: "not allowed"})
+	assert.ErrorIs(s.t, err, auth.ErrSuspended)
+
+	// edits can still be submitted, but not with a comment
+	comment := "not allowed either"
+	_, err = s.resolver.Mutation().TagEdit(ctx, models.TagEditInput{
+		Edit:    &models.EditInput{Operation: models.OperationEnumCreate, Comment: &comment},
+		Details: &models.TagEditDetailsInput{Name: &comment},
+	})
+	assert.ErrorIs(s.t, err, auth.ErrSuspended)
+
+	// everything but reading is withheld
+	ctx = suspend(models.SuspensionRestrictionEnumReadOnly)
+	for _, permission := range []models.PermissionEnum{models.PermissionEnumVote, models.PermissionEnumEdit} {
+		_, err = api.HasPermissionDirective(ctx, nil, graphql.Resolver(next), permission)
+		assert.ErrorIs(s.t, err, auth.ErrSuspended)
+	}
+	_, err = api.HasPermissionDirective(ctx, nil, graphql.Resolver(next), models.PermissionEnumRead)
+	assert.NoError(s.t, err)
+}
+
+func TestSuspendUser(t *testing.T) {
+	pt := createUserSuspensionTestRunner(t)
+	pt.testSuspendUser()
+}
+
+func TestSuspendValidation(t *testing.T) {
+	pt := createUserSuspensionTestRunner(t)
+	pt.testSuspendValidation()
+}
+
+func TestLiftExpiredSuspensions(t *testing.T) {
+	pt := createUserSuspensionTestRunner(t)
+	pt.testLiftExpiredSuspensions()
+}
+
+func TestSuspensionRestrictions(t *testing.T) {
+	pt := createUserSuspensionTestRunner(t)
+	pt.testSuspensionRestrictions()
+}
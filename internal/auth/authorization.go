@@ -3,8 +3,11 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/config"
 	"github.com/stashapp/stash-box/internal/models"
 )
 
@@ -13,11 +16,14 @@ type key int
 const (
 	ContextUser key = iota
 	ContextRoles
+	ContextClientIP
 )
 
 const APIKeyHeader = "ApiKey"
 
 var ErrUnauthorized = errors.New("not authorized")
+var ErrTwoFactorSetupRequired = errors.New("two-factor authentication must be enabled for your roles")
+var ErrGrantNotHeld = errors.New("cannot grant permissions you do not hold")
 
 func GetCurrentUser(ctx context.Context) *AuthUser {
 	userCtxVal := ctx.Value(ContextUser)
@@ -29,44 +35,92 @@ func GetCurrentUser(ctx context.Context) *AuthUser {
 	return nil
 }
 
-func IsRole(ctx context.Context, requiredRole models.RoleEnum) bool {
-	var roles []models.RoleEnum
+// GetClientIP returns the IP address the request was made from, or an empty
+// string outside of a request.
+func GetClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(ContextClientIP).(string)
+	return ip
+}
 
-	roleCtxVal := ctx.Value(ContextRoles)
-	if roleCtxVal != nil {
-		roles = roleCtxVal.([]models.RoleEnum)
+// GrantedPermissions returns the permissions granted by the built-in roles
+// and the permissions of the custom roles of a user.
+func GrantedPermissions(roles []models.RoleEnum, custom []models.PermissionEnum) []models.PermissionEnum {
+	granted := make(map[models.PermissionEnum]bool)
+	for _, role := range roles {
+		for _, permission := range role.Permissions() {
+			granted[permission] = true
+		}
+	}
+	for _, permission := range custom {
+		granted[permission] = true
 	}
 
-	valid := false
+	// unless the tag role is required, everyone who may edit may edit tags.
+	// Editing tags also requires the edit permission, as it did before tag
+	// editing was a permission of its own.
+	if granted[models.PermissionEnumEdit] && !config.GetRequireTagRole() {
+		granted[models.PermissionEnumEditTags] = true
+	}
+	if !granted[models.PermissionEnumEdit] {
+		delete(granted, models.PermissionEnumEditTags)
+	}
 
-	for _, role := range roles {
-		if role.Implies(requiredRole) {
-			valid = true
-			break
+	var ret []models.PermissionEnum
+	for _, permission := range models.AllPermissionEnum {
+		if granted[permission] {
+			ret = append(ret, permission)
 		}
 	}
+	return ret
+}
+
+func HasPermission(ctx context.Context, permission models.PermissionEnum) bool {
+	roles, _ := ctx.Value(ContextRoles).([]models.RoleEnum)
 
-	return valid
+	// custom roles are ignored while the user may only read
+	var custom []models.PermissionEnum
+	if user := GetCurrentUser(ctx); user != nil {
+		if user.Suspension.withholds(permission) {
+			return false
+		}
+		if !user.restricted() {
+			custom = user.Permissions
+		}
+	}
+
+	return slices.Contains(GrantedPermissions(roles, custom), permission)
 }
 
-func ValidateRole(ctx context.Context, requiredRole models.RoleEnum) error {
-	if !IsRole(ctx, requiredRole) {
+func ValidatePermission(ctx context.Context, permission models.PermissionEnum) error {
+	if !HasPermission(ctx, permission) {
 		return ErrUnauthorized
 	}
 
 	return nil
 }
 
+// ValidateGrant returns an error unless the current user holds every
+// permission being granted, so that users can't grant themselves or others
+// more than they have.
+func ValidateGrant(ctx context.Context, permissions []models.PermissionEnum) error {
+	for _, permission := range permissions {
+		if !HasPermission(ctx, permission) {
+			return fmt.Errorf("%w: %s", ErrGrantNotHeld, permission)
+		}
+	}
+	return nil
+}
+
 func ValidateInvite(ctx context.Context) error {
-	return ValidateRole(ctx, models.RoleEnumInvite)
+	return ValidatePermission(ctx, models.PermissionEnumInvite)
 }
 
 func ValidateManageInvites(ctx context.Context) error {
-	return ValidateRole(ctx, models.RoleEnumManageInvites)
+	return ValidatePermission(ctx, models.PermissionEnumManageInvites)
 }
 
-func ValidateAdmin(ctx context.Context) error {
-	return ValidateRole(ctx, models.RoleEnumAdmin)
+func ValidateManageUsers(ctx context.Context) error {
+	return ValidatePermission(ctx, models.PermissionEnumManageUsers)
 }
 
 func ValidateOwner(ctx context.Context, userID uuid.UUID) error {
@@ -82,9 +136,19 @@ func ValidateUserOrAdmin(ctx context.Context, userID uuid.UUID) error {
 	if err := ValidateOwner(ctx, userID); err == nil {
 		return nil
 	}
-	return ValidateRole(ctx, models.RoleEnumAdmin)
+	return ValidateManageUsers(ctx)
 }
 
 func ValidateBot(ctx context.Context) error {
-	return ValidateRole(ctx, models.RoleEnumBot)
+	return ValidatePermission(ctx, models.PermissionEnumBot)
+}
+
+// ValidateTwoFactorSetup returns an error if the current user has to enable
+// two-factor authentication before using their roles.
+func ValidateTwoFactorSetup(ctx context.Context) error {
+	user := GetCurrentUser(ctx)
+	if user != nil && user.TwoFactorSetupRequired {
+		return ErrTwoFactorSetupRequired
+	}
+	return nil
 }
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantedPermissions(t *testing.T) {
+	assert.Equal(t,
+		[]models.PermissionEnum{models.PermissionEnumRead, models.PermissionEnumVote, models.PermissionEnumModerateFingerprints},
+		GrantedPermissions([]models.RoleEnum{models.RoleEnumVote}, []models.PermissionEnum{models.PermissionEnumModerateFingerprints, models.PermissionEnumRead}),
+	)
+
+	// editors may edit tags unless the tag role is required
+	assert.Contains(t, GrantedPermissions([]models.RoleEnum{models.RoleEnumEdit}, nil), models.PermissionEnumEditTags)
+
+	config.C.RequireTagRole = true
+	defer func() { config.C.RequireTagRole = false }()
+	assert.NotContains(t, GrantedPermissions([]models.RoleEnum{models.RoleEnumEdit}, nil), models.PermissionEnumEditTags)
+	assert.Contains(t, GrantedPermissions([]models.RoleEnum{models.RoleEnumEdit, models.RoleEnumEditTags}, nil), models.PermissionEnumEditTags)
+	assert.Contains(t, GrantedPermissions(nil, []models.PermissionEnum{models.PermissionEnumEdit, models.PermissionEnumEditTags}), models.PermissionEnumEditTags)
+
+	// editing tags also requires the edit permission
+	assert.NotContains(t, GrantedPermissions([]models.RoleEnum{models.RoleEnumEditTags}, nil), models.PermissionEnumEditTags)
+	assert.NotContains(t, GrantedPermissions(nil, []models.PermissionEnum{models.PermissionEnumEditTags}), models.PermissionEnumEditTags)
+}
+
+func TestHasPermission(t *testing.T) {
+	assert.False(t, HasPermission(context.Background(), models.PermissionEnumRead))
+
+	user := &AuthUser{
+		ID:          newID(t),
+		Permissions: []models.PermissionEnum{models.PermissionEnumModerateComments},
+	}
+	ctx := context.WithValue(context.Background(), ContextUser, user)
+	ctx = context.WithValue(ctx, ContextRoles, []models.RoleEnum{models.RoleEnumRead})
+
+	assert.True(t, HasPermission(ctx, models.PermissionEnumRead))
+	assert.True(t, HasPermission(ctx, models.PermissionEnumModerateComments))
+	assert.False(t, HasPermission(ctx, models.PermissionEnumModerateEdits))
+	assert.ErrorIs(t, ValidatePermission(ctx, models.PermissionEnumModerateEdits), ErrUnauthorized)
+
+	// custom roles do not apply while the user is suspended
+	user.Suspension = &Suspension{Reason: "spam"}
+	assert.False(t, HasPermission(ctx, models.PermissionEnumModerateComments))
+	assert.True(t, HasPermission(ctx, models.PermissionEnumRead))
+}
+
+func TestValidateGrant(t *testing.T) {
+	user := &AuthUser{
+		ID:          newID(t),
+		Permissions: []models.PermissionEnum{models.PermissionEnumManageRoles},
+	}
+	ctx := context.WithValue(context.Background(), ContextUser, user)
+	ctx = context.WithValue(ctx, ContextRoles, []models.RoleEnum{models.RoleEnumVote})
+
+	assert.NoError(t, ValidateGrant(ctx, []models.PermissionEnum{models.PermissionEnumVote, models.PermissionEnumManageRoles}))
+	assert.ErrorIs(t, ValidateGrant(ctx, []models.PermissionEnum{models.PermissionEnumVote, models.PermissionEnumManageUsers}), ErrGrantNotHeld)
+	assert.ErrorIs(t, ValidateGrant(ctx, models.RoleEnumAdmin.Permissions()), ErrGrantNotHeld)
+
+	admin := context.WithValue(ctx, ContextRoles, []models.RoleEnum{models.RoleEnumAdmin})
+	assert.NoError(t, ValidateGrant(admin, models.AllPermissionEnum))
+}
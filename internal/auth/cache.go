@@ -9,9 +9,20 @@ import (
 )
 
 type AuthUser struct { //nolint:revive // distinct from models.User on purpose
-	ID     uuid.UUID
-	Name   string
-	APIKey string
+	ID               uuid.UUID
+	Name             string
+	APIKey           string
+	Suspension       *Suspension
+	TwoFactorEnabled bool
+	// Granted by the custom roles of the user, in addition to the
+	// permissions of their built-in roles
+	Permissions []models.PermissionEnum
+	// Set for the request, when the user logged in with a session although
+	// their roles require two-factor authentication they have not enabled
+	TwoFactorSetupRequired bool
+	// Set for the request, when the user is authenticated with a session
+	// rather than an api key
+	SessionID *uuid.UUID
 }
 
 // var (not const) so tests can shrink them.
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+var ErrSuspended = errors.New("account suspended")
+
+// Suspension restricts a user to read-only access, or withholds voting or
+// commenting, until it expires. A suspension without an expiry lasts until it
+// is lifted.
+type Suspension struct {
+	Reason string
+	// Read-only if not set
+	Restriction models.SuspensionRestrictionEnum
+	Expires     *time.Time
+}
+
+// Active returns whether the suspension currently restricts the user. A
+// suspension that expired before the cron job lifted it no longer applies.
+func (s *Suspension) Active() bool {
+	return s != nil && (s.Expires == nil || s.Expires.After(time.Now()))
+}
+
+func (s *Suspension) restricts(restriction models.SuspensionRestrictionEnum) bool {
+	if !s.Active() {
+		return false
+	}
+	if s.Restriction == "" {
+		return restriction == models.SuspensionRestrictionEnumReadOnly
+	}
+	return s.Restriction == restriction
+}
+
+// withholds returns whether the suspension withholds a permission, other
+// than by restricting the user to read-only access.
+func (s *Suspension) withholds(permission models.PermissionEnum) bool {
+	return permission == models.PermissionEnumVote && s.restricts(models.SuspensionRestrictionEnumNoVoting)
+}
+
+func (s *Suspension) err() error {
+	var scope string
+	switch s.Restriction {
+	case models.SuspensionRestrictionEnumNoVoting:
+		scope = " from voting"
+	case models.SuspensionRestrictionEnumNoCommenting:
+		scope = " from commenting"
+	}
+
+	if s.Expires == nil {
+		return fmt.Errorf("%w%s: %s", ErrSuspended, scope, s.Reason)
+	}
+	return fmt.Errorf("%w%s until %s: %s", ErrSuspended, scope, s.Expires.UTC().Format(time.RFC3339), s.Reason)
+}
+
+// restricted returns whether the user may only read. This is the case for
+// users suspended to read-only access, and users that have yet to set up
+// required two-factor authentication.
+func (u *AuthUser) restricted() bool {
+	return u.Suspension.restricts(models.SuspensionRestrictionEnumReadOnly) || u.TwoFactorSetupRequired
+}
+
+// EffectiveRoles returns the roles the user may currently act with.
+// Restricted users may only read, and users suspended from voting lose the
+// vote role.
+func EffectiveRoles(user *AuthUser, roles []models.RoleEnum) []models.RoleEnum {
+	if user == nil {
+		return roles
+	}
+	if user.restricted() {
+		return []models.RoleEnum{models.RoleEnumRead}
+	}
+	if user.Suspension.withholds(models.PermissionEnumVote) {
+		return slices.DeleteFunc(slices.Clone(roles), func(role models.RoleEnum) bool {
+			return role == models.RoleEnumVote
+		})
+	}
+	return roles
+}
+
+// ValidateNotSuspended returns an error describing the suspension of the
+// current user, if it withholds the permission.
+func ValidateNotSuspended(ctx context.Context, permission models.PermissionEnum) error {
+	user := GetCurrentUser(ctx)
+	if user == nil {
+		return nil
+	}
+	if user.Suspension.restricts(models.SuspensionRestrictionEnumReadOnly) || user.Suspension.withholds(permission) {
+		return user.Suspension.err()
+	}
+	return nil
+}
+
+// ValidateComment returns an error describing the suspension of the current
+// user, if they are suspended from commenting on edits.
+func ValidateComment(ctx context.Context) error {
+	user := GetCurrentUser(ctx)
+	if user != nil && user.Suspension.restricts(models.SuspensionRestrictionEnumNoCommenting) {
+		return user.Suspension.err()
+	}
+	return nil
+}
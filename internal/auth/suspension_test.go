@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspensionActive(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	var none *Suspension
+	assert.False(t, none.Active())
+	assert.True(t, (&Suspension{Reason: "ban"}).Active())
+	assert.True(t, (&Suspension{Reason: "timeout", Expires: &future}).Active())
+	assert.False(t, (&Suspension{Reason: "timeout", Expires: &past}).Active())
+}
+
+func TestEffectiveRoles(t *testing.T) {
+	roles := []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumVote}
+	past := time.Now().Add(-time.Minute)
+
+	assert.Nil(t, EffectiveRoles(nil, nil))
+	assert.Equal(t, roles, EffectiveRoles(&AuthUser{ID: newID(t)}, roles))
+	assert.Equal(t, roles, EffectiveRoles(&AuthUser{ID: newID(t), Suspension: &Suspension{Expires: &past}}, roles))
+	assert.Equal(t, []models.RoleEnum{models.RoleEnumRead}, EffectiveRoles(&AuthUser{ID: newID(t), Suspension: &Suspension{}}, roles))
+	assert.Equal(t, []models.RoleEnum{models.RoleEnumRead}, EffectiveRoles(&AuthUser{ID: newID(t), Suspension: &Suspension{Restriction: models.SuspensionRestrictionEnumReadOnly}}, roles))
+	assert.Equal(t, []models.RoleEnum{models.RoleEnumEdit}, EffectiveRoles(&AuthUser{ID: newID(t), Suspension: &Suspension{Restriction: models.SuspensionRestrictionEnumNoVoting}}, roles))
+	assert.Equal(t, roles, EffectiveRoles(&AuthUser{ID: newID(t), Suspension: &Suspension{Restriction: models.SuspensionRestrictionEnumNoCommenting}}, roles))
+}
+
+func TestSuspensionRestrictions(t *testing.T) {
+	roles := []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumVote}
+	userContext := func(restriction models.SuspensionRestrictionEnum) context.Context {
+		user := &AuthUser{
+			ID:          newID(t),
+			Suspension:  &Suspension{Reason: "spam", Restriction: restriction},
+			Permissions: []models.PermissionEnum{models.PermissionEnumVote},
+		}
+		ctx := context.WithValue(context.Background(), ContextUser, user)
+		return context.WithValue(ctx, ContextRoles, EffectiveRoles(user, roles))
+	}
+
+	t.Run("read only", func(t *testing.T) {
+		ctx := userContext(models.SuspensionRestrictionEnumReadOnly)
+		assert.True(t, HasPermission(ctx, models.PermissionEnumRead))
+		assert.False(t, HasPermission(ctx, models.PermissionEnumVote))
+		assert.False(t, HasPermission(ctx, models.PermissionEnumEdit))
+		assert.ErrorIs(t, ValidateNotSuspended(ctx, models.PermissionEnumEdit), ErrSuspended)
+	})
+
+	t.Run("no voting", func(t *testing.T) {
+		ctx := userContext(models.SuspensionRestrictionEnumNoVoting)
+		// custom roles don't grant it back either
+		assert.False(t, HasPermission(ctx, models.PermissionEnumVote))
+		assert.True(t, HasPermission(ctx, models.PermissionEnumEdit))
+		assert.EqualError(t, ValidateNotSuspended(ctx, models.PermissionEnumVote), "account suspended from voting: spam")
+		assert.NoError(t, ValidateNotSuspended(ctx, models.PermissionEnumEdit))
+		assert.NoError(t, ValidateComment(ctx))
+	})
+
+	t.Run("no commenting", func(t *testing.T) {
+		ctx := userContext(models.SuspensionRestrictionEnumNoCommenting)
+		assert.True(t, HasPermission(ctx, models.PermissionEnumVote))
+		assert.True(t, HasPermission(ctx, models.PermissionEnumEdit))
+		assert.NoError(t, ValidateNotSuspended(ctx, models.PermissionEnumEdit))
+		assert.EqualError(t, ValidateComment(ctx), "account suspended from commenting: spam")
+	})
+}
+
+func TestValidateNotSuspended(t *testing.T) {
+	assert.NoError(t, ValidateNotSuspended(context.Background(), models.PermissionEnumEdit))
+
+	ctx := context.WithValue(context.Background(), ContextUser, &AuthUser{ID: newID(t)})
+	assert.NoError(t, ValidateNotSuspended(ctx, models.PermissionEnumEdit))
+
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx = context.WithValue(context.Background(), ContextUser, &AuthUser{
+		ID:         newID(t),
+		Suspension: &Suspension{Reason: "spam", Expires: &expires},
+	})
+	err := ValidateNotSuspended(ctx, models.PermissionEnumEdit)
+	assert.True(t, errors.Is(err, ErrSuspended))
+	assert.EqualError(t, err, "account suspended until 2030-01-02T03:04:05Z: spam")
+}
@@ -0,0 +1,94 @@
+// Package calendar renders iCalendar (RFC 5545) feeds of all-day events.
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	dateFormat      = "20060102"
+	timestampFormat = "20060102T150405Z"
+	maxLineLength   = 75
+)
+
+// Event is a single all-day calendar entry.
+type Event struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	URL         string
+}
+
+// Calendar is a named collection of events.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// Write renders the calendar in iCalendar format.
+func (c Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format(timestampFormat)
+
+	writeLine(bw, "BEGIN:VCALENDAR")
+	writeLine(bw, "VERSION:2.0")
+	writeLine(bw, "PRODID:-//stash-box//Release Calendar//EN")
+	writeLine(bw, "CALSCALE:GREGORIAN")
+	writeLine(bw, "METHOD:PUBLISH")
+	writeLine(bw, "X-WR-CALNAME:"+textEscaper.Replace(c.Name))
+
+	for _, event := range c.Events {
+		writeLine(bw, "BEGIN:VEVENT")
+		writeLine(bw, "UID:"+event.UID)
+		writeLine(bw, "DTSTAMP:"+stamp)
+		writeLine(bw, "DTSTART;VALUE=DATE:"+event.Date.Format(dateFormat))
+		writeLine(bw, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format(dateFormat))
+		writeLine(bw, "SUMMARY:"+textEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			writeLine(bw, "DESCRIPTION:"+textEscaper.Replace(event.Description))
+		}
+		if event.URL != "" {
+			writeLine(bw, "URL:"+event.URL)
+		}
+		writeLine(bw, "TRANSP:TRANSPARENT")
+		writeLine(bw, "END:VEVENT")
+	}
+
+	writeLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// writeLine writes a content line terminated by CRLF, folding it into
+// continuation lines of at most 75 octets without splitting UTF-8 sequences.
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		_, _ = w.WriteString(line[:cut])
+		_, _ = w.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space, which counts towards the limit
+		limit = maxLineLength - 1
+	}
+	_, _ = w.WriteString(line)
+	_, _ = w.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
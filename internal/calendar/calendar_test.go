@@ -0,0 +1,77 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	cal := Calendar{
+		Name: "Releases",
+		Events: []Event{{
+			UID:         "abc@example.org",
+			Date:        time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+			Summary:     "Studio; Title, part 1",
+			Description: "line one\nline two",
+			URL:         "https://example.org/scenes/abc",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART;VALUE=DATE:20261231\r\n",
+		"DTEND;VALUE=DATE:20270101\r\n",
+		`SUMMARY:Studio\; Title\, part 1` + "\r\n",
+		`DESCRIPTION:line one\nline two` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteLineFolding(t *testing.T) {
+	cal := Calendar{
+		Name: "Releases",
+		Events: []Event{{
+			UID:     "abc",
+			Date:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Summary: strings.Repeat("é", 100),
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var summary strings.Builder
+	inSummary := false
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("line exceeds %d octets: %q", maxLineLength, line)
+		}
+		switch {
+		case strings.HasPrefix(line, "SUMMARY:"):
+			inSummary = true
+			summary.WriteString(strings.TrimPrefix(line, "SUMMARY:"))
+		case inSummary && strings.HasPrefix(line, " "):
+			summary.WriteString(line[1:])
+		default:
+			inSummary = false
+		}
+	}
+
+	if summary.String() != strings.Repeat("é", 100) {
+		t.Errorf("unfolded summary mismatch: %q", summary.String())
+	}
+}
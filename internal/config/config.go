@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"net/netip"
 	"time"
 
 	"github.com/spf13/viper"
@@ -42,6 +43,36 @@ type AutocertConfig struct {
 	CacheDir string `mapstructure:"cache_dir"`
 }
 
+// ReputationRule grants a role to users whose reputation reaches MinScore
+// after at least MinEdits accepted edits, and removes it again when their
+// score drops below RemoveBelow.
+type ReputationRule struct {
+	Role     string `mapstructure:"role"`
+	MinScore int    `mapstructure:"min_score"`
+	MinEdits int    `mapstructure:"min_edits"`
+	// Score below which the role is removed, 0 to never remove it
+	RemoveBelow int `mapstructure:"remove_below"`
+}
+
+// OIDCRoleMapping grants Roles to users whose roles claim contains Value.
+type OIDCRoleMapping struct {
+	Value string   `mapstructure:"value"`
+	Roles []string `mapstructure:"roles"`
+}
+
+type OIDCConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	Issuer       string   `mapstructure:"issuer"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	Scopes       []string `mapstructure:"scopes"`
+	// Claims holding the username and the roles of the user
+	UsernameClaim string `mapstructure:"username_claim"`
+	RolesClaim    string `mapstructure:"roles_claim"`
+	// Roles granted and removed on each login based on the roles claim
+	RoleMappings []OIDCRoleMapping `mapstructure:"role_mappings"`
+}
+
 type FrontendConfig struct {
 	Path   string `mapstructure:"path"`   // directory holding the build (index.html + assets/)
 	Prefix string `mapstructure:"prefix"` // URL mount point, e.g. "/v2"
@@ -67,11 +98,36 @@ type config struct {
 	ActivationExpiry  int      `mapstructure:"activation_expiry"`
 	EmailCooldown     int      `mapstructure:"email_cooldown"`
 	DefaultUserRoles  []string `mapstructure:"default_user_roles"`
+	// Only allow new users to register through single sign-on
+	DisableLocalRegistration bool `mapstructure:"disable_local_registration"`
+
+	// Roles that must enable two-factor authentication to use the web
+	// interface with more than read access
+	TwoFactorRequiredRoles []string `mapstructure:"two_factor_required_roles"`
+
+	// Failed logins to an account, or password reset and registration
+	// requests for an email address, before further attempts are locked out
+	LockoutAttempts int `mapstructure:"lockout_attempts"`
+	// Failed logins, password resets for unknown email addresses and failed
+	// registrations from an IP address before further attempts are locked out
+	LockoutIPAttempts int `mapstructure:"lockout_ip_attempts"`
+	// Lockout in seconds after reaching the limit, doubled with each further
+	// attempt up to the maximum
+	LockoutDuration    int `mapstructure:"lockout_duration"`
+	LockoutMaxDuration int `mapstructure:"lockout_max_duration"`
+
+	// IP addresses or CIDR ranges of reverse proxies whose X-Forwarded-For
+	// header is trusted for the client IP address
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 
 	// URL link for contributor guidelines for submitting edits
 	GuidelinesURL string `mapstructure:"guidelines_url"`
 	// Number of approved edits before user automatically gets VOTE role
 	VotePromotionThreshold int `mapstructure:"vote_promotion_threshold"`
+	// Roles granted and removed automatically based on user reputation
+	ReputationRules []ReputationRule `mapstructure:"reputation_rules"`
+	// Interval between runs applying the reputation rules
+	ReputationCronInterval string `mapstructure:"reputation_cron_interval"`
 	// Number of positive votes required for immediate approval
 	VoteApplicationThreshold int `mapstructure:"vote_application_threshold"`
 	// Duration, in seconds, of the voting period
@@ -82,6 +138,8 @@ type config struct {
 	VoteCronInterval string `mapstructure:"vote_cron_interval"`
 	// Number of times an edit can be updated by the creator
 	EditUpdateLimit int `mapstructure:"edit_update_limit"`
+	// Maximum number of entities changed by a bulk edit
+	BulkEditLimit int `mapstructure:"bulk_edit_limit"`
 	// Require all scene create edits to be submitted via drafts
 	RequireSceneDraft bool `mapstructure:"require_scene_draft"`
 	// Require the TagRole or Admin to edit tags
@@ -95,6 +153,8 @@ type config struct {
 	EmailFrom    string `mapstructure:"email_from"`
 	EmailTLSMode string `mapstructure:"email_tls_mode"`
 	HostURL      string `mapstructure:"host_url"`
+	// Interval between runs sending notification emails
+	NotificationEmailInterval string `mapstructure:"notification_email_interval"`
 
 	// Image storage settings
 	ImageLocation    string `mapstructure:"image_location"`
@@ -130,6 +190,10 @@ type config struct {
 		AutocertConfig `mapstructure:",squash"`
 	}
 
+	OIDC struct {
+		OIDCConfig `mapstructure:",squash"`
+	}
+
 	PHashDistance int `mapstructure:"phash_distance"`
 
 	Title string `mapstructure:"title"`
@@ -143,6 +207,9 @@ type config struct {
 	// Number of days to retain mod audit logs (0 to disable logging)
 	ModAuditRetentionDays int `mapstructure:"mod_audit_retention_days"`
 
+	// Full text search implementation, paradedb or postgres
+	SearchBackend string `mapstructure:"search_backend"`
+
 	CSP string `mapstructure:"csp"`
 }
 
@@ -157,25 +224,42 @@ const (
 	S3Backend   ImageBackendType = "s3"
 )
 
+type SearchBackendType string
+
+const (
+	// ParadeDBSearch uses the BM25 indexes of the pg_search extension
+	ParadeDBSearch SearchBackendType = "paradedb"
+	// PostgresSearch uses tsvector and pg_trgm, available on plain PostgreSQL
+	PostgresSearch SearchBackendType = "postgres"
+)
+
 var defaultUserRoles = []string{"READ", "VOTE", "EDIT"}
 var C = &config{
 	RequireInvite:              true,
 	RequireActivation:          false,
 	ActivationExpiry:           2 * 60 * 60,
 	EmailCooldown:              5 * 60,
+	LockoutAttempts:            5,
+	LockoutIPAttempts:          20,
+	LockoutDuration:            60,
+	LockoutMaxDuration:         60 * 60,
 	EmailPort:                  25,
 	ImageBackend:               string(FileBackend),
 	PHashDistance:              0,
 	VoteApplicationThreshold:   3,
 	VotePromotionThreshold:     10,
 	VoteCronInterval:           "5m",
+	NotificationEmailInterval:  "5m",
+	ReputationCronInterval:     "1h",
 	VotingPeriod:               345600,
 	MinDestructiveVotingPeriod: 172800,
 	DraftTimeLimit:             86400,
 	EditUpdateLimit:            1,
+	BulkEditLimit:              500,
 	RequireSceneDraft:          false,
 	RequireTagRole:             false,
 	ModAuditRetentionDays:      30,
+	SearchBackend:              string(ParadeDBSearch),
 }
 
 func GetDatabasePath() string {
@@ -219,6 +303,12 @@ func GetRequireInvite() bool {
 	return C.RequireInvite
 }
 
+// GetDisableLocalRegistration returns true if new users can only register
+// through single sign-on.
+func GetDisableLocalRegistration() bool {
+	return C.DisableLocalRegistration
+}
+
 // GetRequireActivation returns true if new users must validate their email address
 // via activation to create an account.
 func GetRequireActivation() bool {
@@ -245,6 +335,47 @@ func GetDefaultUserRoles() []string {
 	return C.DefaultUserRoles
 }
 
+func GetTwoFactorRequiredRoles() []string {
+	return C.TwoFactorRequiredRoles
+}
+
+// GetLockoutAttempts returns the number of attempts for an account or email
+// address before further attempts are locked out. Zero disables the lockout.
+func GetLockoutAttempts() int {
+	return C.LockoutAttempts
+}
+
+// GetLockoutIPAttempts returns the number of attempts from an IP address
+// before further attempts are locked out. Zero disables the lockout.
+func GetLockoutIPAttempts() int {
+	return C.LockoutIPAttempts
+}
+
+// GetTrustedProxies returns the address ranges of trusted reverse proxies.
+// Single addresses are returned as ranges of one address. Invalid entries are
+// ignored.
+func GetTrustedProxies() []netip.Prefix {
+	var ret []netip.Prefix
+	for _, proxy := range C.TrustedProxies {
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			ret = append(ret, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(proxy); err == nil {
+			ret = append(ret, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+	return ret
+}
+
+// GetLockoutDuration returns the lockout after reaching the attempt limit.
+func GetLockoutDuration() time.Duration {
+	return time.Duration(C.LockoutDuration * int(time.Second))
+}
+
+// GetLockoutMaxDuration returns the longest lockout.
+func GetLockoutMaxDuration() time.Duration {
+	return time.Duration(C.LockoutMaxDuration * int(time.Second))
+}
+
 func GetEmailHost() string {
 	return C.EmailHost
 }
@@ -317,6 +448,48 @@ func GetAutocertConfig() *AutocertConfig {
 	return nil
 }
 
+// GetOIDCConfig returns the single sign-on settings, or nil if single sign-on
+// is disabled.
+func GetOIDCConfig() *OIDCConfig {
+	if !C.OIDC.Enabled {
+		return nil
+	}
+
+	ret := C.OIDC.OIDCConfig
+	if len(ret.Scopes) == 0 {
+		ret.Scopes = []string{"profile", "email"}
+	}
+	if ret.UsernameClaim == "" {
+		ret.UsernameClaim = "preferred_username"
+	}
+	if ret.RolesClaim == "" {
+		ret.RolesClaim = "groups"
+	}
+	return &ret
+}
+
+func GetMissingOIDCSettings() []string {
+	if !C.OIDC.Enabled {
+		return nil
+	}
+
+	missing := []string{}
+	if C.OIDC.Issuer == "" {
+		missing = append(missing, "issuer")
+	}
+	if C.OIDC.ClientID == "" {
+		missing = append(missing, "client_id")
+	}
+	if C.OIDC.ClientSecret == "" {
+		missing = append(missing, "client_secret")
+	}
+	if C.HostURL == "" {
+		missing = append(missing, "host_url")
+	}
+
+	return missing
+}
+
 func GetMissingAutocertSettings() []string {
 	if !C.Autocert.Enabled {
 		return nil
@@ -455,6 +628,19 @@ func GetVotePromotionThreshold() *int {
 	return &C.VotePromotionThreshold
 }
 
+func GetReputationRules() []ReputationRule {
+	return C.ReputationRules
+}
+
+// GetReputationCronInterval returns the interval between runs applying the
+// reputation rules, or an empty string when no rules are configured.
+func GetReputationCronInterval() string {
+	if len(C.ReputationRules) == 0 {
+		return ""
+	}
+	return C.ReputationCronInterval
+}
+
 func GetVoteApplicationThreshold() int {
 	return C.VoteApplicationThreshold
 }
@@ -471,10 +657,23 @@ func GetVoteCronInterval() string {
 	return C.VoteCronInterval
 }
 
+// GetNotificationEmailInterval returns the interval between runs sending
+// notification emails. Empty if email is not configured.
+func GetNotificationEmailInterval() string {
+	if GetEmailFrom() == "" || GetEmailHost() == "" || GetHostURL() == "" {
+		return ""
+	}
+	return C.NotificationEmailInterval
+}
+
 func GetEditUpdateLimit() int {
 	return C.EditUpdateLimit
 }
 
+func GetBulkEditLimit() int {
+	return C.BulkEditLimit
+}
+
 func GetRequireSceneDraft() bool {
 	return C.RequireSceneDraft
 }
@@ -509,6 +708,14 @@ func GetModAuditRetentionDays() int {
 	return C.ModAuditRetentionDays
 }
 
+// GetSearchBackend returns the implementation used for full text search.
+func GetSearchBackend() SearchBackendType {
+	if SearchBackendType(C.SearchBackend) == PostgresSearch {
+		return PostgresSearch
+	}
+	return ParadeDBSearch
+}
+
 func GetMaxOpenConns() int {
 	if C.Postgres.MaxOpenConns == 0 {
 		return 25
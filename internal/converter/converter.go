@@ -185,6 +185,37 @@ func BodyModInputToModel(inputs []models.BodyModificationInput) []models.BodyMod
 	return inputConverter.ConvertBodyModInputSlice(inputs)
 }
 
+// CareerPeriodInputToModel converts []models.PerformerCareerPeriodInput to []models.PerformerCareerPeriod
+func CareerPeriodInputToModel(inputs []models.PerformerCareerPeriodInput) []models.PerformerCareerPeriod {
+	if inputs == nil {
+		return nil
+	}
+
+	periods := make([]models.PerformerCareerPeriod, len(inputs))
+	for i, input := range inputs {
+		periods[i] = models.PerformerCareerPeriod{
+			StartDate: input.StartDate,
+			EndDate:   input.EndDate,
+			StudioID:  input.StudioID,
+			Note:      input.Note,
+		}
+	}
+	return periods
+}
+
+// CareerPeriodToModel converts a queries.PerformerCareerPeriod to a models.PerformerCareerPeriod
+func CareerPeriodToModel(p queries.PerformerCareerPeriod) models.PerformerCareerPeriod {
+	period := models.PerformerCareerPeriod{
+		StartDate: p.StartDate,
+		EndDate:   p.EndDate,
+		Note:      p.Note,
+	}
+	if p.StudioID.Valid {
+		period.StudioID = &p.StudioID.UUID
+	}
+	return period
+}
+
 // PerformerToCreateParams converts a models.Performer to a queries.CreatePerformerParams
 func PerformerToCreateParams(p models.Performer) queries.CreatePerformerParams {
 	return createParamsConverter.ConvertPerformerToCreateParams(p)
@@ -638,6 +669,176 @@ func DraftToModelPtr(d queries.Draft) *models.Draft {
 	return &draft
 }
 
+// SavedSearchToModel converts a queries.SavedSearch to a models.SavedSearch
+func SavedSearchToModel(s queries.SavedSearch) models.SavedSearch {
+	return models.SavedSearch{
+		ID:            s.ID,
+		UserID:        s.UserID,
+		Name:          s.Name,
+		Type:          models.SavedSearchTypeEnum(s.Type),
+		Filter:        s.Filter,
+		Notify:        s.Notify,
+		LastCheckedAt: s.LastCheckedAt,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+}
+
+func SavedSearchToModelPtr(s queries.SavedSearch) *models.SavedSearch {
+	savedSearch := SavedSearchToModel(s)
+	return &savedSearch
+}
+
+// SavedSearchesToModels converts []queries.SavedSearch to []models.SavedSearch
+func SavedSearchesToModels(searches []queries.SavedSearch) []models.SavedSearch {
+	ret := make([]models.SavedSearch, len(searches))
+	for i, s := range searches {
+		ret[i] = SavedSearchToModel(s)
+	}
+	return ret
+}
+
+// UserSuspensionToModel converts a queries.UserSuspension to a models.UserSuspension
+func UserSuspensionToModel(s queries.UserSuspension) models.UserSuspension {
+	return models.UserSuspension{
+		ID:          s.ID,
+		UserID:      s.UserID,
+		Reason:      s.Reason,
+		Restriction: models.SuspensionRestrictionEnum(s.Restriction),
+		CreatedByID: s.CreatedBy,
+		Created:     s.CreatedAt,
+		Expires:     s.ExpiresAt,
+		Lifted:      s.LiftedAt,
+		LiftedByID:  s.LiftedBy,
+	}
+}
+
+func UserSuspensionToModelPtr(s queries.UserSuspension) *models.UserSuspension {
+	suspension := UserSuspensionToModel(s)
+	return &suspension
+}
+
+// UserSuspensionsToModels converts []queries.UserSuspension to []models.UserSuspension
+func UserSuspensionsToModels(suspensions []queries.UserSuspension) []models.UserSuspension {
+	ret := make([]models.UserSuspension, len(suspensions))
+	for i, s := range suspensions {
+		ret[i] = UserSuspensionToModel(s)
+	}
+	return ret
+}
+
+// EditGroupToModel converts a queries.EditGroup to a models.EditGroup
+func EditGroupToModel(g queries.EditGroup) models.EditGroup {
+	return models.EditGroup{
+		ID:         g.ID,
+		UserID:     g.UserID,
+		TargetType: models.TargetTypeEnum(g.TargetType),
+		Created:    g.CreatedAt,
+	}
+}
+
+func EditGroupToModelPtr(g queries.EditGroup) *models.EditGroup {
+	group := EditGroupToModel(g)
+	return &group
+}
+
+// EntityLockToModel converts a queries.EntityLock to a models.EntityLock
+func EntityLockToModel(l queries.EntityLock) models.EntityLock {
+	return models.EntityLock{
+		ID:          l.ID,
+		TargetType:  models.TargetTypeEnum(l.TargetType),
+		TargetID:    l.TargetID,
+		Fields:      l.Fields,
+		Level:       models.EntityLockLevelEnum(l.Level),
+		Reason:      l.Reason,
+		CreatedByID: l.CreatedBy,
+		Created:     l.CreatedAt,
+		Expires:     l.ExpiresAt,
+	}
+}
+
+func EntityLockToModelPtr(l queries.EntityLock) *models.EntityLock {
+	lock := EntityLockToModel(l)
+	return &lock
+}
+
+// EntityLocksToModels converts []queries.EntityLock to []models.EntityLock
+func EntityLocksToModels(locks []queries.EntityLock) []models.EntityLock {
+	ret := make([]models.EntityLock, len(locks))
+	for i, l := range locks {
+		ret[i] = EntityLockToModel(l)
+	}
+	return ret
+}
+
+// RoleToModel converts a queries.Role to a models.Role
+func RoleToModel(r queries.Role) models.Role {
+	return models.Role{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: StringsToPermissionEnums(r.Permissions),
+		Created:     r.CreatedAt,
+		Updated:     r.UpdatedAt,
+	}
+}
+
+func RoleToModelPtr(r queries.Role) *models.Role {
+	role := RoleToModel(r)
+	return &role
+}
+
+// RolesToModels converts []queries.Role to []models.Role
+func RolesToModels(roles []queries.Role) []models.Role {
+	ret := make([]models.Role, len(roles))
+	for i, r := range roles {
+		ret[i] = RoleToModel(r)
+	}
+	return ret
+}
+
+func UserSessionToModel(s queries.UserSession) models.UserSession {
+	return models.UserSession{
+		ID:        s.ID,
+		UserID:    s.UserID,
+		Data:      s.Data,
+		UserAgent: s.UserAgent,
+		IPAddress: s.IpAddress,
+		Created:   s.CreatedAt,
+		LastSeen:  s.LastSeenAt,
+		Expires:   s.ExpiresAt,
+	}
+}
+
+// UserSessionsToModels converts []queries.UserSession to []models.UserSession
+func UserSessionsToModels(sessions []queries.UserSession) []models.UserSession {
+	ret := make([]models.UserSession, len(sessions))
+	for i, s := range sessions {
+		ret[i] = UserSessionToModel(s)
+	}
+	return ret
+}
+
+func LockoutToModel(l queries.Lockout) models.Lockout {
+	return models.Lockout{
+		ID:          l.ID,
+		Scope:       models.LockoutScopeEnum(l.Scope),
+		Key:         l.Key,
+		Attempts:    l.Attempts,
+		LastAttempt: l.LastAttemptAt,
+		LockedUntil: l.LockedUntil,
+	}
+}
+
+// LockoutsToModels converts []queries.Lockout to []models.Lockout
+func LockoutsToModels(lockouts []queries.Lockout) []models.Lockout {
+	ret := make([]models.Lockout, len(lockouts))
+	for i, l := range lockouts {
+		ret[i] = LockoutToModel(l)
+	}
+	return ret
+}
+
 // CreateEditCommentParams creates a queries.CreateEditCommentParams from editID, userID, and comment text
 func CreateEditCommentParams(editID, userID uuid.UUID, commentText string) (queries.CreateEditCommentParams, error) {
 	id, err := uuid.NewV7()
@@ -739,6 +940,18 @@ func StringsToRoleEnums(strings []string) []models.RoleEnum {
 	return result
 }
 
+// StringsToPermissionEnums converts []string to []models.PermissionEnum,
+// skipping permissions that no longer exist
+func StringsToPermissionEnums(strings []string) []models.PermissionEnum {
+	result := make([]models.PermissionEnum, 0, len(strings))
+	for _, s := range strings {
+		if permission := models.PermissionEnum(s); permission.IsValid() {
+			result = append(result, permission)
+		}
+	}
+	return result
+}
+
 // NotificationToModel converts a database notification to a models.Notification
 func NotificationToModel(dbNotification queries.Notification) models.Notification {
 	notification := models.Notification{
@@ -19,6 +19,7 @@ func (c *CreateParamsConverterImpl) ConvertEditCommentToCreateParams(source mode
 	queriesCreateEditCommentParams.EditID = c.uuidUUIDToUuidUUID(source.EditID)
 	queriesCreateEditCommentParams.UserID = c.uuidNullUUIDToUuidNullUUID(source.UserID)
 	queriesCreateEditCommentParams.Text = source.Text
+	queriesCreateEditCommentParams.ParentID = c.uuidNullUUIDToUuidNullUUID(source.ParentID)
 	return queriesCreateEditCommentParams
 }
 func (c *CreateParamsConverterImpl) ConvertEditToCreateParams(source models.Edit) queries.CreateEditParams {
@@ -459,6 +460,7 @@ func (c *ModelConverterImpl) ConvertEditComment(source queries.EditComment) mode
 	modelsEditComment.Text = source.Text
 	modelsEditComment.UpdatedAt = c.pTimeTimeToPTimeTime(source.UpdatedAt)
 	modelsEditComment.IsHidden = source.IsHidden
+	modelsEditComment.ParentID = c.uuidNullUUIDToUuidNullUUID2(source.ParentID)
 	return modelsEditComment
 }
 func (c *ModelConverterImpl) ConvertEditComments(source []queries.EditComment) []models.EditComment {
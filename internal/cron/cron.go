@@ -2,6 +2,7 @@ package cron
 
 import (
 	"context"
+	"time"
 
 	"github.com/robfig/cron/v3"
 	"go.opentelemetry.io/otel"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/stashapp/stash-box/internal/autocert"
 	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/internal/service"
 	"github.com/stashapp/stash-box/internal/tracing"
 	"github.com/stashapp/stash-box/pkg/logger"
@@ -68,6 +70,28 @@ func (c Cron) cleanTokens() {
 	}
 }
 
+func (c Cron) cleanSessions() {
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.cleanSessions")
+	defer span.End()
+
+	err := c.fac.User().DeleteExpiredSessions(ctx)
+	tracing.RecordError(span, err)
+	if err != nil {
+		logger.Errorf("Error cleaning sessions: %s", err)
+	}
+}
+
+func (c Cron) cleanLockouts() {
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.cleanLockouts")
+	defer span.End()
+
+	err := c.fac.User().DeleteExpiredLockouts(ctx)
+	tracing.RecordError(span, err)
+	if err != nil {
+		logger.Errorf("Error cleaning lockouts: %s", err)
+	}
+}
+
 func (c Cron) cleanInvites() {
 	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.cleanInvites")
 	defer span.End()
@@ -90,6 +114,73 @@ func (c Cron) cleanNotifications() {
 	}
 }
 
+// notifyReleasedScenes notifies users about scenes from their favorite performers
+// and studios that were scheduled ahead of time and are released today.
+func (c Cron) notifyReleasedScenes() {
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.notifyReleasedScenes")
+	defer span.End()
+
+	err := c.fac.Notification().TriggerSceneReleaseNotifications(ctx, time.Now().Format(time.DateOnly))
+	tracing.RecordError(span, err)
+	if err != nil {
+		logger.Errorf("Error triggering scene release notifications: %s", err)
+	}
+}
+
+// notifySavedSearchMatches notifies users about new matches of their saved searches
+func (c Cron) notifySavedSearchMatches() {
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.notifySavedSearchMatches")
+	defer span.End()
+
+	err := c.fac.SavedSearch().CheckMatches(ctx)
+	tracing.RecordError(span, err)
+	if err != nil {
+		logger.Errorf("Error checking saved search matches: %s", err)
+	}
+}
+
+// sendNotificationEmails emails users their unread notifications, either
+// immediately or as daily and weekly digests.
+func (c Cron) sendNotificationEmails() {
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.sendNotificationEmails")
+	defer span.End()
+
+	for _, frequency := range []models.NotificationEmailFrequency{
+		models.NotificationEmailFrequencyImmediate,
+		models.NotificationEmailFrequencyDaily,
+		models.NotificationEmailFrequencyWeekly,
+	} {
+		err := c.fac.Notification().SendEmails(ctx, frequency)
+		tracing.RecordError(span, err)
+		if err != nil {
+			logger.Errorf("Error sending %s notification emails: %s", frequency, err)
+		}
+	}
+}
+
+func (c Cron) applyReputationRules() {
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.applyReputationRules")
+	defer span.End()
+
+	err := c.fac.User().ApplyReputationRules(ctx)
+	tracing.RecordError(span, err)
+	if err != nil {
+		logger.Errorf("Error applying reputation rules: %s", err)
+	}
+}
+
+// liftExpiredSuspensions lifts user suspensions that have expired
+func (c Cron) liftExpiredSuspensions() {
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.liftExpiredSuspensions")
+	defer span.End()
+
+	err := c.fac.User().LiftExpiredSuspensions(ctx)
+	tracing.RecordError(span, err)
+	if err != nil {
+		logger.Errorf("Error lifting expired suspensions: %s", err)
+	}
+}
+
 func (c Cron) refreshPopularityTrending() {
 	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "cron.refreshPopularityTrending")
 	defer span.End()
@@ -144,6 +235,16 @@ func Init(fac service.Factory) {
 		panic(err.Error())
 	}
 
+	_, err = c.AddFunc("@every 60m", cronJobs.cleanSessions)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = c.AddFunc("@every 60m", cronJobs.cleanLockouts)
+	if err != nil {
+		panic(err.Error())
+	}
+
 	_, err = c.AddFunc("@every 60m", cronJobs.cleanNotifications)
 	if err != nil {
 		panic(err.Error())
@@ -154,6 +255,21 @@ func Init(fac service.Factory) {
 		panic(err.Error())
 	}
 
+	_, err = c.AddFunc("@every 1h", cronJobs.notifyReleasedScenes)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = c.AddFunc("@every 1h", cronJobs.notifySavedSearchMatches)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	_, err = c.AddFunc("@every 5m", cronJobs.liftExpiredSuspensions)
+	if err != nil {
+		panic(err.Error())
+	}
+
 	_, err = c.AddFunc("@every 12h", cronJobs.cleanModAudits)
 	if err != nil {
 		panic(err.Error())
@@ -176,6 +292,20 @@ func Init(fac service.Factory) {
 		}
 	}
 
+	if emailInterval := config.GetNotificationEmailInterval(); emailInterval != "" {
+		_, err = c.AddFunc("@every "+emailInterval, cronJobs.sendNotificationEmails)
+		if err != nil {
+			panic(err.Error())
+		}
+	}
+
+	if reputationInterval := config.GetReputationCronInterval(); reputationInterval != "" {
+		_, err = c.AddFunc("@every "+reputationInterval, cronJobs.applyReputationRules)
+		if err != nil {
+			panic(err.Error())
+		}
+	}
+
 	interval := config.GetVoteCronInterval()
 	if interval != "" {
 		_, err := c.AddFunc("@every "+config.GetVoteCronInterval(), cronJobs.processEdits)
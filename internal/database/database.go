@@ -22,7 +22,7 @@ import (
 
 const (
 	postgresDriver = "postgres"
-	schemaVersion  = 74
+	schemaVersion  = 99
 )
 
 //go:embed migrations/postgres/*.sql
@@ -88,9 +88,36 @@ func Initialize(databasePath string) *pgxpool.Pool {
 		logger.Fatal(err)
 	}
 
+	if err := checkSearchBackend(context.Background(), pool); err != nil {
+		logger.Fatal(err)
+	}
+
 	return pool
 }
 
+// checkSearchBackend fails at startup, rather than on the first search, when
+// the database lacks what the configured search backend needs.
+func checkSearchBackend(ctx context.Context, pool *pgxpool.Pool) error {
+	backend := config.GetSearchBackend()
+
+	// the BM25 indexes are dropped together with pg_search
+	check := "SELECT EXISTS(SELECT 1 FROM pg_indexes WHERE indexname = 'performer_search_bm25_idx')"
+	requirement := "the pg_search extension and its BM25 indexes, set search_backend: postgres to search without pg_search"
+	if backend == config.PostgresSearch {
+		check = "SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')"
+		requirement = "the pg_trgm extension, run CREATE EXTENSION pg_trgm as a superuser"
+	}
+
+	var ok bool
+	if err := pool.QueryRow(ctx, check).Scan(&ok); err != nil {
+		return fmt.Errorf("failed to check search backend: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("search_backend %s requires %s", backend, requirement)
+	}
+	return nil
+}
+
 // runMigrations runs database migrations
 func runMigrations(databasePath string) error {
 	migrations, err := iofs.New(migrationsFS, "migrations/postgres")
@@ -0,0 +1,13 @@
+-- Relationships are stored in both directions so that either performer can be queried directly.
+CREATE TABLE "performer_relationships" (
+  "performer_id" UUID NOT NULL,
+  "related_performer_id" UUID NOT NULL,
+  "type" VARCHAR(20) NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY ("performer_id", "related_performer_id", "type"),
+  FOREIGN KEY ("performer_id") REFERENCES "performers"("id") ON DELETE CASCADE,
+  FOREIGN KEY ("related_performer_id") REFERENCES "performers"("id") ON DELETE CASCADE,
+  CHECK ("performer_id" <> "related_performer_id")
+);
+
+CREATE INDEX "performer_relationships_related_performer_id_idx" ON "performer_relationships" ("related_performer_id");
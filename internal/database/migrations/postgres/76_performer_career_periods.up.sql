@@ -0,0 +1,12 @@
+CREATE TABLE "performer_career_periods" (
+  "performer_id" UUID NOT NULL,
+  "start_date" TEXT NOT NULL,
+  "end_date" TEXT,
+  "studio_id" UUID,
+  "note" TEXT,
+  FOREIGN KEY ("performer_id") REFERENCES "performers"("id") ON DELETE CASCADE,
+  FOREIGN KEY ("studio_id") REFERENCES "studios"("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "performer_career_periods_performer_id_idx" ON "performer_career_periods" ("performer_id");
+CREATE INDEX "performer_career_periods_studio_id_idx" ON "performer_career_periods" ("studio_id");
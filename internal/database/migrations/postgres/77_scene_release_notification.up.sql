@@ -0,0 +1 @@
+ALTER TYPE notification_type ADD VALUE 'FAVORITE_SCENE_RELEASED';
@@ -0,0 +1,18 @@
+-- Audit actions for privileged user, edit, fingerprint, invite and site mutations
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'USER_CREATE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'USER_UPDATE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'USER_DESTROY';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'EDIT_APPROVE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'EDIT_REJECT';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'FINGERPRINT_MOVE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'FINGERPRINT_DELETE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'INVITE_GRANT';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'INVITE_REVOKE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'SITE_CREATE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'SITE_UPDATE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'SITE_DESTROY';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'TAG_CATEGORY_CREATE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'TAG_CATEGORY_UPDATE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'TAG_CATEGORY_DESTROY';
+
+CREATE INDEX mod_audit_target_type_idx ON mod_audit(target_type);
@@ -0,0 +1,68 @@
+-- Full text search for search_backend: postgres. The search tables are shared
+-- with the ParadeDB backend; these functions build the tsvector documents that
+-- the plain PostgreSQL queries match against. Each field is weighted the same
+-- way it is boosted in the BM25 queries.
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')
+    AND EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'pg_trgm') THEN
+    CREATE EXTENSION pg_trgm;
+  END IF;
+EXCEPTION WHEN insufficient_privilege THEN
+  RAISE NOTICE 'pg_trgm could not be created, run CREATE EXTENSION pg_trgm as a superuser';
+END$$;
+
+-- array_to_string is only STABLE, but it is immutable for TEXT[]
+CREATE OR REPLACE FUNCTION search_array_text(TEXT[]) RETURNS TEXT AS $$
+    SELECT COALESCE(array_to_string($1, ' '), '');
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+CREATE OR REPLACE FUNCTION performer_search_document(name TEXT, disambiguation TEXT, aliases TEXT[]) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+           setweight(to_tsvector('simple', search_array_text(aliases)), 'B') ||
+           setweight(to_tsvector('simple', COALESCE(disambiguation, '')), 'C');
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+CREATE OR REPLACE FUNCTION scene_search_document(
+    scene_title TEXT, scene_code TEXT, scene_date TEXT,
+    studio_name TEXT, network_name TEXT, studio_aliases TEXT[], network_aliases TEXT[],
+    performer_names TEXT[]
+) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', search_array_text(performer_names)), 'A') ||
+           setweight(to_tsvector('simple', COALESCE(scene_title, '')), 'B') ||
+           setweight(to_tsvector('simple', COALESCE(scene_code, '') || ' ' || COALESCE(scene_date, '')), 'B') ||
+           setweight(to_tsvector('simple', COALESCE(studio_name, '') || ' ' || search_array_text(studio_aliases)), 'C') ||
+           setweight(to_tsvector('simple', COALESCE(network_name, '') || ' ' || search_array_text(network_aliases)), 'D');
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+CREATE OR REPLACE FUNCTION studio_search_document(name TEXT, network TEXT, aliases TEXT[]) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+           setweight(to_tsvector('simple', search_array_text(aliases)), 'B') ||
+           setweight(to_tsvector('simple', COALESCE(network, '')), 'C');
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+CREATE OR REPLACE FUNCTION tag_search_document(name TEXT, aliases TEXT[]) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+           setweight(to_tsvector('simple', search_array_text(aliases)), 'B');
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+-- Indexes are only needed when the BM25 indexes are not available
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_search') THEN
+    CREATE INDEX performer_search_document_idx ON performer_search
+    USING GIN (performer_search_document(name, disambiguation, aliases));
+    CREATE INDEX scene_search_document_idx ON scene_search
+    USING GIN (scene_search_document(scene_title, scene_code, scene_date, studio_name, network_name, studio_aliases, network_aliases, performer_names));
+    CREATE INDEX studio_search_document_idx ON studio_search
+    USING GIN (studio_search_document(name, network, aliases));
+    CREATE INDEX tag_search_document_idx ON tag_search
+    USING GIN (tag_search_document(name, aliases));
+
+    IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm') THEN
+      CREATE INDEX tag_search_name_trgm_idx ON tag_search USING GIN (name gin_trgm_ops);
+      CREATE INDEX tag_search_aliases_trgm_idx ON tag_search USING GIN (search_array_text(aliases) gin_trgm_ops);
+    END IF;
+  END IF;
+END$$;
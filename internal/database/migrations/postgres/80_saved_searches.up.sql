@@ -0,0 +1,14 @@
+ALTER TYPE notification_type ADD VALUE 'SAVED_SEARCH_MATCH';
+
+CREATE TABLE saved_searches (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    filter JSONB NOT NULL,
+    notify BOOLEAN NOT NULL DEFAULT TRUE,
+    last_checked_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX saved_searches_user_id_idx ON saved_searches (user_id);
@@ -0,0 +1,17 @@
+CREATE TABLE user_notification_emails (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    type notification_type NOT NULL,
+    frequency TEXT NOT NULL CHECK (frequency IN ('IMMEDIATE', 'DAILY', 'WEEKLY', 'OFF')),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, type)
+);
+
+CREATE TABLE user_notification_digests (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    frequency TEXT NOT NULL,
+    sent_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, frequency)
+);
+
+ALTER TABLE notifications ADD COLUMN emailed_at TIMESTAMP;
+CREATE INDEX notifications_unemailed_idx ON notifications (user_id) WHERE emailed_at IS NULL AND read_at IS NULL;
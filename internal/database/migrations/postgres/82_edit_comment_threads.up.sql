@@ -0,0 +1,21 @@
+ALTER TYPE notification_type ADD VALUE 'MENTIONED_IN_COMMENT';
+
+-- Replies to another comment on the same edit
+ALTER TABLE edit_comments ADD COLUMN parent_id UUID REFERENCES edit_comments(id) ON DELETE SET NULL;
+CREATE INDEX edit_comments_parent_id_idx ON edit_comments (parent_id);
+
+-- Users @mentioned in a comment
+CREATE TABLE edit_comment_mentions (
+    comment_id UUID REFERENCES edit_comments(id) ON DELETE CASCADE NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    PRIMARY KEY (comment_id, user_id)
+);
+CREATE INDEX edit_comment_mentions_user_id_idx ON edit_comment_mentions (user_id);
+
+CREATE TABLE edit_comment_reactions (
+    comment_id UUID REFERENCES edit_comments(id) ON DELETE CASCADE NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    reaction TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id, reaction)
+);
@@ -0,0 +1,4 @@
+-- Subscribe existing users to mentions. Kept apart from the migration adding
+-- the enum value, which can not be used in the transaction that added it.
+INSERT INTO user_notifications (user_id, type)
+SELECT id, 'MENTIONED_IN_COMMENT' FROM users;
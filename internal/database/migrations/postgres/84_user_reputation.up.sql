@@ -0,0 +1,2 @@
+-- Roles granted or removed by reputation rules
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'USER_REPUTATION_UPDATE';
@@ -0,0 +1,20 @@
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'USER_SUSPEND';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'USER_LIFT_SUSPENSION';
+ALTER TYPE notification_type ADD VALUE 'USER_SUSPENDED';
+ALTER TYPE notification_type ADD VALUE 'USER_SUSPENSION_LIFTED';
+
+-- Suspensions restrict a user to read-only access. A suspension without an
+-- expiry is a ban. Lifted and expired suspensions are kept as the history of
+-- sanctions of the user.
+CREATE TABLE user_suspensions (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    reason TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP,
+    lifted_at TIMESTAMP,
+    lifted_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX user_suspensions_user_id_idx ON user_suspensions (user_id, created_at);
+CREATE INDEX user_suspensions_expires_at_idx ON user_suspensions (expires_at) WHERE lifted_at IS NULL;
@@ -0,0 +1,18 @@
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'USER_TWO_FACTOR_RESET';
+
+-- TOTP secrets. enabled_at is null until the user has confirmed the secret
+-- with a valid code. last_used_step prevents reuse of a code.
+CREATE TABLE user_totp (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    enabled_at TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE user_recovery_codes (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP,
+    PRIMARY KEY (user_id, code_hash)
+);
@@ -0,0 +1,17 @@
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'USER_REVOKE_SESSIONS';
+
+-- Login sessions. The session cookie only holds the signed id, so deleting
+-- a row logs the session out. user_id is null until the login completed.
+CREATE TABLE user_sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    data BYTEA NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX user_sessions_user_id_idx ON user_sessions (user_id);
+CREATE INDEX user_sessions_expires_at_idx ON user_sessions (expires_at);
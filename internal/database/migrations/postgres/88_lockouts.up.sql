@@ -0,0 +1,16 @@
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'LOCKOUT_CLEAR';
+
+-- Attempt counters for logins, password resets and registrations, per
+-- account, email address or IP address. attempts counts the attempts since
+-- the counter was last idle for a day.
+CREATE TABLE lockouts (
+    id UUID NOT NULL UNIQUE,
+    scope TEXT NOT NULL,
+    key TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    last_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX lockouts_last_attempt_at_idx ON lockouts (last_attempt_at);
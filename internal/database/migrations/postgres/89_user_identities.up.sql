@@ -0,0 +1,10 @@
+-- Accounts of single sign-on providers that users log in with
+CREATE TABLE user_identities (
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issuer, subject)
+);
+
+CREATE INDEX user_identities_user_id_idx ON user_identities (user_id);
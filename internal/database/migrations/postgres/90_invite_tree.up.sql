@@ -0,0 +1 @@
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'INVITE_TREE_REVOKE';
@@ -0,0 +1,24 @@
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'ROLE_CREATE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'ROLE_UPDATE';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'ROLE_DESTROY';
+
+-- Custom roles, granting a set of permissions in addition to the built-in
+-- roles in user_roles
+CREATE TABLE roles (
+  id UUID PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  permissions TEXT[] NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX roles_name_idx ON roles (LOWER(name));
+
+CREATE TABLE user_custom_roles (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+  PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX user_custom_roles_role_id_idx ON user_custom_roles (role_id);
@@ -0,0 +1,19 @@
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'ENTITY_LOCK';
+ALTER TYPE mod_audit_action ADD VALUE IF NOT EXISTS 'ENTITY_UNLOCK';
+
+-- Locks protect an entity, or some of its fields, from edits. Edits touching
+-- the fields of a LOCKED lock are rejected, and edits touching the fields of a
+-- PROTECTED lock are only applied when approved by a moderator. A lock
+-- without fields covers the whole entity.
+CREATE TABLE entity_locks (
+    id UUID PRIMARY KEY,
+    target_type VARCHAR(10) NOT NULL,
+    target_id UUID NOT NULL,
+    fields TEXT[] NOT NULL DEFAULT '{}',
+    level VARCHAR(10) NOT NULL,
+    reason TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP
+);
+CREATE INDEX entity_locks_target_id_idx ON entity_locks (target_id);
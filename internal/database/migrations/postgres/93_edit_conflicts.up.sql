@@ -0,0 +1,9 @@
+-- Pending edits changing the same fields of the same entities, linked when
+-- either is submitted or updated. Each conflict is stored in both directions.
+CREATE TABLE edit_conflicts (
+    edit_id UUID NOT NULL REFERENCES edits(id) ON DELETE CASCADE,
+    conflicting_edit_id UUID NOT NULL REFERENCES edits(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (edit_id, conflicting_edit_id)
+);
+CREATE INDEX edit_conflicts_conflicting_edit_id_idx ON edit_conflicts (conflicting_edit_id);
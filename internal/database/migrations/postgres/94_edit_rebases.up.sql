@@ -0,0 +1,7 @@
+-- Pending edits reopened from a failed or conflicting edit, recomputed
+-- against the current state of the target. An edit is rebased at most once.
+CREATE TABLE edit_rebases (
+    edit_id UUID PRIMARY KEY REFERENCES edits(id) ON DELETE CASCADE,
+    rebased_from_id UUID NOT NULL UNIQUE REFERENCES edits(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,14 @@
+-- Edits generated together by a bulk edit. The edits of a group are voted
+-- on, applied, rejected and failed together.
+CREATE TABLE edit_groups (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    target_type VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE edit_group_edits (
+    edit_id UUID PRIMARY KEY REFERENCES edits(id) ON DELETE CASCADE,
+    group_id UUID NOT NULL REFERENCES edit_groups(id) ON DELETE CASCADE
+);
+CREATE INDEX edit_group_edits_group_id_idx ON edit_group_edits (group_id);
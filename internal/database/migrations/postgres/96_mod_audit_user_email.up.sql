@@ -0,0 +1,5 @@
+-- User audit entries no longer record the email address, which would
+-- otherwise outlive the deletion of the account
+UPDATE mod_audit
+SET data = data #- '{before,email}' #- '{after,email}'
+WHERE target_type = 'USER';
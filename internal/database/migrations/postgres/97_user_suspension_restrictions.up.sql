@@ -0,0 +1,4 @@
+-- Suspensions restrict a user to read-only access, or only withhold voting or
+-- commenting.
+ALTER TABLE user_suspensions ADD COLUMN restriction TEXT NOT NULL DEFAULT 'READ_ONLY'
+    CHECK (restriction IN ('READ_ONLY', 'NO_VOTING', 'NO_COMMENTING'));
@@ -0,0 +1,19 @@
+-- The full text indexes of search_backend: postgres are created whether or
+-- not pg_search is installed, so that the backend can be switched, and
+-- pg_search dropped, without another migration.
+CREATE INDEX IF NOT EXISTS performer_search_document_idx ON performer_search
+USING GIN (performer_search_document(name, disambiguation, aliases));
+CREATE INDEX IF NOT EXISTS scene_search_document_idx ON scene_search
+USING GIN (scene_search_document(scene_title, scene_code, scene_date, studio_name, network_name, studio_aliases, network_aliases, performer_names));
+CREATE INDEX IF NOT EXISTS studio_search_document_idx ON studio_search
+USING GIN (studio_search_document(name, network, aliases));
+CREATE INDEX IF NOT EXISTS tag_search_document_idx ON tag_search
+USING GIN (tag_search_document(name, aliases));
+
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm') THEN
+    CREATE INDEX IF NOT EXISTS tag_search_name_trgm_idx ON tag_search USING GIN (name gin_trgm_ops);
+    CREATE INDEX IF NOT EXISTS tag_search_aliases_trgm_idx ON tag_search USING GIN (search_array_text(aliases) gin_trgm_ops);
+  END IF;
+END$$;
@@ -0,0 +1,6 @@
+-- Edits which passed the vote but change locked fields, and are waiting for a
+-- moderator to approve or reject them.
+CREATE TABLE edit_moderator_queue (
+    edit_id UUID PRIMARY KEY REFERENCES edits(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -48,6 +48,27 @@ func (m *Manager) Send(email, subject, text, html string) error {
 		return err
 	}
 
+	if err := send(email, subject, text, html, nil); err != nil {
+		return err
+	}
+
+	// add to email map
+	m.lastEmailed[email] = time.Now()
+
+	return nil
+}
+
+// SendNotification sends a notification email. Notification emails are not
+// subject to the cooldown, and support one-click unsubscribe from mail clients.
+func (m *Manager) SendNotification(email, subject, text, html, unsubscribeURL string) error {
+	headers := map[mail.Header]string{
+		mail.HeaderListUnsubscribe:     fmt.Sprintf("<%s>", unsubscribeURL),
+		mail.HeaderListUnsubscribePost: "List-Unsubscribe=One-Click",
+	}
+	return send(email, subject, text, html, headers)
+}
+
+func send(email, subject, text, html string, headers map[mail.Header]string) error {
 	if len(config.GetMissingEmailSettings()) > 0 {
 		return errors.New("email settings not configured")
 	}
@@ -62,6 +83,9 @@ func (m *Manager) Send(email, subject, text, html string) error {
 	}
 
 	message.Subject(subject)
+	for header, value := range headers {
+		message.SetGenHeader(header, value)
+	}
 	message.SetBodyString(mail.TypeTextPlain, text)
 	message.AddAlternativeString(mail.TypeTextHTML, html)
 
@@ -93,8 +117,5 @@ func (m *Manager) Send(email, subject, text, html string) error {
 		return fmt.Errorf("failed to send mail: %w", err)
 	}
 
-	// add to email map
-	m.lastEmailed[email] = time.Now()
-
 	return nil
 }
@@ -0,0 +1,167 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+// maxGroupItems caps the number of links listed for each notification type
+const maxGroupItems = 10
+
+var notificationHeadings = map[models.NotificationEnum]string{
+	models.NotificationEnumFavoritePerformerScene: "New scenes involving performers you have favorited",
+	models.NotificationEnumFavoritePerformerEdit:  "Edits to performers you have favorited",
+	models.NotificationEnumFavoriteStudioScene:    "New scenes from studios you have favorited",
+	models.NotificationEnumFavoriteStudioEdit:     "Edits to studios you have favorited",
+	models.NotificationEnumCommentOwnEdit:         "Comments on your edits",
+	models.NotificationEnumDownvoteOwnEdit:        "Downvotes on your edits",
+	models.NotificationEnumFailedOwnEdit:          "Your edits that failed",
+	models.NotificationEnumCommentCommentedEdit:   "Comments on edits you have commented on",
+	models.NotificationEnumCommentVotedEdit:       "Comments on edits you have voted on",
+	models.NotificationEnumUpdatedEdit:            "Updates to edits you have voted on",
+	models.NotificationEnumFingerprintedSceneEdit: "Edits to scenes you have submitted fingerprints for",
+	models.NotificationEnumFingerprintMoved:       "Fingerprints you submitted that were moved to another scene",
+	models.NotificationEnumFavoriteSceneReleased:  "Released scenes from performers and studios you have favorited",
+	models.NotificationEnumSavedSearchMatch:       "New matches for your saved searches",
+	models.NotificationEnumMentionedInComment:     "Comments mentioning you",
+	models.NotificationEnumUserSuspended:          "Suspensions of your account",
+	models.NotificationEnumUserSuspensionLifted:   "Lifted suspensions of your account",
+}
+
+// NotificationItem is a notification listed in a notification email
+type NotificationItem struct {
+	Type     models.NotificationEnum
+	TargetID uuid.UUID
+	// EditID is the edit commented on, for comment notifications
+	EditID *uuid.UUID
+}
+
+// URL returns the page the notification links to
+func (i NotificationItem) URL() string {
+	hostURL := config.GetHostURL()
+
+	switch i.Type {
+	case models.NotificationEnumCommentOwnEdit,
+		models.NotificationEnumCommentCommentedEdit,
+		models.NotificationEnumCommentVotedEdit,
+		models.NotificationEnumMentionedInComment:
+		if i.EditID == nil {
+			return hostURL + "/notifications"
+		}
+		return fmt.Sprintf("%s/edits/%s", hostURL, i.EditID)
+	case models.NotificationEnumFavoritePerformerEdit,
+		models.NotificationEnumFavoriteStudioEdit,
+		models.NotificationEnumDownvoteOwnEdit,
+		models.NotificationEnumFailedOwnEdit,
+		models.NotificationEnumUpdatedEdit,
+		models.NotificationEnumFingerprintedSceneEdit:
+		return fmt.Sprintf("%s/edits/%s", hostURL, i.TargetID)
+	case models.NotificationEnumFavoritePerformerScene,
+		models.NotificationEnumFavoriteStudioScene,
+		models.NotificationEnumFavoriteSceneReleased,
+		models.NotificationEnumFingerprintMoved:
+		return fmt.Sprintf("%s/scenes/%s", hostURL, i.TargetID)
+	default:
+		return hostURL + "/notifications"
+	}
+}
+
+type notificationGroup struct {
+	heading string
+	urls    []string
+}
+
+// groupNotifications groups the items by type, in the order of
+// NotificationEnum, dropping duplicate links
+func groupNotifications(items []NotificationItem) []notificationGroup {
+	urls := make(map[models.NotificationEnum][]string)
+	seen := make(map[string]bool)
+	for _, item := range items {
+		url := item.URL()
+		key := string(item.Type) + url
+		if !seen[key] {
+			seen[key] = true
+			urls[item.Type] = append(urls[item.Type], url)
+		}
+	}
+
+	var groups []notificationGroup
+	for _, t := range models.AllNotificationEnum {
+		if len(urls[t]) > 0 {
+			groups = append(groups, notificationGroup{
+				heading: notificationHeadings[t],
+				urls:    urls[t],
+			})
+		}
+	}
+	return groups
+}
+
+func notificationCount(n int) string {
+	if n == 1 {
+		return "1 new notification"
+	}
+	return fmt.Sprintf("%d new notifications", n)
+}
+
+// renderNotifications returns the HTML and plain text listing of the items
+func renderNotifications(items []NotificationItem) (string, string) {
+	var html, text strings.Builder
+
+	intro := fmt.Sprintf("You have %s on %s.", notificationCount(len(items)), config.GetTitle())
+	html.WriteString(intro)
+	text.WriteString(intro)
+
+	for _, group := range groupNotifications(items) {
+		fmt.Fprintf(&html, "<br><br><strong>%s</strong>", group.heading)
+		fmt.Fprintf(&text, "\n\n%s:", group.heading)
+
+		for i, url := range group.urls {
+			if i == maxGroupItems {
+				more := fmt.Sprintf("and %d more", len(group.urls)-maxGroupItems)
+				fmt.Fprintf(&html, "<br>%s", more)
+				fmt.Fprintf(&text, "\n  %s", more)
+				break
+			}
+			fmt.Fprintf(&html, `<br><a href="%s">%s</a>`, url, url)
+			fmt.Fprintf(&text, "\n  %s", url)
+		}
+	}
+
+	return html.String(), text.String()
+}
+
+func notificationSubject(frequency models.NotificationEmailFrequency, count int) string {
+	switch frequency {
+	case models.NotificationEmailFrequencyDaily:
+		return fmt.Sprintf("Your daily %s digest", config.GetTitle())
+	case models.NotificationEmailFrequencyWeekly:
+		return fmt.Sprintf("Your weekly %s digest", config.GetTitle())
+	default:
+		return fmt.Sprintf("%s: %s", config.GetTitle(), notificationCount(count))
+	}
+}
+
+// SendNotificationEmail emails a user the given notifications, grouped by type
+func SendNotificationEmail(mgr *Manager, user models.User, frequency models.NotificationEmailFrequency, items []NotificationItem, unsubscribeURL string) error {
+	content, textContent := renderNotifications(items)
+
+	text, html, err := renderTemplates(templateData{
+		PreHeader:      fmt.Sprintf("You have %s.", notificationCount(len(items))),
+		Greeting:       fmt.Sprintf("Hi %s,", user.Name),
+		Content:        content,
+		TextContent:    textContent,
+		ActionURL:      config.GetHostURL() + "/notifications",
+		ActionText:     "View notifications",
+		UnsubscribeURL: unsubscribeURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	return mgr.SendNotification(user.Email, notificationSubject(frequency, len(items)), text, html, unsubscribeURL)
+}
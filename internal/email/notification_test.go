@@ -0,0 +1,81 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func TestNotificationItemURL(t *testing.T) {
+	editID := uuid.Must(uuid.NewV4())
+	targetID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		item NotificationItem
+		want string
+	}{
+		{NotificationItem{Type: models.NotificationEnumCommentOwnEdit, TargetID: targetID, EditID: &editID}, "/edits/" + editID.String()},
+		{NotificationItem{Type: models.NotificationEnumCommentOwnEdit, TargetID: targetID}, "/notifications"},
+		{NotificationItem{Type: models.NotificationEnumDownvoteOwnEdit, TargetID: targetID}, "/edits/" + targetID.String()},
+		{NotificationItem{Type: models.NotificationEnumFavoriteStudioScene, TargetID: targetID}, "/scenes/" + targetID.String()},
+		{NotificationItem{Type: models.NotificationEnumSavedSearchMatch, TargetID: targetID}, "/notifications"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.item.URL(), tt.item.Type)
+	}
+}
+
+func TestRenderNotifications(t *testing.T) {
+	editID := uuid.Must(uuid.NewV4())
+	sceneID := uuid.Must(uuid.NewV4())
+
+	items := []NotificationItem{
+		// listed in NotificationEnum order, regardless of the order received
+		{Type: models.NotificationEnumDownvoteOwnEdit, TargetID: editID},
+		{Type: models.NotificationEnumFavoritePerformerScene, TargetID: sceneID},
+		// comments on the same edit are listed once
+		{Type: models.NotificationEnumCommentOwnEdit, TargetID: uuid.Must(uuid.NewV4()), EditID: &editID},
+		{Type: models.NotificationEnumCommentOwnEdit, TargetID: uuid.Must(uuid.NewV4()), EditID: &editID},
+	}
+
+	html, text := renderNotifications(items)
+
+	assert.Contains(t, html, "You have 4 new notifications")
+	assert.Contains(t, html, fmt.Sprintf(`<a href="/edits/%s">`, editID))
+	assert.NotContains(t, text, "<")
+
+	performer := strings.Index(text, notificationHeadings[models.NotificationEnumFavoritePerformerScene])
+	comment := strings.Index(text, notificationHeadings[models.NotificationEnumCommentOwnEdit])
+	downvote := strings.Index(text, notificationHeadings[models.NotificationEnumDownvoteOwnEdit])
+	assert.True(t, performer < comment && comment < downvote, text)
+	assert.Equal(t, 2, strings.Count(text, "/edits/"+editID.String()))
+}
+
+func TestRenderNotificationsLimit(t *testing.T) {
+	var items []NotificationItem
+	for range maxGroupItems + 3 {
+		items = append(items, NotificationItem{
+			Type:     models.NotificationEnumFavoriteStudioScene,
+			TargetID: uuid.Must(uuid.NewV4()),
+		})
+	}
+
+	_, text := renderNotifications(items)
+	assert.Equal(t, maxGroupItems, strings.Count(text, "/scenes/"))
+	assert.Contains(t, text, "and 3 more")
+}
+
+func TestUnsubscribeToken(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	otherID := uuid.Must(uuid.NewV4())
+
+	token := UnsubscribeToken(userID)
+	assert.True(t, ValidateUnsubscribeToken(userID, token))
+	assert.False(t, ValidateUnsubscribeToken(otherID, token))
+	assert.False(t, ValidateUnsubscribeToken(userID, ""))
+}
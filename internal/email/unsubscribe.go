@@ -0,0 +1,30 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/config"
+)
+
+// UnsubscribeToken returns the token authorizing a user to turn off
+// notification emails without logging in.
+func UnsubscribeToken(userID uuid.UUID) string {
+	mac := hmac.New(sha256.New, config.GetJWTSignKey())
+	mac.Write([]byte("unsubscribe:"))
+	mac.Write([]byte(userID.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateUnsubscribeToken reports whether token unsubscribes the user
+func ValidateUnsubscribeToken(userID uuid.UUID, token string) bool {
+	return hmac.Equal([]byte(UnsubscribeToken(userID)), []byte(token))
+}
+
+// UnsubscribeURL returns the link included in notification emails
+func UnsubscribeURL(userID uuid.UUID) string {
+	return fmt.Sprintf("%s/unsubscribe/%s?token=%s", config.GetHostURL(), userID, UnsubscribeToken(userID))
+}
@@ -81,50 +81,63 @@ func ChangeEmail(ctx context.Context, tx *queries.Queries, token models.ChangeEm
 	})
 }
 
-func sendTemplatedEmail(mgr *Manager, email, subject, preHeader, greeting, content, link, cta string) error {
+type templateData struct {
+	SiteName   string
+	SiteURL    string
+	Content    string
+	ActionURL  string
+	ActionText string
+	Greeting   string
+	PreHeader  string
+	// TextContent replaces Content in the plain text email when set
+	TextContent    string
+	UnsubscribeURL string
+}
+
+func renderTemplates(data templateData) (string, string, error) {
+	data.SiteURL = config.GetHostURL()
+	data.SiteName = config.GetTitle()
+
 	htmlTemplates, err := template.ParseFS(templateFS,
 		"templates/email.html",
 	)
 	if err != nil {
-		return err
-	}
-
-	data := struct {
-		SiteName   string
-		SiteURL    string
-		Content    string
-		ActionURL  string
-		ActionText string
-		Greeting   string
-		PreHeader  string
-	}{
-		SiteURL:    config.GetHostURL(),
-		SiteName:   config.GetTitle(),
-		Content:    content,
-		ActionURL:  link,
-		ActionText: cta,
-		Greeting:   greeting,
-		PreHeader:  preHeader,
+		return "", "", err
 	}
 
 	var html bytes.Buffer
 	if err := htmlTemplates.Execute(&html, data); err != nil {
-		return err
+		return "", "", err
 	}
 
 	textTemplate, err := template.ParseFS(templateFS,
 		"templates/email.txt",
 	)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	var text bytes.Buffer
 	if err := textTemplate.Execute(&text, data); err != nil {
+		return "", "", err
+	}
+
+	return text.String(), html.String(), nil
+}
+
+func sendTemplatedEmail(mgr *Manager, email, subject, preHeader, greeting, content, link, cta string) error {
+	text, html, err := renderTemplates(templateData{
+		Content:    content,
+		ActionURL:  link,
+		ActionText: cta,
+		Greeting:   greeting,
+		PreHeader:  preHeader,
+	})
+	if err != nil {
 		return err
 	}
 
-	return mgr.Send(email, subject, text.String(), html.String())
+	return mgr.Send(email, subject, text, html)
 }
 
 func sendConfirmOldEmail(mgr *Manager, user models.User, activationKey uuid.UUID) error {
@@ -170,3 +183,25 @@ func sendConfirmNewEmail(mgr *Manager, user *models.User, email string, activati
 
 	return sendTemplatedEmail(mgr, email, subject, preHeader, greeting, content, link, cta)
 }
+
+func SendLockoutEmail(user queries.User, mgr *Manager) error {
+	subject := fmt.Sprintf("%s login attempts blocked", config.GetTitle())
+	link := fmt.Sprintf("%s/forgot-password", config.GetHostURL())
+	preHeader := fmt.Sprintf("Logins to your %s account were blocked after several failed attempts.", config.GetTitle())
+	greeting := fmt.Sprintf("Hi %s,", user.Name)
+	content := fmt.Sprintf("Logins to your %s account have been temporarily blocked after several attempts with a wrong password. If this was not you, someone may be trying to guess your password. You can reset your password with the button below.", config.GetTitle())
+	cta := "Reset password"
+
+	return sendTemplatedEmail(mgr, user.Email, subject, preHeader, greeting, content, link, cta)
+}
+
+func SendDeleteAccountEmail(user queries.User, activationKey uuid.UUID, mgr *Manager) error {
+	subject := fmt.Sprintf("Confirm %s account deletion", config.GetTitle())
+	link := fmt.Sprintf("%s/users/%s/delete?key=%s", config.GetHostURL(), user.Name, activationKey)
+	preHeader := fmt.Sprintf("Confirm you want to delete your %s account.", config.GetTitle())
+	greeting := fmt.Sprintf("Hi %s,", user.Name)
+	content := fmt.Sprintf("The deletion of your %s account was requested. Your edits, comments and votes are kept, but no longer linked to you. Everything else is permanently deleted. Click the button below to confirm. <strong>The link is valid for %s.</strong>", config.GetTitle(), config.GetActivationExpiry())
+	cta := "Delete account"
+
+	return sendTemplatedEmail(mgr, user.Email, subject, preHeader, greeting, content, link, cta)
+}
@@ -1,21 +1,54 @@
 package models
 
-func (r RoleEnum) Implies(other RoleEnum) bool {
-	// admin has all roles
-	if r == RoleEnumAdmin {
-		return true
-	}
+import "slices"
 
-	// MANAGE_INVITES implies INVITE
-	if r == RoleEnumManageInvites && other == RoleEnumInvite {
-		return true
-	}
+// rolePermissions holds the permissions granted by each built-in role
+var rolePermissions = map[RoleEnum][]PermissionEnum{
+	RoleEnumRead:     {PermissionEnumRead},
+	RoleEnumReadOnly: {PermissionEnumRead},
+	RoleEnumVote:     {PermissionEnumRead, PermissionEnumVote},
+	RoleEnumEdit: {
+		PermissionEnumRead,
+		PermissionEnumEdit,
+		PermissionEnumEditScenes,
+		PermissionEnumEditPerformers,
+		PermissionEnumEditStudios,
+	},
+	RoleEnumEditTags: {PermissionEnumRead, PermissionEnumEditTags},
+	RoleEnumModify:   {PermissionEnumRead, PermissionEnumModify},
+	RoleEnumModerate: {
+		PermissionEnumRead,
+		PermissionEnumModerateEdits,
+		PermissionEnumModerateComments,
+		PermissionEnumModerateFingerprints,
+	},
+	RoleEnumInvite:        {PermissionEnumRead, PermissionEnumInvite},
+	RoleEnumManageInvites: {PermissionEnumRead, PermissionEnumInvite, PermissionEnumManageInvites},
+	RoleEnumBot:           {PermissionEnumRead, PermissionEnumBot},
+	// admin has all permissions
+	RoleEnumAdmin: AllPermissionEnum,
+}
+
+// Permissions returns the permissions granted by the role
+func (r RoleEnum) Permissions() []PermissionEnum {
+	return rolePermissions[r]
+}
+
+// HasPermission returns whether the role grants the permission
+func (r RoleEnum) HasPermission(permission PermissionEnum) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
 
-	// until we add a NONE value, all values imply read
-	if r.IsValid() && other == RoleEnumRead {
-		return true
+// Implies returns whether the role grants every permission of the other role
+func (r RoleEnum) Implies(other RoleEnum) bool {
+	if !r.IsValid() || !other.IsValid() {
+		return false
 	}
 
-	// all others only imply themselves
-	return r == other
+	for _, permission := range other.Permissions() {
+		if !r.HasPermission(permission) {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolePermissions(t *testing.T) {
+	for _, role := range AllRoleEnum {
+		assert.Contains(t, role.Permissions(), PermissionEnumRead, role)
+	}
+	assert.ElementsMatch(t, AllPermissionEnum, RoleEnumAdmin.Permissions())
+}
+
+func TestRoleImplies(t *testing.T) {
+	assert.True(t, RoleEnumAdmin.Implies(RoleEnumModerate))
+	assert.True(t, RoleEnumManageInvites.Implies(RoleEnumInvite))
+	assert.True(t, RoleEnumEdit.Implies(RoleEnumRead))
+	assert.True(t, RoleEnumVote.Implies(RoleEnumVote))
+
+	assert.False(t, RoleEnumEdit.Implies(RoleEnumVote))
+	assert.False(t, RoleEnumInvite.Implies(RoleEnumManageInvites))
+	assert.False(t, RoleEnumModerate.Implies(RoleEnumAdmin))
+	assert.False(t, RoleEnum("INVALID").Implies(RoleEnumRead))
+}
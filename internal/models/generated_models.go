@@ -41,6 +41,10 @@ type SceneDraftTag interface {
 	IsSceneDraftTag()
 }
 
+type SearchResult interface {
+	IsSearchResult()
+}
+
 type ActivateNewUserInput struct {
 	Name          string    `json:"name"`
 	ActivationKey uuid.UUID `json:"activation_key"`
@@ -90,10 +94,51 @@ type BreastTypeCriterionInput struct {
 	Modifier CriterionModifier `json:"modifier"`
 }
 
+type BulkPerformerChangesInput struct {
+	// Country to set on every performer
+	Country *string `json:"country,omitempty"`
+	// Aliases to add to every performer
+	AddedAliases []string `json:"added_aliases,omitempty"`
+	// Aliases to remove from every performer
+	RemovedAliases []string `json:"removed_aliases,omitempty"`
+}
+
+type BulkPerformerEditInput struct {
+	// Performers to change. Pagination and sorting are ignored.
+	Filter  *PerformerQueryInput       `json:"filter"`
+	Changes *BulkPerformerChangesInput `json:"changes"`
+	Comment *string                    `json:"comment,omitempty"`
+	// Edit submitted by an automated script. Requires bot permission
+	Bot *bool `json:"bot,omitempty"`
+}
+
+type BulkSceneChangesInput struct {
+	// Studio to set on every scene
+	StudioID *uuid.UUID `json:"studio_id,omitempty"`
+	// Tags to add to every scene
+	AddedTags []uuid.UUID `json:"added_tags,omitempty"`
+	// Tags to remove from every scene
+	RemovedTags []uuid.UUID `json:"removed_tags,omitempty"`
+}
+
+type BulkSceneEditInput struct {
+	// Scenes to change. Pagination and sorting are ignored.
+	Filter  *SceneQueryInput       `json:"filter"`
+	Changes *BulkSceneChangesInput `json:"changes"`
+	Comment *string                `json:"comment,omitempty"`
+	// Edit submitted by an automated script. Requires bot permission
+	Bot *bool `json:"bot,omitempty"`
+}
+
 type CancelEditInput struct {
 	ID uuid.UUID `json:"id"`
 }
 
+type ClearLockoutInput struct {
+	Scope LockoutScopeEnum `json:"scope"`
+	Key   string           `json:"key"`
+}
+
 type ClusterMember struct {
 	Hash             FingerprintHash          `json:"hash"`
 	SceneSubmissions []ClusterSceneSubmission `json:"scene_submissions"`
@@ -167,6 +212,21 @@ type DurationCount struct {
 type EditCommentInput struct {
 	ID      uuid.UUID `json:"id"`
 	Comment string    `json:"comment"`
+	// ID of the comment being replied to, which must be on the same edit
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+type EditCommentReaction struct {
+	Reaction EditCommentReactionEnum `json:"reaction"`
+	Count    int                     `json:"count"`
+	// Whether the current user has reacted with this reaction
+	Reacted bool `json:"reacted"`
+}
+
+type EditCommentReactionInput struct {
+	// ID of the comment to react to
+	ID       uuid.UUID               `json:"id"`
+	Reaction EditCommentReactionEnum `json:"reaction"`
 }
 
 type EditInput struct {
@@ -202,11 +262,13 @@ type EditQueryInput struct {
 	// Filter to bot edits only
 	IsBot *bool `json:"is_bot,omitempty"`
 	// Filter out user's own edits
-	IncludeUserSubmitted *bool             `json:"include_user_submitted,omitempty"`
-	Page                 int               `json:"page"`
-	PerPage              int               `json:"per_page"`
-	Direction            SortDirectionEnum `json:"direction"`
-	Sort                 EditSortEnum      `json:"sort"`
+	IncludeUserSubmitted *bool `json:"include_user_submitted,omitempty"`
+	// Filter to pending edits which passed the vote but change locked fields, and await a moderator
+	AwaitingModerator *bool             `json:"awaiting_moderator,omitempty"`
+	Page              int               `json:"page"`
+	PerPage           int               `json:"per_page"`
+	Direction         SortDirectionEnum `json:"direction"`
+	Sort              EditSortEnum      `json:"sort"`
 }
 
 type EditVoteInput struct {
@@ -214,6 +276,22 @@ type EditVoteInput struct {
 	Vote VoteTypeEnum `json:"vote"`
 }
 
+type EntityLockInput struct {
+	TargetType TargetTypeEnum `json:"target_type"`
+	TargetID   uuid.UUID      `json:"target_id"`
+	// Fields to lock, named as in the edit details. Locks the whole entity when omitted.
+	Fields  []string            `json:"fields,omitempty"`
+	Level   EntityLockLevelEnum `json:"level"`
+	Reason  string              `json:"reason"`
+	Expires *time.Time          `json:"expires,omitempty"`
+}
+
+type EntityUnlockInput struct {
+	ID uuid.UUID `json:"id"`
+	// Recorded in the moderation audit log
+	Reason *string `json:"reason,omitempty"`
+}
+
 type EyeColorCriterionInput struct {
 	Value    *EyeColorEnum     `json:"value,omitempty"`
 	Modifier CriterionModifier `json:"modifier"`
@@ -237,6 +315,12 @@ type FavoritePerformerScene struct {
 
 func (FavoritePerformerScene) IsNotificationData() {}
 
+type FavoriteSceneReleased struct {
+	Scene *Scene `json:"scene"`
+}
+
+func (FavoriteSceneReleased) IsNotificationData() {}
+
 type FavoriteStudioEdit struct {
 	Edit *Edit `json:"edit"`
 }
@@ -395,6 +479,36 @@ type IntCriterionInput struct {
 	Modifier CriterionModifier `json:"modifier"`
 }
 
+type InviteTreeEditStats struct {
+	Accepted int `json:"accepted"`
+	Rejected int `json:"rejected"`
+	Pending  int `json:"pending"`
+}
+
+type InviteTreeRevokeInput struct {
+	// Root of the invite tree
+	UserID uuid.UUID `json:"user_id"`
+	// Apply to the root user as well as everyone below them
+	IncludeRoot bool `json:"include_root"`
+	// Suspend the users. Admins and the current user are skipped.
+	Suspend bool `json:"suspend"`
+	// Bans the users until the suspensions are lifted if not set
+	Expires *time.Time `json:"expires,omitempty"`
+	// Remove the invite tokens and pending invite codes of the users
+	RevokeInvites bool   `json:"revoke_invites"`
+	Reason        string `json:"reason"`
+}
+
+type InviteTreeRevokeResult struct {
+	// Users the operation applied to
+	Users     int `json:"users"`
+	Suspended int `json:"suspended"`
+	// Invite tokens removed
+	RevokedTokens int `json:"revoked_tokens"`
+	// Pending invite codes removed
+	RevokedCodes int `json:"revoked_codes"`
+}
+
 type MarkNotificationReadInput struct {
 	Type NotificationEnum `json:"type"`
 	ID   uuid.UUID        `json:"id"`
@@ -407,11 +521,24 @@ type Measurements struct {
 	Hip      *int    `json:"hip,omitempty"`
 }
 
+type MentionedInComment struct {
+	Comment *EditComment `json:"comment"`
+}
+
+func (MentionedInComment) IsNotificationData() {}
+
 type ModAuditQueryInput struct {
 	Page    int                 `json:"page"`
 	PerPage int                 `json:"per_page"`
 	Action  *ModAuditActionEnum `json:"action,omitempty"`
 	UserID  *uuid.UUID          `json:"user_id,omitempty"`
+	// EDIT, EDIT_COMMENT, USER, ROLE, LOCKOUT, PERFORMER, SCENE, STUDIO, TAG, SITE or TAG_CATEGORY
+	TargetType *string    `json:"target_type,omitempty"`
+	TargetID   *uuid.UUID `json:"target_id,omitempty"`
+	// Only include entries created at or after this time
+	StartDate *time.Time `json:"start_date,omitempty"`
+	// Only include entries created before this time
+	EndDate *time.Time `json:"end_date,omitempty"`
 }
 
 type MoveFingerprintSubmissionsInput struct {
@@ -438,6 +565,16 @@ type NewUserInput struct {
 	InviteKey *uuid.UUID `json:"invite_key,omitempty"`
 }
 
+type NotificationEmailPreference struct {
+	Type      NotificationEnum           `json:"type"`
+	Frequency NotificationEmailFrequency `json:"frequency"`
+}
+
+type NotificationEmailPreferenceInput struct {
+	Type      NotificationEnum           `json:"type"`
+	Frequency NotificationEmailFrequency `json:"frequency"`
+}
+
 type PerformerAppearance struct {
 	Performer *Performer `json:"performer"`
 	// Performing as alias
@@ -450,6 +587,21 @@ type PerformerAppearanceInput struct {
 	As *string `json:"as,omitempty"`
 }
 
+type PerformerCareerPeriodInput struct {
+	StartDate string     `json:"start_date"`
+	EndDate   *string    `json:"end_date,omitempty"`
+	StudioID  *uuid.UUID `json:"studio_id,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+}
+
+type PerformerCostar struct {
+	Performer *Performer `json:"performer"`
+	// Number of scenes both performers appear in
+	SceneCount     int     `json:"scene_count"`
+	FirstSceneDate *string `json:"first_scene_date,omitempty"`
+	LastSceneDate  *string `json:"last_scene_date,omitempty"`
+}
+
 type PerformerCreateInput struct {
 	Name            string                  `json:"name"`
 	Disambiguation  *string                 `json:"disambiguation,omitempty"`
@@ -504,29 +656,31 @@ type PerformerDraftInput struct {
 }
 
 type PerformerEditDetailsInput struct {
-	Name            *string                 `json:"name,omitempty"`
-	Disambiguation  *string                 `json:"disambiguation,omitempty"`
-	Aliases         []string                `json:"aliases,omitempty"`
-	Gender          *GenderEnum             `json:"gender,omitempty"`
-	Urls            []URL                   `json:"urls,omitempty"`
-	Birthdate       *string                 `json:"birthdate,omitempty"`
-	Deathdate       *string                 `json:"deathdate,omitempty"`
-	Ethnicity       *EthnicityEnum          `json:"ethnicity,omitempty"`
-	Country         *string                 `json:"country,omitempty"`
-	EyeColor        *EyeColorEnum           `json:"eye_color,omitempty"`
-	HairColor       *HairColorEnum          `json:"hair_color,omitempty"`
-	Height          *int                    `json:"height,omitempty"`
-	CupSize         *string                 `json:"cup_size,omitempty"`
-	BandSize        *int                    `json:"band_size,omitempty"`
-	WaistSize       *int                    `json:"waist_size,omitempty"`
-	HipSize         *int                    `json:"hip_size,omitempty"`
-	BreastType      *BreastTypeEnum         `json:"breast_type,omitempty"`
-	CareerStartYear *int                    `json:"career_start_year,omitempty"`
-	CareerEndYear   *int                    `json:"career_end_year,omitempty"`
-	Tattoos         []BodyModificationInput `json:"tattoos,omitempty"`
-	Piercings       []BodyModificationInput `json:"piercings,omitempty"`
-	ImageIds        []uuid.UUID             `json:"image_ids,omitempty"`
-	DraftID         *uuid.UUID              `json:"draft_id,omitempty"`
+	Name            *string                      `json:"name,omitempty"`
+	Disambiguation  *string                      `json:"disambiguation,omitempty"`
+	Aliases         []string                     `json:"aliases,omitempty"`
+	Gender          *GenderEnum                  `json:"gender,omitempty"`
+	Urls            []URL                        `json:"urls,omitempty"`
+	Birthdate       *string                      `json:"birthdate,omitempty"`
+	Deathdate       *string                      `json:"deathdate,omitempty"`
+	Ethnicity       *EthnicityEnum               `json:"ethnicity,omitempty"`
+	Country         *string                      `json:"country,omitempty"`
+	EyeColor        *EyeColorEnum                `json:"eye_color,omitempty"`
+	HairColor       *HairColorEnum               `json:"hair_color,omitempty"`
+	Height          *int                         `json:"height,omitempty"`
+	CupSize         *string                      `json:"cup_size,omitempty"`
+	BandSize        *int                         `json:"band_size,omitempty"`
+	WaistSize       *int                         `json:"waist_size,omitempty"`
+	HipSize         *int                         `json:"hip_size,omitempty"`
+	BreastType      *BreastTypeEnum              `json:"breast_type,omitempty"`
+	CareerStartYear *int                         `json:"career_start_year,omitempty"`
+	CareerEndYear   *int                         `json:"career_end_year,omitempty"`
+	Tattoos         []BodyModificationInput      `json:"tattoos,omitempty"`
+	Piercings       []BodyModificationInput      `json:"piercings,omitempty"`
+	ImageIds        []uuid.UUID                  `json:"image_ids,omitempty"`
+	Relationships   []PerformerRelationshipInput `json:"relationships,omitempty"`
+	CareerPeriods   []PerformerCareerPeriodInput `json:"career_periods,omitempty"`
+	DraftID         *uuid.UUID                   `json:"draft_id,omitempty"`
 }
 
 type PerformerEditInput struct {
@@ -561,35 +715,49 @@ type PerformerQueryInput struct {
 	Disambiguation *StringCriterionInput `json:"disambiguation,omitempty"`
 	Gender         *GenderFilterEnum     `json:"gender,omitempty"`
 	// Filter to search urls - assumes like query unless quoted
-	URL             *string                         `json:"url,omitempty"`
-	Birthdate       *DateCriterionInput             `json:"birthdate,omitempty"`
-	Deathdate       *DateCriterionInput             `json:"deathdate,omitempty"`
-	BirthYear       *IntCriterionInput              `json:"birth_year,omitempty"`
-	Age             *IntCriterionInput              `json:"age,omitempty"`
-	Ethnicity       *EthnicityFilterEnum            `json:"ethnicity,omitempty"`
-	Country         *StringCriterionInput           `json:"country,omitempty"`
-	EyeColor        *EyeColorCriterionInput         `json:"eye_color,omitempty"`
-	HairColor       *HairColorCriterionInput        `json:"hair_color,omitempty"`
-	Height          *IntCriterionInput              `json:"height,omitempty"`
-	CupSize         *StringCriterionInput           `json:"cup_size,omitempty"`
-	BandSize        *IntCriterionInput              `json:"band_size,omitempty"`
-	WaistSize       *IntCriterionInput              `json:"waist_size,omitempty"`
-	HipSize         *IntCriterionInput              `json:"hip_size,omitempty"`
-	BreastType      *BreastTypeCriterionInput       `json:"breast_type,omitempty"`
-	CareerStartYear *IntCriterionInput              `json:"career_start_year,omitempty"`
-	CareerEndYear   *IntCriterionInput              `json:"career_end_year,omitempty"`
-	Tattoos         *BodyModificationCriterionInput `json:"tattoos,omitempty"`
-	Piercings       *BodyModificationCriterionInput `json:"piercings,omitempty"`
+	URL             *string                   `json:"url,omitempty"`
+	Birthdate       *DateCriterionInput       `json:"birthdate,omitempty"`
+	Deathdate       *DateCriterionInput       `json:"deathdate,omitempty"`
+	BirthYear       *IntCriterionInput        `json:"birth_year,omitempty"`
+	Age             *IntCriterionInput        `json:"age,omitempty"`
+	Ethnicity       *EthnicityFilterEnum      `json:"ethnicity,omitempty"`
+	Country         *StringCriterionInput     `json:"country,omitempty"`
+	EyeColor        *EyeColorCriterionInput   `json:"eye_color,omitempty"`
+	HairColor       *HairColorCriterionInput  `json:"hair_color,omitempty"`
+	Height          *IntCriterionInput        `json:"height,omitempty"`
+	CupSize         *StringCriterionInput     `json:"cup_size,omitempty"`
+	BandSize        *IntCriterionInput        `json:"band_size,omitempty"`
+	WaistSize       *IntCriterionInput        `json:"waist_size,omitempty"`
+	HipSize         *IntCriterionInput        `json:"hip_size,omitempty"`
+	BreastType      *BreastTypeCriterionInput `json:"breast_type,omitempty"`
+	CareerStartYear *IntCriterionInput        `json:"career_start_year,omitempty"`
+	CareerEndYear   *IntCriterionInput        `json:"career_end_year,omitempty"`
+	// Filter by active status, derived from career periods or career years
+	Active    *bool                           `json:"active,omitempty"`
+	Tattoos   *BodyModificationCriterionInput `json:"tattoos,omitempty"`
+	Piercings *BodyModificationCriterionInput `json:"piercings,omitempty"`
 	// Filter by performerfavorite status for the current user
 	IsFavorite *bool `json:"is_favorite,omitempty"`
 	// Filter by a performer they have performed in scenes with
 	PerformedWith *uuid.UUID `json:"performed_with,omitempty"`
 	// Filter by a studio
-	StudioID  *uuid.UUID        `json:"studio_id,omitempty"`
-	Page      int               `json:"page"`
-	PerPage   int               `json:"per_page"`
-	Direction SortDirectionEnum `json:"direction"`
-	Sort      PerformerSortEnum `json:"sort"`
+	StudioID *uuid.UUID `json:"studio_id,omitempty"`
+	// Include scenes from all studios below studio_id in the network
+	IncludeSubStudios *bool             `json:"include_sub_studios,omitempty"`
+	Page              int               `json:"page"`
+	PerPage           int               `json:"per_page"`
+	Direction         SortDirectionEnum `json:"direction"`
+	Sort              PerformerSortEnum `json:"sort"`
+}
+
+type PerformerRelationship struct {
+	Performer *Performer                    `json:"performer"`
+	Type      PerformerRelationshipTypeEnum `json:"type"`
+}
+
+type PerformerRelationshipInput struct {
+	PerformerID uuid.UUID                     `json:"performer_id"`
+	Type        PerformerRelationshipTypeEnum `json:"type"`
 }
 
 type PerformerScenesInput struct {
@@ -690,6 +858,11 @@ type QueryUsersResultType struct {
 	Users []User `json:"users"`
 }
 
+type RebaseEditInput struct {
+	ID      uuid.UUID `json:"id"`
+	Comment *string   `json:"comment,omitempty"`
+}
+
 type ResetPasswordInput struct {
 	Email string `json:"email"`
 }
@@ -699,11 +872,67 @@ type RevokeInviteInput struct {
 	Amount int       `json:"amount"`
 }
 
+type RoleCreateInput struct {
+	Name        string           `json:"name"`
+	Description *string          `json:"description,omitempty"`
+	Permissions []PermissionEnum `json:"permissions"`
+}
+
 type RoleCriterionInput struct {
 	Value    []RoleEnum        `json:"value"`
 	Modifier CriterionModifier `json:"modifier"`
 }
 
+type RoleDestroyInput struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Permissions granted by a built-in role
+type RolePreset struct {
+	Role        RoleEnum         `json:"role"`
+	Permissions []PermissionEnum `json:"permissions"`
+}
+
+type RoleUpdateInput struct {
+	ID          uuid.UUID        `json:"id"`
+	Name        *string          `json:"name,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	Permissions []PermissionEnum `json:"permissions,omitempty"`
+}
+
+// Exactly one of scene_filter and performer_filter must be set
+type SavedSearchCreateInput struct {
+	Name            string               `json:"name"`
+	SceneFilter     *SceneQueryInput     `json:"scene_filter,omitempty"`
+	PerformerFilter *PerformerQueryInput `json:"performer_filter,omitempty"`
+	Notify          bool                 `json:"notify"`
+}
+
+type SavedSearchDestroyInput struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type SavedSearchMatch struct {
+	SavedSearch *SavedSearch `json:"saved_search"`
+	// Number of new matches
+	Count int `json:"count"`
+	// New matches of a SCENE saved search, up to 50
+	Scenes []Scene `json:"scenes"`
+	// New matches of a PERFORMER saved search, up to 50
+	Performers []Performer `json:"performers"`
+}
+
+func (SavedSearchMatch) IsNotificationData() {}
+
+// Setting scene_filter or performer_filter replaces the saved filter
+type SavedSearchUpdateInput struct {
+	ID              uuid.UUID            `json:"id"`
+	Name            *string              `json:"name,omitempty"`
+	SceneFilter     *SceneQueryInput     `json:"scene_filter,omitempty"`
+	PerformerFilter *PerformerQueryInput `json:"performer_filter,omitempty"`
+	Notify          *bool                `json:"notify,omitempty"`
+}
+
 type SceneCreateInput struct {
 	Title          *string                    `json:"title,omitempty"`
 	Details        *string                    `json:"details,omitempty"`
@@ -741,6 +970,11 @@ type SceneDraftInput struct {
 	Fingerprints   []FingerprintInput `json:"fingerprints"`
 }
 
+type SceneDurationFacet struct {
+	Bucket SceneDurationBucketEnum `json:"bucket"`
+	Count  int                     `json:"count"`
+}
+
 type SceneEditDetailsInput struct {
 	Title          *string                    `json:"title,omitempty"`
 	Details        *string                    `json:"details,omitempty"`
@@ -778,7 +1012,7 @@ type SceneQueryInput struct {
 	ProductionDate *DateCriterionInput `json:"production_date,omitempty"`
 	// Filter to only include scenes with this studio
 	Studios *MultiIDCriterionInput `json:"studios,omitempty"`
-	// Filter to only include scenes with this studio as primary or parent
+	// Filter to only include scenes with this studio or any studio below it in the network
 	ParentStudio *string `json:"parentStudio,omitempty"`
 	// Filter to only include scenes with these tags
 	Tags *MultiIDCriterionInput `json:"tags,omitempty"`
@@ -790,12 +1024,39 @@ type SceneQueryInput struct {
 	Fingerprints *MultiStringCriterionInput `json:"fingerprints,omitempty"`
 	// Filter by favorited entity
 	Favorites *FavoriteFilter `json:"favorites,omitempty"`
+	// Filter to scenes with a release date after today
+	Upcoming *bool `json:"upcoming,omitempty"`
 	// Filter to scenes with fingerprints submitted by the user
-	HasFingerprintSubmissions *bool             `json:"has_fingerprint_submissions,omitempty"`
-	Page                      int               `json:"page"`
-	PerPage                   int               `json:"per_page"`
-	Direction                 SortDirectionEnum `json:"direction"`
-	Sort                      SceneSortEnum     `json:"sort"`
+	HasFingerprintSubmissions *bool `json:"has_fingerprint_submissions,omitempty"`
+	// Filter to scenes released in any of these years
+	Years []int `json:"years,omitempty"`
+	// Filter to scenes with a duration in any of these buckets
+	Durations []SceneDurationBucketEnum `json:"durations,omitempty"`
+	Page      int                       `json:"page"`
+	PerPage   int                       `json:"per_page"`
+	Direction SortDirectionEnum         `json:"direction"`
+	Sort      SceneSortEnum             `json:"sort"`
+}
+
+type SceneSearchFacets struct {
+	// Most common studios, most frequent first
+	Studios []SceneStudioFacet `json:"studios"`
+	// Most common tags, most frequent first
+	Tags []SceneTagFacet `json:"tags"`
+	// Most common performers, most frequent first
+	Performers []ScenePerformerFacet `json:"performers"`
+	// Release years, most recent first
+	Years     []SceneYearFacet     `json:"years"`
+	Durations []SceneDurationFacet `json:"durations"`
+}
+
+// Drill-down filters for searchScenes, accepting the values of SceneSearchFacets
+type SceneSearchFilterInput struct {
+	Studios    *MultiIDCriterionInput    `json:"studios,omitempty"`
+	Tags       *MultiIDCriterionInput    `json:"tags,omitempty"`
+	Performers *MultiIDCriterionInput    `json:"performers,omitempty"`
+	Years      []int                     `json:"years,omitempty"`
+	Durations  []SceneDurationBucketEnum `json:"durations,omitempty"`
 }
 
 type SceneUpdateInput struct {
@@ -815,6 +1076,29 @@ type SceneUpdateInput struct {
 	Code           *string                    `json:"code,omitempty"`
 }
 
+type SceneYearFacet struct {
+	Year  int `json:"year"`
+	Count int `json:"count"`
+}
+
+// Indexed field that matched the search term, and the value it matched
+type SearchHighlight struct {
+	// name, alias, disambiguation, title, code, performer, studio or network
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+type SearchResultType struct {
+	Hits   []SearchHit       `json:"hits"`
+	Counts []SearchTypeCount `json:"counts"`
+}
+
+type SearchTypeCount struct {
+	Type SearchTypeEnum `json:"type"`
+	// Total number of matches of this type, not limited by the hit limit
+	Count int `json:"count"`
+}
+
 type SiteCategoryCreateInput struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description,omitempty"`
@@ -882,6 +1166,10 @@ type StashBoxConfig struct {
 	RequireSceneDraft          bool   `json:"require_scene_draft"`
 	EditUpdateLimit            int    `json:"edit_update_limit"`
 	RequireTagRole             bool   `json:"require_tag_role"`
+	// Users can log in with single sign-on at /oidc/login
+	OidcEnabled bool `json:"oidc_enabled"`
+	// New users can only register through single sign-on
+	DisableLocalRegistration bool `json:"disable_local_registration"`
 }
 
 type StringCriterionInput struct {
@@ -915,6 +1203,17 @@ type StudioEditInput struct {
 	Details *StudioEditDetailsInput `json:"details,omitempty"`
 }
 
+type StudioNetworkStats struct {
+	// Number of studios in the network, including this one
+	StudioCount    int `json:"studio_count"`
+	SceneCount     int `json:"scene_count"`
+	PerformerCount int `json:"performer_count"`
+	// Earliest release date of any scene in the network
+	FirstSceneDate *string `json:"first_scene_date,omitempty"`
+	// Latest release date of any scene in the network
+	LastSceneDate *string `json:"last_scene_date,omitempty"`
+}
+
 type StudioQueryInput struct {
 	// Filter to search name - assumes like query unless quoted
 	Name *string `json:"name,omitempty"`
@@ -1005,6 +1304,20 @@ type TagUpdateInput struct {
 	CategoryID  *uuid.UUID `json:"category_id,omitempty"`
 }
 
+type TwoFactorSetup struct {
+	// Base32 encoded TOTP secret, for manual entry into an authenticator app
+	Secret string `json:"secret"`
+	// otpauth URI of the secret, to be shown as a QR code
+	URI string `json:"uri"`
+}
+
+type TwoFactorStatus struct {
+	Enabled bool `json:"enabled"`
+	// Whether the roles of the user require two-factor authentication
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
 type UnreadNotificationCount struct {
 	Total  int `json:"total"`
 	Urgent int `json:"urgent"`
@@ -1040,10 +1353,17 @@ type UserChangePasswordInput struct {
 type UserCreateInput struct {
 	Name string `json:"name"`
 	// Password in plain text
-	Password    string     `json:"password"`
-	Roles       []RoleEnum `json:"roles"`
-	Email       string     `json:"email"`
-	InvitedByID *uuid.UUID `json:"invited_by_id,omitempty"`
+	Password      string      `json:"password"`
+	Roles         []RoleEnum  `json:"roles"`
+	Email         string      `json:"email"`
+	InvitedByID   *uuid.UUID  `json:"invited_by_id,omitempty"`
+	CustomRoleIds []uuid.UUID `json:"custom_role_ids,omitempty"`
+}
+
+type UserDataExport struct {
+	// Link to download a zip archive of JSON files from. The link can be used once.
+	URL     string    `json:"url"`
+	Expires time.Time `json:"expires"`
 }
 
 type UserDestroyInput struct {
@@ -1067,6 +1387,12 @@ type UserEditCount struct {
 	CanceledBot          int `json:"canceled_bot"`
 }
 
+type UserLiftSuspensionInput struct {
+	UserID uuid.UUID `json:"user_id"`
+	// Recorded in the moderation audit log
+	Reason *string `json:"reason,omitempty"`
+}
+
 type UserQueryInput struct {
 	// Filter to search user name - assumes like query unless quoted
 	Name *string `json:"name,omitempty"`
@@ -1092,13 +1418,50 @@ type UserQueryInput struct {
 	PerPage   int        `json:"per_page"`
 }
 
+type UserReputation struct {
+	// Score from 0 to 100, weighing edit acceptance, vote agreement and fingerprint report accuracy
+	Score         int `json:"score"`
+	AcceptedEdits int `json:"accepted_edits"`
+	RejectedEdits int `json:"rejected_edits"`
+	// Votes on closed edits that matched the outcome
+	AgreeingVotes int `json:"agreeing_votes"`
+	// Votes on closed edits that went against the outcome
+	DisagreeingVotes int `json:"disagreeing_votes"`
+	// Fingerprint reports that other users agreed with
+	ConfirmedReports int `json:"confirmed_reports"`
+	// Fingerprint reports that other users disagreed with
+	DisputedReports int `json:"disputed_reports"`
+}
+
+type UserSuspendInput struct {
+	UserID      uuid.UUID                 `json:"user_id"`
+	Reason      string                    `json:"reason"`
+	Restriction SuspensionRestrictionEnum `json:"restriction"`
+	// Bans the user until the suspension is lifted if not set
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+type UserSuspended struct {
+	Suspension *UserSuspension `json:"suspension"`
+}
+
+func (UserSuspended) IsNotificationData() {}
+
+type UserSuspensionLifted struct {
+	Suspension *UserSuspension `json:"suspension"`
+}
+
+func (UserSuspensionLifted) IsNotificationData() {}
+
 type UserUpdateInput struct {
 	ID   uuid.UUID `json:"id"`
 	Name *string   `json:"name,omitempty"`
 	// Password in plain text
 	Password *string    `json:"password,omitempty"`
 	Roles    []RoleEnum `json:"roles,omitempty"`
-	Email    *string    `json:"email,omitempty"`
+	// Replaces the custom roles of the user if set
+	CustomRoleIds []uuid.UUID `json:"custom_role_ids,omitempty"`
+	Email         *string     `json:"email,omitempty"`
 }
 
 type UserVoteCount struct {
@@ -1306,6 +1669,67 @@ func (e DateAccuracyEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type EditCommentReactionEnum string
+
+const (
+	EditCommentReactionEnumThumbsUp   EditCommentReactionEnum = "THUMBS_UP"
+	EditCommentReactionEnumThumbsDown EditCommentReactionEnum = "THUMBS_DOWN"
+	EditCommentReactionEnumLaugh      EditCommentReactionEnum = "LAUGH"
+	EditCommentReactionEnumHeart      EditCommentReactionEnum = "HEART"
+	EditCommentReactionEnumConfused   EditCommentReactionEnum = "CONFUSED"
+)
+
+var AllEditCommentReactionEnum = []EditCommentReactionEnum{
+	EditCommentReactionEnumThumbsUp,
+	EditCommentReactionEnumThumbsDown,
+	EditCommentReactionEnumLaugh,
+	EditCommentReactionEnumHeart,
+	EditCommentReactionEnumConfused,
+}
+
+func (e EditCommentReactionEnum) IsValid() bool {
+	switch e {
+	case EditCommentReactionEnumThumbsUp, EditCommentReactionEnumThumbsDown, EditCommentReactionEnumLaugh, EditCommentReactionEnumHeart, EditCommentReactionEnumConfused:
+		return true
+	}
+	return false
+}
+
+func (e EditCommentReactionEnum) String() string {
+	return string(e)
+}
+
+func (e *EditCommentReactionEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EditCommentReactionEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EditCommentReactionEnum", str)
+	}
+	return nil
+}
+
+func (e EditCommentReactionEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *EditCommentReactionEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e EditCommentReactionEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type EditSortEnum string
 
 const (
@@ -1363,6 +1787,63 @@ func (e EditSortEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type EntityLockLevelEnum string
+
+const (
+	// Edits touching the locked fields are rejected, unless submitted by a moderator
+	EntityLockLevelEnumLocked EntityLockLevelEnum = "LOCKED"
+	// Edits touching the locked fields are only applied when approved by a moderator
+	EntityLockLevelEnumProtected EntityLockLevelEnum = "PROTECTED"
+)
+
+var AllEntityLockLevelEnum = []EntityLockLevelEnum{
+	EntityLockLevelEnumLocked,
+	EntityLockLevelEnumProtected,
+}
+
+func (e EntityLockLevelEnum) IsValid() bool {
+	switch e {
+	case EntityLockLevelEnumLocked, EntityLockLevelEnumProtected:
+		return true
+	}
+	return false
+}
+
+func (e EntityLockLevelEnum) String() string {
+	return string(e)
+}
+
+func (e *EntityLockLevelEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EntityLockLevelEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EntityLockLevelEnum", str)
+	}
+	return nil
+}
+
+func (e EntityLockLevelEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *EntityLockLevelEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e EntityLockLevelEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type EthnicityEnum string
 
 const (
@@ -1935,13 +2416,111 @@ func (e HairColorEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type LockoutScopeEnum string
+
+const (
+	// Failed logins to an account
+	LockoutScopeEnumLoginAccount LockoutScopeEnum = "LOGIN_ACCOUNT"
+	// Failed logins from an IP address
+	LockoutScopeEnumLoginIP LockoutScopeEnum = "LOGIN_IP"
+	// Password reset requests for an email address
+	LockoutScopeEnumResetPasswordEmail LockoutScopeEnum = "RESET_PASSWORD_EMAIL"
+	// Password reset requests from an IP address
+	LockoutScopeEnumResetPasswordIP LockoutScopeEnum = "RESET_PASSWORD_IP"
+	// Registrations for an email address
+	LockoutScopeEnumNewUserEmail LockoutScopeEnum = "NEW_USER_EMAIL"
+	// Registrations from an IP address
+	LockoutScopeEnumNewUserIP LockoutScopeEnum = "NEW_USER_IP"
+)
+
+var AllLockoutScopeEnum = []LockoutScopeEnum{
+	LockoutScopeEnumLoginAccount,
+	LockoutScopeEnumLoginIP,
+	LockoutScopeEnumResetPasswordEmail,
+	LockoutScopeEnumResetPasswordIP,
+	LockoutScopeEnumNewUserEmail,
+	LockoutScopeEnumNewUserIP,
+}
+
+func (e LockoutScopeEnum) IsValid() bool {
+	switch e {
+	case LockoutScopeEnumLoginAccount, LockoutScopeEnumLoginIP, LockoutScopeEnumResetPasswordEmail, LockoutScopeEnumResetPasswordIP, LockoutScopeEnumNewUserEmail, LockoutScopeEnumNewUserIP:
+		return true
+	}
+	return false
+}
+
+func (e LockoutScopeEnum) String() string {
+	return string(e)
+}
+
+func (e *LockoutScopeEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LockoutScopeEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LockoutScopeEnum", str)
+	}
+	return nil
+}
+
+func (e LockoutScopeEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *LockoutScopeEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e LockoutScopeEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type ModAuditActionEnum string
 
 const (
-	ModAuditActionEnumEditDelete        ModAuditActionEnum = "EDIT_DELETE"
-	ModAuditActionEnumEditAmendment     ModAuditActionEnum = "EDIT_AMENDMENT"
-	ModAuditActionEnumEditCommentUpdate ModAuditActionEnum = "EDIT_COMMENT_UPDATE"
-	ModAuditActionEnumEditCommentHide   ModAuditActionEnum = "EDIT_COMMENT_HIDE"
+	ModAuditActionEnumEditDelete         ModAuditActionEnum = "EDIT_DELETE"
+	ModAuditActionEnumEditAmendment      ModAuditActionEnum = "EDIT_AMENDMENT"
+	ModAuditActionEnumEditCommentUpdate  ModAuditActionEnum = "EDIT_COMMENT_UPDATE"
+	ModAuditActionEnumEditCommentHide    ModAuditActionEnum = "EDIT_COMMENT_HIDE"
+	ModAuditActionEnumUserCreate         ModAuditActionEnum = "USER_CREATE"
+	ModAuditActionEnumUserUpdate         ModAuditActionEnum = "USER_UPDATE"
+	ModAuditActionEnumUserDestroy        ModAuditActionEnum = "USER_DESTROY"
+	ModAuditActionEnumEditApprove        ModAuditActionEnum = "EDIT_APPROVE"
+	ModAuditActionEnumEditReject         ModAuditActionEnum = "EDIT_REJECT"
+	ModAuditActionEnumFingerprintMove    ModAuditActionEnum = "FINGERPRINT_MOVE"
+	ModAuditActionEnumFingerprintDelete  ModAuditActionEnum = "FINGERPRINT_DELETE"
+	ModAuditActionEnumInviteGrant        ModAuditActionEnum = "INVITE_GRANT"
+	ModAuditActionEnumInviteRevoke       ModAuditActionEnum = "INVITE_REVOKE"
+	ModAuditActionEnumSiteCreate         ModAuditActionEnum = "SITE_CREATE"
+	ModAuditActionEnumSiteUpdate         ModAuditActionEnum = "SITE_UPDATE"
+	ModAuditActionEnumSiteDestroy        ModAuditActionEnum = "SITE_DESTROY"
+	ModAuditActionEnumTagCategoryCreate  ModAuditActionEnum = "TAG_CATEGORY_CREATE"
+	ModAuditActionEnumTagCategoryUpdate  ModAuditActionEnum = "TAG_CATEGORY_UPDATE"
+	ModAuditActionEnumTagCategoryDestroy ModAuditActionEnum = "TAG_CATEGORY_DESTROY"
+	// Roles granted or removed by reputation rules
+	ModAuditActionEnumUserReputationUpdate ModAuditActionEnum = "USER_REPUTATION_UPDATE"
+	ModAuditActionEnumUserSuspend          ModAuditActionEnum = "USER_SUSPEND"
+	ModAuditActionEnumUserLiftSuspension   ModAuditActionEnum = "USER_LIFT_SUSPENSION"
+	ModAuditActionEnumUserTwoFactorReset   ModAuditActionEnum = "USER_TWO_FACTOR_RESET"
+	ModAuditActionEnumUserRevokeSessions   ModAuditActionEnum = "USER_REVOKE_SESSIONS"
+	ModAuditActionEnumLockoutClear         ModAuditActionEnum = "LOCKOUT_CLEAR"
+	// Suspensions and invite revocations applied to an invite tree
+	ModAuditActionEnumInviteTreeRevoke ModAuditActionEnum = "INVITE_TREE_REVOKE"
+	ModAuditActionEnumRoleCreate       ModAuditActionEnum = "ROLE_CREATE"
+	ModAuditActionEnumRoleUpdate       ModAuditActionEnum = "ROLE_UPDATE"
+	ModAuditActionEnumRoleDestroy      ModAuditActionEnum = "ROLE_DESTROY"
+	ModAuditActionEnumEntityLock       ModAuditActionEnum = "ENTITY_LOCK"
+	ModAuditActionEnumEntityUnlock     ModAuditActionEnum = "ENTITY_UNLOCK"
 )
 
 var AllModAuditActionEnum = []ModAuditActionEnum{
@@ -1949,11 +2528,38 @@ var AllModAuditActionEnum = []ModAuditActionEnum{
 	ModAuditActionEnumEditAmendment,
 	ModAuditActionEnumEditCommentUpdate,
 	ModAuditActionEnumEditCommentHide,
+	ModAuditActionEnumUserCreate,
+	ModAuditActionEnumUserUpdate,
+	ModAuditActionEnumUserDestroy,
+	ModAuditActionEnumEditApprove,
+	ModAuditActionEnumEditReject,
+	ModAuditActionEnumFingerprintMove,
+	ModAuditActionEnumFingerprintDelete,
+	ModAuditActionEnumInviteGrant,
+	ModAuditActionEnumInviteRevoke,
+	ModAuditActionEnumSiteCreate,
+	ModAuditActionEnumSiteUpdate,
+	ModAuditActionEnumSiteDestroy,
+	ModAuditActionEnumTagCategoryCreate,
+	ModAuditActionEnumTagCategoryUpdate,
+	ModAuditActionEnumTagCategoryDestroy,
+	ModAuditActionEnumUserReputationUpdate,
+	ModAuditActionEnumUserSuspend,
+	ModAuditActionEnumUserLiftSuspension,
+	ModAuditActionEnumUserTwoFactorReset,
+	ModAuditActionEnumUserRevokeSessions,
+	ModAuditActionEnumLockoutClear,
+	ModAuditActionEnumInviteTreeRevoke,
+	ModAuditActionEnumRoleCreate,
+	ModAuditActionEnumRoleUpdate,
+	ModAuditActionEnumRoleDestroy,
+	ModAuditActionEnumEntityLock,
+	ModAuditActionEnumEntityUnlock,
 }
 
 func (e ModAuditActionEnum) IsValid() bool {
 	switch e {
-	case ModAuditActionEnumEditDelete, ModAuditActionEnumEditAmendment, ModAuditActionEnumEditCommentUpdate, ModAuditActionEnumEditCommentHide:
+	case ModAuditActionEnumEditDelete, ModAuditActionEnumEditAmendment, ModAuditActionEnumEditCommentUpdate, ModAuditActionEnumEditCommentHide, ModAuditActionEnumUserCreate, ModAuditActionEnumUserUpdate, ModAuditActionEnumUserDestroy, ModAuditActionEnumEditApprove, ModAuditActionEnumEditReject, ModAuditActionEnumFingerprintMove, ModAuditActionEnumFingerprintDelete, ModAuditActionEnumInviteGrant, ModAuditActionEnumInviteRevoke, ModAuditActionEnumSiteCreate, ModAuditActionEnumSiteUpdate, ModAuditActionEnumSiteDestroy, ModAuditActionEnumTagCategoryCreate, ModAuditActionEnumTagCategoryUpdate, ModAuditActionEnumTagCategoryDestroy, ModAuditActionEnumUserReputationUpdate, ModAuditActionEnumUserSuspend, ModAuditActionEnumUserLiftSuspension, ModAuditActionEnumUserTwoFactorReset, ModAuditActionEnumUserRevokeSessions, ModAuditActionEnumLockoutClear, ModAuditActionEnumInviteTreeRevoke, ModAuditActionEnumRoleCreate, ModAuditActionEnumRoleUpdate, ModAuditActionEnumRoleDestroy, ModAuditActionEnumEntityLock, ModAuditActionEnumEntityUnlock:
 		return true
 	}
 	return false
@@ -1994,12 +2600,129 @@ func (e ModAuditActionEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-type NotificationEnum string
+type ModAuditExportFormatEnum string
 
 const (
-	NotificationEnumFavoritePerformerScene NotificationEnum = "FAVORITE_PERFORMER_SCENE"
-	NotificationEnumFavoritePerformerEdit  NotificationEnum = "FAVORITE_PERFORMER_EDIT"
-	NotificationEnumFavoriteStudioScene    NotificationEnum = "FAVORITE_STUDIO_SCENE"
+	ModAuditExportFormatEnumCSV  ModAuditExportFormatEnum = "CSV"
+	ModAuditExportFormatEnumJSON ModAuditExportFormatEnum = "JSON"
+)
+
+var AllModAuditExportFormatEnum = []ModAuditExportFormatEnum{
+	ModAuditExportFormatEnumCSV,
+	ModAuditExportFormatEnumJSON,
+}
+
+func (e ModAuditExportFormatEnum) IsValid() bool {
+	switch e {
+	case ModAuditExportFormatEnumCSV, ModAuditExportFormatEnumJSON:
+		return true
+	}
+	return false
+}
+
+func (e ModAuditExportFormatEnum) String() string {
+	return string(e)
+}
+
+func (e *ModAuditExportFormatEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModAuditExportFormatEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModAuditExportFormatEnum", str)
+	}
+	return nil
+}
+
+func (e ModAuditExportFormatEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ModAuditExportFormatEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ModAuditExportFormatEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type NotificationEmailFrequency string
+
+const (
+	// Emailed within a few minutes
+	NotificationEmailFrequencyImmediate NotificationEmailFrequency = "IMMEDIATE"
+	// Collected into a digest emailed at most once a day
+	NotificationEmailFrequencyDaily NotificationEmailFrequency = "DAILY"
+	// Collected into a digest emailed at most once a week
+	NotificationEmailFrequencyWeekly NotificationEmailFrequency = "WEEKLY"
+	NotificationEmailFrequencyOff    NotificationEmailFrequency = "OFF"
+)
+
+var AllNotificationEmailFrequency = []NotificationEmailFrequency{
+	NotificationEmailFrequencyImmediate,
+	NotificationEmailFrequencyDaily,
+	NotificationEmailFrequencyWeekly,
+	NotificationEmailFrequencyOff,
+}
+
+func (e NotificationEmailFrequency) IsValid() bool {
+	switch e {
+	case NotificationEmailFrequencyImmediate, NotificationEmailFrequencyDaily, NotificationEmailFrequencyWeekly, NotificationEmailFrequencyOff:
+		return true
+	}
+	return false
+}
+
+func (e NotificationEmailFrequency) String() string {
+	return string(e)
+}
+
+func (e *NotificationEmailFrequency) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationEmailFrequency(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationEmailFrequency", str)
+	}
+	return nil
+}
+
+func (e NotificationEmailFrequency) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NotificationEmailFrequency) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NotificationEmailFrequency) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type NotificationEnum string
+
+const (
+	NotificationEnumFavoritePerformerScene NotificationEnum = "FAVORITE_PERFORMER_SCENE"
+	NotificationEnumFavoritePerformerEdit  NotificationEnum = "FAVORITE_PERFORMER_EDIT"
+	NotificationEnumFavoriteStudioScene    NotificationEnum = "FAVORITE_STUDIO_SCENE"
 	NotificationEnumFavoriteStudioEdit     NotificationEnum = "FAVORITE_STUDIO_EDIT"
 	NotificationEnumCommentOwnEdit         NotificationEnum = "COMMENT_OWN_EDIT"
 	NotificationEnumDownvoteOwnEdit        NotificationEnum = "DOWNVOTE_OWN_EDIT"
@@ -2009,6 +2732,11 @@ const (
 	NotificationEnumUpdatedEdit            NotificationEnum = "UPDATED_EDIT"
 	NotificationEnumFingerprintedSceneEdit NotificationEnum = "FINGERPRINTED_SCENE_EDIT"
 	NotificationEnumFingerprintMoved       NotificationEnum = "FINGERPRINT_MOVED"
+	NotificationEnumFavoriteSceneReleased  NotificationEnum = "FAVORITE_SCENE_RELEASED"
+	NotificationEnumSavedSearchMatch       NotificationEnum = "SAVED_SEARCH_MATCH"
+	NotificationEnumMentionedInComment     NotificationEnum = "MENTIONED_IN_COMMENT"
+	NotificationEnumUserSuspended          NotificationEnum = "USER_SUSPENDED"
+	NotificationEnumUserSuspensionLifted   NotificationEnum = "USER_SUSPENSION_LIFTED"
 )
 
 var AllNotificationEnum = []NotificationEnum{
@@ -2024,11 +2752,16 @@ var AllNotificationEnum = []NotificationEnum{
 	NotificationEnumUpdatedEdit,
 	NotificationEnumFingerprintedSceneEdit,
 	NotificationEnumFingerprintMoved,
+	NotificationEnumFavoriteSceneReleased,
+	NotificationEnumSavedSearchMatch,
+	NotificationEnumMentionedInComment,
+	NotificationEnumUserSuspended,
+	NotificationEnumUserSuspensionLifted,
 }
 
 func (e NotificationEnum) IsValid() bool {
 	switch e {
-	case NotificationEnumFavoritePerformerScene, NotificationEnumFavoritePerformerEdit, NotificationEnumFavoriteStudioScene, NotificationEnumFavoriteStudioEdit, NotificationEnumCommentOwnEdit, NotificationEnumDownvoteOwnEdit, NotificationEnumFailedOwnEdit, NotificationEnumCommentCommentedEdit, NotificationEnumCommentVotedEdit, NotificationEnumUpdatedEdit, NotificationEnumFingerprintedSceneEdit, NotificationEnumFingerprintMoved:
+	case NotificationEnumFavoritePerformerScene, NotificationEnumFavoritePerformerEdit, NotificationEnumFavoriteStudioScene, NotificationEnumFavoriteStudioEdit, NotificationEnumCommentOwnEdit, NotificationEnumDownvoteOwnEdit, NotificationEnumFailedOwnEdit, NotificationEnumCommentCommentedEdit, NotificationEnumCommentVotedEdit, NotificationEnumUpdatedEdit, NotificationEnumFingerprintedSceneEdit, NotificationEnumFingerprintMoved, NotificationEnumFavoriteSceneReleased, NotificationEnumSavedSearchMatch, NotificationEnumMentionedInComment, NotificationEnumUserSuspended, NotificationEnumUserSuspensionLifted:
 		return true
 	}
 	return false
@@ -2183,6 +2916,64 @@ func (e OperationEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type PerformerRelationshipTypeEnum string
+
+const (
+	PerformerRelationshipTypeEnumSibling PerformerRelationshipTypeEnum = "SIBLING"
+	PerformerRelationshipTypeEnumPartner PerformerRelationshipTypeEnum = "PARTNER"
+	// The same person performing under a separate identity
+	PerformerRelationshipTypeEnumAlterEgo PerformerRelationshipTypeEnum = "ALTER_EGO"
+)
+
+var AllPerformerRelationshipTypeEnum = []PerformerRelationshipTypeEnum{
+	PerformerRelationshipTypeEnumSibling,
+	PerformerRelationshipTypeEnumPartner,
+	PerformerRelationshipTypeEnumAlterEgo,
+}
+
+func (e PerformerRelationshipTypeEnum) IsValid() bool {
+	switch e {
+	case PerformerRelationshipTypeEnumSibling, PerformerRelationshipTypeEnumPartner, PerformerRelationshipTypeEnumAlterEgo:
+		return true
+	}
+	return false
+}
+
+func (e PerformerRelationshipTypeEnum) String() string {
+	return string(e)
+}
+
+func (e *PerformerRelationshipTypeEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PerformerRelationshipTypeEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PerformerRelationshipTypeEnum", str)
+	}
+	return nil
+}
+
+func (e PerformerRelationshipTypeEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PerformerRelationshipTypeEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PerformerRelationshipTypeEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type PerformerSortEnum string
 
 const (
@@ -2254,6 +3045,107 @@ func (e PerformerSortEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type PermissionEnum string
+
+const (
+	PermissionEnumRead PermissionEnum = "READ"
+	PermissionEnumVote PermissionEnum = "VOTE"
+	// Comment on edits, submit drafts and upload images
+	PermissionEnumEdit           PermissionEnum = "EDIT"
+	PermissionEnumEditScenes     PermissionEnum = "EDIT_SCENES"
+	PermissionEnumEditPerformers PermissionEnum = "EDIT_PERFORMERS"
+	PermissionEnumEditStudios    PermissionEnum = "EDIT_STUDIOS"
+	// Granted to everyone with EDIT unless require_tag_role is set
+	PermissionEnumEditTags PermissionEnum = "EDIT_TAGS"
+	// Create, update and delete entities directly, without edits
+	PermissionEnumModify PermissionEnum = "MODIFY"
+	// Approve, cancel, amend and delete edits of other users
+	PermissionEnumModerateEdits PermissionEnum = "MODERATE_EDITS"
+	// Update and hide comments of other users
+	PermissionEnumModerateComments PermissionEnum = "MODERATE_COMMENTS"
+	// Move and delete fingerprint submissions
+	PermissionEnumModerateFingerprints PermissionEnum = "MODERATE_FINGERPRINTS"
+	// Manage sites and site categories
+	PermissionEnumManageSites         PermissionEnum = "MANAGE_SITES"
+	PermissionEnumManageTagCategories PermissionEnum = "MANAGE_TAG_CATEGORIES"
+	// Manage, suspend and log out users
+	PermissionEnumManageUsers PermissionEnum = "MANAGE_USERS"
+	// Manage custom roles
+	PermissionEnumManageRoles  PermissionEnum = "MANAGE_ROLES"
+	PermissionEnumViewAuditLog PermissionEnum = "VIEW_AUDIT_LOG"
+	// May generate invites without tokens
+	PermissionEnumInvite PermissionEnum = "INVITE"
+	// May grant and rescind invite tokens and resind invite keys
+	PermissionEnumManageInvites PermissionEnum = "MANAGE_INVITES"
+	// May submit edits marked as bot edits
+	PermissionEnumBot PermissionEnum = "BOT"
+)
+
+var AllPermissionEnum = []PermissionEnum{
+	PermissionEnumRead,
+	PermissionEnumVote,
+	PermissionEnumEdit,
+	PermissionEnumEditScenes,
+	PermissionEnumEditPerformers,
+	PermissionEnumEditStudios,
+	PermissionEnumEditTags,
+	PermissionEnumModify,
+	PermissionEnumModerateEdits,
+	PermissionEnumModerateComments,
+	PermissionEnumModerateFingerprints,
+	PermissionEnumManageSites,
+	PermissionEnumManageTagCategories,
+	PermissionEnumManageUsers,
+	PermissionEnumManageRoles,
+	PermissionEnumViewAuditLog,
+	PermissionEnumInvite,
+	PermissionEnumManageInvites,
+	PermissionEnumBot,
+}
+
+func (e PermissionEnum) IsValid() bool {
+	switch e {
+	case PermissionEnumRead, PermissionEnumVote, PermissionEnumEdit, PermissionEnumEditScenes, PermissionEnumEditPerformers, PermissionEnumEditStudios, PermissionEnumEditTags, PermissionEnumModify, PermissionEnumModerateEdits, PermissionEnumModerateComments, PermissionEnumModerateFingerprints, PermissionEnumManageSites, PermissionEnumManageTagCategories, PermissionEnumManageUsers, PermissionEnumManageRoles, PermissionEnumViewAuditLog, PermissionEnumInvite, PermissionEnumManageInvites, PermissionEnumBot:
+		return true
+	}
+	return false
+}
+
+func (e PermissionEnum) String() string {
+	return string(e)
+}
+
+func (e *PermissionEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PermissionEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PermissionEnum", str)
+	}
+	return nil
+}
+
+func (e PermissionEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PermissionEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PermissionEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type RoleEnum string
 
 const (
@@ -2329,6 +3221,120 @@ func (e RoleEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type SavedSearchTypeEnum string
+
+const (
+	SavedSearchTypeEnumScene     SavedSearchTypeEnum = "SCENE"
+	SavedSearchTypeEnumPerformer SavedSearchTypeEnum = "PERFORMER"
+)
+
+var AllSavedSearchTypeEnum = []SavedSearchTypeEnum{
+	SavedSearchTypeEnumScene,
+	SavedSearchTypeEnumPerformer,
+}
+
+func (e SavedSearchTypeEnum) IsValid() bool {
+	switch e {
+	case SavedSearchTypeEnumScene, SavedSearchTypeEnumPerformer:
+		return true
+	}
+	return false
+}
+
+func (e SavedSearchTypeEnum) String() string {
+	return string(e)
+}
+
+func (e *SavedSearchTypeEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SavedSearchTypeEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SavedSearchTypeEnum", str)
+	}
+	return nil
+}
+
+func (e SavedSearchTypeEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SavedSearchTypeEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SavedSearchTypeEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SceneDurationBucketEnum string
+
+const (
+	SceneDurationBucketEnumUnder10Minutes    SceneDurationBucketEnum = "UNDER_10_MINUTES"
+	SceneDurationBucketEnumFrom10To30Minutes SceneDurationBucketEnum = "FROM_10_TO_30_MINUTES"
+	SceneDurationBucketEnumFrom30To60Minutes SceneDurationBucketEnum = "FROM_30_TO_60_MINUTES"
+	SceneDurationBucketEnumOver60Minutes     SceneDurationBucketEnum = "OVER_60_MINUTES"
+)
+
+var AllSceneDurationBucketEnum = []SceneDurationBucketEnum{
+	SceneDurationBucketEnumUnder10Minutes,
+	SceneDurationBucketEnumFrom10To30Minutes,
+	SceneDurationBucketEnumFrom30To60Minutes,
+	SceneDurationBucketEnumOver60Minutes,
+}
+
+func (e SceneDurationBucketEnum) IsValid() bool {
+	switch e {
+	case SceneDurationBucketEnumUnder10Minutes, SceneDurationBucketEnumFrom10To30Minutes, SceneDurationBucketEnumFrom30To60Minutes, SceneDurationBucketEnumOver60Minutes:
+		return true
+	}
+	return false
+}
+
+func (e SceneDurationBucketEnum) String() string {
+	return string(e)
+}
+
+func (e *SceneDurationBucketEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneDurationBucketEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneDurationBucketEnum", str)
+	}
+	return nil
+}
+
+func (e SceneDurationBucketEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SceneDurationBucketEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SceneDurationBucketEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type SceneSortEnum string
 
 const (
@@ -2394,6 +3400,65 @@ func (e SceneSortEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type SearchTypeEnum string
+
+const (
+	SearchTypeEnumPerformer SearchTypeEnum = "PERFORMER"
+	SearchTypeEnumScene     SearchTypeEnum = "SCENE"
+	SearchTypeEnumStudio    SearchTypeEnum = "STUDIO"
+	SearchTypeEnumTag       SearchTypeEnum = "TAG"
+)
+
+var AllSearchTypeEnum = []SearchTypeEnum{
+	SearchTypeEnumPerformer,
+	SearchTypeEnumScene,
+	SearchTypeEnumStudio,
+	SearchTypeEnumTag,
+}
+
+func (e SearchTypeEnum) IsValid() bool {
+	switch e {
+	case SearchTypeEnumPerformer, SearchTypeEnumScene, SearchTypeEnumStudio, SearchTypeEnumTag:
+		return true
+	}
+	return false
+}
+
+func (e SearchTypeEnum) String() string {
+	return string(e)
+}
+
+func (e *SearchTypeEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchTypeEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchTypeEnum", str)
+	}
+	return nil
+}
+
+func (e SearchTypeEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SearchTypeEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SearchTypeEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type SortDirectionEnum string
 
 const (
@@ -2506,6 +3571,66 @@ func (e StudioSortEnum) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type SuspensionRestrictionEnum string
+
+const (
+	// Restricts the user to read-only access
+	SuspensionRestrictionEnumReadOnly SuspensionRestrictionEnum = "READ_ONLY"
+	// Withholds voting on edits
+	SuspensionRestrictionEnumNoVoting SuspensionRestrictionEnum = "NO_VOTING"
+	// Withholds commenting on edits
+	SuspensionRestrictionEnumNoCommenting SuspensionRestrictionEnum = "NO_COMMENTING"
+)
+
+var AllSuspensionRestrictionEnum = []SuspensionRestrictionEnum{
+	SuspensionRestrictionEnumReadOnly,
+	SuspensionRestrictionEnumNoVoting,
+	SuspensionRestrictionEnumNoCommenting,
+}
+
+func (e SuspensionRestrictionEnum) IsValid() bool {
+	switch e {
+	case SuspensionRestrictionEnumReadOnly, SuspensionRestrictionEnumNoVoting, SuspensionRestrictionEnumNoCommenting:
+		return true
+	}
+	return false
+}
+
+func (e SuspensionRestrictionEnum) String() string {
+	return string(e)
+}
+
+func (e *SuspensionRestrictionEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SuspensionRestrictionEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SuspensionRestrictionEnum", str)
+	}
+	return nil
+}
+
+func (e SuspensionRestrictionEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SuspensionRestrictionEnum) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SuspensionRestrictionEnum) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type TagGroupEnum string
 
 const (
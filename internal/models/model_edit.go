@@ -33,6 +33,7 @@ type EditComment struct {
 	Text      string        `json:"text"`
 	UpdatedAt *time.Time    `json:"updated_at"`
 	IsHidden  bool          `json:"is_hidden"`
+	ParentID  uuid.NullUUID `json:"parent_id"`
 }
 
 type EditVote struct {
@@ -196,35 +197,39 @@ type TagEditData struct {
 }
 
 type PerformerEdit struct {
-	EditID           uuid.UUID          `json:"-"`
-	Name             *string            `json:"name,omitempty"`
-	Disambiguation   *string            `json:"disambiguation,omitempty"`
-	AddedAliases     []string           `json:"added_aliases,omitempty"`
-	RemovedAliases   []string           `json:"removed_aliases,omitempty"`
-	Gender           *string            `json:"gender,omitempty"`
-	AddedUrls        []URL              `json:"added_urls,omitempty"`
-	RemovedUrls      []URL              `json:"removed_urls,omitempty"`
-	Birthdate        *string            `json:"birthdate,omitempty"`
-	Deathdate        *string            `json:"deathdate,omitempty"`
-	Ethnicity        *string            `json:"ethnicity,omitempty"`
-	Country          *string            `json:"country,omitempty"`
-	EyeColor         *string            `json:"eye_color,omitempty"`
-	HairColor        *string            `json:"hair_color,omitempty"`
-	Height           *int               `json:"height,omitempty"`
-	CupSize          *string            `json:"cup_size,omitempty"`
-	BandSize         *int               `json:"band_size,omitempty"`
-	WaistSize        *int               `json:"waist_size,omitempty"`
-	HipSize          *int               `json:"hip_size,omitempty"`
-	BreastType       *string            `json:"breast_type,omitempty"`
-	CareerStartYear  *int               `json:"career_start_year,omitempty"`
-	CareerEndYear    *int               `json:"career_end_year,omitempty"`
-	AddedTattoos     []BodyModification `json:"added_tattoos,omitempty"`
-	RemovedTattoos   []BodyModification `json:"removed_tattoos,omitempty"`
-	AddedPiercings   []BodyModification `json:"added_piercings,omitempty"`
-	RemovedPiercings []BodyModification `json:"removed_piercings,omitempty"`
-	AddedImages      []uuid.UUID        `json:"added_images,omitempty"`
-	RemovedImages    []uuid.UUID        `json:"removed_images,omitempty"`
-	DraftID          *uuid.UUID         `json:"draft_id,omitempty"`
+	EditID               uuid.UUID                    `json:"-"`
+	Name                 *string                      `json:"name,omitempty"`
+	Disambiguation       *string                      `json:"disambiguation,omitempty"`
+	AddedAliases         []string                     `json:"added_aliases,omitempty"`
+	RemovedAliases       []string                     `json:"removed_aliases,omitempty"`
+	Gender               *string                      `json:"gender,omitempty"`
+	AddedUrls            []URL                        `json:"added_urls,omitempty"`
+	RemovedUrls          []URL                        `json:"removed_urls,omitempty"`
+	Birthdate            *string                      `json:"birthdate,omitempty"`
+	Deathdate            *string                      `json:"deathdate,omitempty"`
+	Ethnicity            *string                      `json:"ethnicity,omitempty"`
+	Country              *string                      `json:"country,omitempty"`
+	EyeColor             *string                      `json:"eye_color,omitempty"`
+	HairColor            *string                      `json:"hair_color,omitempty"`
+	Height               *int                         `json:"height,omitempty"`
+	CupSize              *string                      `json:"cup_size,omitempty"`
+	BandSize             *int                         `json:"band_size,omitempty"`
+	WaistSize            *int                         `json:"waist_size,omitempty"`
+	HipSize              *int                         `json:"hip_size,omitempty"`
+	BreastType           *string                      `json:"breast_type,omitempty"`
+	CareerStartYear      *int                         `json:"career_start_year,omitempty"`
+	CareerEndYear        *int                         `json:"career_end_year,omitempty"`
+	AddedTattoos         []BodyModification           `json:"added_tattoos,omitempty"`
+	RemovedTattoos       []BodyModification           `json:"removed_tattoos,omitempty"`
+	AddedPiercings       []BodyModification           `json:"added_piercings,omitempty"`
+	RemovedPiercings     []BodyModification           `json:"removed_piercings,omitempty"`
+	AddedImages          []uuid.UUID                  `json:"added_images,omitempty"`
+	RemovedImages        []uuid.UUID                  `json:"removed_images,omitempty"`
+	AddedRelationships   []PerformerRelationshipInput `json:"added_relationships,omitempty"`
+	RemovedRelationships []PerformerRelationshipInput `json:"removed_relationships,omitempty"`
+	AddedCareerPeriods   []PerformerCareerPeriod      `json:"added_career_periods,omitempty"`
+	RemovedCareerPeriods []PerformerCareerPeriod      `json:"removed_career_periods,omitempty"`
+	DraftID              *uuid.UUID                   `json:"draft_id,omitempty"`
 }
 
 func (PerformerEdit) IsEditDetails() {}
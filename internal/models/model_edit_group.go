@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type EditGroup struct {
+	ID         uuid.UUID      `json:"id"`
+	UserID     uuid.NullUUID  `json:"user_id"`
+	TargetType TargetTypeEnum `json:"target_type"`
+	Created    time.Time      `json:"created_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type EntityLock struct {
+	ID          uuid.UUID           `json:"id"`
+	TargetType  TargetTypeEnum      `json:"target_type"`
+	TargetID    uuid.UUID           `json:"target_id"`
+	Fields      []string            `json:"fields"`
+	Level       EntityLockLevelEnum `json:"level"`
+	Reason      string              `json:"reason"`
+	CreatedByID uuid.NullUUID       `json:"created_by"`
+	Created     time.Time           `json:"created_at"`
+	Expires     *time.Time          `json:"expires_at"`
+}
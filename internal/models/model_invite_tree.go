@@ -0,0 +1,14 @@
+package models
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+type InviteTreeNode struct {
+	UserID      uuid.UUID           `json:"user_id"`
+	InvitedByID uuid.NullUUID       `json:"invited_by"`
+	Depth       int                 `json:"depth"`
+	Edits       InviteTreeEditStats `json:"edits"`
+	BranchEdits InviteTreeEditStats `json:"branch_edits"`
+	Descendants int                 `json:"descendants"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type Lockout struct {
+	ID          uuid.UUID        `json:"id"`
+	Scope       LockoutScopeEnum `json:"scope"`
+	Key         string           `json:"key"`
+	Attempts    int              `json:"attempts"`
+	LastAttempt time.Time        `json:"last_attempt_at"`
+	LockedUntil *time.Time       `json:"locked_until"`
+}
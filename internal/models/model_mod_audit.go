@@ -44,3 +44,11 @@ type EditCommentHideAuditData struct {
 	ChangedAt time.Time `json:"changed_at"`
 	Hidden    bool      `json:"hidden"`
 }
+
+// ModAuditChangeData records the state of an audited target before and after
+// a privileged change. Either side is null when the target was created or
+// destroyed.
+type ModAuditChangeData struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
@@ -27,6 +27,7 @@ var defaultSubscriptions = []NotificationEnum{
 	NotificationEnumCommentCommentedEdit,
 	NotificationEnumCommentVotedEdit,
 	NotificationEnumUpdatedEdit,
+	NotificationEnumMentionedInComment,
 }
 
 func GetDefaultNotificationSubscriptions() []NotificationEnum {
@@ -34,6 +34,14 @@ type Performer struct {
 
 func (Performer) IsSceneDraftPerformer() {}
 func (p *Performer) IsEditTarget()       {}
+func (p *Performer) IsSearchResult()     {}
+
+type PerformerCareerPeriod struct {
+	StartDate string     `json:"start_date"`
+	EndDate   *string    `json:"end_date,omitempty"`
+	StudioID  *uuid.UUID `json:"studio_id,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+}
 
 type PerformerQuery struct {
 	Filter PerformerQueryInput
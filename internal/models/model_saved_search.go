@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type SavedSearch struct {
+	ID            uuid.UUID           `json:"id"`
+	UserID        uuid.UUID           `json:"user_id"`
+	Name          string              `json:"name"`
+	Type          SavedSearchTypeEnum `json:"type"`
+	Filter        json.RawMessage     `json:"filter"`
+	Notify        bool                `json:"notify"`
+	LastCheckedAt time.Time           `json:"last_checked_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// SceneFilter decodes the filter of a SCENE saved search
+func (s SavedSearch) SceneFilter() (SceneQueryInput, error) {
+	var filter SceneQueryInput
+	err := json.Unmarshal(s.Filter, &filter)
+	return filter, err
+}
+
+// PerformerFilter decodes the filter of a PERFORMER saved search
+func (s SavedSearch) PerformerFilter() (PerformerQueryInput, error) {
+	var filter PerformerQueryInput
+	err := json.Unmarshal(s.Filter, &filter)
+	return filter, err
+}
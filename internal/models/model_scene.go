@@ -23,7 +23,8 @@ type Scene struct {
 	Deleted        bool          `json:"deleted"`
 }
 
-func (s *Scene) IsEditTarget() {}
+func (s *Scene) IsEditTarget()   {}
+func (s *Scene) IsSearchResult() {}
 
 type SceneFingerprint struct {
 	SceneID   uuid.UUID       `json:"scene_id"`
@@ -37,15 +38,36 @@ type SceneFingerprint struct {
 
 type SceneQuery struct {
 	Filter SceneQueryInput
+	Search *SceneSearchParams
 
 	SearchResults *SceneSearchResults
 }
 
+type SceneSearchParams struct {
+	Term   string
+	Filter *SceneSearchFilterInput
+}
+
 type SceneSearchResults struct {
 	Scenes []Scene
 	Count  int
 }
 
+type SceneStudioFacet struct {
+	StudioID uuid.UUID
+	Count    int
+}
+
+type SceneTagFacet struct {
+	TagID uuid.UUID
+	Count int
+}
+
+type ScenePerformerFacet struct {
+	PerformerID uuid.UUID
+	Count       int
+}
+
 type QueryExistingSceneResult struct {
 	Input QueryExistingSceneInput
 }
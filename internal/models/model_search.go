@@ -0,0 +1,10 @@
+package models
+
+import "github.com/gofrs/uuid"
+
+type SearchHit struct {
+	Type       SearchTypeEnum    `json:"type"`
+	ID         uuid.UUID         `json:"id"`
+	Score      float64           `json:"score"`
+	Highlights []SearchHighlight `json:"highlights"`
+}
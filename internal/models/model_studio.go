@@ -19,6 +19,7 @@ type Studio struct {
 
 func (Studio) IsSceneDraftStudio() {}
 func (s *Studio) IsEditTarget()    {}
+func (s *Studio) IsSearchResult()  {}
 
 func (s Studio) IsDeleted() bool {
 	return s.Deleted
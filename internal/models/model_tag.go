@@ -18,8 +18,9 @@ type Tag struct {
 	Updated     time.Time `json:"updated"`
 }
 
-func (Tag) IsSceneDraftTag() {}
-func (t *Tag) IsEditTarget() {}
+func (Tag) IsSceneDraftTag()   {}
+func (t *Tag) IsEditTarget()   {}
+func (t *Tag) IsSearchResult() {}
 
 func (t Tag) IsDeleted() bool {
 	return t.Deleted
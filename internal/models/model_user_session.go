@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type UserSession struct {
+	ID        uuid.UUID     `json:"id"`
+	UserID    uuid.NullUUID `json:"user_id"`
+	Data      []byte        `json:"data"`
+	UserAgent string        `json:"user_agent"`
+	IPAddress string        `json:"ip_address"`
+	Created   time.Time     `json:"created_at"`
+	LastSeen  time.Time     `json:"last_seen_at"`
+	Expires   time.Time     `json:"expires_at"`
+}
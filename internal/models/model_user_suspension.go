@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type UserSuspension struct {
+	ID          uuid.UUID                 `json:"id"`
+	UserID      uuid.UUID                 `json:"user_id"`
+	Reason      string                    `json:"reason"`
+	Restriction SuspensionRestrictionEnum `json:"restriction"`
+	CreatedByID uuid.NullUUID             `json:"created_by"`
+	Created     time.Time                 `json:"created_at"`
+	Expires     *time.Time                `json:"expires_at"`
+	Lifted      *time.Time                `json:"lifted_at"`
+	LiftedByID  uuid.NullUUID             `json:"lifted_by"`
+}
+
+// Active returns whether the suspension currently restricts the user
+func (s UserSuspension) Active() bool {
+	return s.Lifted == nil && (s.Expires == nil || s.Expires.After(time.Now()))
+}
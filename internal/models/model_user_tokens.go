@@ -13,6 +13,8 @@ const (
 	UserTokenTypeResetPassword   = "RESET_PASSWORD"
 	UserTokenTypeConfirmOldEmail = "CONFIRM_OLD_EMAIL"
 	UserTokenTypeConfirmNewEmail = "CONFIRM_NEW_EMAIL"
+	UserTokenTypeDeleteAccount   = "DELETE_ACCOUNT"
+	UserTokenTypeDataExport      = "DATA_EXPORT"
 )
 
 type UserToken struct {
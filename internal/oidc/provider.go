@@ -0,0 +1,307 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidIDToken = errors.New("invalid id token")
+	ErrUnknownKey     = errors.New("unknown signing key")
+)
+
+// Config holds the client settings of a provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// Claims holding the username and the roles of the user
+	UsernameClaim string
+	RolesClaim    string
+}
+
+// Claims holds the identity of a user, read from a verified id token.
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Username      string
+	Roles         []string
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Provider signs users in with the authorization code flow of an OpenID
+// Connect provider.
+type Provider struct {
+	config    Config
+	client    *http.Client
+	discovery discovery
+
+	mutex sync.Mutex
+	keys  map[string]*rsa.PublicKey
+}
+
+// Discover reads the endpoints of the provider from its discovery document.
+func Discover(ctx context.Context, config Config) (*Provider, error) {
+	p := &Provider{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	wellKnown := strings.TrimSuffix(config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, wellKnown, &p.discovery); err != nil {
+		return nil, fmt.Errorf("error reading provider configuration: %w", err)
+	}
+
+	if p.discovery.Issuer != config.Issuer {
+		return nil, fmt.Errorf("provider issuer %q does not match configured issuer %q", p.discovery.Issuer, config.Issuer)
+	}
+	if p.discovery.AuthorizationEndpoint == "" || p.discovery.TokenEndpoint == "" || p.discovery.JWKSURI == "" {
+		return nil, errors.New("provider configuration is missing endpoints")
+	}
+
+	return p, nil
+}
+
+// AuthCodeURL returns the URL users are redirected to for signing in. The
+// state and nonce are checked when they return, and the verifier is needed
+// to exchange the code they return with.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	challenge := sha256.Sum256([]byte(verifier))
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", p.config.RedirectURL)
+	params.Set("scope", strings.Join(append([]string{"openid"}, p.config.Scopes...), " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	params.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(p.discovery.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.discovery.AuthorizationEndpoint + sep + params.Encode()
+}
+
+// Exchange redeems an authorization code, and returns the claims of the id
+// token issued for it.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Claims, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return nil, fmt.Errorf("error reading token response: %w", err)
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("token request failed: %s %s", token.Error, token.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed: %s", resp.Status)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: missing from token response", ErrInvalidIDToken)
+	}
+
+	return p.verify(ctx, token.IDToken, nonce)
+}
+
+func (p *Provider) verify(ctx context.Context, idToken, nonce string) (*Claims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	ret := &Claims{Issuer: p.config.Issuer}
+	ret.Subject, _ = claims["sub"].(string)
+	if ret.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	ret.Email, _ = claims["email"].(string)
+	ret.Username, _ = claims[p.config.UsernameClaim].(string)
+
+	// some providers send booleans as strings
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		ret.EmailVerified = verified
+	case string:
+		ret.EmailVerified = verified == "true"
+	}
+
+	switch roles := claims[p.config.RolesClaim].(type) {
+	case []any:
+		for _, role := range roles {
+			if s, ok := role.(string); ok {
+				ret.Roles = append(ret.Roles, s)
+			}
+		}
+	case string:
+		ret.Roles = strings.Fields(roles)
+	}
+
+	return ret, nil
+}
+
+// key returns the signing key with the given id. The keys are fetched again
+// when the id is unknown, as providers rotate their keys.
+func (p *Provider) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if key := p.findKey(kid); key != nil {
+		return key, nil
+	}
+
+	if err := p.fetchKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	if key := p.findKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (p *Provider) findKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[kid]
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) error {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.discovery.JWKSURI, &jwks); err != nil {
+		return fmt.Errorf("error reading provider keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return fmt.Errorf("invalid key %s: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return fmt.Errorf("invalid key %s: %w", k.Kid, err)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	p.keys = keys
+	return nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// RandomString returns a random URL safe string, for use as state, nonce
+// or code verifier.
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,250 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "stash-box"
+	testClientSecret = "secret"
+	testRedirectURL  = "https://stash-box.test/oidc/callback"
+	testCode         = "code"
+)
+
+// mockProvider is a local OpenID Connect provider, issuing the claims of
+// its id token for testCode.
+type mockProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	kid      string
+	claims   jwt.MapClaims
+	verifier string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	m := &mockProvider{key: key, kid: "key-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(discovery{
+			Issuer:                m.server.URL,
+			AuthorizationEndpoint: m.server.URL + "/authorize",
+			TokenEndpoint:         m.server.URL + "/token",
+			JWKSURI:               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string][]jsonWebKey{
+			"keys": {{
+				Kty: "RSA",
+				Kid: m.kid,
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		challenge := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if id != testClientID || secret != testClientSecret || r.FormValue("code") != testCode ||
+			r.FormValue("redirect_uri") != testRedirectURL ||
+			base64.RawURLEncoding.EncodeToString(challenge[:]) != m.verifier {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(tokenResponse{Error: "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.claims)
+		token.Header["kid"] = m.kid
+		signed, err := token.SignedString(m.key)
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(tokenResponse{IDToken: signed})
+	})
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	m.claims = jwt.MapClaims{
+		"iss":                m.server.URL,
+		"aud":                testClientID,
+		"sub":                "subject",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              "nonce",
+		"email":              "user@example.com",
+		"email_verified":     true,
+		"preferred_username": "user",
+		"groups":             []string{"editors", "voters"},
+	}
+
+	return m
+}
+
+func (m *mockProvider) discover(t *testing.T) *Provider {
+	p, err := Discover(context.Background(), Config{
+		Issuer:        m.server.URL,
+		ClientID:      testClientID,
+		ClientSecret:  testClientSecret,
+		RedirectURL:   testRedirectURL,
+		Scopes:        []string{"profile", "email"},
+		UsernameClaim: "preferred_username",
+		RolesClaim:    "groups",
+	})
+	require.NoError(t, err)
+	return p
+}
+
+// authorize follows the auth code URL, remembering the code challenge as
+// the provider would.
+func (m *mockProvider) authorize(t *testing.T, p *Provider, verifier string) {
+	u, err := url.Parse(p.AuthCodeURL("state", "nonce", verifier))
+	require.NoError(t, err)
+
+	q := u.Query()
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, "openid profile email", q.Get("scope"))
+	assert.Equal(t, testClientID, q.Get("client_id"))
+	m.verifier = q.Get("code_challenge")
+}
+
+func TestExchange(t *testing.T) {
+	m := newMockProvider(t)
+	p := m.discover(t)
+	m.authorize(t, p, "verifier")
+
+	claims, err := p.Exchange(context.Background(), testCode, "verifier", "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, &Claims{
+		Issuer:        m.server.URL,
+		Subject:       "subject",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Username:      "user",
+		Roles:         []string{"editors", "voters"},
+	}, claims)
+}
+
+func TestExchangeStringClaims(t *testing.T) {
+	m := newMockProvider(t)
+	m.claims["email_verified"] = "false"
+	m.claims["groups"] = "editors voters"
+	p := m.discover(t)
+	m.authorize(t, p, "verifier")
+
+	claims, err := p.Exchange(context.Background(), testCode, "verifier", "nonce")
+	require.NoError(t, err)
+	assert.False(t, claims.EmailVerified)
+	assert.Equal(t, []string{"editors", "voters"}, claims.Roles)
+}
+
+func TestExchangeInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(m *mockProvider)
+		verifier string
+		nonce    string
+	}{
+		{"wrong verifier", nil, "other", "nonce"},
+		{"wrong nonce", nil, "verifier", "other"},
+		{"wrong audience", func(m *mockProvider) { m.claims["aud"] = "other" }, "verifier", "nonce"},
+		{"wrong issuer", func(m *mockProvider) { m.claims["iss"] = "https://other.test" }, "verifier", "nonce"},
+		{"expired", func(m *mockProvider) { m.claims["exp"] = time.Now().Add(-time.Hour).Unix() }, "verifier", "nonce"},
+		{"missing expiry", func(m *mockProvider) { delete(m.claims, "exp") }, "verifier", "nonce"},
+		{"missing subject", func(m *mockProvider) { delete(m.claims, "sub") }, "verifier", "nonce"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockProvider(t)
+			if tt.modify != nil {
+				tt.modify(m)
+			}
+			p := m.discover(t)
+			m.authorize(t, p, "verifier")
+
+			_, err := p.Exchange(context.Background(), testCode, tt.verifier, tt.nonce)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestExchangeRotatedKey(t *testing.T) {
+	m := newMockProvider(t)
+	p := m.discover(t)
+	m.authorize(t, p, "verifier")
+
+	_, err := p.Exchange(context.Background(), testCode, "verifier", "nonce")
+	require.NoError(t, err)
+
+	// the new key is fetched when the provider signs with it
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	m.key = key
+	m.kid = "key-2"
+
+	_, err = p.Exchange(context.Background(), testCode, "verifier", "nonce")
+	require.NoError(t, err)
+}
+
+func TestExchangeUnknownKey(t *testing.T) {
+	m := newMockProvider(t)
+	p := m.discover(t)
+	m.authorize(t, p, "verifier")
+
+	// signed with a key the provider does not publish
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	published := m.key
+	m.key = other
+	m.server.Config.Handler = wrapJWKS(m.server.Config.Handler, published, m.kid)
+
+	_, err = p.Exchange(context.Background(), testCode, "verifier", "nonce")
+	assert.ErrorIs(t, err, ErrInvalidIDToken)
+}
+
+// wrapJWKS publishes key instead of the key the provider signs with.
+func wrapJWKS(next http.Handler, key *rsa.PrivateKey, kid string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jwks" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]jsonWebKey{
+			"keys": {{
+				Kty: "RSA",
+				Kid: kid,
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+}
+
+func TestDiscoverIssuerMismatch(t *testing.T) {
+	m := newMockProvider(t)
+
+	_, err := Discover(context.Background(), Config{
+		Issuer:   m.server.URL + "/other",
+		ClientID: testClientID,
+	})
+	assert.Error(t, err)
+}
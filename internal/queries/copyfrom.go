@@ -42,6 +42,42 @@ func (q *Queries) CreatePerformerAliases(ctx context.Context, arg []CreatePerfor
 	return q.db.CopyFrom(ctx, []string{"performer_aliases"}, []string{"performer_id", "alias"}, &iteratorForCreatePerformerAliases{rows: arg})
 }
 
+// iteratorForCreatePerformerCareerPeriods implements pgx.CopyFromSource.
+type iteratorForCreatePerformerCareerPeriods struct {
+	rows                 []CreatePerformerCareerPeriodsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreatePerformerCareerPeriods) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreatePerformerCareerPeriods) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].PerformerID,
+		r.rows[0].StartDate,
+		r.rows[0].EndDate,
+		r.rows[0].StudioID,
+		r.rows[0].Note,
+	}, nil
+}
+
+func (r iteratorForCreatePerformerCareerPeriods) Err() error {
+	return nil
+}
+
+func (q *Queries) CreatePerformerCareerPeriods(ctx context.Context, arg []CreatePerformerCareerPeriodsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"performer_career_periods"}, []string{"performer_id", "start_date", "end_date", "studio_id", "note"}, &iteratorForCreatePerformerCareerPeriods{rows: arg})
+}
+
 // iteratorForCreatePerformerImages implements pgx.CopyFromSource.
 type iteratorForCreatePerformerImages struct {
 	rows                 []CreatePerformerImagesParams
@@ -12,6 +12,22 @@ import (
 	"github.com/gofrs/uuid"
 )
 
+const addEditCommentReaction = `-- name: AddEditCommentReaction :exec
+INSERT INTO edit_comment_reactions (comment_id, user_id, reaction) VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+`
+
+type AddEditCommentReactionParams struct {
+	CommentID uuid.UUID `db:"comment_id" json:"comment_id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Reaction  string    `db:"reaction" json:"reaction"`
+}
+
+func (q *Queries) AddEditCommentReaction(ctx context.Context, arg AddEditCommentReactionParams) error {
+	_, err := q.db.Exec(ctx, addEditCommentReaction, arg.CommentID, arg.UserID, arg.Reaction)
+	return err
+}
+
 const cancelUserEdits = `-- name: CancelUserEdits :exec
 UPDATE edits SET status = 'CANCELED', updated_at = NOW() WHERE user_id = $1
 `
@@ -77,16 +93,17 @@ func (q *Queries) CreateEdit(ctx context.Context, arg CreateEditParams) (Edit, e
 
 const createEditComment = `-- name: CreateEditComment :one
 
-INSERT INTO edit_comments (id, edit_id, user_id, text, created_at)
-VALUES ($1, $2, $3, $4, NOW())
-RETURNING id, edit_id, user_id, created_at, text, updated_at, is_hidden
+INSERT INTO edit_comments (id, edit_id, user_id, text, parent_id, created_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
+RETURNING id, edit_id, user_id, created_at, text, updated_at, is_hidden, parent_id
 `
 
 type CreateEditCommentParams struct {
-	ID     uuid.UUID     `db:"id" json:"id"`
-	EditID uuid.UUID     `db:"edit_id" json:"edit_id"`
-	UserID uuid.NullUUID `db:"user_id" json:"user_id"`
-	Text   string        `db:"text" json:"text"`
+	ID       uuid.UUID     `db:"id" json:"id"`
+	EditID   uuid.UUID     `db:"edit_id" json:"edit_id"`
+	UserID   uuid.NullUUID `db:"user_id" json:"user_id"`
+	Text     string        `db:"text" json:"text"`
+	ParentID uuid.NullUUID `db:"parent_id" json:"parent_id"`
 }
 
 // Edit comments
@@ -96,6 +113,7 @@ func (q *Queries) CreateEditComment(ctx context.Context, arg CreateEditCommentPa
 		arg.EditID,
 		arg.UserID,
 		arg.Text,
+		arg.ParentID,
 	)
 	var i EditComment
 	err := row.Scan(
@@ -106,10 +124,59 @@ func (q *Queries) CreateEditComment(ctx context.Context, arg CreateEditCommentPa
 		&i.Text,
 		&i.UpdatedAt,
 		&i.IsHidden,
+		&i.ParentID,
 	)
 	return i, err
 }
 
+const createEditCommentMentions = `-- name: CreateEditCommentMentions :exec
+INSERT INTO edit_comment_mentions (comment_id, user_id)
+SELECT $1, unnest($2::uuid[])
+ON CONFLICT DO NOTHING
+`
+
+type CreateEditCommentMentionsParams struct {
+	CommentID uuid.UUID   `db:"comment_id" json:"comment_id"`
+	UserIds   []uuid.UUID `db:"user_ids" json:"user_ids"`
+}
+
+func (q *Queries) CreateEditCommentMentions(ctx context.Context, arg CreateEditCommentMentionsParams) error {
+	_, err := q.db.Exec(ctx, createEditCommentMentions, arg.CommentID, arg.UserIds)
+	return err
+}
+
+const createEditConflicts = `-- name: CreateEditConflicts :exec
+INSERT INTO edit_conflicts (edit_id, conflicting_edit_id)
+SELECT $1::UUID, UNNEST($2::UUID[])
+UNION ALL
+SELECT UNNEST($2::UUID[]), $1::UUID
+ON CONFLICT DO NOTHING
+`
+
+type CreateEditConflictsParams struct {
+	EditID             uuid.UUID   `db:"edit_id" json:"edit_id"`
+	ConflictingEditIds []uuid.UUID `db:"conflicting_edit_ids" json:"conflicting_edit_ids"`
+}
+
+func (q *Queries) CreateEditConflicts(ctx context.Context, arg CreateEditConflictsParams) error {
+	_, err := q.db.Exec(ctx, createEditConflicts, arg.EditID, arg.ConflictingEditIds)
+	return err
+}
+
+const createEditRebase = `-- name: CreateEditRebase :exec
+INSERT INTO edit_rebases (edit_id, rebased_from_id) VALUES ($1, $2)
+`
+
+type CreateEditRebaseParams struct {
+	EditID        uuid.UUID `db:"edit_id" json:"edit_id"`
+	RebasedFromID uuid.UUID `db:"rebased_from_id" json:"rebased_from_id"`
+}
+
+func (q *Queries) CreateEditRebase(ctx context.Context, arg CreateEditRebaseParams) error {
+	_, err := q.db.Exec(ctx, createEditRebase, arg.EditID, arg.RebasedFromID)
+	return err
+}
+
 const createEditVote = `-- name: CreateEditVote :exec
 
 INSERT INTO edit_votes (edit_id, user_id, vote, created_at) VALUES ($1, $2, $3, NOW())
@@ -194,6 +261,39 @@ func (q *Queries) DeleteEdit(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteEditCommentReaction = `-- name: DeleteEditCommentReaction :exec
+DELETE FROM edit_comment_reactions WHERE comment_id = $1 AND user_id = $2 AND reaction = $3
+`
+
+type DeleteEditCommentReactionParams struct {
+	CommentID uuid.UUID `db:"comment_id" json:"comment_id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Reaction  string    `db:"reaction" json:"reaction"`
+}
+
+func (q *Queries) DeleteEditCommentReaction(ctx context.Context, arg DeleteEditCommentReactionParams) error {
+	_, err := q.db.Exec(ctx, deleteEditCommentReaction, arg.CommentID, arg.UserID, arg.Reaction)
+	return err
+}
+
+const deleteEditConflicts = `-- name: DeleteEditConflicts :exec
+DELETE FROM edit_conflicts WHERE edit_id = $1 OR conflicting_edit_id = $1
+`
+
+func (q *Queries) DeleteEditConflicts(ctx context.Context, editID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteEditConflicts, editID)
+	return err
+}
+
+const dequeueEditForModerator = `-- name: DequeueEditForModerator :exec
+DELETE FROM edit_moderator_queue WHERE edit_id = $1
+`
+
+func (q *Queries) DequeueEditForModerator(ctx context.Context, editID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, dequeueEditForModerator, editID)
+	return err
+}
+
 const findCompletedEdits = `-- name: FindCompletedEdits :many
 SELECT id, user_id, operation, target_type, data, votes, status, applied, created_at, updated_at, closed_at, bot, update_count FROM edits
 WHERE status = 'PENDING'
@@ -282,7 +382,7 @@ func (q *Queries) FindEdit(ctx context.Context, id uuid.UUID) (Edit, error) {
 }
 
 const findEditComment = `-- name: FindEditComment :one
-SELECT id, edit_id, user_id, created_at, text, updated_at, is_hidden FROM edit_comments WHERE id = $1
+SELECT id, edit_id, user_id, created_at, text, updated_at, is_hidden, parent_id FROM edit_comments WHERE id = $1
 `
 
 func (q *Queries) FindEditComment(ctx context.Context, id uuid.UUID) (EditComment, error) {
@@ -296,10 +396,65 @@ func (q *Queries) FindEditComment(ctx context.Context, id uuid.UUID) (EditCommen
 		&i.Text,
 		&i.UpdatedAt,
 		&i.IsHidden,
+		&i.ParentID,
 	)
 	return i, err
 }
 
+const findPendingEditsForEntities = `-- name: FindPendingEditsForEntities :many
+SELECT id, user_id, operation, target_type, data, votes, status, applied, created_at, updated_at, closed_at, bot, update_count FROM edits E
+WHERE E.status = 'PENDING' AND E.id != $1 AND (
+    E.id IN (
+        SELECT edit_id FROM performer_edits WHERE performer_id = ANY($2::UUID[])
+        UNION SELECT edit_id FROM scene_edits WHERE scene_id = ANY($2::UUID[])
+        UNION SELECT edit_id FROM studio_edits WHERE studio_id = ANY($2::UUID[])
+        UNION SELECT edit_id FROM tag_edits WHERE tag_id = ANY($2::UUID[])
+    )
+    OR E.data->'merge_sources' ?| $2::UUID[]::TEXT[]
+)
+ORDER BY E.created_at ASC
+`
+
+type FindPendingEditsForEntitiesParams struct {
+	EditID    uuid.UUID   `db:"edit_id" json:"edit_id"`
+	EntityIds []uuid.UUID `db:"entity_ids" json:"entity_ids"`
+}
+
+// Pending edits, other than the given edit, targeting or merging any of the entities
+func (q *Queries) FindPendingEditsForEntities(ctx context.Context, arg FindPendingEditsForEntitiesParams) ([]Edit, error) {
+	rows, err := q.db.Query(ctx, findPendingEditsForEntities, arg.EditID, arg.EntityIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Edit{}
+	for rows.Next() {
+		var i Edit
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Operation,
+			&i.TargetType,
+			&i.Data,
+			&i.Votes,
+			&i.Status,
+			&i.Applied,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ClosedAt,
+			&i.Bot,
+			&i.UpdateCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findPendingPerformerCreation = `-- name: FindPendingPerformerCreation :many
 SELECT id, user_id, operation, target_type, data, votes, status, applied, created_at, updated_at, closed_at, bot, update_count FROM edits
 WHERE status = 'PENDING'
@@ -403,8 +558,117 @@ func (q *Queries) FindPendingSceneCreation(ctx context.Context, arg FindPendingS
 	return items, nil
 }
 
+const getConflictingEdits = `-- name: GetConflictingEdits :many
+SELECT E.id, E.user_id, E.operation, E.target_type, E.data, E.votes, E.status, E.applied, E.created_at, E.updated_at, E.closed_at, E.bot, E.update_count FROM edits E
+JOIN edit_conflicts C ON C.conflicting_edit_id = E.id
+WHERE C.edit_id = $1
+ORDER BY E.created_at ASC
+`
+
+func (q *Queries) GetConflictingEdits(ctx context.Context, editID uuid.UUID) ([]Edit, error) {
+	rows, err := q.db.Query(ctx, getConflictingEdits, editID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Edit{}
+	for rows.Next() {
+		var i Edit
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Operation,
+			&i.TargetType,
+			&i.Data,
+			&i.Votes,
+			&i.Status,
+			&i.Applied,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ClosedAt,
+			&i.Bot,
+			&i.UpdateCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEditCommentMentions = `-- name: GetEditCommentMentions :many
+SELECT user_id FROM edit_comment_mentions WHERE comment_id = $1
+`
+
+func (q *Queries) GetEditCommentMentions(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getEditCommentMentions, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEditCommentReactions = `-- name: GetEditCommentReactions :many
+SELECT reaction, COUNT(*)::int AS count, BOOL_OR(user_id = $1) AS reacted
+FROM edit_comment_reactions
+WHERE comment_id = $2
+GROUP BY reaction
+ORDER BY MIN(created_at)
+`
+
+type GetEditCommentReactionsParams struct {
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	CommentID uuid.UUID `db:"comment_id" json:"comment_id"`
+}
+
+type GetEditCommentReactionsRow struct {
+	Reaction string `db:"reaction" json:"reaction"`
+	Count    int    `db:"count" json:"count"`
+	Reacted  bool   `db:"reacted" json:"reacted"`
+}
+
+// Reactions to a comment with their counts, in the order they were first used
+func (q *Queries) GetEditCommentReactions(ctx context.Context, arg GetEditCommentReactionsParams) ([]GetEditCommentReactionsRow, error) {
+	rows, err := q.db.Query(ctx, getEditCommentReactions, arg.UserID, arg.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetEditCommentReactionsRow{}
+	for rows.Next() {
+		var i GetEditCommentReactionsRow
+		if err := rows.Scan(
+			&i.Reaction,
+			&i.Count,
+			&i.Reacted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getEditComments = `-- name: GetEditComments :many
-SELECT id, edit_id, user_id, created_at, text, updated_at, is_hidden FROM edit_comments WHERE edit_id = $1 ORDER BY created_at ASC
+SELECT id, edit_id, user_id, created_at, text, updated_at, is_hidden, parent_id FROM edit_comments WHERE edit_id = $1 ORDER BY created_at ASC
 `
 
 func (q *Queries) GetEditComments(ctx context.Context, editID uuid.UUID) ([]EditComment, error) {
@@ -424,6 +688,7 @@ func (q *Queries) GetEditComments(ctx context.Context, editID uuid.UUID) ([]Edit
 			&i.Text,
 			&i.UpdatedAt,
 			&i.IsHidden,
+			&i.ParentID,
 		); err != nil {
 			return nil, err
 		}
@@ -436,7 +701,7 @@ func (q *Queries) GetEditComments(ctx context.Context, editID uuid.UUID) ([]Edit
 }
 
 const getEditCommentsByIds = `-- name: GetEditCommentsByIds :many
-SELECT id, edit_id, user_id, created_at, text, updated_at, is_hidden FROM edit_comments WHERE id = ANY($1::UUID[])
+SELECT id, edit_id, user_id, created_at, text, updated_at, is_hidden, parent_id FROM edit_comments WHERE id = ANY($1::UUID[])
 `
 
 func (q *Queries) GetEditCommentsByIds(ctx context.Context, dollar_1 []uuid.UUID) ([]EditComment, error) {
@@ -456,6 +721,7 @@ func (q *Queries) GetEditCommentsByIds(ctx context.Context, dollar_1 []uuid.UUID
 			&i.Text,
 			&i.UpdatedAt,
 			&i.IsHidden,
+			&i.ParentID,
 		); err != nil {
 			return nil, err
 		}
@@ -503,6 +769,75 @@ func (q *Queries) GetEditPerformerAliases(ctx context.Context, id uuid.UUID) ([]
 	return items, nil
 }
 
+const getEditPerformerCareerPeriods = `-- name: GetEditPerformerCareerPeriods :many
+WITH edit AS (
+  SELECT id, user_id, operation, target_type, data, votes, status, applied, created_at, updated_at, closed_at, bot, update_count FROM edits WHERE id = $1
+),
+current_periods AS (
+    SELECT start_date, end_date, studio_id, note
+    FROM edit E
+    JOIN performer_edits PE ON E.id = PE.edit_id
+    JOIN performer_career_periods PCP ON PE.performer_id = PCP.performer_id
+),
+removed_periods AS (
+    SELECT
+        elem->>'start_date' AS start_date,
+        elem->>'end_date' AS end_date,
+        (elem->>'studio_id')::uuid AS studio_id,
+        elem->>'note' AS note
+    FROM edit, jsonb_array_elements(COALESCE(data->'new_data'->'removed_career_periods', '[]'::jsonb)) AS elem
+),
+added_periods AS (
+    SELECT
+        elem->>'start_date' AS start_date,
+        elem->>'end_date' AS end_date,
+        (elem->>'studio_id')::uuid AS studio_id,
+        elem->>'note' AS note
+    FROM edit, jsonb_array_elements(COALESCE(data->'new_data'->'added_career_periods', '[]'::jsonb)) AS elem
+),
+final_periods AS (
+    SELECT start_date, end_date, studio_id, note FROM current_periods
+    EXCEPT
+    SELECT start_date, end_date, studio_id, note FROM removed_periods
+    UNION
+    SELECT start_date, end_date, studio_id, note FROM added_periods
+)
+SELECT start_date, end_date, studio_id, note FROM final_periods
+ORDER BY start_date
+`
+
+type GetEditPerformerCareerPeriodsRow struct {
+	StartDate string        `db:"start_date" json:"start_date"`
+	EndDate   *string       `db:"end_date" json:"end_date"`
+	StudioID  uuid.NullUUID `db:"studio_id" json:"studio_id"`
+	Note      *string       `db:"note" json:"note"`
+}
+
+func (q *Queries) GetEditPerformerCareerPeriods(ctx context.Context, id uuid.UUID) ([]GetEditPerformerCareerPeriodsRow, error) {
+	rows, err := q.db.Query(ctx, getEditPerformerCareerPeriods, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetEditPerformerCareerPeriodsRow{}
+	for rows.Next() {
+		var i GetEditPerformerCareerPeriodsRow
+		if err := rows.Scan(
+			&i.StartDate,
+			&i.EndDate,
+			&i.StudioID,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getEditPerformerPiercings = `-- name: GetEditPerformerPiercings :many
 WITH edit AS (
   SELECT id, user_id, operation, target_type, data, votes, status, applied, created_at, updated_at, closed_at, bot, update_count FROM edits WHERE id = $1
@@ -617,6 +952,60 @@ func (q *Queries) GetEditPerformerTattoos(ctx context.Context, id uuid.UUID) ([]
 	return items, nil
 }
 
+const getEditRebasedFrom = `-- name: GetEditRebasedFrom :one
+SELECT E.id, E.user_id, E.operation, E.target_type, E.data, E.votes, E.status, E.applied, E.created_at, E.updated_at, E.closed_at, E.bot, E.update_count FROM edits E
+JOIN edit_rebases R ON R.rebased_from_id = E.id
+WHERE R.edit_id = $1
+`
+
+func (q *Queries) GetEditRebasedFrom(ctx context.Context, editID uuid.UUID) (Edit, error) {
+	row := q.db.QueryRow(ctx, getEditRebasedFrom, editID)
+	var i Edit
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Operation,
+		&i.TargetType,
+		&i.Data,
+		&i.Votes,
+		&i.Status,
+		&i.Applied,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ClosedAt,
+		&i.Bot,
+		&i.UpdateCount,
+	)
+	return i, err
+}
+
+const getEditRebasedTo = `-- name: GetEditRebasedTo :one
+SELECT E.id, E.user_id, E.operation, E.target_type, E.data, E.votes, E.status, E.applied, E.created_at, E.updated_at, E.closed_at, E.bot, E.update_count FROM edits E
+JOIN edit_rebases R ON R.edit_id = E.id
+WHERE R.rebased_from_id = $1
+`
+
+func (q *Queries) GetEditRebasedTo(ctx context.Context, rebasedFromID uuid.UUID) (Edit, error) {
+	row := q.db.QueryRow(ctx, getEditRebasedTo, rebasedFromID)
+	var i Edit
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Operation,
+		&i.TargetType,
+		&i.Data,
+		&i.Votes,
+		&i.Status,
+		&i.Applied,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ClosedAt,
+		&i.Bot,
+		&i.UpdateCount,
+	)
+	return i, err
+}
+
 const getEditTargetID = `-- name: GetEditTargetID :one
 SELECT CASE e.target_type
             WHEN 'SCENE' THEN se.scene_id
@@ -937,6 +1326,89 @@ func (q *Queries) GetImagesForEdit(ctx context.Context, id uuid.UUID) ([]Image,
 	return items, nil
 }
 
+const getMergedPerformerRelationshipsForEdit = `-- name: GetMergedPerformerRelationshipsForEdit :many
+WITH edit AS (
+  SELECT id, user_id, operation, target_type, data, votes, status, applied, created_at, updated_at, closed_at, bot, update_count FROM edits WHERE edits.id = $1
+), current_relationships AS (
+    SELECT pr.related_performer_id AS performer_id, pr.type FROM edit e
+    JOIN performer_edits pe ON e.id = pe.edit_id
+    JOIN performer_relationships pr ON pe.performer_id = pr.performer_id
+    WHERE e.target_type = 'PERFORMER'
+),
+removed_relationships AS (
+    SELECT
+        (elem->>'performer_id')::uuid AS performer_id,
+        elem->>'type' AS type
+    FROM edit, jsonb_array_elements(COALESCE(data->'new_data'->'removed_relationships', '[]'::jsonb)) AS elem
+),
+added_relationships AS (
+    SELECT
+        (elem->>'performer_id')::uuid AS performer_id,
+        elem->>'type' AS type
+    FROM edit, jsonb_array_elements(COALESCE(data->'new_data'->'added_relationships', '[]'::jsonb)) AS elem
+),
+final_relationships AS (
+    SELECT performer_id, type FROM current_relationships
+    EXCEPT
+    SELECT performer_id, type FROM removed_relationships
+    UNION
+    SELECT performer_id, type FROM added_relationships
+)
+SELECT p.id, p.name, p.disambiguation, p.gender, p.ethnicity, p.country, p.eye_color, p.hair_color, p.height, p.cup_size, p.band_size, p.hip_size, p.waist_size, p.breast_type, p.career_start_year, p.career_end_year, p.created_at, p.updated_at, p.deleted, p.birthdate, p.deathdate, fr.type FROM final_relationships fr
+JOIN performers p ON fr.performer_id = p.id
+WHERE p.deleted = FALSE
+ORDER BY fr.type, p.name
+`
+
+type GetMergedPerformerRelationshipsForEditRow struct {
+	Performer Performer `db:"performer" json:"performer"`
+	Type      string    `db:"type" json:"type"`
+}
+
+// Gets current relationships for target performer and merges with edit's added_relationships/removed_relationships
+func (q *Queries) GetMergedPerformerRelationshipsForEdit(ctx context.Context, id uuid.UUID) ([]GetMergedPerformerRelationshipsForEditRow, error) {
+	rows, err := q.db.Query(ctx, getMergedPerformerRelationshipsForEdit, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMergedPerformerRelationshipsForEditRow{}
+	for rows.Next() {
+		var i GetMergedPerformerRelationshipsForEditRow
+		if err := rows.Scan(
+			&i.Performer.ID,
+			&i.Performer.Name,
+			&i.Performer.Disambiguation,
+			&i.Performer.Gender,
+			&i.Performer.Ethnicity,
+			&i.Performer.Country,
+			&i.Performer.EyeColor,
+			&i.Performer.HairColor,
+			&i.Performer.Height,
+			&i.Performer.CupSize,
+			&i.Performer.BandSize,
+			&i.Performer.HipSize,
+			&i.Performer.WaistSize,
+			&i.Performer.BreastType,
+			&i.Performer.CareerStartYear,
+			&i.Performer.CareerEndYear,
+			&i.Performer.CreatedAt,
+			&i.Performer.UpdatedAt,
+			&i.Performer.Deleted,
+			&i.Performer.Birthdate,
+			&i.Performer.Deathdate,
+			&i.Type,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMergedPerformersForEdit = `-- name: GetMergedPerformersForEdit :many
 WITH edit AS (
   SELECT id, user_id, operation, target_type, data, votes, status, applied, created_at, updated_at, closed_at, bot, update_count FROM edits WHERE edits.id = $1
@@ -1235,6 +1707,19 @@ func (q *Queries) GetPrimaryEditCommentID(ctx context.Context, editID uuid.UUID)
 	return id, err
 }
 
+const queueEditForModerator = `-- name: QueueEditForModerator :execrows
+INSERT INTO edit_moderator_queue (edit_id) VALUES ($1)
+ON CONFLICT DO NOTHING
+`
+
+func (q *Queries) QueueEditForModerator(ctx context.Context, editID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, queueEditForModerator, editID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const resetVotes = `-- name: ResetVotes :exec
 UPDATE edit_votes
 SET vote = 'ABSTAIN'
@@ -1284,7 +1769,7 @@ func (q *Queries) ResolveEntityTypes(ctx context.Context, ids []uuid.UUID) ([]Re
 }
 
 const setEditCommentHidden = `-- name: SetEditCommentHidden :one
-UPDATE edit_comments SET is_hidden = $2 WHERE id = $1 RETURNING id, edit_id, user_id, created_at, text, updated_at, is_hidden
+UPDATE edit_comments SET is_hidden = $2 WHERE id = $1 RETURNING id, edit_id, user_id, created_at, text, updated_at, is_hidden, parent_id
 `
 
 type SetEditCommentHiddenParams struct {
@@ -1303,6 +1788,7 @@ func (q *Queries) SetEditCommentHidden(ctx context.Context, arg SetEditCommentHi
 		&i.Text,
 		&i.UpdatedAt,
 		&i.IsHidden,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -1355,7 +1841,7 @@ func (q *Queries) UpdateEdit(ctx context.Context, arg UpdateEditParams) (Edit, e
 }
 
 const updateEditCommentText = `-- name: UpdateEditCommentText :one
-UPDATE edit_comments SET text = $2, updated_at = NOW() WHERE id = $1 RETURNING id, edit_id, user_id, created_at, text, updated_at, is_hidden
+UPDATE edit_comments SET text = $2, updated_at = NOW() WHERE id = $1 RETURNING id, edit_id, user_id, created_at, text, updated_at, is_hidden, parent_id
 `
 
 type UpdateEditCommentTextParams struct {
@@ -1374,6 +1860,7 @@ func (q *Queries) UpdateEditCommentText(ctx context.Context, arg UpdateEditComme
 		&i.Text,
 		&i.UpdatedAt,
 		&i.IsHidden,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -54,6 +54,18 @@ func (q *Queries) DeleteInviteKey(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteInviteKeysForUsers = `-- name: DeleteInviteKeysForUsers :execrows
+DELETE FROM invite_keys WHERE generated_by = ANY($1::UUID[])
+`
+
+func (q *Queries) DeleteInviteKeysForUsers(ctx context.Context, dollar_1 []uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteInviteKeysForUsers, dollar_1)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const destroyExpiredInvites = `-- name: DestroyExpiredInvites :exec
 DELETE FROM invite_keys WHERE expire_time IS NOT NULL AND expire_time < NOW()
 `
@@ -8,6 +8,7 @@ package queries
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -68,15 +69,30 @@ const getModAuditCount = `-- name: GetModAuditCount :one
 SELECT COUNT(*) FROM mod_audit
 WHERE ($1::mod_audit_action IS NULL OR action = $1)
   AND ($2::uuid IS NULL OR user_id = $2)
+  AND ($3::text IS NULL OR target_type = $3)
+  AND ($4::uuid IS NULL OR target_id = $4)
+  AND ($5::timestamp IS NULL OR created_at >= $5)
+  AND ($6::timestamp IS NULL OR created_at < $6)
 `
 
 type GetModAuditCountParams struct {
-	Action NullModAuditAction `db:"action" json:"action"`
-	UserID uuid.NullUUID      `db:"user_id" json:"user_id"`
+	Action     NullModAuditAction `db:"action" json:"action"`
+	UserID     uuid.NullUUID      `db:"user_id" json:"user_id"`
+	TargetType *string            `db:"target_type" json:"target_type"`
+	TargetID   uuid.NullUUID      `db:"target_id" json:"target_id"`
+	StartDate  *time.Time         `db:"start_date" json:"start_date"`
+	EndDate    *time.Time         `db:"end_date" json:"end_date"`
 }
 
 func (q *Queries) GetModAuditCount(ctx context.Context, arg GetModAuditCountParams) (int64, error) {
-	row := q.db.QueryRow(ctx, getModAuditCount, arg.Action, arg.UserID)
+	row := q.db.QueryRow(ctx, getModAuditCount,
+		arg.Action,
+		arg.UserID,
+		arg.TargetType,
+		arg.TargetID,
+		arg.StartDate,
+		arg.EndDate,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -86,15 +102,23 @@ const queryModAudits = `-- name: QueryModAudits :many
 SELECT id, action, user_id, target_id, target_type, data, reason, created_at FROM mod_audit
 WHERE ($3::mod_audit_action IS NULL OR action = $3)
   AND ($4::uuid IS NULL OR user_id = $4)
+  AND ($5::text IS NULL OR target_type = $5)
+  AND ($6::uuid IS NULL OR target_id = $6)
+  AND ($7::timestamp IS NULL OR created_at >= $7)
+  AND ($8::timestamp IS NULL OR created_at < $8)
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
 `
 
 type QueryModAuditsParams struct {
-	Limit  int32              `db:"limit" json:"limit"`
-	Offset int32              `db:"offset" json:"offset"`
-	Action NullModAuditAction `db:"action" json:"action"`
-	UserID uuid.NullUUID      `db:"user_id" json:"user_id"`
+	Limit      int32              `db:"limit" json:"limit"`
+	Offset     int32              `db:"offset" json:"offset"`
+	Action     NullModAuditAction `db:"action" json:"action"`
+	UserID     uuid.NullUUID      `db:"user_id" json:"user_id"`
+	TargetType *string            `db:"target_type" json:"target_type"`
+	TargetID   uuid.NullUUID      `db:"target_id" json:"target_id"`
+	StartDate  *time.Time         `db:"start_date" json:"start_date"`
+	EndDate    *time.Time         `db:"end_date" json:"end_date"`
 }
 
 func (q *Queries) QueryModAudits(ctx context.Context, arg QueryModAuditsParams) ([]ModAudit, error) {
@@ -103,6 +127,10 @@ func (q *Queries) QueryModAudits(ctx context.Context, arg QueryModAuditsParams)
 		arg.Offset,
 		arg.Action,
 		arg.UserID,
+		arg.TargetType,
+		arg.TargetID,
+		arg.StartDate,
+		arg.EndDate,
 	)
 	if err != nil {
 		return nil, err
@@ -17,10 +17,37 @@ import (
 type ModAuditAction string
 
 const (
-	ModAuditActionEDITDELETE        ModAuditAction = "EDIT_DELETE"
-	ModAuditActionEDITAMENDMENT     ModAuditAction = "EDIT_AMENDMENT"
-	ModAuditActionEDITCOMMENTUPDATE ModAuditAction = "EDIT_COMMENT_UPDATE"
-	ModAuditActionEDITCOMMENTHIDE   ModAuditAction = "EDIT_COMMENT_HIDE"
+	ModAuditActionEDITDELETE           ModAuditAction = "EDIT_DELETE"
+	ModAuditActionEDITAMENDMENT        ModAuditAction = "EDIT_AMENDMENT"
+	ModAuditActionEDITCOMMENTUPDATE    ModAuditAction = "EDIT_COMMENT_UPDATE"
+	ModAuditActionEDITCOMMENTHIDE      ModAuditAction = "EDIT_COMMENT_HIDE"
+	ModAuditActionUSERCREATE           ModAuditAction = "USER_CREATE"
+	ModAuditActionUSERUPDATE           ModAuditAction = "USER_UPDATE"
+	ModAuditActionUSERDESTROY          ModAuditAction = "USER_DESTROY"
+	ModAuditActionEDITAPPROVE          ModAuditAction = "EDIT_APPROVE"
+	ModAuditActionEDITREJECT           ModAuditAction = "EDIT_REJECT"
+	ModAuditActionFINGERPRINTMOVE      ModAuditAction = "FINGERPRINT_MOVE"
+	ModAuditActionFINGERPRINTDELETE    ModAuditAction = "FINGERPRINT_DELETE"
+	ModAuditActionINVITEGRANT          ModAuditAction = "INVITE_GRANT"
+	ModAuditActionINVITEREVOKE         ModAuditAction = "INVITE_REVOKE"
+	ModAuditActionSITECREATE           ModAuditAction = "SITE_CREATE"
+	ModAuditActionSITEUPDATE           ModAuditAction = "SITE_UPDATE"
+	ModAuditActionSITEDESTROY          ModAuditAction = "SITE_DESTROY"
+	ModAuditActionTAGCATEGORYCREATE    ModAuditAction = "TAG_CATEGORY_CREATE"
+	ModAuditActionTAGCATEGORYUPDATE    ModAuditAction = "TAG_CATEGORY_UPDATE"
+	ModAuditActionTAGCATEGORYDESTROY   ModAuditAction = "TAG_CATEGORY_DESTROY"
+	ModAuditActionUSERREPUTATIONUPDATE ModAuditAction = "USER_REPUTATION_UPDATE"
+	ModAuditActionUSERSUSPEND          ModAuditAction = "USER_SUSPEND"
+	ModAuditActionUSERLIFTSUSPENSION   ModAuditAction = "USER_LIFT_SUSPENSION"
+	ModAuditActionUSERTWOFACTORRESET   ModAuditAction = "USER_TWO_FACTOR_RESET"
+	ModAuditActionUSERREVOKESESSIONS   ModAuditAction = "USER_REVOKE_SESSIONS"
+	ModAuditActionLOCKOUTCLEAR         ModAuditAction = "LOCKOUT_CLEAR"
+	ModAuditActionINVITETREEREVOKE     ModAuditAction = "INVITE_TREE_REVOKE"
+	ModAuditActionROLECREATE           ModAuditAction = "ROLE_CREATE"
+	ModAuditActionROLEUPDATE           ModAuditAction = "ROLE_UPDATE"
+	ModAuditActionROLEDESTROY          ModAuditAction = "ROLE_DESTROY"
+	ModAuditActionENTITYLOCK           ModAuditAction = "ENTITY_LOCK"
+	ModAuditActionENTITYUNLOCK         ModAuditAction = "ENTITY_UNLOCK"
 )
 
 func (e *ModAuditAction) Scan(src interface{}) error {
@@ -73,6 +100,11 @@ const (
 	NotificationTypeUPDATEDEDIT            NotificationType = "UPDATED_EDIT"
 	NotificationTypeFINGERPRINTEDSCENEEDIT NotificationType = "FINGERPRINTED_SCENE_EDIT"
 	NotificationTypeFINGERPRINTMOVED       NotificationType = "FINGERPRINT_MOVED"
+	NotificationTypeFAVORITESCENERELEASED  NotificationType = "FAVORITE_SCENE_RELEASED"
+	NotificationTypeSAVEDSEARCHMATCH       NotificationType = "SAVED_SEARCH_MATCH"
+	NotificationTypeMENTIONEDINCOMMENT     NotificationType = "MENTIONED_IN_COMMENT"
+	NotificationTypeUSERSUSPENDED          NotificationType = "USER_SUSPENDED"
+	NotificationTypeUSERSUSPENSIONLIFTED   NotificationType = "USER_SUSPENSION_LIFTED"
 )
 
 func (e *NotificationType) Scan(src interface{}) error {
@@ -142,6 +174,48 @@ type EditComment struct {
 	Text      string        `db:"text" json:"text"`
 	UpdatedAt *time.Time    `db:"updated_at" json:"updated_at"`
 	IsHidden  bool          `db:"is_hidden" json:"is_hidden"`
+	ParentID  uuid.NullUUID `db:"parent_id" json:"parent_id"`
+}
+
+type EditCommentMention struct {
+	CommentID uuid.UUID `db:"comment_id" json:"comment_id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+type EditCommentReaction struct {
+	CommentID uuid.UUID `db:"comment_id" json:"comment_id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Reaction  string    `db:"reaction" json:"reaction"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type EditConflict struct {
+	EditID            uuid.UUID `db:"edit_id" json:"edit_id"`
+	ConflictingEditID uuid.UUID `db:"conflicting_edit_id" json:"conflicting_edit_id"`
+	CreatedAt         time.Time `db:"created_at" json:"created_at"`
+}
+
+type EditGroup struct {
+	ID         uuid.UUID     `db:"id" json:"id"`
+	UserID     uuid.NullUUID `db:"user_id" json:"user_id"`
+	TargetType string        `db:"target_type" json:"target_type"`
+	CreatedAt  time.Time     `db:"created_at" json:"created_at"`
+}
+
+type EditGroupEdit struct {
+	EditID  uuid.UUID `db:"edit_id" json:"edit_id"`
+	GroupID uuid.UUID `db:"group_id" json:"group_id"`
+}
+
+type EditModeratorQueue struct {
+	EditID    uuid.UUID `db:"edit_id" json:"edit_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type EditRebase struct {
+	EditID        uuid.UUID `db:"edit_id" json:"edit_id"`
+	RebasedFromID uuid.UUID `db:"rebased_from_id" json:"rebased_from_id"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
 }
 
 type EditVote struct {
@@ -151,6 +225,18 @@ type EditVote struct {
 	Vote      string        `db:"vote" json:"vote"`
 }
 
+type EntityLock struct {
+	ID         uuid.UUID     `db:"id" json:"id"`
+	TargetType string        `db:"target_type" json:"target_type"`
+	TargetID   uuid.UUID     `db:"target_id" json:"target_id"`
+	Fields     []string      `db:"fields" json:"fields"`
+	Level      string        `db:"level" json:"level"`
+	Reason     string        `db:"reason" json:"reason"`
+	CreatedBy  uuid.NullUUID `db:"created_by" json:"created_by"`
+	CreatedAt  time.Time     `db:"created_at" json:"created_at"`
+	ExpiresAt  *time.Time    `db:"expires_at" json:"expires_at"`
+}
+
 type Fingerprint struct {
 	ID        int    `db:"id" json:"id"`
 	Algorithm string `db:"algorithm" json:"algorithm"`
@@ -173,6 +259,15 @@ type InviteKey struct {
 	ExpireTime  *time.Time `db:"expire_time" json:"expire_time"`
 }
 
+type Lockout struct {
+	ID            uuid.UUID  `db:"id" json:"id"`
+	Scope         string     `db:"scope" json:"scope"`
+	Key           string     `db:"key" json:"key"`
+	Attempts      int        `db:"attempts" json:"attempts"`
+	LastAttemptAt time.Time  `db:"last_attempt_at" json:"last_attempt_at"`
+	LockedUntil   *time.Time `db:"locked_until" json:"locked_until"`
+}
+
 type ModAudit struct {
 	ID         uuid.UUID       `db:"id" json:"id"`
 	Action     ModAuditAction  `db:"action" json:"action"`
@@ -191,6 +286,7 @@ type Notification struct {
 	CreatedAt time.Time        `db:"created_at" json:"created_at"`
 	ReadAt    *time.Time       `db:"read_at" json:"read_at"`
 	Data      *json.RawMessage `db:"data" json:"data"`
+	EmailedAt *time.Time       `db:"emailed_at" json:"emailed_at"`
 }
 
 type Performer struct {
@@ -222,6 +318,14 @@ type PerformerAlias struct {
 	Alias       string    `db:"alias" json:"alias"`
 }
 
+type PerformerCareerPeriod struct {
+	PerformerID uuid.UUID     `db:"performer_id" json:"performer_id"`
+	StartDate   string        `db:"start_date" json:"start_date"`
+	EndDate     *string       `db:"end_date" json:"end_date"`
+	StudioID    uuid.NullUUID `db:"studio_id" json:"studio_id"`
+	Note        *string       `db:"note" json:"note"`
+}
+
 type PerformerEdit struct {
 	EditID      uuid.UUID `db:"edit_id" json:"edit_id"`
 	PerformerID uuid.UUID `db:"performer_id" json:"performer_id"`
@@ -254,6 +358,13 @@ type PerformerRedirect struct {
 	TargetID uuid.UUID `db:"target_id" json:"target_id"`
 }
 
+type PerformerRelationship struct {
+	PerformerID        uuid.UUID `db:"performer_id" json:"performer_id"`
+	RelatedPerformerID uuid.UUID `db:"related_performer_id" json:"related_performer_id"`
+	Type               string    `db:"type" json:"type"`
+	CreatedAt          time.Time `db:"created_at" json:"created_at"`
+}
+
 type PerformerSearch struct {
 	PerformerID    uuid.UUID `db:"performer_id" json:"performer_id"`
 	Name           *string   `db:"name" json:"name"`
@@ -274,6 +385,27 @@ type PerformerUrl struct {
 	SiteID      uuid.UUID `db:"site_id" json:"site_id"`
 }
 
+type SavedSearch struct {
+	ID            uuid.UUID       `db:"id" json:"id"`
+	UserID        uuid.UUID       `db:"user_id" json:"user_id"`
+	Name          string          `db:"name" json:"name"`
+	Type          string          `db:"type" json:"type"`
+	Filter        json.RawMessage `db:"filter" json:"filter"`
+	Notify        bool            `db:"notify" json:"notify"`
+	LastCheckedAt time.Time       `db:"last_checked_at" json:"last_checked_at"`
+	CreatedAt     time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at" json:"updated_at"`
+}
+
+type Role struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Description string    `db:"description" json:"description"`
+	Permissions []string  `db:"permissions" json:"permissions"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
 type Scene struct {
 	ID             uuid.UUID     `db:"id" json:"id"`
 	Title          *string       `db:"title" json:"title"`
@@ -481,11 +613,73 @@ type UserNotification struct {
 	Type   NotificationType `db:"type" json:"type"`
 }
 
+type UserCustomRole struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	RoleID uuid.UUID `db:"role_id" json:"role_id"`
+}
+
+type UserIdentity struct {
+	Issuer    string    `db:"issuer" json:"issuer"`
+	Subject   string    `db:"subject" json:"subject"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type UserNotificationDigest struct {
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Frequency string    `db:"frequency" json:"frequency"`
+	SentAt    time.Time `db:"sent_at" json:"sent_at"`
+}
+
+type UserNotificationEmail struct {
+	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
+	Type      NotificationType `db:"type" json:"type"`
+	Frequency string           `db:"frequency" json:"frequency"`
+	UpdatedAt time.Time        `db:"updated_at" json:"updated_at"`
+}
+
 type UserRole struct {
 	UserID uuid.UUID `db:"user_id" json:"user_id"`
 	Role   string    `db:"role" json:"role"`
 }
 
+type UserRecoveryCode struct {
+	UserID   uuid.UUID  `db:"user_id" json:"user_id"`
+	CodeHash string     `db:"code_hash" json:"code_hash"`
+	UsedAt   *time.Time `db:"used_at" json:"used_at"`
+}
+
+type UserSession struct {
+	ID         uuid.UUID     `db:"id" json:"id"`
+	UserID     uuid.NullUUID `db:"user_id" json:"user_id"`
+	Data       []byte        `db:"data" json:"data"`
+	UserAgent  string        `db:"user_agent" json:"user_agent"`
+	IpAddress  string        `db:"ip_address" json:"ip_address"`
+	CreatedAt  time.Time     `db:"created_at" json:"created_at"`
+	LastSeenAt time.Time     `db:"last_seen_at" json:"last_seen_at"`
+	ExpiresAt  time.Time     `db:"expires_at" json:"expires_at"`
+}
+
+type UserSuspension struct {
+	ID          uuid.UUID     `db:"id" json:"id"`
+	UserID      uuid.UUID     `db:"user_id" json:"user_id"`
+	Reason      string        `db:"reason" json:"reason"`
+	CreatedBy   uuid.NullUUID `db:"created_by" json:"created_by"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
+	ExpiresAt   *time.Time    `db:"expires_at" json:"expires_at"`
+	LiftedAt    *time.Time    `db:"lifted_at" json:"lifted_at"`
+	LiftedBy    uuid.NullUUID `db:"lifted_by" json:"lifted_by"`
+	Restriction string        `db:"restriction" json:"restriction"`
+}
+
+type UserTotp struct {
+	UserID       uuid.UUID  `db:"user_id" json:"user_id"`
+	Secret       string     `db:"secret" json:"secret"`
+	EnabledAt    *time.Time `db:"enabled_at" json:"enabled_at"`
+	LastUsedStep int64      `db:"last_used_step" json:"last_used_step"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+}
+
 type UserToken struct {
 	ID        uuid.UUID `db:"id" json:"id"`
 	Data      []byte    `db:"data" json:"data"`
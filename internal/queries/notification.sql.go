@@ -7,10 +7,68 @@ package queries
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
 
+const claimNotificationEmails = `-- name: ClaimNotificationEmails :many
+WITH claimed AS (
+    UPDATE notifications N SET emailed_at = NOW()
+    FROM user_notification_emails E
+    WHERE E.user_id = N.user_id AND E.type = N.type
+    AND N.user_id = $1
+    AND E.frequency = $2
+    AND N.emailed_at IS NULL
+    AND N.read_at IS NULL
+    AND N.created_at >= E.updated_at
+    RETURNING N.type, N.id, N.created_at
+)
+SELECT C.type, C.id, C.created_at, EC.edit_id
+FROM claimed C
+LEFT JOIN edit_comments EC ON EC.id = C.id
+ORDER BY C.created_at
+`
+
+type ClaimNotificationEmailsParams struct {
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Frequency string    `db:"frequency" json:"frequency"`
+}
+
+type ClaimNotificationEmailsRow struct {
+	Type      NotificationType `db:"type" json:"type"`
+	ID        uuid.UUID        `db:"id" json:"id"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+	EditID    uuid.NullUUID    `db:"edit_id" json:"edit_id"`
+}
+
+// Marks the pending notifications of the given frequency as emailed and
+// returns them, along with the edit of comment notifications
+func (q *Queries) ClaimNotificationEmails(ctx context.Context, arg ClaimNotificationEmailsParams) ([]ClaimNotificationEmailsRow, error) {
+	rows, err := q.db.Query(ctx, claimNotificationEmails, arg.UserID, arg.Frequency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimNotificationEmailsRow{}
+	for rows.Next() {
+		var i ClaimNotificationEmailsRow
+		if err := rows.Scan(
+			&i.Type,
+			&i.ID,
+			&i.CreatedAt,
+			&i.EditID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countNotificationsByUser = `-- name: CountNotificationsByUser :one
 SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND ($2::boolean = FALSE OR read_at IS NULL) AND ($3::notification_type IS NULL OR type = $3::notification_type)
 `
@@ -62,6 +120,47 @@ type CreateUserNotificationSubscriptionsParams struct {
 	Type   NotificationType `db:"type" json:"type"`
 }
 
+const createSavedSearchMatchNotification = `-- name: CreateSavedSearchMatchNotification :exec
+INSERT INTO notifications (user_id, type, id, data)
+VALUES (
+    $1, 'SAVED_SEARCH_MATCH', $2,
+    jsonb_build_object('ids', $3::uuid[], 'count', $4::int)
+)
+`
+
+type CreateSavedSearchMatchNotificationParams struct {
+	UserID        uuid.UUID   `db:"user_id" json:"user_id"`
+	SavedSearchID uuid.UUID   `db:"saved_search_id" json:"saved_search_id"`
+	Ids           []uuid.UUID `db:"ids" json:"ids"`
+	Count         int         `db:"count" json:"count"`
+}
+
+func (q *Queries) CreateSavedSearchMatchNotification(ctx context.Context, arg CreateSavedSearchMatchNotificationParams) error {
+	_, err := q.db.Exec(ctx, createSavedSearchMatchNotification,
+		arg.UserID,
+		arg.SavedSearchID,
+		arg.Ids,
+		arg.Count,
+	)
+	return err
+}
+
+const createUserSuspensionNotification = `-- name: CreateUserSuspensionNotification :exec
+INSERT INTO notifications (user_id, type, id)
+VALUES ($1, $2, $3)
+`
+
+type CreateUserSuspensionNotificationParams struct {
+	UserID       uuid.UUID        `db:"user_id" json:"user_id"`
+	Type         NotificationType `db:"type" json:"type"`
+	SuspensionID uuid.UUID        `db:"suspension_id" json:"suspension_id"`
+}
+
+func (q *Queries) CreateUserSuspensionNotification(ctx context.Context, arg CreateUserSuspensionNotificationParams) error {
+	_, err := q.db.Exec(ctx, createUserSuspensionNotification, arg.UserID, arg.Type, arg.SuspensionID)
+	return err
+}
+
 const deleteNotificationsByEditComments = `-- name: DeleteNotificationsByEditComments :exec
 DELETE FROM notifications WHERE id IN (SELECT id FROM edit_comments WHERE edit_id = $1)
 `
@@ -100,9 +199,48 @@ func (q *Queries) DestroyExpiredNotifications(ctx context.Context) error {
 	return err
 }
 
+const findNotificationEmailRecipients = `-- name: FindNotificationEmailRecipients :many
+SELECT DISTINCT N.user_id
+FROM notifications N
+JOIN user_notification_emails E ON E.user_id = N.user_id AND E.type = N.type
+LEFT JOIN user_notification_digests D ON D.user_id = N.user_id AND D.frequency = E.frequency
+WHERE E.frequency = $1
+AND N.emailed_at IS NULL
+AND N.read_at IS NULL
+AND N.created_at >= E.updated_at
+AND (D.sent_at IS NULL OR D.sent_at <= $2)
+`
+
+type FindNotificationEmailRecipientsParams struct {
+	Frequency  string    `db:"frequency" json:"frequency"`
+	SentBefore time.Time `db:"sent_before" json:"sent_before"`
+}
+
+// Users with pending notifications of the given frequency whose previous
+// email of that frequency was sent before sent_before
+func (q *Queries) FindNotificationEmailRecipients(ctx context.Context, arg FindNotificationEmailRecipientsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, findNotificationEmailRecipients, arg.Frequency, arg.SentBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findNotificationsByUser = `-- name: FindNotificationsByUser :many
 
-SELECT user_id, type, id, created_at, read_at, data FROM notifications WHERE user_id = $1 AND ($4::notification_type IS NULL OR type = $4::notification_type) ORDER BY created_at DESC LIMIT $2 OFFSET $3
+SELECT user_id, type, id, created_at, read_at, data, emailed_at FROM notifications WHERE user_id = $1 AND ($4::notification_type IS NULL OR type = $4::notification_type) ORDER BY created_at DESC LIMIT $2 OFFSET $3
 `
 
 type FindNotificationsByUserParams struct {
@@ -134,6 +272,7 @@ func (q *Queries) FindNotificationsByUser(ctx context.Context, arg FindNotificat
 			&i.CreatedAt,
 			&i.ReadAt,
 			&i.Data,
+			&i.EmailedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -146,7 +285,7 @@ func (q *Queries) FindNotificationsByUser(ctx context.Context, arg FindNotificat
 }
 
 const findUnreadNotificationsByUser = `-- name: FindUnreadNotificationsByUser :many
-SELECT user_id, type, id, created_at, read_at, data FROM notifications WHERE user_id = $1 AND read_at IS NULL AND ($4::notification_type IS NULL OR type = $4::notification_type) ORDER BY created_at DESC LIMIT $2 OFFSET $3
+SELECT user_id, type, id, created_at, read_at, data, emailed_at FROM notifications WHERE user_id = $1 AND read_at IS NULL AND ($4::notification_type IS NULL OR type = $4::notification_type) ORDER BY created_at DESC LIMIT $2 OFFSET $3
 `
 
 type FindUnreadNotificationsByUserParams struct {
@@ -177,6 +316,36 @@ func (q *Queries) FindUnreadNotificationsByUser(ctx context.Context, arg FindUnr
 			&i.CreatedAt,
 			&i.ReadAt,
 			&i.Data,
+			&i.EmailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserNotificationEmailPreferences = `-- name: GetUserNotificationEmailPreferences :many
+SELECT user_id, type, frequency, updated_at FROM user_notification_emails WHERE user_id = $1 ORDER BY type
+`
+
+func (q *Queries) GetUserNotificationEmailPreferences(ctx context.Context, userID uuid.UUID) ([]UserNotificationEmail, error) {
+	rows, err := q.db.Query(ctx, getUserNotificationEmailPreferences, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserNotificationEmail{}
+	for rows.Next() {
+		var i UserNotificationEmail
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Type,
+			&i.Frequency,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -197,6 +366,21 @@ func (q *Queries) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID
 	return err
 }
 
+const markNotificationDigestSent = `-- name: MarkNotificationDigestSent :exec
+INSERT INTO user_notification_digests (user_id, frequency, sent_at) VALUES ($1, $2, NOW())
+ON CONFLICT (user_id, frequency) DO UPDATE SET sent_at = EXCLUDED.sent_at
+`
+
+type MarkNotificationDigestSentParams struct {
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Frequency string    `db:"frequency" json:"frequency"`
+}
+
+func (q *Queries) MarkNotificationDigestSent(ctx context.Context, arg MarkNotificationDigestSentParams) error {
+	_, err := q.db.Exec(ctx, markNotificationDigestSent, arg.UserID, arg.Frequency)
+	return err
+}
+
 const markNotificationRead = `-- name: MarkNotificationRead :exec
 UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND type = $2 AND id = $3 AND read_at IS NULL
 `
@@ -228,6 +412,13 @@ func (q *Queries) TriggerDownvoteEditNotifications(ctx context.Context, id uuid.
 const triggerEditCommentNotifications = `-- name: TriggerEditCommentNotifications :exec
 INSERT INTO notifications (user_id, type, id)
 SELECT DISTINCT ON (user_id) user_id, type, $1 FROM (
+    SELECT N.user_id, N.type, 0 as ordering
+    FROM edit_comments EC
+    JOIN edit_comment_mentions M ON M.comment_id = EC.id
+    JOIN user_notifications N ON M.user_id = N.user_id AND N.type = 'MENTIONED_IN_COMMENT'
+    WHERE M.user_id != EC.user_id
+    AND EC.id = $1
+    UNION
     SELECT N.user_id, N.type, 1 as ordering
     FROM edit_comments EC
     JOIN edits E ON EC.edit_id = E.id
@@ -392,6 +583,36 @@ func (q *Queries) TriggerSceneEditNotifications(ctx context.Context, id uuid.UUI
 	return err
 }
 
+const triggerSceneReleaseNotifications = `-- name: TriggerSceneReleaseNotifications :exec
+INSERT INTO notifications (user_id, type, id)
+SELECT DISTINCT F.user_id, N.type, F.scene_id
+FROM (
+    SELECT SF.user_id, S.id AS scene_id
+    FROM scenes S
+    JOIN studio_favorites SF ON S.studio_id = SF.studio_id
+    WHERE S.date = $1::text AND S.deleted = FALSE
+      AND S.created_at < to_date(S.date, 'YYYY-MM-DD')
+    UNION
+    SELECT PF.user_id, S.id AS scene_id
+    FROM scenes S
+    JOIN scene_performers SP ON S.id = SP.scene_id
+    JOIN performer_favorites PF ON SP.performer_id = PF.performer_id
+    WHERE S.date = $1::text AND S.deleted = FALSE
+      AND S.created_at < to_date(S.date, 'YYYY-MM-DD')
+) F
+JOIN user_notifications N ON F.user_id = N.user_id AND N.type = 'FAVORITE_SCENE_RELEASED'
+WHERE NOT EXISTS (
+    SELECT 1 FROM notifications E
+    WHERE E.user_id = F.user_id AND E.type = N.type AND E.id = F.scene_id
+)
+`
+
+// Notify users when a scene added ahead of its release date, featuring a favorite performer or studio, is released
+func (q *Queries) TriggerSceneReleaseNotifications(ctx context.Context, releaseDate string) error {
+	_, err := q.db.Exec(ctx, triggerSceneReleaseNotifications, releaseDate)
+	return err
+}
+
 const triggerStudioEditNotifications = `-- name: TriggerStudioEditNotifications :exec
 INSERT INTO notifications (user_id, type, id)
 SELECT N.user_id, N.type, $1
@@ -420,3 +641,31 @@ func (q *Queries) TriggerUpdatedEditNotifications(ctx context.Context, id uuid.U
 	_, err := q.db.Exec(ctx, triggerUpdatedEditNotifications, id)
 	return err
 }
+
+const unsubscribeUserNotificationEmails = `-- name: UnsubscribeUserNotificationEmails :exec
+UPDATE user_notification_emails SET frequency = 'OFF', updated_at = NOW() WHERE user_id = $1 AND frequency != 'OFF'
+`
+
+func (q *Queries) UnsubscribeUserNotificationEmails(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, unsubscribeUserNotificationEmails, userID)
+	return err
+}
+
+const upsertUserNotificationEmailPreference = `-- name: UpsertUserNotificationEmailPreference :exec
+INSERT INTO user_notification_emails (user_id, type, frequency) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, type) DO UPDATE
+SET frequency = EXCLUDED.frequency,
+    updated_at = CASE WHEN user_notification_emails.frequency = EXCLUDED.frequency THEN user_notification_emails.updated_at ELSE NOW() END
+`
+
+type UpsertUserNotificationEmailPreferenceParams struct {
+	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
+	Type      NotificationType `db:"type" json:"type"`
+	Frequency string           `db:"frequency" json:"frequency"`
+}
+
+// Only notifications created after the frequency last changed are emailed
+func (q *Queries) UpsertUserNotificationEmailPreference(ctx context.Context, arg UpsertUserNotificationEmailPreferenceParams) error {
+	_, err := q.db.Exec(ctx, upsertUserNotificationEmailPreference, arg.UserID, arg.Type, arg.Frequency)
+	return err
+}
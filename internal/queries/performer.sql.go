@@ -63,6 +63,25 @@ func (q *Queries) CountPerformerSearchMatches(ctx context.Context, arg CountPerf
 	return total_count, err
 }
 
+const countPerformerSearchMatchesPostgres = `-- name: CountPerformerSearchMatchesPostgres :one
+SELECT COUNT(*)
+FROM performer_search
+WHERE performer_search_document(name, disambiguation, aliases) @@ to_tsquery('simple', $1::TEXT)
+AND ($2::TEXT IS NULL OR gender = $2::TEXT)
+`
+
+type CountPerformerSearchMatchesPostgresParams struct {
+	Query        string  `db:"query" json:"query"`
+	FilterGender *string `db:"filter_gender" json:"filter_gender"`
+}
+
+func (q *Queries) CountPerformerSearchMatchesPostgres(ctx context.Context, arg CountPerformerSearchMatchesPostgresParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPerformerSearchMatchesPostgres, arg.Query, arg.FilterGender)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createPerformer = `-- name: CreatePerformer :one
 
 INSERT INTO performers (
@@ -153,6 +172,14 @@ type CreatePerformerAliasesParams struct {
 	Alias       string    `db:"alias" json:"alias"`
 }
 
+type CreatePerformerCareerPeriodsParams struct {
+	PerformerID uuid.UUID     `db:"performer_id" json:"performer_id"`
+	StartDate   string        `db:"start_date" json:"start_date"`
+	EndDate     *string       `db:"end_date" json:"end_date"`
+	StudioID    uuid.NullUUID `db:"studio_id" json:"studio_id"`
+	Note        *string       `db:"note" json:"note"`
+}
+
 const createPerformerFavorite = `-- name: CreatePerformerFavorite :exec
 INSERT INTO performer_favorites (performer_id, user_id, created_at) VALUES ($1, $2, now())
 ON CONFLICT (performer_id, user_id) DO NOTHING
@@ -195,6 +222,25 @@ func (q *Queries) CreatePerformerRedirect(ctx context.Context, arg CreatePerform
 	return err
 }
 
+const createPerformerRelationship = `-- name: CreatePerformerRelationship :exec
+INSERT INTO performer_relationships (performer_id, related_performer_id, type)
+VALUES ($1, $2, $3),
+       ($2, $1, $3)
+ON CONFLICT DO NOTHING
+`
+
+type CreatePerformerRelationshipParams struct {
+	PerformerID        uuid.UUID `db:"performer_id" json:"performer_id"`
+	RelatedPerformerID uuid.UUID `db:"related_performer_id" json:"related_performer_id"`
+	Type               string    `db:"type" json:"type"`
+}
+
+// Create a relationship in both directions
+func (q *Queries) CreatePerformerRelationship(ctx context.Context, arg CreatePerformerRelationshipParams) error {
+	_, err := q.db.Exec(ctx, createPerformerRelationship, arg.PerformerID, arg.RelatedPerformerID, arg.Type)
+	return err
+}
+
 type CreatePerformerTattoosParams struct {
 	PerformerID uuid.UUID `db:"performer_id" json:"performer_id"`
 	Location    *string   `db:"location" json:"location"`
@@ -227,6 +273,15 @@ func (q *Queries) DeletePerformerAliases(ctx context.Context, performerID uuid.U
 	return err
 }
 
+const deletePerformerCareerPeriods = `-- name: DeletePerformerCareerPeriods :exec
+DELETE FROM performer_career_periods WHERE performer_id = $1
+`
+
+func (q *Queries) DeletePerformerCareerPeriods(ctx context.Context, performerID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePerformerCareerPeriods, performerID)
+	return err
+}
+
 const deletePerformerFavorite = `-- name: DeletePerformerFavorite :exec
 DELETE FROM performer_favorites WHERE performer_id = $1 AND user_id = $2
 `
@@ -272,6 +327,35 @@ func (q *Queries) DeletePerformerPiercings(ctx context.Context, performerID uuid
 	return err
 }
 
+const deletePerformerRelationship = `-- name: DeletePerformerRelationship :exec
+DELETE FROM performer_relationships
+WHERE type = $1
+  AND ((performer_id = $2 AND related_performer_id = $3)
+    OR (performer_id = $3 AND related_performer_id = $2))
+`
+
+type DeletePerformerRelationshipParams struct {
+	Type               string    `db:"type" json:"type"`
+	PerformerID        uuid.UUID `db:"performer_id" json:"performer_id"`
+	RelatedPerformerID uuid.UUID `db:"related_performer_id" json:"related_performer_id"`
+}
+
+// Delete a relationship in both directions
+func (q *Queries) DeletePerformerRelationship(ctx context.Context, arg DeletePerformerRelationshipParams) error {
+	_, err := q.db.Exec(ctx, deletePerformerRelationship, arg.Type, arg.PerformerID, arg.RelatedPerformerID)
+	return err
+}
+
+const deletePerformerRelationships = `-- name: DeletePerformerRelationships :exec
+DELETE FROM performer_relationships WHERE performer_id = $1 OR related_performer_id = $1
+`
+
+// Delete all relationships of a performer in both directions
+func (q *Queries) DeletePerformerRelationships(ctx context.Context, performerID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePerformerRelationships, performerID)
+	return err
+}
+
 const deletePerformerScenes = `-- name: DeletePerformerScenes :exec
 DELETE FROM scene_performers WHERE performer_id = $1
 `
@@ -845,6 +929,153 @@ func (q *Queries) GetPerformerAliases(ctx context.Context, performerID uuid.UUID
 	return items, nil
 }
 
+const getPerformerCareerPeriods = `-- name: GetPerformerCareerPeriods :many
+SELECT performer_id, start_date, end_date, studio_id, note FROM performer_career_periods WHERE performer_id = $1 ORDER BY start_date
+`
+
+func (q *Queries) GetPerformerCareerPeriods(ctx context.Context, performerID uuid.UUID) ([]PerformerCareerPeriod, error) {
+	rows, err := q.db.Query(ctx, getPerformerCareerPeriods, performerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PerformerCareerPeriod{}
+	for rows.Next() {
+		var i PerformerCareerPeriod
+		if err := rows.Scan(
+			&i.PerformerID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.StudioID,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPerformerCostarLinks = `-- name: GetPerformerCostarLinks :many
+SELECT DISTINCT SP.performer_id, CP.performer_id AS costar_id
+FROM scene_performers SP
+JOIN scene_performers CP ON CP.scene_id = SP.scene_id AND CP.performer_id != SP.performer_id
+JOIN scenes ON scenes.id = SP.scene_id AND scenes.deleted = FALSE
+JOIN performers ON performers.id = CP.performer_id AND performers.deleted = FALSE
+WHERE SP.performer_id = ANY($1::UUID[])
+LIMIT $2
+`
+
+type GetPerformerCostarLinksParams struct {
+	PerformerIds []uuid.UUID `db:"performer_ids" json:"performer_ids"`
+	MaxLinks     int32       `db:"max_links" json:"max_links"`
+}
+
+type GetPerformerCostarLinksRow struct {
+	PerformerID uuid.UUID `db:"performer_id" json:"performer_id"`
+	CostarID    uuid.UUID `db:"costar_id" json:"costar_id"`
+}
+
+// Get the distinct co-appearance links for a set of performers
+func (q *Queries) GetPerformerCostarLinks(ctx context.Context, arg GetPerformerCostarLinksParams) ([]GetPerformerCostarLinksRow, error) {
+	rows, err := q.db.Query(ctx, getPerformerCostarLinks, arg.PerformerIds, arg.MaxLinks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPerformerCostarLinksRow{}
+	for rows.Next() {
+		var i GetPerformerCostarLinksRow
+		if err := rows.Scan(&i.PerformerID, &i.CostarID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPerformerCostars = `-- name: GetPerformerCostars :many
+SELECT
+    performers.id, performers.name, performers.disambiguation, performers.gender, performers.ethnicity, performers.country, performers.eye_color, performers.hair_color, performers.height, performers.cup_size, performers.band_size, performers.hip_size, performers.waist_size, performers.breast_type, performers.career_start_year, performers.career_end_year, performers.created_at, performers.updated_at, performers.deleted, performers.birthdate, performers.deathdate,
+    COUNT(DISTINCT scenes.id) AS scene_count,
+    MIN(scenes.date) AS first_scene_date,
+    MAX(scenes.date) AS last_scene_date
+FROM scene_performers SP
+JOIN scene_performers CP ON CP.scene_id = SP.scene_id AND CP.performer_id != SP.performer_id
+JOIN scenes ON scenes.id = SP.scene_id AND scenes.deleted = FALSE
+JOIN performers ON performers.id = CP.performer_id AND performers.deleted = FALSE
+WHERE SP.performer_id = $1
+  AND ($2::uuid IS NULL OR scenes.studio_id = $2)
+GROUP BY performers.id
+ORDER BY scene_count DESC, performers.name
+LIMIT $3
+`
+
+type GetPerformerCostarsParams struct {
+	PerformerID uuid.UUID     `db:"performer_id" json:"performer_id"`
+	StudioID    uuid.NullUUID `db:"studio_id" json:"studio_id"`
+	Limit       int32         `db:"limit" json:"limit"`
+}
+
+type GetPerformerCostarsRow struct {
+	Performer      Performer `db:"performer" json:"performer"`
+	SceneCount     int64     `db:"scene_count" json:"scene_count"`
+	FirstSceneDate *string   `db:"first_scene_date" json:"first_scene_date"`
+	LastSceneDate  *string   `db:"last_scene_date" json:"last_scene_date"`
+}
+
+// Get performers sharing scenes with a performer, with shared scene counts and date range
+func (q *Queries) GetPerformerCostars(ctx context.Context, arg GetPerformerCostarsParams) ([]GetPerformerCostarsRow, error) {
+	rows, err := q.db.Query(ctx, getPerformerCostars, arg.PerformerID, arg.StudioID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPerformerCostarsRow{}
+	for rows.Next() {
+		var i GetPerformerCostarsRow
+		if err := rows.Scan(
+			&i.Performer.ID,
+			&i.Performer.Name,
+			&i.Performer.Disambiguation,
+			&i.Performer.Gender,
+			&i.Performer.Ethnicity,
+			&i.Performer.Country,
+			&i.Performer.EyeColor,
+			&i.Performer.HairColor,
+			&i.Performer.Height,
+			&i.Performer.CupSize,
+			&i.Performer.BandSize,
+			&i.Performer.HipSize,
+			&i.Performer.WaistSize,
+			&i.Performer.BreastType,
+			&i.Performer.CareerStartYear,
+			&i.Performer.CareerEndYear,
+			&i.Performer.CreatedAt,
+			&i.Performer.UpdatedAt,
+			&i.Performer.Deleted,
+			&i.Performer.Birthdate,
+			&i.Performer.Deathdate,
+			&i.SceneCount,
+			&i.FirstSceneDate,
+			&i.LastSceneDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPerformerImages = `-- name: GetPerformerImages :many
 
 SELECT images.id, images.url, images.width, images.height, images.checksum FROM images
@@ -908,6 +1139,35 @@ func (q *Queries) GetPerformerPiercings(ctx context.Context, performerID uuid.UU
 	return items, nil
 }
 
+const getPerformerRelationships = `-- name: GetPerformerRelationships :many
+SELECT performer_id, related_performer_id, type, created_at FROM performer_relationships WHERE performer_id = $1
+`
+
+func (q *Queries) GetPerformerRelationships(ctx context.Context, performerID uuid.UUID) ([]PerformerRelationship, error) {
+	rows, err := q.db.Query(ctx, getPerformerRelationships, performerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PerformerRelationship{}
+	for rows.Next() {
+		var i PerformerRelationship
+		if err := rows.Scan(
+			&i.PerformerID,
+			&i.RelatedPerformerID,
+			&i.Type,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPerformerSearchFacets = `-- name: GetPerformerSearchFacets :one
 SELECT pdb.agg('{"terms": {"field": "gender"}}') AS gender_facets
 FROM performer_search
@@ -942,6 +1202,49 @@ func (q *Queries) GetPerformerSearchFacets(ctx context.Context, arg GetPerformer
 	return gender_facets, err
 }
 
+const getPerformerSearchFacetsPostgres = `-- name: GetPerformerSearchFacetsPostgres :many
+SELECT gender, COUNT(*) AS count
+FROM performer_search
+WHERE performer_search_document(name, disambiguation, aliases) @@ to_tsquery('simple', $1::TEXT)
+AND ($2::TEXT IS NULL OR gender = $2::TEXT)
+AND gender IS NOT NULL
+GROUP BY gender
+ORDER BY count DESC, gender
+`
+
+type GetPerformerSearchFacetsPostgresParams struct {
+	Query        string  `db:"query" json:"query"`
+	FilterGender *string `db:"filter_gender" json:"filter_gender"`
+}
+
+type GetPerformerSearchFacetsPostgresRow struct {
+	Gender *string `db:"gender" json:"gender"`
+	Count  int64   `db:"count" json:"count"`
+}
+
+func (q *Queries) GetPerformerSearchFacetsPostgres(ctx context.Context, arg GetPerformerSearchFacetsPostgresParams) ([]GetPerformerSearchFacetsPostgresRow, error) {
+	rows, err := q.db.Query(ctx, getPerformerSearchFacetsPostgres, arg.Query, arg.FilterGender)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPerformerSearchFacetsPostgresRow{}
+	for rows.Next() {
+		var i GetPerformerSearchFacetsPostgresRow
+		if err := rows.Scan(
+			&i.Gender,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPerformerTattoos = `-- name: GetPerformerTattoos :many
 SELECT location, description FROM performer_tattoos WHERE performer_id = $1
 `
@@ -1038,6 +1341,27 @@ func (q *Queries) ReassignPerformerFavorites(ctx context.Context, arg ReassignPe
 	return err
 }
 
+const reassignPerformerRelationships = `-- name: ReassignPerformerRelationships :exec
+INSERT INTO performer_relationships (performer_id, related_performer_id, type)
+SELECT $1::uuid, related_performer_id, type FROM performer_relationships
+WHERE performer_id = $2 AND related_performer_id <> $1
+UNION
+SELECT related_performer_id, $1::uuid, type FROM performer_relationships
+WHERE performer_id = $2 AND related_performer_id <> $1
+ON CONFLICT DO NOTHING
+`
+
+type ReassignPerformerRelationshipsParams struct {
+	NewPerformerID uuid.UUID `db:"new_performer_id" json:"new_performer_id"`
+	OldPerformerID uuid.UUID `db:"old_performer_id" json:"old_performer_id"`
+}
+
+// Copy the relationships of a merge source to its target, in both directions
+func (q *Queries) ReassignPerformerRelationships(ctx context.Context, arg ReassignPerformerRelationshipsParams) error {
+	_, err := q.db.Exec(ctx, reassignPerformerRelationships, arg.NewPerformerID, arg.OldPerformerID)
+	return err
+}
+
 const searchPerformers = `-- name: SearchPerformers :many
 
 SELECT performer_id
@@ -1097,6 +1421,57 @@ func (q *Queries) SearchPerformers(ctx context.Context, arg SearchPerformersPara
 	return items, nil
 }
 
+const searchPerformersPostgres = `-- name: SearchPerformersPostgres :many
+SELECT performer_id
+FROM performer_search
+WHERE performer_search_document(name, disambiguation, aliases) @@ to_tsquery('simple', $1::TEXT)
+AND ($2::TEXT IS NULL OR gender = $2::TEXT)
+ORDER BY
+    ts_rank(performer_search_document(name, disambiguation, aliases), to_tsquery('simple', $1::TEXT))
+        + similarity(name, $3::TEXT) DESC,
+    performer_id
+LIMIT $5 OFFSET $4
+`
+
+type SearchPerformersPostgresParams struct {
+	Query        string  `db:"query" json:"query"`
+	FilterGender *string `db:"filter_gender" json:"filter_gender"`
+	Term         string  `db:"term" json:"term"`
+	Offset       int32   `db:"offset" json:"offset"`
+	Limit        int32   `db:"limit" json:"limit"`
+}
+
+// search_backend: postgres equivalent of SearchPerformers. The query is a
+// to_tsquery expression built from the search term; the raw term is used to
+// rank names that closely match the whole term first.
+// Keep the WHERE clause in sync across SearchPerformersPostgres,
+// CountPerformerSearchMatchesPostgres and GetPerformerSearchFacetsPostgres.
+func (q *Queries) SearchPerformersPostgres(ctx context.Context, arg SearchPerformersPostgresParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, searchPerformersPostgres,
+		arg.Query,
+		arg.FilterGender,
+		arg.Term,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var performer_id uuid.UUID
+		if err := rows.Scan(&performer_id); err != nil {
+			return nil, err
+		}
+		items = append(items, performer_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const setScenePerformerAlias = `-- name: SetScenePerformerAlias :exec
 UPDATE scene_performers
 SET "as" = $2
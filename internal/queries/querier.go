@@ -6,18 +6,29 @@ package queries
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
 
 type Querier interface {
+	AddEditCommentReaction(ctx context.Context, arg AddEditCommentReactionParams) error
+	// Moves the last check of a saved search to now, returning the window of
+	// creation times to look for new matches in.
+	AdvanceSavedSearchCheck(ctx context.Context, id uuid.UUID) (AdvanceSavedSearchCheckRow, error)
 	CancelUserEdits(ctx context.Context, userID uuid.NullUUID) error
+	// Marks the pending notifications of the given frequency as emailed and
+	// returns them, along with the edit of comment notifications
+	ClaimNotificationEmails(ctx context.Context, arg ClaimNotificationEmailsParams) ([]ClaimNotificationEmailsRow, error)
 	ClearScenePerformerAlias(ctx context.Context, arg ClearScenePerformerAliasParams) error
 	CountNotificationsByUser(ctx context.Context, arg CountNotificationsByUserParams) (int64, error)
 	CountPerformerSearchMatches(ctx context.Context, arg CountPerformerSearchMatchesParams) (interface{}, error)
+	CountPerformerSearchMatchesPostgres(ctx context.Context, arg CountPerformerSearchMatchesPostgresParams) (int64, error)
+	CountRoleUsers(ctx context.Context, roleID uuid.UUID) (int64, error)
 	CountScenesByPerformer(ctx context.Context, performerID uuid.UUID) (int64, error)
 	CountUnreadNotificationsByUserGroupedByType(ctx context.Context, userID uuid.UUID) ([]CountUnreadNotificationsByUserGroupedByTypeRow, error)
 	CountUserEditsByStatus(ctx context.Context, userID uuid.NullUUID) ([]CountUserEditsByStatusRow, error)
+	CountUserRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
 	CountVotesByType(ctx context.Context, userID uuid.NullUUID) ([]CountVotesByTypeRow, error)
 	// Draft queries
@@ -26,8 +37,14 @@ type Querier interface {
 	CreateEdit(ctx context.Context, arg CreateEditParams) (Edit, error)
 	// Edit comments
 	CreateEditComment(ctx context.Context, arg CreateEditCommentParams) (EditComment, error)
+	CreateEditCommentMentions(ctx context.Context, arg CreateEditCommentMentionsParams) error
+	CreateEditConflicts(ctx context.Context, arg CreateEditConflictsParams) error
+	CreateEditGroup(ctx context.Context, arg CreateEditGroupParams) (EditGroup, error)
+	CreateEditGroupEdits(ctx context.Context, arg CreateEditGroupEditsParams) error
+	CreateEditRebase(ctx context.Context, arg CreateEditRebaseParams) error
 	// Edit votes
 	CreateEditVote(ctx context.Context, arg CreateEditVoteParams) error
+	CreateEntityLock(ctx context.Context, arg CreateEntityLockParams) (EntityLock, error)
 	// Fingerprint queries (normalized schema)
 	CreateFingerprint(ctx context.Context, arg CreateFingerprintParams) (Fingerprint, error)
 	// Image queries
@@ -39,14 +56,20 @@ type Querier interface {
 	// Performer queries
 	CreatePerformer(ctx context.Context, arg CreatePerformerParams) (Performer, error)
 	CreatePerformerAliases(ctx context.Context, arg []CreatePerformerAliasesParams) (int64, error)
+	CreatePerformerCareerPeriods(ctx context.Context, arg []CreatePerformerCareerPeriodsParams) (int64, error)
 	CreatePerformerEdit(ctx context.Context, arg CreatePerformerEditParams) error
 	CreatePerformerFavorite(ctx context.Context, arg CreatePerformerFavoriteParams) error
 	CreatePerformerImages(ctx context.Context, arg []CreatePerformerImagesParams) (int64, error)
 	CreatePerformerPiercings(ctx context.Context, arg []CreatePerformerPiercingsParams) (int64, error)
 	// Performer redirects
 	CreatePerformerRedirect(ctx context.Context, arg CreatePerformerRedirectParams) error
+	// Create a relationship in both directions
+	CreatePerformerRelationship(ctx context.Context, arg CreatePerformerRelationshipParams) error
 	CreatePerformerTattoos(ctx context.Context, arg []CreatePerformerTattoosParams) (int64, error)
 	CreatePerformerURLs(ctx context.Context, arg []CreatePerformerURLsParams) (int64, error)
+	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
+	CreateSavedSearch(ctx context.Context, arg CreateSavedSearchParams) (SavedSearch, error)
+	CreateSavedSearchMatchNotification(ctx context.Context, arg CreateSavedSearchMatchNotificationParams) error
 	// Scene queries
 	CreateScene(ctx context.Context, arg CreateSceneParams) (Scene, error)
 	CreateSceneEdit(ctx context.Context, arg CreateSceneEditParams) error
@@ -88,36 +111,58 @@ type Querier interface {
 	CreateTagRedirect(ctx context.Context, arg CreateTagRedirectParams) error
 	// User queries
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserCustomRoles(ctx context.Context, arg CreateUserCustomRolesParams) error
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error
 	// User notification subscriptions
 	CreateUserNotificationSubscriptions(ctx context.Context, arg []CreateUserNotificationSubscriptionsParams) (int64, error)
+	CreateUserRecoveryCodes(ctx context.Context, arg CreateUserRecoveryCodesParams) error
 	// User roles
 	CreateUserRoles(ctx context.Context, arg []CreateUserRolesParams) (int64, error)
+	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) error
+	CreateUserSuspension(ctx context.Context, arg CreateUserSuspensionParams) (UserSuspension, error)
+	CreateUserSuspensionNotification(ctx context.Context, arg CreateUserSuspensionNotificationParams) error
+	// Starts a new setup, replacing a previous one that was never confirmed
+	CreateUserTOTP(ctx context.Context, arg CreateUserTOTPParams) error
 	// User token queries
 	CreateUserToken(ctx context.Context, arg CreateUserTokenParams) (UserToken, error)
 	DeleteAllSceneFingerprintSubmissions(ctx context.Context, arg DeleteAllSceneFingerprintSubmissionsParams) (int64, error)
 	DeleteDraft(ctx context.Context, id uuid.UUID) error
 	DeleteEdit(ctx context.Context, id uuid.UUID) error
+	DeleteEditCommentReaction(ctx context.Context, arg DeleteEditCommentReactionParams) error
+	DeleteEditConflicts(ctx context.Context, editID uuid.UUID) error
+	DeleteEntityLock(ctx context.Context, id uuid.UUID) error
 	DeleteExpiredDrafts(ctx context.Context, dollar_1 interface{}) error
+	DeleteExpiredLockouts(ctx context.Context, windowStart time.Time) error
 	DeleteExpiredModAudits(ctx context.Context, dollar_1 interface{}) error
+	DeleteExpiredUserSessions(ctx context.Context) error
 	DeleteExpiredUserTokens(ctx context.Context) error
 	DeleteImage(ctx context.Context, id uuid.UUID) error
 	DeleteInviteKey(ctx context.Context, id uuid.UUID) error
+	DeleteInviteKeysForUsers(ctx context.Context, dollar_1 []uuid.UUID) (int64, error)
+	DeleteLockout(ctx context.Context, arg DeleteLockoutParams) error
 	DeleteNotificationsByEditComments(ctx context.Context, editID uuid.UUID) error
 	DeleteNotificationsByTargetID(ctx context.Context, id uuid.UUID) error
 	DeletePerformer(ctx context.Context, id uuid.UUID) error
 	// Performer aliases
 	DeletePerformerAliases(ctx context.Context, performerID uuid.UUID) error
+	DeletePerformerCareerPeriods(ctx context.Context, performerID uuid.UUID) error
 	DeletePerformerFavorite(ctx context.Context, arg DeletePerformerFavoriteParams) error
 	// Performer favorites
 	DeletePerformerFavorites(ctx context.Context, performerID uuid.UUID) error
 	DeletePerformerImages(ctx context.Context, performerID uuid.UUID) error
 	// Performer piercings
 	DeletePerformerPiercings(ctx context.Context, performerID uuid.UUID) error
+	// Delete a relationship in both directions
+	DeletePerformerRelationship(ctx context.Context, arg DeletePerformerRelationshipParams) error
+	// Delete all relationships of a performer in both directions
+	DeletePerformerRelationships(ctx context.Context, performerID uuid.UUID) error
 	DeletePerformerScenes(ctx context.Context, performerID uuid.UUID) error
 	// Performer tattoos
 	DeletePerformerTattoos(ctx context.Context, performerID uuid.UUID) error
 	// Performer URLs
 	DeletePerformerURLs(ctx context.Context, performerID uuid.UUID) error
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	DeleteSavedSearch(ctx context.Context, id uuid.UUID) error
 	DeleteScene(ctx context.Context, id uuid.UUID) error
 	DeleteSceneFingerprint(ctx context.Context, arg DeleteSceneFingerprintParams) error
 	DeleteSceneFingerprintsByScene(ctx context.Context, sceneID uuid.UUID) error
@@ -141,18 +186,34 @@ type Querier interface {
 	DeleteTagAliasesByNames(ctx context.Context, arg DeleteTagAliasesByNamesParams) error
 	DeleteTagCategory(ctx context.Context, id uuid.UUID) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteUserCustomRoles(ctx context.Context, userID uuid.UUID) error
 	DeleteUserNotificationSubscriptions(ctx context.Context, userID uuid.UUID) error
+	DeleteUserRecoveryCodes(ctx context.Context, userID uuid.UUID) error
 	DeleteUserRoles(ctx context.Context, userID uuid.UUID) error
+	DeleteUserSession(ctx context.Context, id uuid.UUID) error
+	// Logs out all sessions of a user, except the session making the request
+	DeleteUserSessions(ctx context.Context, arg DeleteUserSessionsParams) (int64, error)
+	DeleteUserTOTP(ctx context.Context, userID uuid.UUID) error
 	DeleteUserToken(ctx context.Context, id uuid.UUID) error
+	DequeueEditForModerator(ctx context.Context, editID uuid.UUID) error
 	DestroyExpiredInvites(ctx context.Context) error
 	DestroyExpiredNotifications(ctx context.Context) error
+	EnableUserTOTP(ctx context.Context, userID uuid.UUID) error
 	// The pg-spgist_hamming custom-scan hook turns this UNNEST + <@ into a single
 	// batch BK-tree traversal when ≤64 hashes are supplied; caller must chunk.
 	// The scene_id join is intentionally NOT here: the planner overestimates the
 	// customscan's row count and picks a hash-join + seq scan of scene_fingerprints.
 	ExpandPhashNeighbors(ctx context.Context, arg ExpandPhashNeighborsParams) ([]ExpandPhashNeighborsRow, error)
 	ExpandSceneCoMembers(ctx context.Context, sceneIds []uuid.UUID) ([]ExpandSceneCoMembersRow, error)
+	ExportUserEditComments(ctx context.Context, userID uuid.NullUUID) ([]EditComment, error)
+	ExportUserEditVotes(ctx context.Context, userID uuid.NullUUID) ([]EditVote, error)
+	ExportUserEdits(ctx context.Context, userID uuid.NullUUID) ([]Edit, error)
+	ExportUserNotifications(ctx context.Context, userID uuid.UUID) ([]Notification, error)
+	ExportUserPerformerFavorites(ctx context.Context, userID uuid.UUID) ([]PerformerFavorite, error)
+	ExportUserSceneFingerprints(ctx context.Context, userID uuid.UUID) ([]ExportUserSceneFingerprintsRow, error)
+	ExportUserStudioFavorites(ctx context.Context, userID uuid.UUID) ([]StudioFavorite, error)
 	FindActiveInviteKeysForUser(ctx context.Context, generatedBy uuid.UUID) ([]InviteKey, error)
+	FindActiveUserSuspension(ctx context.Context, userID uuid.UUID) (UserSuspension, error)
 	// Returns pending edits that fulfill one of the criteria for being closed:
 	// * The full voting period has passed
 	// * The minimum voting period has passed, and the number of votes has crossed the voting threshold.
@@ -162,6 +223,9 @@ type Querier interface {
 	FindDraftsByUser(ctx context.Context, userID uuid.UUID) ([]Draft, error)
 	FindEdit(ctx context.Context, id uuid.UUID) (Edit, error)
 	FindEditComment(ctx context.Context, id uuid.UUID) (EditComment, error)
+	FindEditGroup(ctx context.Context, id uuid.UUID) (EditGroup, error)
+	FindEditGroupByEdit(ctx context.Context, editID uuid.UUID) (EditGroup, error)
+	FindEntityLock(ctx context.Context, id uuid.UUID) (EntityLock, error)
 	FindExistingPerformers(ctx context.Context, arg FindExistingPerformersParams) ([]Performer, error)
 	FindExistingScenes(ctx context.Context, arg FindExistingScenesParams) ([]Scene, error)
 	FindImage(ctx context.Context, id uuid.UUID) (Image, error)
@@ -173,12 +237,19 @@ type Querier interface {
 	FindImagesBySceneID(ctx context.Context, id uuid.UUID) ([]Image, error)
 	FindImagesByStudioID(ctx context.Context, id uuid.UUID) ([]Image, error)
 	FindInviteKey(ctx context.Context, id uuid.UUID) (InviteKey, error)
+	FindLockout(ctx context.Context, arg FindLockoutParams) (Lockout, error)
 	// Find merge target IDs for performers (for merges where these are sources)
 	FindMergeIDsByPerformerIds(ctx context.Context, performerIds []uuid.UUID) ([]FindMergeIDsByPerformerIdsRow, error)
 	// Find merge source IDs for performers (for merges where these are targets)
 	FindMergeIDsBySourcePerformerIds(ctx context.Context, performerIds []uuid.UUID) ([]FindMergeIDsBySourcePerformerIdsRow, error)
+	FindNotifiableSavedSearches(ctx context.Context) ([]SavedSearch, error)
+	// Users with pending notifications of the given frequency whose previous
+	// email of that frequency was sent before sent_before
+	FindNotificationEmailRecipients(ctx context.Context, arg FindNotificationEmailRecipientsParams) ([]uuid.UUID, error)
 	// Notification queries
 	FindNotificationsByUser(ctx context.Context, arg FindNotificationsByUserParams) ([]Notification, error)
+	// Pending edits, other than the given edit, targeting or merging any of the entities
+	FindPendingEditsForEntities(ctx context.Context, arg FindPendingEditsForEntitiesParams) ([]Edit, error)
 	FindPendingPerformerCreation(ctx context.Context, arg FindPendingPerformerCreationParams) ([]Edit, error)
 	FindPendingSceneCreation(ctx context.Context, arg FindPendingSceneCreationParams) ([]Edit, error)
 	FindPerformer(ctx context.Context, id uuid.UUID) (Performer, error)
@@ -197,6 +268,10 @@ type Querier interface {
 	FindPerformerWithRedirect(ctx context.Context, id uuid.UUID) ([]Performer, error)
 	FindPerformersByIds(ctx context.Context, dollar_1 []uuid.UUID) ([]Performer, error)
 	FindPerformersByURL(ctx context.Context, arg FindPerformersByURLParams) ([]Performer, error)
+	FindRole(ctx context.Context, id uuid.UUID) (Role, error)
+	FindRoleByName(ctx context.Context, lower string) (Role, error)
+	FindSavedSearch(ctx context.Context, id uuid.UUID) (SavedSearch, error)
+	FindSavedSearchesByUser(ctx context.Context, userID uuid.UUID) ([]SavedSearch, error)
 	FindScene(ctx context.Context, id uuid.UUID) (Scene, error)
 	// Get performer appearances for multiple scenes
 	FindSceneAppearancesByIds(ctx context.Context, sceneIds []uuid.UUID) ([]FindSceneAppearancesByIdsRow, error)
@@ -232,25 +307,43 @@ type Querier interface {
 	FindTagsBySceneID(ctx context.Context, sceneID uuid.UUID) ([]Tag, error)
 	FindUnreadNotificationsByUser(ctx context.Context, arg FindUnreadNotificationsByUserParams) ([]Notification, error)
 	FindUnusedImages(ctx context.Context) ([]Image, error)
+	// Scenes with a full release date in the given range featuring a favorite performer or studio of the user
+	FindUpcomingFavoriteScenes(ctx context.Context, arg FindUpcomingFavoriteScenesParams) ([]Scene, error)
 	FindUser(ctx context.Context, id uuid.UUID) (User, error)
 	FindUserByEmail(ctx context.Context, upper interface{}) (User, error)
 	FindUserByName(ctx context.Context, name string) (User, error)
+	FindUserIdentity(ctx context.Context, arg FindUserIdentityParams) (UserIdentity, error)
+	FindUserSession(ctx context.Context, id uuid.UUID) (UserSession, error)
+	FindUserSuspension(ctx context.Context, id uuid.UUID) (UserSuspension, error)
+	FindUserTOTP(ctx context.Context, userID uuid.UUID) (UserTotp, error)
 	FindUserToken(ctx context.Context, id uuid.UUID) (UserToken, error)
 	FindUserTokensByEmail(ctx context.Context, dollar_1 string) ([]UserToken, error)
 	FindUserTokensByInviteKey(ctx context.Context, dollar_1 uuid.UUID) ([]UserToken, error)
 	FindUserWithRoles(ctx context.Context, id uuid.UUID) (FindUserWithRolesRow, error)
+	FindUsersByNames(ctx context.Context, names []string) ([]FindUsersByNamesRow, error)
+	// Locks that have not expired on any of the entities
+	GetActiveEntityLocks(ctx context.Context, dollar_1 []uuid.UUID) ([]EntityLock, error)
 	// Get all fingerprints for multiple scenes with aggregated vote data
 	// When onlySubmitted is true, pass the actual user ID, when false pass NULL
 	GetAllFingerprints(ctx context.Context, arg GetAllFingerprintsParams) ([]GetAllFingerprintsRow, error)
+	GetAllRoles(ctx context.Context) ([]Role, error)
 	GetAllSceneFingerprints(ctx context.Context, sceneID uuid.UUID) ([]GetAllSceneFingerprintsRow, error)
 	GetAllSiteCategories(ctx context.Context) ([]SiteCategory, error)
 	GetAllTagCategories(ctx context.Context) ([]TagCategory, error)
 	GetChildStudios(ctx context.Context, parentStudioID uuid.NullUUID) ([]Studio, error)
+	GetConflictingEdits(ctx context.Context, editID uuid.UUID) ([]Edit, error)
+	GetEditCommentMentions(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error)
+	// Reactions to a comment with their counts, in the order they were first used
+	GetEditCommentReactions(ctx context.Context, arg GetEditCommentReactionsParams) ([]GetEditCommentReactionsRow, error)
 	GetEditComments(ctx context.Context, editID uuid.UUID) ([]EditComment, error)
 	GetEditCommentsByIds(ctx context.Context, dollar_1 []uuid.UUID) ([]EditComment, error)
+	GetEditGroupEdits(ctx context.Context, groupID uuid.UUID) ([]Edit, error)
 	GetEditPerformerAliases(ctx context.Context, id uuid.UUID) ([]string, error)
+	GetEditPerformerCareerPeriods(ctx context.Context, id uuid.UUID) ([]GetEditPerformerCareerPeriodsRow, error)
 	GetEditPerformerPiercings(ctx context.Context, id uuid.UUID) ([]GetEditPerformerPiercingsRow, error)
 	GetEditPerformerTattoos(ctx context.Context, id uuid.UUID) ([]GetEditPerformerTattoosRow, error)
+	GetEditRebasedFrom(ctx context.Context, editID uuid.UUID) (Edit, error)
+	GetEditRebasedTo(ctx context.Context, rebasedFromID uuid.UUID) (Edit, error)
 	GetEditTargetID(ctx context.Context, id uuid.UUID) (GetEditTargetIDRow, error)
 	GetEditVotes(ctx context.Context, editID uuid.UUID) ([]EditVote, error)
 	GetEditsByIds(ctx context.Context, dollar_1 []uuid.UUID) ([]Edit, error)
@@ -261,6 +354,12 @@ type Querier interface {
 	GetFingerprint(ctx context.Context, arg GetFingerprintParams) (Fingerprint, error)
 	// Gets current images for target entity and merges with edit's added_images/removed_images
 	GetImagesForEdit(ctx context.Context, id uuid.UUID) ([]Image, error)
+	// The user and every user invited by them, directly or indirectly, in
+	// depth-first order. depth is the number of invites between the user and
+	// the root. Bot edits are not counted.
+	GetInviteTree(ctx context.Context, id uuid.UUID) ([]GetInviteTreeRow, error)
+	// Gets current relationships for target performer and merges with edit's added_relationships/removed_relationships
+	GetMergedPerformerRelationshipsForEdit(ctx context.Context, id uuid.UUID) ([]GetMergedPerformerRelationshipsForEditRow, error)
 	// Gets current performers for target entity and merges with edit's added_performers/removed_performers
 	GetMergedPerformersForEdit(ctx context.Context, id uuid.UUID) ([]GetMergedPerformersForEditRow, error)
 	// Gets current aliases for target studio entity and merges with edit's added_aliases/removed_aliases
@@ -276,12 +375,21 @@ type Querier interface {
 	GetModAuditCount(ctx context.Context, arg GetModAuditCountParams) (int64, error)
 	GetPerformerAliases(ctx context.Context, performerID uuid.UUID) ([]string, error)
 	// Performer images
+	GetPerformerCareerPeriods(ctx context.Context, performerID uuid.UUID) ([]PerformerCareerPeriod, error)
+	// Get the distinct co-appearance links for a set of performers
+	GetPerformerCostarLinks(ctx context.Context, arg GetPerformerCostarLinksParams) ([]GetPerformerCostarLinksRow, error)
+	// Get performers sharing scenes with a performer, with shared scene counts and date range
+	GetPerformerCostars(ctx context.Context, arg GetPerformerCostarsParams) ([]GetPerformerCostarsRow, error)
 	GetPerformerImages(ctx context.Context, performerID uuid.UUID) ([]Image, error)
 	GetPerformerPiercings(ctx context.Context, performerID uuid.UUID) ([]GetPerformerPiercingsRow, error)
+	GetPerformerRelationships(ctx context.Context, performerID uuid.UUID) ([]PerformerRelationship, error)
 	GetPerformerSearchFacets(ctx context.Context, arg GetPerformerSearchFacetsParams) (interface{}, error)
+	GetPerformerSearchFacetsPostgres(ctx context.Context, arg GetPerformerSearchFacetsPostgresParams) ([]GetPerformerSearchFacetsPostgresRow, error)
 	GetPerformerTattoos(ctx context.Context, performerID uuid.UUID) ([]GetPerformerTattoosRow, error)
 	GetPerformerURLs(ctx context.Context, performerID uuid.UUID) ([]GetPerformerURLsRow, error)
 	GetPrimaryEditCommentID(ctx context.Context, editID uuid.UUID) (uuid.UUID, error)
+	GetRoleUserIDs(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error)
+	GetRolesByIds(ctx context.Context, dollar_1 []uuid.UUID) ([]Role, error)
 	GetSceneFingerprintScenes(ctx context.Context, fingerprintIds []int) ([]GetSceneFingerprintScenesRow, error)
 	GetScenePerformers(ctx context.Context, sceneID uuid.UUID) ([]GetScenePerformersRow, error)
 	GetScenePhashSeeds(ctx context.Context, sceneID uuid.UUID) ([]GetScenePhashSeedsRow, error)
@@ -292,6 +400,10 @@ type Querier interface {
 	GetSiteCategoriesByIds(ctx context.Context, dollar_1 []int) ([]SiteCategory, error)
 	GetStudioAliases(ctx context.Context, studioID uuid.UUID) ([]string, error)
 	GetStudioImages(ctx context.Context, studioID uuid.UUID) ([]uuid.UUID, error)
+	// Get the ids of a studio and every studio below it in the network, at any depth
+	GetStudioNetworkIDs(ctx context.Context, studioID uuid.UUID) ([]uuid.UUID, error)
+	// Aggregate scene and performer statistics across a studio and all of its descendants
+	GetStudioNetworkStats(ctx context.Context, studioID uuid.UUID) (GetStudioNetworkStatsRow, error)
 	GetStudioURLs(ctx context.Context, studioID uuid.UUID) ([]StudioUrl, error)
 	GetStudios(ctx context.Context, dollar_1 []uuid.UUID) ([]Studio, error)
 	GetStudiosByPerformer(ctx context.Context, performerID uuid.UUID) ([]GetStudiosByPerformerRow, error)
@@ -299,31 +411,69 @@ type Querier interface {
 	GetStudiosByPerformerAndNetwork(ctx context.Context, arg GetStudiosByPerformerAndNetworkParams) ([]GetStudiosByPerformerAndNetworkRow, error)
 	GetTagAliases(ctx context.Context, tagID uuid.UUID) ([]string, error)
 	GetTagCategoriesByIds(ctx context.Context, dollar_1 []uuid.UUID) ([]TagCategory, error)
+	// Permissions granted to a user by their custom roles
+	GetUserCustomPermissions(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetUserCustomRoles(ctx context.Context, userID uuid.UUID) ([]Role, error)
+	GetUserNotificationEmailPreferences(ctx context.Context, userID uuid.UUID) ([]UserNotificationEmail, error)
 	GetUserNotificationSubscriptions(ctx context.Context, userID uuid.UUID) ([]NotificationType, error)
+	// Reputation inputs of a single user, or of every user with any edit, vote
+	// or fingerprint report when user_id is null. Bot edits, failed edits and
+	// fingerprint reports no other user has voted on are not counted.
+	GetUserReputationStats(ctx context.Context, userID uuid.NullUUID) ([]GetUserReputationStatsRow, error)
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetUserSessions(ctx context.Context, userID uuid.NullUUID) ([]UserSession, error)
+	GetUserSuspensions(ctx context.Context, userID uuid.UUID) ([]UserSuspension, error)
 	GetUsers(ctx context.Context, dollar_1 []uuid.UUID) ([]User, error)
 	InviteKeyUsed(ctx context.Context, id uuid.UUID) (*int, error)
 	IsImageUnused(ctx context.Context, id uuid.UUID) (bool, error)
+	LiftExpiredUserSuspensions(ctx context.Context) ([]UserSuspension, error)
+	// Lifts every suspension of a user that has not been lifted yet, including
+	// expired ones the cron job has not processed.
+	LiftUserSuspensions(ctx context.Context, arg LiftUserSuspensionsParams) ([]UserSuspension, error)
 	LoadClusterSubmissions(ctx context.Context, fingerprintIds []int) ([]LoadClusterSubmissionsRow, error)
 	LoadLinkedOshashSubmissions(ctx context.Context, phashFingerprintIds []int) ([]LoadLinkedOshashSubmissionsRow, error)
 	MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error
+	MarkNotificationDigestSent(ctx context.Context, arg MarkNotificationDigestSentParams) error
 	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) error
 	MoveSceneFingerprintSubmissions(ctx context.Context, arg MoveSceneFingerprintSubmissionsParams) ([]uuid.UUID, error)
 	// Prepare a fingerprint move by dropping reports and dupe fingerprint submissions
 	PruneSceneFingerprintsForMove(ctx context.Context, arg PruneSceneFingerprintsForMoveParams) ([]PruneSceneFingerprintsForMoveRow, error)
+	QueryLockouts(ctx context.Context, arg QueryLockoutsParams) ([]Lockout, error)
 	QueryModAudits(ctx context.Context, arg QueryModAuditsParams) ([]ModAudit, error)
+	QueueEditForModerator(ctx context.Context, editID uuid.UUID) (int64, error)
 	ReassignPerformerAliases(ctx context.Context, arg ReassignPerformerAliasesParams) error
 	ReassignPerformerFavorites(ctx context.Context, arg ReassignPerformerFavoritesParams) error
+	// Copy the relationships of a merge source to its target, in both directions
+	ReassignPerformerRelationships(ctx context.Context, arg ReassignPerformerRelationshipsParams) error
 	ReassignStudioFavorites(ctx context.Context, arg ReassignStudioFavoritesParams) error
 	// Reassign to the sentinel user only the deleted user's scene fingerprints that are unique
 	ReassignUniqueSceneFingerprints(ctx context.Context, arg ReassignUniqueSceneFingerprintsParams) error
+	// Counts an attempt, restarting the count if there was no attempt since
+	// window_start
+	RecordLockoutAttempt(ctx context.Context, arg RecordLockoutAttemptParams) (Lockout, error)
 	ResetVotes(ctx context.Context, editID uuid.UUID) error
 	// Resolves a set of UUIDs to the type of entity they belong to, used to turn
 	// bare UUIDs in comments into links.
 	ResolveEntityTypes(ctx context.Context, ids []uuid.UUID) ([]ResolveEntityTypesRow, error)
+	// Same match as SearchPerformers, returning the score and the indexed
+	// name fields so that callers can report which field matched.
+	SearchPerformerHits(ctx context.Context, arg SearchPerformerHitsParams) ([]SearchPerformerHitsRow, error)
+	// search_backend: postgres equivalent of SearchPerformerHits.
+	SearchPerformerHitsPostgres(ctx context.Context, arg SearchPerformerHitsPostgresParams) ([]SearchPerformerHitsPostgresRow, error)
 	// Keep the WHERE clause in sync across SearchPerformers, CountPerformerSearchMatches,
 	// and GetPerformerSearchFacets so paging, counts, and facets stay consistent.
 	SearchPerformers(ctx context.Context, arg SearchPerformersParams) ([]uuid.UUID, error)
+	// search_backend: postgres equivalent of SearchPerformers. The query is a
+	// to_tsquery expression built from the search term; the raw term is used to
+	// rank names that closely match the whole term first.
+	// Keep the WHERE clause in sync across SearchPerformersPostgres,
+	// CountPerformerSearchMatchesPostgres and GetPerformerSearchFacetsPostgres.
+	SearchPerformersPostgres(ctx context.Context, arg SearchPerformersPostgresParams) ([]uuid.UUID, error)
+	// Same match as SearchScenes, returning the score and the indexed fields.
+	SearchSceneHits(ctx context.Context, arg SearchSceneHitsParams) ([]SearchSceneHitsRow, error)
+	// search_backend: postgres equivalent of SearchSceneHits, scored like
+	// SearchScenesPostgres.
+	SearchSceneHitsPostgres(ctx context.Context, arg SearchSceneHitsPostgresParams) ([]SearchSceneHitsPostgresRow, error)
 	// Token-at-a-time scoring. The search term is tokenized by the caller and
 	// passed as an array. Each token is scored independently against every field
 	// via disjunction_max, so a token that hits several fields (e.g. a studio named
@@ -339,9 +489,27 @@ type Querier interface {
 	// The 10000 constant must exceed the max achievable BM25 sum; search terms are
 	// short so the relevance total stays well under it.
 	SearchScenes(ctx context.Context, arg SearchScenesParams) ([]SearchScenesRow, error)
+	// search_backend: postgres equivalent of SearchScenes. Scenes are ranked by
+	// the number of distinct terms they match, then by ts_rank, mirroring the
+	// coverage tiers of the BM25 query.
+	SearchScenesPostgres(ctx context.Context, arg SearchScenesPostgresParams) ([]SearchScenesPostgresRow, error)
+	// Same match as SearchStudios, returning the score and the indexed fields.
+	SearchStudioHits(ctx context.Context, arg SearchStudioHitsParams) ([]SearchStudioHitsRow, error)
+	// search_backend: postgres equivalent of SearchStudioHits.
+	SearchStudioHitsPostgres(ctx context.Context, arg SearchStudioHitsPostgresParams) ([]SearchStudioHitsPostgresRow, error)
 	SearchStudios(ctx context.Context, arg SearchStudiosParams) ([]SearchStudiosRow, error)
+	// search_backend: postgres equivalent of SearchStudios.
+	SearchStudiosPostgres(ctx context.Context, arg SearchStudiosPostgresParams) ([]uuid.UUID, error)
+	// Same match as SearchTags, returning the score and the indexed fields.
+	SearchTagHits(ctx context.Context, arg SearchTagHitsParams) ([]SearchTagHitsRow, error)
+	// search_backend: postgres equivalent of SearchTagHits.
+	SearchTagHitsPostgres(ctx context.Context, arg SearchTagHitsPostgresParams) ([]SearchTagHitsPostgresRow, error)
 	SearchTags(ctx context.Context, arg SearchTagsParams) ([]Tag, error)
+	// search_backend: postgres equivalent of SearchTags. Trigram word similarity
+	// stands in for the fuzzy matching of the BM25 query.
+	SearchTagsPostgres(ctx context.Context, arg SearchTagsPostgresParams) ([]Tag, error)
 	SetEditCommentHidden(ctx context.Context, arg SetEditCommentHiddenParams) (EditComment, error)
+	SetLockoutUntil(ctx context.Context, arg SetLockoutUntilParams) error
 	SetScenePerformerAlias(ctx context.Context, arg SetScenePerformerAliasParams) error
 	SoftDeletePerformer(ctx context.Context, id uuid.UUID) (Performer, error)
 	SoftDeleteScene(ctx context.Context, id uuid.UUID) (Scene, error)
@@ -355,13 +523,18 @@ type Querier interface {
 	TriggerPerformerEditNotifications(ctx context.Context, id uuid.UUID) error
 	TriggerSceneCreationNotifications(ctx context.Context, id uuid.UUID) error
 	TriggerSceneEditNotifications(ctx context.Context, id uuid.UUID) error
+	// Notify users when a scene added ahead of its release date, featuring a favorite performer or studio, is released
+	TriggerSceneReleaseNotifications(ctx context.Context, releaseDate string) error
 	TriggerStudioEditNotifications(ctx context.Context, id uuid.UUID) error
 	TriggerUpdatedEditNotifications(ctx context.Context, id uuid.UUID) error
+	UnsubscribeUserNotificationEmails(ctx context.Context, userID uuid.UUID) error
 	UpdateEdit(ctx context.Context, arg UpdateEditParams) (Edit, error)
 	UpdateEditCommentText(ctx context.Context, arg UpdateEditCommentTextParams) (EditComment, error)
 	UpdateEditData(ctx context.Context, arg UpdateEditDataParams) (Edit, error)
 	UpdatePerformer(ctx context.Context, arg UpdatePerformerParams) (Performer, error)
 	UpdatePerformerRedirects(ctx context.Context, arg UpdatePerformerRedirectsParams) error
+	UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error)
+	UpdateSavedSearch(ctx context.Context, arg UpdateSavedSearchParams) (SavedSearch, error)
 	UpdateScene(ctx context.Context, arg UpdateSceneParams) (Scene, error)
 	UpdateSceneRedirects(ctx context.Context, arg UpdateSceneRedirectsParams) error
 	UpdateSceneStudios(ctx context.Context, arg UpdateSceneStudiosParams) error
@@ -378,6 +551,14 @@ type Querier interface {
 	UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) error
 	UpdateUserInviteTokenCount(ctx context.Context, arg UpdateUserInviteTokenCountParams) error
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	// Does not recreate a session that was revoked in the meantime
+	UpdateUserSession(ctx context.Context, arg UpdateUserSessionParams) (int64, error)
+	// Only notifications created after the frequency last changed are emailed
+	UpsertUserNotificationEmailPreference(ctx context.Context, arg UpsertUserNotificationEmailPreferenceParams) error
+	UseUserRecoveryCode(ctx context.Context, arg UseUserRecoveryCodeParams) (int64, error)
+	// Records the time step of a used code, failing if a code of the same or a
+	// later step has already been used.
+	UseUserTOTPStep(ctx context.Context, arg UseUserTOTPStepParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
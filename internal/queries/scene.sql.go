@@ -458,6 +458,70 @@ func (q *Queries) FindScenesByFullFingerprintsWithHash(ctx context.Context, arg
 	return items, nil
 }
 
+const findUpcomingFavoriteScenes = `-- name: FindUpcomingFavoriteScenes :many
+SELECT s.id, s.title, s.details, s.studio_id, s.created_at, s.updated_at, s.duration, s.director, s.deleted, s.code, s.date, s.production_date FROM scenes S
+WHERE S.deleted = FALSE
+  AND LENGTH(S.date) = 10
+  AND S.date > $1::text
+  AND S.date <= $2::text
+  AND (
+    S.studio_id IN (SELECT SF.studio_id FROM studio_favorites SF WHERE SF.user_id = $3)
+    OR S.id IN (
+      SELECT SP.scene_id FROM scene_performers SP
+      JOIN performer_favorites PF ON SP.performer_id = PF.performer_id
+      WHERE PF.user_id = $3
+    )
+  )
+ORDER BY S.date ASC, S.title ASC
+LIMIT $4
+`
+
+type FindUpcomingFavoriteScenesParams struct {
+	FromDate string    `db:"from_date" json:"from_date"`
+	ToDate   string    `db:"to_date" json:"to_date"`
+	UserID   uuid.UUID `db:"user_id" json:"user_id"`
+	Limit    int32     `db:"limit" json:"limit"`
+}
+
+// Scenes with a full release date in the given range featuring a favorite performer or studio of the user
+func (q *Queries) FindUpcomingFavoriteScenes(ctx context.Context, arg FindUpcomingFavoriteScenesParams) ([]Scene, error) {
+	rows, err := q.db.Query(ctx, findUpcomingFavoriteScenes,
+		arg.FromDate,
+		arg.ToDate,
+		arg.UserID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Scene{}
+	for rows.Next() {
+		var i Scene
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Details,
+			&i.StudioID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Duration,
+			&i.Director,
+			&i.Deleted,
+			&i.Code,
+			&i.Date,
+			&i.ProductionDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getScenePerformers = `-- name: GetScenePerformers :many
 SELECT p.id, p.name, p.disambiguation, p.gender, p.ethnicity, p.country, p.eye_color, p.hair_color, p.height, p.cup_size, p.band_size, p.hip_size, p.waist_size, p.breast_type, p.career_start_year, p.career_end_year, p.created_at, p.updated_at, p.deleted, p.birthdate, p.deathdate, "as" FROM scene_performers SP JOIN performers P ON SP.performer_id = P.id WHERE scene_id = $1
 `
@@ -658,6 +722,68 @@ func (q *Queries) SearchScenes(ctx context.Context, arg SearchScenesParams) ([]S
 	return items, nil
 }
 
+const searchScenesPostgres = `-- name: SearchScenesPostgres :many
+WITH matches AS (
+    SELECT
+        scene_id,
+        scene_search_document(
+            scene_title, scene_code, scene_date, studio_name, network_name,
+            studio_aliases, network_aliases, performer_names
+        ) AS document
+    FROM scene_search
+    WHERE scene_search_document(
+        scene_title, scene_code, scene_date, studio_name, network_name,
+        studio_aliases, network_aliases, performer_names
+    ) @@ to_tsquery('simple', array_to_string($1::TEXT[], ' | '))
+)
+SELECT
+    scene_id,
+    COUNT(*) OVER () AS total_count
+FROM matches
+ORDER BY
+    (SELECT COUNT(*) FROM unnest($1::TEXT[]) AS term WHERE document @@ to_tsquery('simple', term)) DESC,
+    ts_rank(document, to_tsquery('simple', array_to_string($1::TEXT[], ' | '))) DESC,
+    scene_id
+LIMIT $3 OFFSET $2
+`
+
+type SearchScenesPostgresParams struct {
+	Terms  []string `db:"terms" json:"terms"`
+	Offset int32    `db:"offset" json:"offset"`
+	Limit  int32    `db:"limit" json:"limit"`
+}
+
+type SearchScenesPostgresRow struct {
+	SceneID    uuid.UUID `db:"scene_id" json:"scene_id"`
+	TotalCount int64     `db:"total_count" json:"total_count"`
+}
+
+// search_backend: postgres equivalent of SearchScenes. Scenes are ranked by
+// the number of distinct terms they match, then by ts_rank, mirroring the
+// coverage tiers of the BM25 query.
+func (q *Queries) SearchScenesPostgres(ctx context.Context, arg SearchScenesPostgresParams) ([]SearchScenesPostgresRow, error) {
+	rows, err := q.db.Query(ctx, searchScenesPostgres, arg.Terms, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchScenesPostgresRow{}
+	for rows.Next() {
+		var i SearchScenesPostgresRow
+		if err := rows.Scan(
+			&i.SceneID,
+			&i.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const softDeleteScene = `-- name: SoftDeleteScene :one
 UPDATE scenes SET deleted = true, updated_at = NOW() WHERE id = $1
 RETURNING id, title, details, studio_id, created_at, updated_at, duration, director, deleted, code, date, production_date
@@ -85,8 +85,8 @@ ORDER BY e.created_at DESC;
 -- Edit comments
 
 -- name: CreateEditComment :one
-INSERT INTO edit_comments (id, edit_id, user_id, text, created_at)
-VALUES ($1, $2, $3, $4, NOW())
+INSERT INTO edit_comments (id, edit_id, user_id, text, parent_id, created_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
 RETURNING *;
 
 -- name: GetEditComments :many
@@ -104,6 +104,29 @@ UPDATE edit_comments SET text = $2, updated_at = NOW() WHERE id = $1 RETURNING *
 -- name: SetEditCommentHidden :one
 UPDATE edit_comments SET is_hidden = $2 WHERE id = $1 RETURNING *;
 
+-- name: CreateEditCommentMentions :exec
+INSERT INTO edit_comment_mentions (comment_id, user_id)
+SELECT sqlc.arg(comment_id), unnest(sqlc.arg(user_ids)::uuid[])
+ON CONFLICT DO NOTHING;
+
+-- name: GetEditCommentMentions :many
+SELECT user_id FROM edit_comment_mentions WHERE comment_id = $1;
+
+-- name: AddEditCommentReaction :exec
+INSERT INTO edit_comment_reactions (comment_id, user_id, reaction) VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteEditCommentReaction :exec
+DELETE FROM edit_comment_reactions WHERE comment_id = $1 AND user_id = $2 AND reaction = $3;
+
+-- name: GetEditCommentReactions :many
+-- Reactions to a comment with their counts, in the order they were first used
+SELECT reaction, COUNT(*)::int AS count, BOOL_OR(user_id = sqlc.arg(user_id)) AS reacted
+FROM edit_comment_reactions
+WHERE comment_id = sqlc.arg(comment_id)
+GROUP BY reaction
+ORDER BY MIN(created_at);
+
 -- Edit votes
 
 -- name: CreateEditVote :exec
@@ -289,6 +312,42 @@ final_piercings AS (
 )
 SELECT DISTINCT location, description FROM final_piercings;
 
+-- name: GetEditPerformerCareerPeriods :many
+WITH edit AS (
+  SELECT * FROM edits WHERE id = $1
+),
+current_periods AS (
+    SELECT start_date, end_date, studio_id, note
+    FROM edit E
+    JOIN performer_edits PE ON E.id = PE.edit_id
+    JOIN performer_career_periods PCP ON PE.performer_id = PCP.performer_id
+),
+removed_periods AS (
+    SELECT
+        elem->>'start_date' AS start_date,
+        elem->>'end_date' AS end_date,
+        (elem->>'studio_id')::uuid AS studio_id,
+        elem->>'note' AS note
+    FROM edit, jsonb_array_elements(COALESCE(data->'new_data'->'removed_career_periods', '[]'::jsonb)) AS elem
+),
+added_periods AS (
+    SELECT
+        elem->>'start_date' AS start_date,
+        elem->>'end_date' AS end_date,
+        (elem->>'studio_id')::uuid AS studio_id,
+        elem->>'note' AS note
+    FROM edit, jsonb_array_elements(COALESCE(data->'new_data'->'added_career_periods', '[]'::jsonb)) AS elem
+),
+final_periods AS (
+    SELECT * FROM current_periods
+    EXCEPT
+    SELECT * FROM removed_periods
+    UNION
+    SELECT * FROM added_periods
+)
+SELECT start_date, end_date, studio_id, note FROM final_periods
+ORDER BY start_date;
+
 -- name: GetMergedTagsForEdit :many
 -- Gets current tags for target entity and merges with edit's added_tags/removed_tags
 WITH edit AS (
@@ -319,6 +378,40 @@ JOIN tags t ON ft.tag_id = t.id
 WHERE t.deleted = FALSE
 ORDER BY t.name;
 
+-- name: GetMergedPerformerRelationshipsForEdit :many
+-- Gets current relationships for target performer and merges with edit's added_relationships/removed_relationships
+WITH edit AS (
+  SELECT * FROM edits WHERE edits.id = $1
+), current_relationships AS (
+    SELECT pr.related_performer_id AS performer_id, pr.type FROM edit e
+    JOIN performer_edits pe ON e.id = pe.edit_id
+    JOIN performer_relationships pr ON pe.performer_id = pr.performer_id
+    WHERE e.target_type = 'PERFORMER'
+),
+removed_relationships AS (
+    SELECT
+        (elem->>'performer_id')::uuid AS performer_id,
+        elem->>'type' AS type
+    FROM edit, jsonb_array_elements(COALESCE(data->'new_data'->'removed_relationships', '[]'::jsonb)) AS elem
+),
+added_relationships AS (
+    SELECT
+        (elem->>'performer_id')::uuid AS performer_id,
+        elem->>'type' AS type
+    FROM edit, jsonb_array_elements(COALESCE(data->'new_data'->'added_relationships', '[]'::jsonb)) AS elem
+),
+final_relationships AS (
+    SELECT performer_id, type FROM current_relationships
+    EXCEPT
+    SELECT performer_id, type FROM removed_relationships
+    UNION
+    SELECT performer_id, type FROM added_relationships
+)
+SELECT sqlc.embed(p), fr.type FROM final_relationships fr
+JOIN performers p ON fr.performer_id = p.id
+WHERE p.deleted = FALSE
+ORDER BY fr.type, p.name;
+
 -- name: GetMergedPerformersForEdit :many
 -- Gets current performers for target entity and merges with edit's added_performers/removed_performers
 WITH edit AS (
@@ -426,3 +519,53 @@ UNION ALL
 SELECT id, 'STUDIO'::TEXT FROM studios WHERE id = ANY(sqlc.arg(ids)::UUID[])
 UNION ALL
 SELECT id, 'TAG'::TEXT FROM tags WHERE id = ANY(sqlc.arg(ids)::UUID[]);
+
+-- name: FindPendingEditsForEntities :many
+-- Pending edits, other than the given edit, targeting or merging any of the entities
+SELECT * FROM edits E
+WHERE E.status = 'PENDING' AND E.id != sqlc.arg(edit_id) AND (
+    E.id IN (
+        SELECT edit_id FROM performer_edits WHERE performer_id = ANY(sqlc.arg(entity_ids)::UUID[])
+        UNION SELECT edit_id FROM scene_edits WHERE scene_id = ANY(sqlc.arg(entity_ids)::UUID[])
+        UNION SELECT edit_id FROM studio_edits WHERE studio_id = ANY(sqlc.arg(entity_ids)::UUID[])
+        UNION SELECT edit_id FROM tag_edits WHERE tag_id = ANY(sqlc.arg(entity_ids)::UUID[])
+    )
+    OR E.data->'merge_sources' ?| sqlc.arg(entity_ids)::UUID[]::TEXT[]
+)
+ORDER BY E.created_at ASC;
+
+-- name: CreateEditConflicts :exec
+INSERT INTO edit_conflicts (edit_id, conflicting_edit_id)
+SELECT sqlc.arg(edit_id)::UUID, UNNEST(sqlc.arg(conflicting_edit_ids)::UUID[])
+UNION ALL
+SELECT UNNEST(sqlc.arg(conflicting_edit_ids)::UUID[]), sqlc.arg(edit_id)::UUID
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteEditConflicts :exec
+DELETE FROM edit_conflicts WHERE edit_id = $1 OR conflicting_edit_id = $1;
+
+-- name: GetConflictingEdits :many
+SELECT E.* FROM edits E
+JOIN edit_conflicts C ON C.conflicting_edit_id = E.id
+WHERE C.edit_id = $1
+ORDER BY E.created_at ASC;
+
+-- name: QueueEditForModerator :execrows
+INSERT INTO edit_moderator_queue (edit_id) VALUES ($1)
+ON CONFLICT DO NOTHING;
+
+-- name: DequeueEditForModerator :exec
+DELETE FROM edit_moderator_queue WHERE edit_id = $1;
+
+-- name: CreateEditRebase :exec
+INSERT INTO edit_rebases (edit_id, rebased_from_id) VALUES ($1, $2);
+
+-- name: GetEditRebasedFrom :one
+SELECT E.* FROM edits E
+JOIN edit_rebases R ON R.rebased_from_id = E.id
+WHERE R.edit_id = $1;
+
+-- name: GetEditRebasedTo :one
+SELECT E.* FROM edits E
+JOIN edit_rebases R ON R.edit_id = E.id
+WHERE R.rebased_from_id = $1;
@@ -0,0 +1,24 @@
+-- Edit group queries
+
+-- name: CreateEditGroup :one
+INSERT INTO edit_groups (id, user_id, target_type, created_at)
+VALUES ($1, $2, $3, NOW())
+RETURNING *;
+
+-- name: CreateEditGroupEdits :exec
+INSERT INTO edit_group_edits (group_id, edit_id)
+SELECT $1, UNNEST(sqlc.arg(edit_ids)::UUID[]);
+
+-- name: FindEditGroup :one
+SELECT * FROM edit_groups WHERE id = $1;
+
+-- name: FindEditGroupByEdit :one
+SELECT G.* FROM edit_groups G
+JOIN edit_group_edits GE ON GE.group_id = G.id
+WHERE GE.edit_id = $1;
+
+-- name: GetEditGroupEdits :many
+SELECT E.* FROM edits E
+JOIN edit_group_edits GE ON GE.edit_id = E.id
+WHERE GE.group_id = $1
+ORDER BY E.created_at ASC, E.id ASC;
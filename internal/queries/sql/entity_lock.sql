@@ -0,0 +1,18 @@
+-- Entity lock queries
+
+-- name: CreateEntityLock :one
+INSERT INTO entity_locks (id, target_type, target_id, fields, level, reason, created_by, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+RETURNING *;
+
+-- name: FindEntityLock :one
+SELECT * FROM entity_locks WHERE id = $1;
+
+-- name: DeleteEntityLock :exec
+DELETE FROM entity_locks WHERE id = $1;
+
+-- name: GetActiveEntityLocks :many
+-- Locks that have not expired on any of the entities
+SELECT * FROM entity_locks
+WHERE target_id = ANY($1::UUID[]) AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY created_at ASC;
@@ -31,3 +31,6 @@ RETURNING uses;
 
 -- name: DestroyExpiredInvites :exec
 DELETE FROM invite_keys WHERE expire_time IS NOT NULL AND expire_time < NOW();
+
+-- name: DeleteInviteKeysForUsers :execrows
+DELETE FROM invite_keys WHERE generated_by = ANY($1::UUID[]);
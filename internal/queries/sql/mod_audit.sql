@@ -8,12 +8,20 @@ RETURNING *;
 -- name: GetModAuditCount :one
 SELECT COUNT(*) FROM mod_audit
 WHERE (sqlc.narg('action')::mod_audit_action IS NULL OR action = sqlc.narg('action'))
-  AND (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'));
+  AND (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'))
+  AND (sqlc.narg('target_type')::text IS NULL OR target_type = sqlc.narg('target_type'))
+  AND (sqlc.narg('target_id')::uuid IS NULL OR target_id = sqlc.narg('target_id'))
+  AND (sqlc.narg('start_date')::timestamp IS NULL OR created_at >= sqlc.narg('start_date'))
+  AND (sqlc.narg('end_date')::timestamp IS NULL OR created_at < sqlc.narg('end_date'));
 
 -- name: QueryModAudits :many
 SELECT * FROM mod_audit
 WHERE (sqlc.narg('action')::mod_audit_action IS NULL OR action = sqlc.narg('action'))
   AND (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'))
+  AND (sqlc.narg('target_type')::text IS NULL OR target_type = sqlc.narg('target_type'))
+  AND (sqlc.narg('target_id')::uuid IS NULL OR target_id = sqlc.narg('target_id'))
+  AND (sqlc.narg('start_date')::timestamp IS NULL OR created_at >= sqlc.narg('start_date'))
+  AND (sqlc.narg('end_date')::timestamp IS NULL OR created_at < sqlc.narg('end_date'))
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
@@ -147,9 +147,47 @@ WHERE N.type = 'FINGERPRINT_MOVED'
   AND N.user_id = ANY(sqlc.arg(user_ids)::uuid[])
   AND N.user_id != sqlc.arg(acting_user_id);
 
+-- name: TriggerSceneReleaseNotifications :exec
+-- Notify users when a scene added ahead of its release date, featuring a favorite performer or studio, is released
+INSERT INTO notifications (user_id, type, id)
+SELECT DISTINCT F.user_id, N.type, F.scene_id
+FROM (
+    SELECT SF.user_id, S.id AS scene_id
+    FROM scenes S
+    JOIN studio_favorites SF ON S.studio_id = SF.studio_id
+    WHERE S.date = sqlc.arg(release_date)::text AND S.deleted = FALSE
+      AND S.created_at < to_date(S.date, 'YYYY-MM-DD')
+    UNION
+    SELECT PF.user_id, S.id AS scene_id
+    FROM scenes S
+    JOIN scene_performers SP ON S.id = SP.scene_id
+    JOIN performer_favorites PF ON SP.performer_id = PF.performer_id
+    WHERE S.date = sqlc.arg(release_date)::text AND S.deleted = FALSE
+      AND S.created_at < to_date(S.date, 'YYYY-MM-DD')
+) F
+JOIN user_notifications N ON F.user_id = N.user_id AND N.type = 'FAVORITE_SCENE_RELEASED'
+WHERE NOT EXISTS (
+    SELECT 1 FROM notifications E
+    WHERE E.user_id = F.user_id AND E.type = N.type AND E.id = F.scene_id
+);
+
+-- name: CreateSavedSearchMatchNotification :exec
+INSERT INTO notifications (user_id, type, id, data)
+VALUES (
+    sqlc.arg(user_id), 'SAVED_SEARCH_MATCH', sqlc.arg(saved_search_id),
+    jsonb_build_object('ids', sqlc.arg(ids)::uuid[], 'count', sqlc.arg(count)::int)
+);
+
 -- name: TriggerEditCommentNotifications :exec
 INSERT INTO notifications (user_id, type, id)
 SELECT DISTINCT ON (user_id) user_id, type, $1 FROM (
+    SELECT N.user_id, N.type, 0 as ordering
+    FROM edit_comments EC
+    JOIN edit_comment_mentions M ON M.comment_id = EC.id
+    JOIN user_notifications N ON M.user_id = N.user_id AND N.type = 'MENTIONED_IN_COMMENT'
+    WHERE M.user_id != EC.user_id
+    AND EC.id = $1
+    UNION
     SELECT N.user_id, N.type, 1 as ordering
     FROM edit_comments EC
     JOIN edits E ON EC.edit_id = E.id
@@ -177,3 +215,58 @@ SELECT DISTINCT ON (user_id) user_id, type, $1 FROM (
     AND EC.id = $1
 ) notifications
 ORDER BY user_id, ordering ASC;
+
+-- Notification emails
+
+-- name: GetUserNotificationEmailPreferences :many
+SELECT * FROM user_notification_emails WHERE user_id = $1 ORDER BY type;
+
+-- name: UpsertUserNotificationEmailPreference :exec
+-- Only notifications created after the frequency last changed are emailed
+INSERT INTO user_notification_emails (user_id, type, frequency) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, type) DO UPDATE
+SET frequency = EXCLUDED.frequency,
+    updated_at = CASE WHEN user_notification_emails.frequency = EXCLUDED.frequency THEN user_notification_emails.updated_at ELSE NOW() END;
+
+-- name: UnsubscribeUserNotificationEmails :exec
+UPDATE user_notification_emails SET frequency = 'OFF', updated_at = NOW() WHERE user_id = $1 AND frequency != 'OFF';
+
+-- name: FindNotificationEmailRecipients :many
+-- Users with pending notifications of the given frequency whose previous
+-- email of that frequency was sent before sent_before
+SELECT DISTINCT N.user_id
+FROM notifications N
+JOIN user_notification_emails E ON E.user_id = N.user_id AND E.type = N.type
+LEFT JOIN user_notification_digests D ON D.user_id = N.user_id AND D.frequency = E.frequency
+WHERE E.frequency = sqlc.arg(frequency)
+AND N.emailed_at IS NULL
+AND N.read_at IS NULL
+AND N.created_at >= E.updated_at
+AND (D.sent_at IS NULL OR D.sent_at <= sqlc.arg(sent_before));
+
+-- name: ClaimNotificationEmails :many
+-- Marks the pending notifications of the given frequency as emailed and
+-- returns them, along with the edit of comment notifications
+WITH claimed AS (
+    UPDATE notifications N SET emailed_at = NOW()
+    FROM user_notification_emails E
+    WHERE E.user_id = N.user_id AND E.type = N.type
+    AND N.user_id = sqlc.arg(user_id)
+    AND E.frequency = sqlc.arg(frequency)
+    AND N.emailed_at IS NULL
+    AND N.read_at IS NULL
+    AND N.created_at >= E.updated_at
+    RETURNING N.type, N.id, N.created_at
+)
+SELECT C.type, C.id, C.created_at, EC.edit_id
+FROM claimed C
+LEFT JOIN edit_comments EC ON EC.id = C.id
+ORDER BY C.created_at;
+
+-- name: MarkNotificationDigestSent :exec
+INSERT INTO user_notification_digests (user_id, frequency, sent_at) VALUES ($1, $2, NOW())
+ON CONFLICT (user_id, frequency) DO UPDATE SET sent_at = EXCLUDED.sent_at;
+
+-- name: CreateUserSuspensionNotification :exec
+INSERT INTO notifications (user_id, type, id)
+VALUES (sqlc.arg(user_id), sqlc.arg(type), sqlc.arg(suspension_id));
@@ -141,6 +141,37 @@ WHERE performer_id @@@ paradedb.disjunction_max(disjuncts => ARRAY[
 ])
 AND (sqlc.narg('filter_gender')::TEXT IS NULL OR gender = sqlc.narg('filter_gender')::TEXT);
 
+-- name: SearchPerformersPostgres :many
+-- search_backend: postgres equivalent of SearchPerformers. The query is a
+-- to_tsquery expression built from the search term; the raw term is used to
+-- rank names that closely match the whole term first.
+-- Keep the WHERE clause in sync across SearchPerformersPostgres,
+-- CountPerformerSearchMatchesPostgres and GetPerformerSearchFacetsPostgres.
+SELECT performer_id
+FROM performer_search
+WHERE performer_search_document(name, disambiguation, aliases) @@ to_tsquery('simple', sqlc.arg('query')::TEXT)
+AND (sqlc.narg('filter_gender')::TEXT IS NULL OR gender = sqlc.narg('filter_gender')::TEXT)
+ORDER BY
+    ts_rank(performer_search_document(name, disambiguation, aliases), to_tsquery('simple', sqlc.arg('query')::TEXT))
+        + similarity(name, sqlc.arg('term')::TEXT) DESC,
+    performer_id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountPerformerSearchMatchesPostgres :one
+SELECT COUNT(*)
+FROM performer_search
+WHERE performer_search_document(name, disambiguation, aliases) @@ to_tsquery('simple', sqlc.arg('query')::TEXT)
+AND (sqlc.narg('filter_gender')::TEXT IS NULL OR gender = sqlc.narg('filter_gender')::TEXT);
+
+-- name: GetPerformerSearchFacetsPostgres :many
+SELECT gender, COUNT(*) AS count
+FROM performer_search
+WHERE performer_search_document(name, disambiguation, aliases) @@ to_tsquery('simple', sqlc.arg('query')::TEXT)
+AND (sqlc.narg('filter_gender')::TEXT IS NULL OR gender = sqlc.narg('filter_gender')::TEXT)
+AND gender IS NOT NULL
+GROUP BY gender
+ORDER BY count DESC, gender;
+
 -- Performer aliases
 
 -- name: DeletePerformerAliases :exec
@@ -283,3 +314,70 @@ SELECT performer_id, location, description FROM performer_piercings WHERE perfor
 -- name: FindPerformerUrlsByIds :many
 -- Get URLs for multiple performers
 SELECT performer_id, url, site_id FROM performer_urls WHERE performer_id = ANY(sqlc.arg(performer_ids)::UUID[]);
+
+-- name: GetPerformerCostars :many
+-- Get performers sharing scenes with a performer, with shared scene counts and date range
+SELECT
+    sqlc.embed(performers),
+    COUNT(DISTINCT scenes.id) AS scene_count,
+    MIN(scenes.date) AS first_scene_date,
+    MAX(scenes.date) AS last_scene_date
+FROM scene_performers SP
+JOIN scene_performers CP ON CP.scene_id = SP.scene_id AND CP.performer_id != SP.performer_id
+JOIN scenes ON scenes.id = SP.scene_id AND scenes.deleted = FALSE
+JOIN performers ON performers.id = CP.performer_id AND performers.deleted = FALSE
+WHERE SP.performer_id = sqlc.arg('performer_id')
+  AND (sqlc.narg('studio_id')::uuid IS NULL OR scenes.studio_id = sqlc.narg('studio_id'))
+GROUP BY performers.id
+ORDER BY scene_count DESC, performers.name
+LIMIT sqlc.arg('limit');
+
+-- name: GetPerformerCostarLinks :many
+-- Get the distinct co-appearance links for a set of performers
+SELECT DISTINCT SP.performer_id, CP.performer_id AS costar_id
+FROM scene_performers SP
+JOIN scene_performers CP ON CP.scene_id = SP.scene_id AND CP.performer_id != SP.performer_id
+JOIN scenes ON scenes.id = SP.scene_id AND scenes.deleted = FALSE
+JOIN performers ON performers.id = CP.performer_id AND performers.deleted = FALSE
+WHERE SP.performer_id = ANY(sqlc.arg(performer_ids)::UUID[])
+LIMIT sqlc.arg(max_links);
+
+-- name: GetPerformerRelationships :many
+SELECT * FROM performer_relationships WHERE performer_id = $1;
+
+-- name: CreatePerformerRelationship :exec
+-- Create a relationship in both directions
+INSERT INTO performer_relationships (performer_id, related_performer_id, type)
+VALUES (sqlc.arg(performer_id), sqlc.arg(related_performer_id), sqlc.arg(type)),
+       (sqlc.arg(related_performer_id), sqlc.arg(performer_id), sqlc.arg(type))
+ON CONFLICT DO NOTHING;
+
+-- name: DeletePerformerRelationship :exec
+-- Delete a relationship in both directions
+DELETE FROM performer_relationships
+WHERE type = sqlc.arg(type)
+  AND ((performer_id = sqlc.arg(performer_id) AND related_performer_id = sqlc.arg(related_performer_id))
+    OR (performer_id = sqlc.arg(related_performer_id) AND related_performer_id = sqlc.arg(performer_id)));
+
+-- name: DeletePerformerRelationships :exec
+-- Delete all relationships of a performer in both directions
+DELETE FROM performer_relationships WHERE performer_id = $1 OR related_performer_id = $1;
+
+-- name: ReassignPerformerRelationships :exec
+-- Copy the relationships of a merge source to its target, in both directions
+INSERT INTO performer_relationships (performer_id, related_performer_id, type)
+SELECT sqlc.arg(new_performer_id)::uuid, related_performer_id, type FROM performer_relationships
+WHERE performer_id = sqlc.arg(old_performer_id) AND related_performer_id <> sqlc.arg(new_performer_id)
+UNION
+SELECT related_performer_id, sqlc.arg(new_performer_id)::uuid, type FROM performer_relationships
+WHERE performer_id = sqlc.arg(old_performer_id) AND related_performer_id <> sqlc.arg(new_performer_id)
+ON CONFLICT DO NOTHING;
+
+-- name: GetPerformerCareerPeriods :many
+SELECT * FROM performer_career_periods WHERE performer_id = $1 ORDER BY start_date;
+
+-- name: DeletePerformerCareerPeriods :exec
+DELETE FROM performer_career_periods WHERE performer_id = $1;
+
+-- name: CreatePerformerCareerPeriods :copyfrom
+INSERT INTO performer_career_periods (performer_id, start_date, end_date, studio_id, note) VALUES ($1, $2, $3, $4, $5);
@@ -0,0 +1,53 @@
+-- Custom role queries
+
+-- name: CreateRole :one
+INSERT INTO roles (id, name, description, permissions, created_at, updated_at)
+VALUES ($1, $2, $3, $4, now(), now())
+RETURNING *;
+
+-- name: UpdateRole :one
+UPDATE roles
+SET name = $2, description = $3, permissions = $4, updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRole :exec
+DELETE FROM roles WHERE id = $1;
+
+-- name: FindRole :one
+SELECT * FROM roles WHERE id = $1;
+
+-- name: FindRoleByName :one
+SELECT * FROM roles WHERE LOWER(name) = LOWER($1);
+
+-- name: GetAllRoles :many
+SELECT * FROM roles ORDER BY name ASC;
+
+-- name: GetRolesByIds :many
+SELECT * FROM roles WHERE id = ANY($1::UUID[]);
+
+-- name: CountRoleUsers :one
+SELECT COUNT(*) FROM user_custom_roles WHERE role_id = $1;
+
+-- name: GetRoleUserIDs :many
+SELECT user_id FROM user_custom_roles WHERE role_id = $1;
+
+-- name: GetUserCustomRoles :many
+SELECT R.* FROM roles R
+JOIN user_custom_roles UR ON UR.role_id = R.id
+WHERE UR.user_id = $1
+ORDER BY R.name ASC;
+
+-- name: GetUserCustomPermissions :many
+-- Permissions granted to a user by their custom roles
+SELECT DISTINCT UNNEST(R.permissions)::TEXT AS permission
+FROM roles R
+JOIN user_custom_roles UR ON UR.role_id = R.id
+WHERE UR.user_id = $1;
+
+-- name: CreateUserCustomRoles :exec
+INSERT INTO user_custom_roles (user_id, role_id)
+SELECT sqlc.arg(user_id)::UUID, UNNEST(sqlc.arg(role_ids)::UUID[]);
+
+-- name: DeleteUserCustomRoles :exec
+DELETE FROM user_custom_roles WHERE user_id = $1;
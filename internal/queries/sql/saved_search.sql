@@ -0,0 +1,31 @@
+-- name: CreateSavedSearch :one
+INSERT INTO saved_searches (id, user_id, name, type, filter, notify, last_checked_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, now(), now(), now())
+RETURNING *;
+
+-- name: UpdateSavedSearch :one
+UPDATE saved_searches
+SET name = $2, type = $3, filter = $4, notify = $5, updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSavedSearch :exec
+DELETE FROM saved_searches WHERE id = $1;
+
+-- name: FindSavedSearch :one
+SELECT * FROM saved_searches WHERE id = $1;
+
+-- name: FindSavedSearchesByUser :many
+SELECT * FROM saved_searches WHERE user_id = $1 ORDER BY name, id;
+
+-- name: FindNotifiableSavedSearches :many
+SELECT * FROM saved_searches WHERE notify = TRUE ORDER BY last_checked_at;
+
+-- name: AdvanceSavedSearchCheck :one
+-- Moves the last check of a saved search to now, returning the window of
+-- creation times to look for new matches in.
+UPDATE saved_searches S
+SET last_checked_at = now()
+FROM (SELECT id, last_checked_at FROM saved_searches WHERE id = $1 FOR UPDATE) P
+WHERE S.id = P.id
+RETURNING P.last_checked_at AS since, S.last_checked_at AS until;
@@ -105,6 +105,33 @@ WHERE scene_id @@@ paradedb.boolean(should =>
 ORDER BY pdb.score(scene_id) DESC, scene_id
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
+-- name: SearchScenesPostgres :many
+-- search_backend: postgres equivalent of SearchScenes. Scenes are ranked by
+-- the number of distinct terms they match, then by ts_rank, mirroring the
+-- coverage tiers of the BM25 query.
+WITH matches AS (
+    SELECT
+        scene_id,
+        scene_search_document(
+            scene_title, scene_code, scene_date, studio_name, network_name,
+            studio_aliases, network_aliases, performer_names
+        ) AS document
+    FROM scene_search
+    WHERE scene_search_document(
+        scene_title, scene_code, scene_date, studio_name, network_name,
+        studio_aliases, network_aliases, performer_names
+    ) @@ to_tsquery('simple', array_to_string(sqlc.arg('terms')::TEXT[], ' | '))
+)
+SELECT
+    scene_id,
+    COUNT(*) OVER () AS total_count
+FROM matches
+ORDER BY
+    (SELECT COUNT(*) FROM unnest(sqlc.arg('terms')::TEXT[]) AS term WHERE document @@ to_tsquery('simple', term)) DESC,
+    ts_rank(document, to_tsquery('simple', array_to_string(sqlc.arg('terms')::TEXT[], ' | '))) DESC,
+    scene_id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
 -- name: CountScenesByPerformer :one
 SELECT COUNT(*) FROM scene_performers WHERE performer_id = $1;
 
@@ -189,3 +216,21 @@ SELECT scene_id, performer_id, "as" FROM scene_performers WHERE scene_id = ANY(s
 -- name: FindSceneUrlsByIds :many
 -- Get URLs for multiple scenes
 SELECT scene_id, url, site_id FROM scene_urls WHERE scene_id = ANY(sqlc.arg(scene_ids)::UUID[]);
+
+-- name: FindUpcomingFavoriteScenes :many
+-- Scenes with a full release date in the given range featuring a favorite performer or studio of the user
+SELECT S.* FROM scenes S
+WHERE S.deleted = FALSE
+  AND LENGTH(S.date) = 10
+  AND S.date > sqlc.arg(from_date)::text
+  AND S.date <= sqlc.arg(to_date)::text
+  AND (
+    S.studio_id IN (SELECT SF.studio_id FROM studio_favorites SF WHERE SF.user_id = sqlc.arg(user_id))
+    OR S.id IN (
+      SELECT SP.scene_id FROM scene_performers SP
+      JOIN performer_favorites PF ON SP.performer_id = PF.performer_id
+      WHERE PF.user_id = sqlc.arg(user_id)
+    )
+  )
+ORDER BY S.date ASC, S.title ASC
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,183 @@
+-- name: SearchPerformerHits :many
+-- Same match as SearchPerformers, returning the score and the indexed
+-- name fields so that callers can report which field matched.
+SELECT
+    performer_id,
+    name,
+    disambiguation,
+    aliases,
+    pdb.score(performer_id)::FLOAT8 AS score,
+    pdb.agg('{"value_count": {"field": "performer_id"}}') OVER () AS total_count
+FROM performer_search
+WHERE performer_id @@@ paradedb.disjunction_max(disjuncts => ARRAY[
+    paradedb.boolean(
+        should => ARRAY[
+            paradedb.disjunction_max(disjuncts => ARRAY[
+                paradedb.boost(factor => 1.5, query => paradedb.match(field => 'name', value => sqlc.arg('term')::TEXT)),
+                paradedb.boost(factor => 2.0, query => (jsonb_build_object(
+                    'tokenized_phrase', jsonb_build_object('field', 'name', 'phrase', sqlc.arg('term')::TEXT)
+                ))::paradedb.searchqueryinput)
+            ]),
+            paradedb.match(field => 'disambiguation', value => sqlc.arg('term')::TEXT)
+        ]
+    ),
+    (jsonb_build_object(
+        'tokenized_phrase', jsonb_build_object('field', 'aliases', 'phrase', sqlc.arg('term')::TEXT)
+    ))::paradedb.searchqueryinput
+])
+ORDER BY pdb.score(performer_id) DESC, performer_id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchSceneHits :many
+-- Same match as SearchScenes, returning the score and the indexed fields.
+SELECT
+    scene_id,
+    scene_title,
+    scene_code,
+    studio_name,
+    performer_names,
+    pdb.score(scene_id)::FLOAT8 AS score,
+    pdb.agg('{"value_count": {"field": "scene_id"}}') OVER () AS total_count
+FROM scene_search
+WHERE scene_id @@@ paradedb.boolean(should =>
+    ARRAY(
+        SELECT paradedb.const_score(10000.0, paradedb.disjunction_max(disjuncts => ARRAY[
+            paradedb.match(field => 'scene_title', value => tok),
+            paradedb.match(field => 'scene_code', value => tok),
+            paradedb.match(field => 'scene_date', value => tok),
+            paradedb.match(field => 'performer_names', value => tok),
+            paradedb.match(field => 'studio_name', value => tok),
+            paradedb.match(field => 'studio_aliases', value => tok),
+            paradedb.match(field => 'network_name', value => tok),
+            paradedb.match(field => 'network_aliases', value => tok)
+        ]))
+        FROM unnest(sqlc.arg('tokens')::TEXT[]) AS tok
+    ) || ARRAY(
+        SELECT paradedb.disjunction_max(disjuncts => ARRAY[
+            paradedb.boost(factor => 2.0, query => paradedb.match(field => 'performer_names', value => tok)),
+            paradedb.match(field => 'scene_title', value => tok),
+            paradedb.match(field => 'scene_code', value => tok),
+            paradedb.match(field => 'scene_date', value => tok),
+            paradedb.match(field => 'studio_name', value => tok),
+            paradedb.match(field => 'studio_aliases', value => tok),
+            paradedb.match(field => 'network_name', value => tok),
+            paradedb.match(field => 'network_aliases', value => tok)
+        ])
+        FROM unnest(sqlc.arg('tokens')::TEXT[]) AS tok
+    )
+)
+ORDER BY pdb.score(scene_id) DESC, scene_id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchStudioHits :many
+-- Same match as SearchStudios, returning the score and the indexed fields.
+SELECT
+    studio_id,
+    name,
+    network,
+    aliases,
+    pdb.score(studio_id)::FLOAT8 AS score,
+    pdb.agg('{"value_count": {"field": "studio_id"}}') OVER () AS total_count
+FROM studio_search
+WHERE studio_id @@@ paradedb.disjunction_max(disjuncts => ARRAY[
+    paradedb.boost(factor => 2, query => paradedb.match(field => 'name', value => sqlc.arg('term')::TEXT)),
+    paradedb.match(field => 'network', value => sqlc.arg('term')::TEXT),
+    paradedb.match(field => 'aliases', value => sqlc.arg('term')::TEXT)
+])
+ORDER BY pdb.score(studio_id) DESC, studio_id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchTagHits :many
+-- Same match as SearchTags, returning the score and the indexed fields.
+SELECT
+    tag_id,
+    name,
+    aliases,
+    pdb.score(tag_id)::FLOAT8 AS score,
+    pdb.agg('{"value_count": {"field": "tag_id"}}') OVER () AS total_count
+FROM tag_search
+WHERE tag_id @@@ paradedb.boolean(should => ARRAY[
+    paradedb.match(field => 'name', value => sqlc.arg('term')::TEXT, distance => 1, prefix => true, conjunction_mode => true),
+    paradedb.match(field => 'aliases', value => sqlc.arg('term')::TEXT, distance => 1, prefix => true, conjunction_mode => true)
+])
+ORDER BY pdb.score(tag_id) DESC, tag_id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchPerformerHitsPostgres :many
+-- search_backend: postgres equivalent of SearchPerformerHits.
+SELECT
+    performer_id,
+    name,
+    disambiguation,
+    aliases,
+    (ts_rank(performer_search_document(name, disambiguation, aliases), to_tsquery('simple', sqlc.arg('query')::TEXT))
+        + similarity(name, sqlc.arg('term')::TEXT))::FLOAT8 AS score,
+    COUNT(*) OVER () AS total_count
+FROM performer_search
+WHERE performer_search_document(name, disambiguation, aliases) @@ to_tsquery('simple', sqlc.arg('query')::TEXT)
+ORDER BY score DESC, performer_id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchSceneHitsPostgres :many
+-- search_backend: postgres equivalent of SearchSceneHits, scored like
+-- SearchScenesPostgres.
+WITH matches AS (
+    SELECT
+        scene_id,
+        scene_title,
+        scene_code,
+        studio_name,
+        performer_names,
+        scene_search_document(
+            scene_title, scene_code, scene_date, studio_name, network_name,
+            studio_aliases, network_aliases, performer_names
+        ) AS document
+    FROM scene_search
+    WHERE scene_search_document(
+        scene_title, scene_code, scene_date, studio_name, network_name,
+        studio_aliases, network_aliases, performer_names
+    ) @@ to_tsquery('simple', array_to_string(sqlc.arg('terms')::TEXT[], ' | '))
+)
+SELECT
+    scene_id,
+    scene_title,
+    scene_code,
+    studio_name,
+    performer_names,
+    ((SELECT COUNT(*) FROM unnest(sqlc.arg('terms')::TEXT[]) AS term WHERE document @@ to_tsquery('simple', term))
+        + ts_rank(document, to_tsquery('simple', array_to_string(sqlc.arg('terms')::TEXT[], ' | '))))::FLOAT8 AS score,
+    COUNT(*) OVER () AS total_count
+FROM matches
+ORDER BY score DESC, scene_id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchStudioHitsPostgres :many
+-- search_backend: postgres equivalent of SearchStudioHits.
+SELECT
+    studio_id,
+    name,
+    network,
+    aliases,
+    (ts_rank(studio_search_document(name, network, aliases), to_tsquery('simple', sqlc.arg('query')::TEXT))
+        + similarity(name, sqlc.arg('term')::TEXT))::FLOAT8 AS score,
+    COUNT(*) OVER () AS total_count
+FROM studio_search
+WHERE studio_search_document(name, network, aliases) @@ to_tsquery('simple', sqlc.arg('query')::TEXT)
+ORDER BY score DESC, studio_id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchTagHitsPostgres :many
+-- search_backend: postgres equivalent of SearchTagHits.
+SELECT
+    tag_id,
+    name,
+    aliases,
+    (ts_rank(tag_search_document(name, aliases), to_tsquery('simple', sqlc.arg('query')::TEXT))
+        + word_similarity(sqlc.arg('term')::TEXT, name))::FLOAT8 AS score,
+    COUNT(*) OVER () AS total_count
+FROM tag_search
+WHERE tag_search_document(name, aliases) @@ to_tsquery('simple', sqlc.arg('query')::TEXT)
+    OR sqlc.arg('term')::TEXT <% name
+    OR sqlc.arg('term')::TEXT <% search_array_text(aliases)
+ORDER BY score DESC, tag_id
+LIMIT sqlc.arg('limit');
@@ -40,6 +40,17 @@ WHERE studio_id @@@ paradedb.disjunction_max(disjuncts => ARRAY[
 ORDER BY pdb.score(studio_id) DESC
 LIMIT sqlc.arg('limit');
 
+-- name: SearchStudiosPostgres :many
+-- search_backend: postgres equivalent of SearchStudios.
+SELECT studio_id
+FROM studio_search
+WHERE studio_search_document(name, network, aliases) @@ to_tsquery('simple', sqlc.arg('query')::TEXT)
+ORDER BY
+    ts_rank(studio_search_document(name, network, aliases), to_tsquery('simple', sqlc.arg('query')::TEXT))
+        + similarity(name, sqlc.arg('term')::TEXT) DESC,
+    studio_id
+LIMIT sqlc.arg('limit');
+
 -- name: GetStudiosByPerformer :many
 SELECT
     sqlc.embed(studios),
@@ -75,6 +86,32 @@ GROUP BY studios.id;
 -- name: GetChildStudios :many
 SELECT * FROM studios WHERE parent_studio_id = $1 AND deleted = false ORDER BY name;
 
+-- name: GetStudioNetworkIDs :many
+-- Get the ids of a studio and every studio below it in the network, at any depth
+WITH RECURSIVE network AS (
+    SELECT studios.id FROM studios WHERE studios.id = sqlc.arg('studio_id')
+    UNION
+    SELECT S.id FROM studios S JOIN network N ON S.parent_studio_id = N.id
+)
+SELECT id FROM network;
+
+-- name: GetStudioNetworkStats :one
+-- Aggregate scene and performer statistics across a studio and all of its descendants
+WITH RECURSIVE network AS (
+    SELECT studios.id FROM studios WHERE studios.id = sqlc.arg('studio_id')
+    UNION
+    SELECT S.id FROM studios S JOIN network N ON S.parent_studio_id = N.id WHERE S.deleted = FALSE
+)
+SELECT
+    (SELECT COUNT(*) FROM network) AS studio_count,
+    COUNT(DISTINCT scenes.id) AS scene_count,
+    COUNT(DISTINCT SP.performer_id) AS performer_count,
+    MIN(scenes.date) AS first_scene_date,
+    MAX(scenes.date) AS last_scene_date
+FROM network
+JOIN scenes ON scenes.studio_id = network.id AND scenes.deleted = FALSE
+LEFT JOIN scene_performers SP ON SP.scene_id = scenes.id;
+
 -- Studio URLs
 
 -- name: CreateStudioURLs :copyfrom
@@ -35,6 +35,23 @@ AND T.deleted = FALSE
 ORDER BY pdb.score(TS.tag_id) DESC
 LIMIT sqlc.arg('limit');
 
+-- name: SearchTagsPostgres :many
+-- search_backend: postgres equivalent of SearchTags. Trigram word similarity
+-- stands in for the fuzzy matching of the BM25 query.
+SELECT T.* FROM tags T
+JOIN tag_search TS ON TS.tag_id = T.id
+WHERE (
+    tag_search_document(TS.name, TS.aliases) @@ to_tsquery('simple', sqlc.arg('query')::TEXT)
+    OR sqlc.arg('term')::TEXT <% TS.name
+    OR sqlc.arg('term')::TEXT <% search_array_text(TS.aliases)
+)
+AND T.deleted = FALSE
+ORDER BY
+    ts_rank(tag_search_document(TS.name, TS.aliases), to_tsquery('simple', sqlc.arg('query')::TEXT))
+        + word_similarity(sqlc.arg('term')::TEXT, TS.name) DESC,
+    T.name
+LIMIT sqlc.arg('limit');
+
 -- name: FindTagsByIds :many
 SELECT * FROM tags WHERE id = ANY($1::UUID[]);
 
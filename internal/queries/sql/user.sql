@@ -28,6 +28,9 @@ FROM users WHERE users.id = $1;
 -- name: FindUserByName :one
 SELECT * FROM users WHERE UPPER(name) = UPPER(sqlc.arg(name)::text);
 
+-- name: FindUsersByNames :many
+SELECT id, name FROM users WHERE UPPER(name) = ANY(SELECT UPPER(unnest(sqlc.arg(names)::text[])));
+
 -- name: FindUserByEmail :one
 SELECT * FROM users WHERE UPPER(email) = UPPER($1);
 
@@ -71,5 +74,242 @@ SELECT vote, COUNT(*) as count FROM edit_votes WHERE user_id = $1 GROUP BY vote;
 -- name: CountUserEditsByStatus :many
 SELECT status, bot, COUNT(*) as count FROM edits WHERE user_id = $1 GROUP BY status, bot;
 
+-- name: GetUserReputationStats :many
+-- Reputation inputs of a single user, or of every user with any edit, vote
+-- or fingerprint report when user_id is null. Bot edits, failed edits and
+-- fingerprint reports no other user has voted on are not counted.
+SELECT U.id AS user_id,
+  ARRAY(SELECT role FROM user_roles WHERE user_id = U.id)::TEXT[] AS roles,
+  COALESCE(E.accepted, 0)::int AS accepted_edits,
+  COALESCE(E.rejected, 0)::int AS rejected_edits,
+  COALESCE(V.agreeing, 0)::int AS agreeing_votes,
+  COALESCE(V.disagreeing, 0)::int AS disagreeing_votes,
+  COALESCE(F.confirmed, 0)::int AS confirmed_reports,
+  COALESCE(F.disputed, 0)::int AS disputed_reports
+FROM users U
+LEFT JOIN (
+  SELECT user_id,
+    COUNT(*) FILTER (WHERE status IN ('ACCEPTED', 'IMMEDIATE_ACCEPTED')) AS accepted,
+    COUNT(*) FILTER (WHERE status IN ('REJECTED', 'IMMEDIATE_REJECTED')) AS rejected
+  FROM edits
+  WHERE bot = FALSE AND user_id IS NOT NULL
+  GROUP BY user_id
+) E ON E.user_id = U.id
+LEFT JOIN (
+  SELECT EV.user_id,
+    COUNT(*) FILTER (WHERE (EV.vote = 'ACCEPT' AND ED.status IN ('ACCEPTED', 'IMMEDIATE_ACCEPTED'))
+      OR (EV.vote = 'REJECT' AND ED.status IN ('REJECTED', 'IMMEDIATE_REJECTED'))) AS agreeing,
+    COUNT(*) FILTER (WHERE (EV.vote = 'ACCEPT' AND ED.status IN ('REJECTED', 'IMMEDIATE_REJECTED'))
+      OR (EV.vote = 'REJECT' AND ED.status IN ('ACCEPTED', 'IMMEDIATE_ACCEPTED'))) AS disagreeing
+  FROM edit_votes EV
+  JOIN edits ED ON ED.id = EV.edit_id
+  WHERE EV.user_id IS NOT NULL
+  GROUP BY EV.user_id
+) V ON V.user_id = U.id
+LEFT JOIN (
+  SELECT R.user_id,
+    COUNT(*) FILTER (WHERE O.net < 0) AS confirmed,
+    COUNT(*) FILTER (WHERE O.net > 0) AS disputed
+  FROM scene_fingerprints R
+  JOIN LATERAL (
+    SELECT SUM(vote) AS net FROM scene_fingerprints O
+    WHERE O.fingerprint_id = R.fingerprint_id AND O.scene_id = R.scene_id AND O.user_id != R.user_id
+  ) O ON TRUE
+  WHERE R.vote = -1
+  GROUP BY R.user_id
+) F ON F.user_id = U.id
+WHERE (sqlc.narg(user_id)::uuid IS NULL AND (E.user_id IS NOT NULL OR V.user_id IS NOT NULL OR F.user_id IS NOT NULL))
+  OR U.id = sqlc.narg(user_id)::uuid;
+
 -- name: GetUserNotificationSubscriptions :many
 SELECT type FROM user_notifications WHERE user_id = $1;
+
+-- name: CreateUserSuspension :one
+INSERT INTO user_suspensions (id, user_id, reason, created_by, expires_at, restriction, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+RETURNING *;
+
+-- name: FindUserSuspension :one
+SELECT * FROM user_suspensions WHERE id = $1;
+
+-- name: FindActiveUserSuspension :one
+SELECT * FROM user_suspensions
+WHERE user_id = $1 AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetUserSuspensions :many
+SELECT * FROM user_suspensions WHERE user_id = $1 ORDER BY created_at DESC;
+
+-- name: LiftUserSuspensions :many
+-- Lifts every suspension of a user that has not been lifted yet, including
+-- expired ones the cron job has not processed.
+UPDATE user_suspensions
+SET lifted_at = NOW(), lifted_by = sqlc.narg(lifted_by)
+WHERE user_id = sqlc.arg(user_id) AND lifted_at IS NULL
+RETURNING *;
+
+-- name: LiftExpiredUserSuspensions :many
+UPDATE user_suspensions
+SET lifted_at = NOW()
+WHERE lifted_at IS NULL AND expires_at <= NOW()
+RETURNING *;
+
+-- name: FindUserTOTP :one
+SELECT * FROM user_totp WHERE user_id = $1;
+
+-- name: CreateUserTOTP :exec
+-- Starts a new setup, replacing a previous one that was never confirmed
+INSERT INTO user_totp (user_id, secret, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+WHERE user_totp.enabled_at IS NULL;
+
+-- name: EnableUserTOTP :exec
+UPDATE user_totp SET enabled_at = NOW() WHERE user_id = $1;
+
+-- name: UseUserTOTPStep :execrows
+-- Records the time step of a used code, failing if a code of the same or a
+-- later step has already been used.
+UPDATE user_totp SET last_used_step = sqlc.arg(step)
+WHERE user_id = sqlc.arg(user_id) AND last_used_step < sqlc.arg(step);
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1;
+
+-- name: CreateUserRecoveryCodes :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+SELECT sqlc.arg(user_id), unnest(sqlc.arg(code_hashes)::text[]);
+
+-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes SET used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUserRecoveryCodes :one
+SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes WHERE user_id = $1;
+
+-- name: FindUserSession :one
+SELECT * FROM user_sessions WHERE id = $1 AND expires_at > NOW();
+
+-- name: CreateUserSession :exec
+INSERT INTO user_sessions (id, user_id, data, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: UpdateUserSession :execrows
+-- Does not recreate a session that was revoked in the meantime
+UPDATE user_sessions
+SET user_id = $2, data = $3, user_agent = $4, ip_address = $5, last_seen_at = NOW(), expires_at = $6
+WHERE id = $1;
+
+-- name: DeleteUserSession :exec
+DELETE FROM user_sessions WHERE id = $1;
+
+-- name: GetUserSessions :many
+SELECT * FROM user_sessions
+WHERE user_id = $1 AND expires_at > NOW()
+ORDER BY last_seen_at DESC;
+
+-- name: DeleteUserSessions :execrows
+-- Logs out all sessions of a user, except the session making the request
+DELETE FROM user_sessions
+WHERE user_id = sqlc.arg(user_id) AND id IS DISTINCT FROM sqlc.narg(except_id);
+
+-- name: DeleteExpiredUserSessions :exec
+DELETE FROM user_sessions WHERE expires_at <= NOW();
+
+-- name: FindLockout :one
+SELECT * FROM lockouts WHERE scope = $1 AND key = $2;
+
+-- name: RecordLockoutAttempt :one
+-- Counts an attempt, restarting the count if there was no attempt since
+-- window_start
+INSERT INTO lockouts (id, scope, key, attempts, last_attempt_at)
+VALUES (sqlc.arg(id), sqlc.arg(scope), sqlc.arg(key), 1, NOW())
+ON CONFLICT (scope, key) DO UPDATE
+SET attempts = CASE WHEN lockouts.last_attempt_at < sqlc.arg(window_start) THEN 1 ELSE lockouts.attempts + 1 END,
+    last_attempt_at = NOW()
+RETURNING *;
+
+-- name: SetLockoutUntil :exec
+UPDATE lockouts SET locked_until = $3 WHERE scope = $1 AND key = $2;
+
+-- name: DeleteLockout :exec
+DELETE FROM lockouts WHERE scope = $1 AND key = $2;
+
+-- name: QueryLockouts :many
+SELECT * FROM lockouts
+WHERE last_attempt_at >= sqlc.arg(window_start)
+  AND (NOT sqlc.arg(locked_only)::BOOLEAN OR locked_until > NOW())
+ORDER BY last_attempt_at DESC;
+
+-- name: DeleteExpiredLockouts :exec
+DELETE FROM lockouts
+WHERE last_attempt_at < sqlc.arg(window_start)
+  AND (locked_until IS NULL OR locked_until <= NOW());
+
+-- name: FindUserIdentity :one
+SELECT * FROM user_identities WHERE issuer = $1 AND subject = $2;
+
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (issuer, subject, user_id) VALUES ($1, $2, $3);
+
+-- User data export
+
+-- name: ExportUserEdits :many
+SELECT * FROM edits WHERE user_id = $1 ORDER BY created_at, id;
+
+-- name: ExportUserEditVotes :many
+SELECT * FROM edit_votes WHERE user_id = $1 ORDER BY created_at, edit_id;
+
+-- name: ExportUserEditComments :many
+SELECT * FROM edit_comments WHERE user_id = $1 ORDER BY created_at, id;
+
+-- name: ExportUserSceneFingerprints :many
+SELECT SF.scene_id, F.hash, F.algorithm, SF.duration, SF.vote, SF.created_at
+FROM scene_fingerprints SF
+JOIN fingerprints F ON SF.fingerprint_id = F.id
+WHERE SF.user_id = $1
+ORDER BY SF.created_at, SF.scene_id;
+
+-- name: ExportUserPerformerFavorites :many
+SELECT * FROM performer_favorites WHERE user_id = $1 ORDER BY created_at, performer_id;
+
+-- name: ExportUserStudioFavorites :many
+SELECT * FROM studio_favorites WHERE user_id = $1 ORDER BY created_at, studio_id;
+
+-- name: ExportUserNotifications :many
+SELECT * FROM notifications WHERE user_id = $1 ORDER BY created_at, id;
+
+-- name: GetInviteTree :many
+-- The user and every user invited by them, directly or indirectly, in
+-- depth-first order. depth is the number of invites between the user and
+-- the root. Bot edits are not counted.
+WITH RECURSIVE tree AS (
+  SELECT id, invited_by, 0 AS depth, ARRAY[id] AS path
+  FROM users
+  WHERE id = $1
+  UNION ALL
+  SELECT U.id, U.invited_by, T.depth + 1, T.path || U.id
+  FROM users U
+  JOIN tree T ON U.invited_by = T.id
+  WHERE NOT U.id = ANY(T.path)
+)
+SELECT T.id AS user_id, T.invited_by, T.depth::int AS depth,
+  COALESCE(E.accepted, 0)::int AS accepted_edits,
+  COALESCE(E.rejected, 0)::int AS rejected_edits,
+  COALESCE(E.pending, 0)::int AS pending_edits
+FROM tree T
+LEFT JOIN (
+  SELECT user_id,
+    COUNT(*) FILTER (WHERE status IN ('ACCEPTED', 'IMMEDIATE_ACCEPTED')) AS accepted,
+    COUNT(*) FILTER (WHERE status IN ('REJECTED', 'IMMEDIATE_REJECTED')) AS rejected,
+    COUNT(*) FILTER (WHERE status = 'PENDING') AS pending
+  FROM edits
+  WHERE bot = FALSE AND user_id IN (SELECT id FROM tree)
+  GROUP BY user_id
+) E ON E.user_id = T.id
+ORDER BY T.path;
@@ -392,6 +392,75 @@ func (q *Queries) GetStudioImages(ctx context.Context, studioID uuid.UUID) ([]uu
 	return items, nil
 }
 
+const getStudioNetworkIDs = `-- name: GetStudioNetworkIDs :many
+WITH RECURSIVE network AS (
+    SELECT studios.id FROM studios WHERE studios.id = $1
+    UNION
+    SELECT S.id FROM studios S JOIN network N ON S.parent_studio_id = N.id
+)
+SELECT id FROM network
+`
+
+// Get the ids of a studio and every studio below it in the network, at any depth
+func (q *Queries) GetStudioNetworkIDs(ctx context.Context, studioID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getStudioNetworkIDs, studioID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStudioNetworkStats = `-- name: GetStudioNetworkStats :one
+WITH RECURSIVE network AS (
+    SELECT studios.id FROM studios WHERE studios.id = $1
+    UNION
+    SELECT S.id FROM studios S JOIN network N ON S.parent_studio_id = N.id WHERE S.deleted = FALSE
+)
+SELECT
+    (SELECT COUNT(*) FROM network) AS studio_count,
+    COUNT(DISTINCT scenes.id) AS scene_count,
+    COUNT(DISTINCT SP.performer_id) AS performer_count,
+    MIN(scenes.date) AS first_scene_date,
+    MAX(scenes.date) AS last_scene_date
+FROM network
+JOIN scenes ON scenes.studio_id = network.id AND scenes.deleted = FALSE
+LEFT JOIN scene_performers SP ON SP.scene_id = scenes.id
+`
+
+type GetStudioNetworkStatsRow struct {
+	StudioCount    int64   `db:"studio_count" json:"studio_count"`
+	SceneCount     int64   `db:"scene_count" json:"scene_count"`
+	PerformerCount int64   `db:"performer_count" json:"performer_count"`
+	FirstSceneDate *string `db:"first_scene_date" json:"first_scene_date"`
+	LastSceneDate  *string `db:"last_scene_date" json:"last_scene_date"`
+}
+
+// Aggregate scene and performer statistics across a studio and all of its descendants
+func (q *Queries) GetStudioNetworkStats(ctx context.Context, studioID uuid.UUID) (GetStudioNetworkStatsRow, error) {
+	row := q.db.QueryRow(ctx, getStudioNetworkStats, studioID)
+	var i GetStudioNetworkStatsRow
+	err := row.Scan(
+		&i.StudioCount,
+		&i.SceneCount,
+		&i.PerformerCount,
+		&i.FirstSceneDate,
+		&i.LastSceneDate,
+	)
+	return i, err
+}
+
 const getStudioURLs = `-- name: GetStudioURLs :many
 SELECT studio_id, url, site_id FROM studio_urls WHERE studio_id = $1
 `
@@ -617,6 +686,44 @@ func (q *Queries) SearchStudios(ctx context.Context, arg SearchStudiosParams) ([
 	return items, nil
 }
 
+const searchStudiosPostgres = `-- name: SearchStudiosPostgres :many
+SELECT studio_id
+FROM studio_search
+WHERE studio_search_document(name, network, aliases) @@ to_tsquery('simple', $1::TEXT)
+ORDER BY
+    ts_rank(studio_search_document(name, network, aliases), to_tsquery('simple', $1::TEXT))
+        + similarity(name, $2::TEXT) DESC,
+    studio_id
+LIMIT $3
+`
+
+type SearchStudiosPostgresParams struct {
+	Query string `db:"query" json:"query"`
+	Term  string `db:"term" json:"term"`
+	Limit int32  `db:"limit" json:"limit"`
+}
+
+// search_backend: postgres equivalent of SearchStudios.
+func (q *Queries) SearchStudiosPostgres(ctx context.Context, arg SearchStudiosPostgresParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, searchStudiosPostgres, arg.Query, arg.Term, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var studio_id uuid.UUID
+		if err := rows.Scan(&studio_id); err != nil {
+			return nil, err
+		}
+		items = append(items, studio_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const softDeleteStudio = `-- name: SoftDeleteStudio :one
 UPDATE studios SET deleted = true, updated_at = NOW() WHERE id = $1
 RETURNING id, name, parent_studio_id, created_at, updated_at, deleted
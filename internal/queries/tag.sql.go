@@ -459,6 +459,58 @@ func (q *Queries) SearchTags(ctx context.Context, arg SearchTagsParams) ([]Tag,
 	return items, nil
 }
 
+const searchTagsPostgres = `-- name: SearchTagsPostgres :many
+SELECT t.id, t.name, t.description, t.created_at, t.updated_at, t.deleted, t.category_id FROM tags T
+JOIN tag_search TS ON TS.tag_id = T.id
+WHERE (
+    tag_search_document(TS.name, TS.aliases) @@ to_tsquery('simple', $1::TEXT)
+    OR $2::TEXT <% TS.name
+    OR $2::TEXT <% search_array_text(TS.aliases)
+)
+AND T.deleted = FALSE
+ORDER BY
+    ts_rank(tag_search_document(TS.name, TS.aliases), to_tsquery('simple', $1::TEXT))
+        + word_similarity($2::TEXT, TS.name) DESC,
+    T.name
+LIMIT $3
+`
+
+type SearchTagsPostgresParams struct {
+	Query string `db:"query" json:"query"`
+	Term  string `db:"term" json:"term"`
+	Limit int32  `db:"limit" json:"limit"`
+}
+
+// search_backend: postgres equivalent of SearchTags. Trigram word similarity
+// stands in for the fuzzy matching of the BM25 query.
+func (q *Queries) SearchTagsPostgres(ctx context.Context, arg SearchTagsPostgresParams) ([]Tag, error) {
+	rows, err := q.db.Query(ctx, searchTagsPostgres, arg.Query, arg.Term, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Deleted,
+			&i.CategoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const softDeleteTag = `-- name: SoftDeleteTag :one
 UPDATE tags SET deleted = true, updated_at = NOW() WHERE id = $1
 RETURNING id, name, description, created_at, updated_at, deleted, category_id
@@ -7,6 +7,7 @@ package queries
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -41,6 +42,17 @@ func (q *Queries) CountUserEditsByStatus(ctx context.Context, userID uuid.NullUU
 	return items, nil
 }
 
+const countUserRecoveryCodes = `-- name: CountUserRecoveryCodes :one
+SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL
+`
+
+func (q *Queries) CountUserRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserRecoveryCodes, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM users
 `
@@ -133,6 +145,154 @@ type CreateUserRolesParams struct {
 	Role   string    `db:"role" json:"role"`
 }
 
+const createUserIdentity = `-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (issuer, subject, user_id) VALUES ($1, $2, $3)
+`
+
+type CreateUserIdentityParams struct {
+	Issuer  string    `db:"issuer" json:"issuer"`
+	Subject string    `db:"subject" json:"subject"`
+	UserID  uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error {
+	_, err := q.db.Exec(ctx, createUserIdentity, arg.Issuer, arg.Subject, arg.UserID)
+	return err
+}
+
+const createUserRecoveryCodes = `-- name: CreateUserRecoveryCodes :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+SELECT $1, unnest($2::text[])
+`
+
+type CreateUserRecoveryCodesParams struct {
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+	CodeHashes []string  `db:"code_hashes" json:"code_hashes"`
+}
+
+func (q *Queries) CreateUserRecoveryCodes(ctx context.Context, arg CreateUserRecoveryCodesParams) error {
+	_, err := q.db.Exec(ctx, createUserRecoveryCodes, arg.UserID, arg.CodeHashes)
+	return err
+}
+
+const createUserSession = `-- name: CreateUserSession :exec
+INSERT INTO user_sessions (id, user_id, data, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateUserSessionParams struct {
+	ID        uuid.UUID     `db:"id" json:"id"`
+	UserID    uuid.NullUUID `db:"user_id" json:"user_id"`
+	Data      []byte        `db:"data" json:"data"`
+	UserAgent string        `db:"user_agent" json:"user_agent"`
+	IpAddress string        `db:"ip_address" json:"ip_address"`
+	ExpiresAt time.Time     `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionParams) error {
+	_, err := q.db.Exec(ctx, createUserSession,
+		arg.ID,
+		arg.UserID,
+		arg.Data,
+		arg.UserAgent,
+		arg.IpAddress,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createUserSuspension = `-- name: CreateUserSuspension :one
+INSERT INTO user_suspensions (id, user_id, reason, created_by, expires_at, restriction, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+RETURNING id, user_id, reason, created_by, created_at, expires_at, lifted_at, lifted_by, restriction
+`
+
+type CreateUserSuspensionParams struct {
+	ID          uuid.UUID     `db:"id" json:"id"`
+	UserID      uuid.UUID     `db:"user_id" json:"user_id"`
+	Reason      string        `db:"reason" json:"reason"`
+	CreatedBy   uuid.NullUUID `db:"created_by" json:"created_by"`
+	ExpiresAt   *time.Time    `db:"expires_at" json:"expires_at"`
+	Restriction string        `db:"restriction" json:"restriction"`
+}
+
+func (q *Queries) CreateUserSuspension(ctx context.Context, arg CreateUserSuspensionParams) (UserSuspension, error) {
+	row := q.db.QueryRow(ctx, createUserSuspension,
+		arg.ID,
+		arg.UserID,
+		arg.Reason,
+		arg.CreatedBy,
+		arg.ExpiresAt,
+		arg.Restriction,
+	)
+	var i UserSuspension
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Reason,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LiftedAt,
+		&i.LiftedBy,
+		&i.Restriction,
+	)
+	return i, err
+}
+
+const createUserTOTP = `-- name: CreateUserTOTP :exec
+INSERT INTO user_totp (user_id, secret, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+WHERE user_totp.enabled_at IS NULL
+`
+
+type CreateUserTOTPParams struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Secret string    `db:"secret" json:"secret"`
+}
+
+// Starts a new setup, replacing a previous one that was never confirmed
+func (q *Queries) CreateUserTOTP(ctx context.Context, arg CreateUserTOTPParams) error {
+	_, err := q.db.Exec(ctx, createUserTOTP, arg.UserID, arg.Secret)
+	return err
+}
+
+const deleteExpiredLockouts = `-- name: DeleteExpiredLockouts :exec
+DELETE FROM lockouts
+WHERE last_attempt_at < $1
+  AND (locked_until IS NULL OR locked_until <= NOW())
+`
+
+func (q *Queries) DeleteExpiredLockouts(ctx context.Context, windowStart time.Time) error {
+	_, err := q.db.Exec(ctx, deleteExpiredLockouts, windowStart)
+	return err
+}
+
+const deleteExpiredUserSessions = `-- name: DeleteExpiredUserSessions :exec
+DELETE FROM user_sessions WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredUserSessions(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteExpiredUserSessions)
+	return err
+}
+
+const deleteLockout = `-- name: DeleteLockout :exec
+DELETE FROM lockouts WHERE scope = $1 AND key = $2
+`
+
+type DeleteLockoutParams struct {
+	Scope string `db:"scope" json:"scope"`
+	Key   string `db:"key" json:"key"`
+}
+
+func (q *Queries) DeleteLockout(ctx context.Context, arg DeleteLockoutParams) error {
+	_, err := q.db.Exec(ctx, deleteLockout, arg.Scope, arg.Key)
+	return err
+}
+
 const deleteUser = `-- name: DeleteUser :exec
 DELETE FROM users WHERE id = $1
 `
@@ -142,6 +302,15 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteUserRecoveryCodes = `-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserRecoveryCodes, userID)
+	return err
+}
+
 const deleteUserRoles = `-- name: DeleteUserRoles :exec
 DELETE FROM user_roles WHERE user_id = $1
 `
@@ -151,6 +320,331 @@ func (q *Queries) DeleteUserRoles(ctx context.Context, userID uuid.UUID) error {
 	return err
 }
 
+const deleteUserSession = `-- name: DeleteUserSession :exec
+DELETE FROM user_sessions WHERE id = $1
+`
+
+func (q *Queries) DeleteUserSession(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserSession, id)
+	return err
+}
+
+const deleteUserSessions = `-- name: DeleteUserSessions :execrows
+DELETE FROM user_sessions
+WHERE user_id = $1 AND id IS DISTINCT FROM $2
+`
+
+type DeleteUserSessionsParams struct {
+	UserID   uuid.NullUUID `db:"user_id" json:"user_id"`
+	ExceptID uuid.NullUUID `db:"except_id" json:"except_id"`
+}
+
+// Logs out all sessions of a user, except the session making the request
+func (q *Queries) DeleteUserSessions(ctx context.Context, arg DeleteUserSessionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserSessions, arg.UserID, arg.ExceptID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUserTOTP = `-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserTOTP(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserTOTP, userID)
+	return err
+}
+
+const enableUserTOTP = `-- name: EnableUserTOTP :exec
+UPDATE user_totp SET enabled_at = NOW() WHERE user_id = $1
+`
+
+func (q *Queries) EnableUserTOTP(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, enableUserTOTP, userID)
+	return err
+}
+
+const exportUserEditComments = `-- name: ExportUserEditComments :many
+SELECT id, edit_id, user_id, created_at, text, updated_at, is_hidden, parent_id FROM edit_comments WHERE user_id = $1 ORDER BY created_at, id
+`
+
+func (q *Queries) ExportUserEditComments(ctx context.Context, userID uuid.NullUUID) ([]EditComment, error) {
+	rows, err := q.db.Query(ctx, exportUserEditComments, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EditComment{}
+	for rows.Next() {
+		var i EditComment
+		if err := rows.Scan(
+			&i.ID,
+			&i.EditID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.Text,
+			&i.UpdatedAt,
+			&i.IsHidden,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportUserEditVotes = `-- name: ExportUserEditVotes :many
+SELECT edit_id, user_id, created_at, vote FROM edit_votes WHERE user_id = $1 ORDER BY created_at, edit_id
+`
+
+func (q *Queries) ExportUserEditVotes(ctx context.Context, userID uuid.NullUUID) ([]EditVote, error) {
+	rows, err := q.db.Query(ctx, exportUserEditVotes, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EditVote{}
+	for rows.Next() {
+		var i EditVote
+		if err := rows.Scan(
+			&i.EditID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.Vote,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportUserEdits = `-- name: ExportUserEdits :many
+SELECT id, user_id, operation, target_type, data, votes, status, applied, created_at, updated_at, closed_at, bot, update_count FROM edits WHERE user_id = $1 ORDER BY created_at, id
+`
+
+func (q *Queries) ExportUserEdits(ctx context.Context, userID uuid.NullUUID) ([]Edit, error) {
+	rows, err := q.db.Query(ctx, exportUserEdits, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Edit{}
+	for rows.Next() {
+		var i Edit
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Operation,
+			&i.TargetType,
+			&i.Data,
+			&i.Votes,
+			&i.Status,
+			&i.Applied,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ClosedAt,
+			&i.Bot,
+			&i.UpdateCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportUserNotifications = `-- name: ExportUserNotifications :many
+SELECT user_id, type, id, created_at, read_at, data, emailed_at FROM notifications WHERE user_id = $1 ORDER BY created_at, id
+`
+
+func (q *Queries) ExportUserNotifications(ctx context.Context, userID uuid.UUID) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, exportUserNotifications, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Type,
+			&i.ID,
+			&i.CreatedAt,
+			&i.ReadAt,
+			&i.Data,
+			&i.EmailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportUserPerformerFavorites = `-- name: ExportUserPerformerFavorites :many
+SELECT performer_id, user_id, created_at FROM performer_favorites WHERE user_id = $1 ORDER BY created_at, performer_id
+`
+
+func (q *Queries) ExportUserPerformerFavorites(ctx context.Context, userID uuid.UUID) ([]PerformerFavorite, error) {
+	rows, err := q.db.Query(ctx, exportUserPerformerFavorites, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PerformerFavorite{}
+	for rows.Next() {
+		var i PerformerFavorite
+		if err := rows.Scan(
+			&i.PerformerID,
+			&i.UserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportUserSceneFingerprints = `-- name: ExportUserSceneFingerprints :many
+SELECT SF.scene_id, F.hash, F.algorithm, SF.duration, SF.vote, SF.created_at
+FROM scene_fingerprints SF
+JOIN fingerprints F ON SF.fingerprint_id = F.id
+WHERE SF.user_id = $1
+ORDER BY SF.created_at, SF.scene_id
+`
+
+type ExportUserSceneFingerprintsRow struct {
+	SceneID   uuid.UUID `db:"scene_id" json:"scene_id"`
+	Hash      int64     `db:"hash" json:"hash"`
+	Algorithm string    `db:"algorithm" json:"algorithm"`
+	Duration  int       `db:"duration" json:"duration"`
+	Vote      int16     `db:"vote" json:"vote"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+func (q *Queries) ExportUserSceneFingerprints(ctx context.Context, userID uuid.UUID) ([]ExportUserSceneFingerprintsRow, error) {
+	rows, err := q.db.Query(ctx, exportUserSceneFingerprints, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExportUserSceneFingerprintsRow{}
+	for rows.Next() {
+		var i ExportUserSceneFingerprintsRow
+		if err := rows.Scan(
+			&i.SceneID,
+			&i.Hash,
+			&i.Algorithm,
+			&i.Duration,
+			&i.Vote,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportUserStudioFavorites = `-- name: ExportUserStudioFavorites :many
+SELECT studio_id, user_id, created_at FROM studio_favorites WHERE user_id = $1 ORDER BY created_at, studio_id
+`
+
+func (q *Queries) ExportUserStudioFavorites(ctx context.Context, userID uuid.UUID) ([]StudioFavorite, error) {
+	rows, err := q.db.Query(ctx, exportUserStudioFavorites, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StudioFavorite{}
+	for rows.Next() {
+		var i StudioFavorite
+		if err := rows.Scan(
+			&i.StudioID,
+			&i.UserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findActiveUserSuspension = `-- name: FindActiveUserSuspension :one
+SELECT id, user_id, reason, created_by, created_at, expires_at, lifted_at, lifted_by, restriction FROM user_suspensions
+WHERE user_id = $1 AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) FindActiveUserSuspension(ctx context.Context, userID uuid.UUID) (UserSuspension, error) {
+	row := q.db.QueryRow(ctx, findActiveUserSuspension, userID)
+	var i UserSuspension
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Reason,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LiftedAt,
+		&i.LiftedBy,
+		&i.Restriction,
+	)
+	return i, err
+}
+
+const findLockout = `-- name: FindLockout :one
+SELECT id, scope, key, attempts, last_attempt_at, locked_until FROM lockouts WHERE scope = $1 AND key = $2
+`
+
+type FindLockoutParams struct {
+	Scope string `db:"scope" json:"scope"`
+	Key   string `db:"key" json:"key"`
+}
+
+func (q *Queries) FindLockout(ctx context.Context, arg FindLockoutParams) (Lockout, error) {
+	row := q.db.QueryRow(ctx, findLockout, arg.Scope, arg.Key)
+	var i Lockout
+	err := row.Scan(
+		&i.ID,
+		&i.Scope,
+		&i.Key,
+		&i.Attempts,
+		&i.LastAttemptAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
 const findUser = `-- name: FindUser :one
 SELECT id, name, password_hash, email, api_key, api_calls, last_api_call, created_at, updated_at, invited_by, invite_tokens FROM users WHERE id = $1
 `
@@ -167,55 +661,134 @@ func (q *Queries) FindUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.ApiCalls,
 		&i.LastApiCall,
 		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.InvitedBy,
-		&i.InviteTokens,
+		&i.UpdatedAt,
+		&i.InvitedBy,
+		&i.InviteTokens,
+	)
+	return i, err
+}
+
+const findUserByEmail = `-- name: FindUserByEmail :one
+SELECT id, name, password_hash, email, api_key, api_calls, last_api_call, created_at, updated_at, invited_by, invite_tokens FROM users WHERE UPPER(email) = UPPER($1)
+`
+
+func (q *Queries) FindUserByEmail(ctx context.Context, upper interface{}) (User, error) {
+	row := q.db.QueryRow(ctx, findUserByEmail, upper)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PasswordHash,
+		&i.Email,
+		&i.ApiKey,
+		&i.ApiCalls,
+		&i.LastApiCall,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.InvitedBy,
+		&i.InviteTokens,
+	)
+	return i, err
+}
+
+const findUserByName = `-- name: FindUserByName :one
+SELECT id, name, password_hash, email, api_key, api_calls, last_api_call, created_at, updated_at, invited_by, invite_tokens FROM users WHERE UPPER(name) = UPPER($1::text)
+`
+
+func (q *Queries) FindUserByName(ctx context.Context, name string) (User, error) {
+	row := q.db.QueryRow(ctx, findUserByName, name)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PasswordHash,
+		&i.Email,
+		&i.ApiKey,
+		&i.ApiCalls,
+		&i.LastApiCall,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.InvitedBy,
+		&i.InviteTokens,
+	)
+	return i, err
+}
+
+const findUserIdentity = `-- name: FindUserIdentity :one
+SELECT issuer, subject, user_id, created_at FROM user_identities WHERE issuer = $1 AND subject = $2
+`
+
+type FindUserIdentityParams struct {
+	Issuer  string `db:"issuer" json:"issuer"`
+	Subject string `db:"subject" json:"subject"`
+}
+
+func (q *Queries) FindUserIdentity(ctx context.Context, arg FindUserIdentityParams) (UserIdentity, error) {
+	row := q.db.QueryRow(ctx, findUserIdentity, arg.Issuer, arg.Subject)
+	var i UserIdentity
+	err := row.Scan(
+		&i.Issuer,
+		&i.Subject,
+		&i.UserID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const findUserSession = `-- name: FindUserSession :one
+SELECT id, user_id, data, user_agent, ip_address, created_at, last_seen_at, expires_at FROM user_sessions WHERE id = $1 AND expires_at > NOW()
+`
+
+func (q *Queries) FindUserSession(ctx context.Context, id uuid.UUID) (UserSession, error) {
+	row := q.db.QueryRow(ctx, findUserSession, id)
+	var i UserSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Data,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.LastSeenAt,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
 
-const findUserByEmail = `-- name: FindUserByEmail :one
-SELECT id, name, password_hash, email, api_key, api_calls, last_api_call, created_at, updated_at, invited_by, invite_tokens FROM users WHERE UPPER(email) = UPPER($1)
+const findUserSuspension = `-- name: FindUserSuspension :one
+SELECT id, user_id, reason, created_by, created_at, expires_at, lifted_at, lifted_by, restriction FROM user_suspensions WHERE id = $1
 `
 
-func (q *Queries) FindUserByEmail(ctx context.Context, upper interface{}) (User, error) {
-	row := q.db.QueryRow(ctx, findUserByEmail, upper)
-	var i User
+func (q *Queries) FindUserSuspension(ctx context.Context, id uuid.UUID) (UserSuspension, error) {
+	row := q.db.QueryRow(ctx, findUserSuspension, id)
+	var i UserSuspension
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.PasswordHash,
-		&i.Email,
-		&i.ApiKey,
-		&i.ApiCalls,
-		&i.LastApiCall,
+		&i.UserID,
+		&i.Reason,
+		&i.CreatedBy,
 		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.InvitedBy,
-		&i.InviteTokens,
+		&i.ExpiresAt,
+		&i.LiftedAt,
+		&i.LiftedBy,
+		&i.Restriction,
 	)
 	return i, err
 }
 
-const findUserByName = `-- name: FindUserByName :one
-SELECT id, name, password_hash, email, api_key, api_calls, last_api_call, created_at, updated_at, invited_by, invite_tokens FROM users WHERE UPPER(name) = UPPER($1::text)
+const findUserTOTP = `-- name: FindUserTOTP :one
+SELECT user_id, secret, enabled_at, last_used_step, created_at FROM user_totp WHERE user_id = $1
 `
 
-func (q *Queries) FindUserByName(ctx context.Context, name string) (User, error) {
-	row := q.db.QueryRow(ctx, findUserByName, name)
-	var i User
+func (q *Queries) FindUserTOTP(ctx context.Context, userID uuid.UUID) (UserTotp, error) {
+	row := q.db.QueryRow(ctx, findUserTOTP, userID)
+	var i UserTotp
 	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.PasswordHash,
-		&i.Email,
-		&i.ApiKey,
-		&i.ApiCalls,
-		&i.LastApiCall,
+		&i.UserID,
+		&i.Secret,
+		&i.EnabledAt,
+		&i.LastUsedStep,
 		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.InvitedBy,
-		&i.InviteTokens,
 	)
 	return i, err
 }
@@ -251,6 +824,105 @@ func (q *Queries) FindUserWithRoles(ctx context.Context, id uuid.UUID) (FindUser
 	return i, err
 }
 
+const findUsersByNames = `-- name: FindUsersByNames :many
+SELECT id, name FROM users WHERE UPPER(name) = ANY(SELECT UPPER(unnest($1::text[])))
+`
+
+type FindUsersByNamesRow struct {
+	ID   uuid.UUID `db:"id" json:"id"`
+	Name string    `db:"name" json:"name"`
+}
+
+func (q *Queries) FindUsersByNames(ctx context.Context, names []string) ([]FindUsersByNamesRow, error) {
+	rows, err := q.db.Query(ctx, findUsersByNames, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FindUsersByNamesRow{}
+	for rows.Next() {
+		var i FindUsersByNamesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInviteTree = `-- name: GetInviteTree :many
+WITH RECURSIVE tree AS (
+  SELECT id, invited_by, 0 AS depth, ARRAY[id] AS path
+  FROM users
+  WHERE id = $1
+  UNION ALL
+  SELECT U.id, U.invited_by, T.depth + 1, T.path || U.id
+  FROM users U
+  JOIN tree T ON U.invited_by = T.id
+  WHERE NOT U.id = ANY(T.path)
+)
+SELECT T.id AS user_id, T.invited_by, T.depth::int AS depth,
+  COALESCE(E.accepted, 0)::int AS accepted_edits,
+  COALESCE(E.rejected, 0)::int AS rejected_edits,
+  COALESCE(E.pending, 0)::int AS pending_edits
+FROM tree T
+LEFT JOIN (
+  SELECT user_id,
+    COUNT(*) FILTER (WHERE status IN ('ACCEPTED', 'IMMEDIATE_ACCEPTED')) AS accepted,
+    COUNT(*) FILTER (WHERE status IN ('REJECTED', 'IMMEDIATE_REJECTED')) AS rejected,
+    COUNT(*) FILTER (WHERE status = 'PENDING') AS pending
+  FROM edits
+  WHERE bot = FALSE AND user_id IN (SELECT id FROM tree)
+  GROUP BY user_id
+) E ON E.user_id = T.id
+ORDER BY T.path
+`
+
+type GetInviteTreeRow struct {
+	UserID        uuid.UUID     `db:"user_id" json:"user_id"`
+	InvitedBy     uuid.NullUUID `db:"invited_by" json:"invited_by"`
+	Depth         int           `db:"depth" json:"depth"`
+	AcceptedEdits int           `db:"accepted_edits" json:"accepted_edits"`
+	RejectedEdits int           `db:"rejected_edits" json:"rejected_edits"`
+	PendingEdits  int           `db:"pending_edits" json:"pending_edits"`
+}
+
+// The user and every user invited by them, directly or indirectly, in
+// depth-first order. depth is the number of invites between the user and
+// the root. Bot edits are not counted.
+func (q *Queries) GetInviteTree(ctx context.Context, id uuid.UUID) ([]GetInviteTreeRow, error) {
+	rows, err := q.db.Query(ctx, getInviteTree, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetInviteTreeRow{}
+	for rows.Next() {
+		var i GetInviteTreeRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.InvitedBy,
+			&i.Depth,
+			&i.AcceptedEdits,
+			&i.RejectedEdits,
+			&i.PendingEdits,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserNotificationSubscriptions = `-- name: GetUserNotificationSubscriptions :many
 SELECT type FROM user_notifications WHERE user_id = $1
 `
@@ -275,6 +947,94 @@ func (q *Queries) GetUserNotificationSubscriptions(ctx context.Context, userID u
 	return items, nil
 }
 
+const getUserReputationStats = `-- name: GetUserReputationStats :many
+SELECT U.id AS user_id,
+  ARRAY(SELECT role FROM user_roles WHERE user_id = U.id)::TEXT[] AS roles,
+  COALESCE(E.accepted, 0)::int AS accepted_edits,
+  COALESCE(E.rejected, 0)::int AS rejected_edits,
+  COALESCE(V.agreeing, 0)::int AS agreeing_votes,
+  COALESCE(V.disagreeing, 0)::int AS disagreeing_votes,
+  COALESCE(F.confirmed, 0)::int AS confirmed_reports,
+  COALESCE(F.disputed, 0)::int AS disputed_reports
+FROM users U
+LEFT JOIN (
+  SELECT user_id,
+    COUNT(*) FILTER (WHERE status IN ('ACCEPTED', 'IMMEDIATE_ACCEPTED')) AS accepted,
+    COUNT(*) FILTER (WHERE status IN ('REJECTED', 'IMMEDIATE_REJECTED')) AS rejected
+  FROM edits
+  WHERE bot = FALSE AND user_id IS NOT NULL
+  GROUP BY user_id
+) E ON E.user_id = U.id
+LEFT JOIN (
+  SELECT EV.user_id,
+    COUNT(*) FILTER (WHERE (EV.vote = 'ACCEPT' AND ED.status IN ('ACCEPTED', 'IMMEDIATE_ACCEPTED'))
+      OR (EV.vote = 'REJECT' AND ED.status IN ('REJECTED', 'IMMEDIATE_REJECTED'))) AS agreeing,
+    COUNT(*) FILTER (WHERE (EV.vote = 'ACCEPT' AND ED.status IN ('REJECTED', 'IMMEDIATE_REJECTED'))
+      OR (EV.vote = 'REJECT' AND ED.status IN ('ACCEPTED', 'IMMEDIATE_ACCEPTED'))) AS disagreeing
+  FROM edit_votes EV
+  JOIN edits ED ON ED.id = EV.edit_id
+  WHERE EV.user_id IS NOT NULL
+  GROUP BY EV.user_id
+) V ON V.user_id = U.id
+LEFT JOIN (
+  SELECT R.user_id,
+    COUNT(*) FILTER (WHERE O.net < 0) AS confirmed,
+    COUNT(*) FILTER (WHERE O.net > 0) AS disputed
+  FROM scene_fingerprints R
+  JOIN LATERAL (
+    SELECT SUM(vote) AS net FROM scene_fingerprints O
+    WHERE O.fingerprint_id = R.fingerprint_id AND O.scene_id = R.scene_id AND O.user_id != R.user_id
+  ) O ON TRUE
+  WHERE R.vote = -1
+  GROUP BY R.user_id
+) F ON F.user_id = U.id
+WHERE ($1::uuid IS NULL AND (E.user_id IS NOT NULL OR V.user_id IS NOT NULL OR F.user_id IS NOT NULL))
+  OR U.id = $1::uuid
+`
+
+type GetUserReputationStatsRow struct {
+	UserID           uuid.UUID `db:"user_id" json:"user_id"`
+	Roles            []string  `db:"roles" json:"roles"`
+	AcceptedEdits    int       `db:"accepted_edits" json:"accepted_edits"`
+	RejectedEdits    int       `db:"rejected_edits" json:"rejected_edits"`
+	AgreeingVotes    int       `db:"agreeing_votes" json:"agreeing_votes"`
+	DisagreeingVotes int       `db:"disagreeing_votes" json:"disagreeing_votes"`
+	ConfirmedReports int       `db:"confirmed_reports" json:"confirmed_reports"`
+	DisputedReports  int       `db:"disputed_reports" json:"disputed_reports"`
+}
+
+// Reputation inputs of a single user, or of every user with any edit, vote
+// or fingerprint report when user_id is null. Bot edits, failed edits and
+// fingerprint reports no other user has voted on are not counted.
+func (q *Queries) GetUserReputationStats(ctx context.Context, userID uuid.NullUUID) ([]GetUserReputationStatsRow, error) {
+	rows, err := q.db.Query(ctx, getUserReputationStats, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserReputationStatsRow{}
+	for rows.Next() {
+		var i GetUserReputationStatsRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Roles,
+			&i.AcceptedEdits,
+			&i.RejectedEdits,
+			&i.AgreeingVotes,
+			&i.DisagreeingVotes,
+			&i.ConfirmedReports,
+			&i.DisputedReports,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserRoles = `-- name: GetUserRoles :many
 SELECT role FROM user_roles WHERE user_id = $1
 `
@@ -299,6 +1059,75 @@ func (q *Queries) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string,
 	return items, nil
 }
 
+const getUserSessions = `-- name: GetUserSessions :many
+SELECT id, user_id, data, user_agent, ip_address, created_at, last_seen_at, expires_at FROM user_sessions
+WHERE user_id = $1 AND expires_at > NOW()
+ORDER BY last_seen_at DESC
+`
+
+func (q *Queries) GetUserSessions(ctx context.Context, userID uuid.NullUUID) ([]UserSession, error) {
+	rows, err := q.db.Query(ctx, getUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSession{}
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Data,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.LastSeenAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserSuspensions = `-- name: GetUserSuspensions :many
+SELECT id, user_id, reason, created_by, created_at, expires_at, lifted_at, lifted_by, restriction FROM user_suspensions WHERE user_id = $1 ORDER BY created_at DESC
+`
+
+func (q *Queries) GetUserSuspensions(ctx context.Context, userID uuid.UUID) ([]UserSuspension, error) {
+	rows, err := q.db.Query(ctx, getUserSuspensions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSuspension{}
+	for rows.Next() {
+		var i UserSuspension
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Reason,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.LiftedAt,
+			&i.LiftedBy,
+			&i.Restriction,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUsers = `-- name: GetUsers :many
 SELECT id, name, password_hash, email, api_key, api_calls, last_api_call, created_at, updated_at, invited_by, invite_tokens FROM users WHERE id = ANY($1::UUID[])
 `
@@ -335,6 +1164,178 @@ func (q *Queries) GetUsers(ctx context.Context, dollar_1 []uuid.UUID) ([]User, e
 	return items, nil
 }
 
+const liftExpiredUserSuspensions = `-- name: LiftExpiredUserSuspensions :many
+UPDATE user_suspensions
+SET lifted_at = NOW()
+WHERE lifted_at IS NULL AND expires_at <= NOW()
+RETURNING id, user_id, reason, created_by, created_at, expires_at, lifted_at, lifted_by, restriction
+`
+
+func (q *Queries) LiftExpiredUserSuspensions(ctx context.Context) ([]UserSuspension, error) {
+	rows, err := q.db.Query(ctx, liftExpiredUserSuspensions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSuspension{}
+	for rows.Next() {
+		var i UserSuspension
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Reason,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.LiftedAt,
+			&i.LiftedBy,
+			&i.Restriction,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const liftUserSuspensions = `-- name: LiftUserSuspensions :many
+UPDATE user_suspensions
+SET lifted_at = NOW(), lifted_by = $1
+WHERE user_id = $2 AND lifted_at IS NULL
+RETURNING id, user_id, reason, created_by, created_at, expires_at, lifted_at, lifted_by, restriction
+`
+
+type LiftUserSuspensionsParams struct {
+	LiftedBy uuid.NullUUID `db:"lifted_by" json:"lifted_by"`
+	UserID   uuid.UUID     `db:"user_id" json:"user_id"`
+}
+
+// Lifts every suspension of a user that has not been lifted yet, including
+// expired ones the cron job has not processed.
+func (q *Queries) LiftUserSuspensions(ctx context.Context, arg LiftUserSuspensionsParams) ([]UserSuspension, error) {
+	rows, err := q.db.Query(ctx, liftUserSuspensions, arg.LiftedBy, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSuspension{}
+	for rows.Next() {
+		var i UserSuspension
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Reason,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.LiftedAt,
+			&i.LiftedBy,
+			&i.Restriction,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const queryLockouts = `-- name: QueryLockouts :many
+SELECT id, scope, key, attempts, last_attempt_at, locked_until FROM lockouts
+WHERE last_attempt_at >= $1
+  AND (NOT $2::BOOLEAN OR locked_until > NOW())
+ORDER BY last_attempt_at DESC
+`
+
+type QueryLockoutsParams struct {
+	WindowStart time.Time `db:"window_start" json:"window_start"`
+	LockedOnly  bool      `db:"locked_only" json:"locked_only"`
+}
+
+func (q *Queries) QueryLockouts(ctx context.Context, arg QueryLockoutsParams) ([]Lockout, error) {
+	rows, err := q.db.Query(ctx, queryLockouts, arg.WindowStart, arg.LockedOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Lockout{}
+	for rows.Next() {
+		var i Lockout
+		if err := rows.Scan(
+			&i.ID,
+			&i.Scope,
+			&i.Key,
+			&i.Attempts,
+			&i.LastAttemptAt,
+			&i.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordLockoutAttempt = `-- name: RecordLockoutAttempt :one
+INSERT INTO lockouts (id, scope, key, attempts, last_attempt_at)
+VALUES ($1, $2, $3, 1, NOW())
+ON CONFLICT (scope, key) DO UPDATE
+SET attempts = CASE WHEN lockouts.last_attempt_at < $4 THEN 1 ELSE lockouts.attempts + 1 END,
+    last_attempt_at = NOW()
+RETURNING id, scope, key, attempts, last_attempt_at, locked_until
+`
+
+type RecordLockoutAttemptParams struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	Scope       string    `db:"scope" json:"scope"`
+	Key         string    `db:"key" json:"key"`
+	WindowStart time.Time `db:"window_start" json:"window_start"`
+}
+
+// Counts an attempt, restarting the count if there was no attempt since
+// window_start
+func (q *Queries) RecordLockoutAttempt(ctx context.Context, arg RecordLockoutAttemptParams) (Lockout, error) {
+	row := q.db.QueryRow(ctx, recordLockoutAttempt,
+		arg.ID,
+		arg.Scope,
+		arg.Key,
+		arg.WindowStart,
+	)
+	var i Lockout
+	err := row.Scan(
+		&i.ID,
+		&i.Scope,
+		&i.Key,
+		&i.Attempts,
+		&i.LastAttemptAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const setLockoutUntil = `-- name: SetLockoutUntil :exec
+UPDATE lockouts SET locked_until = $3 WHERE scope = $1 AND key = $2
+`
+
+type SetLockoutUntilParams struct {
+	Scope       string     `db:"scope" json:"scope"`
+	Key         string     `db:"key" json:"key"`
+	LockedUntil *time.Time `db:"locked_until" json:"locked_until"`
+}
+
+func (q *Queries) SetLockoutUntil(ctx context.Context, arg SetLockoutUntilParams) error {
+	_, err := q.db.Exec(ctx, setLockoutUntil, arg.Scope, arg.Key, arg.LockedUntil)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users 
 SET name = $2, password_hash = $3, email = $4, updated_at = NOW()
@@ -436,3 +1437,72 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
 	return err
 }
+
+const updateUserSession = `-- name: UpdateUserSession :execrows
+UPDATE user_sessions
+SET user_id = $2, data = $3, user_agent = $4, ip_address = $5, last_seen_at = NOW(), expires_at = $6
+WHERE id = $1
+`
+
+type UpdateUserSessionParams struct {
+	ID        uuid.UUID     `db:"id" json:"id"`
+	UserID    uuid.NullUUID `db:"user_id" json:"user_id"`
+	Data      []byte        `db:"data" json:"data"`
+	UserAgent string        `db:"user_agent" json:"user_agent"`
+	IpAddress string        `db:"ip_address" json:"ip_address"`
+	ExpiresAt time.Time     `db:"expires_at" json:"expires_at"`
+}
+
+// Does not recreate a session that was revoked in the meantime
+func (q *Queries) UpdateUserSession(ctx context.Context, arg UpdateUserSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserSession,
+		arg.ID,
+		arg.UserID,
+		arg.Data,
+		arg.UserAgent,
+		arg.IpAddress,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useUserRecoveryCode = `-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes SET used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+`
+
+type UseUserRecoveryCodeParams struct {
+	UserID   uuid.UUID `db:"user_id" json:"user_id"`
+	CodeHash string    `db:"code_hash" json:"code_hash"`
+}
+
+func (q *Queries) UseUserRecoveryCode(ctx context.Context, arg UseUserRecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useUserRecoveryCode, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useUserTOTPStep = `-- name: UseUserTOTPStep :execrows
+UPDATE user_totp SET last_used_step = $1
+WHERE user_id = $2 AND last_used_step < $1
+`
+
+type UseUserTOTPStepParams struct {
+	Step   int64     `db:"step" json:"step"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+// Records the time step of a used code, failing if a code of the same or a
+// later step has already been used.
+func (q *Queries) UseUserTOTPStep(ctx context.Context, arg UseUserTOTPStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useUserTOTPStep, arg.Step, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
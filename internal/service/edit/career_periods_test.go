@@ -0,0 +1,66 @@
+package edit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func TestValidateCareerPeriods(t *testing.T) {
+	period := func(start string, end string) models.PerformerCareerPeriod {
+		p := models.PerformerCareerPeriod{StartDate: start}
+		if end != "" {
+			p.EndDate = &end
+		}
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		periods []models.PerformerCareerPeriod
+		want    error
+	}{
+		{"none", nil, nil},
+		{"ongoing", []models.PerformerCareerPeriod{period("2015", "")}, nil},
+		{"comeback", []models.PerformerCareerPeriod{period("2010-03", "2014"), period("2018-06-01", "")}, nil},
+		{"adjacent fuzzy", []models.PerformerCareerPeriod{period("2010", "2014"), period("2014", "")}, nil},
+		{"invalid date", []models.PerformerCareerPeriod{period("2010-13", "")}, models.ErrInvalidDate},
+		{"ends before start", []models.PerformerCareerPeriod{period("2015", "2012")}, ErrInvalidCareerPeriod},
+		{"overlap", []models.PerformerCareerPeriod{period("2010", "2016"), period("2014", "")}, ErrCareerPeriodOverlap},
+		{"two ongoing", []models.PerformerCareerPeriod{period("2010", ""), period("2020", "")}, ErrCareerPeriodOverlap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCareerPeriods(context.Background(), nil, tt.periods)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCareerPeriodCompare(t *testing.T) {
+	end := "2014"
+	endCopy := "2014"
+	note := "retired"
+
+	existing := []models.PerformerCareerPeriod{
+		{StartDate: "2010", EndDate: &end},
+		{StartDate: "2018"},
+	}
+	updated := []models.PerformerCareerPeriod{
+		{StartDate: "2010", EndDate: &endCopy},
+		{StartDate: "2018", Note: &note},
+	}
+
+	added, removed := careerPeriodCompare(updated, existing)
+	if len(added) != 1 || added[0].Note == nil || *added[0].Note != note {
+		t.Errorf("added = %v", added)
+	}
+	if len(removed) != 1 || removed[0].StartDate != "2018" || removed[0].Note != nil {
+		t.Errorf("removed = %v", removed)
+	}
+}
@@ -0,0 +1,79 @@
+package edit
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+// Matches a whitespace-delimited @username. As with UUIDs, requiring leading
+// whitespace (or the start of the string) leaves email addresses and mentions
+// that are already part of a link untouched.
+var commentMentionRe = regexp.MustCompile(`(?:^|\s)@[\w.-]+`)
+
+// splitMention returns the username of a mention match, along with the
+// leading whitespace and trailing punctuation that are not part of it.
+func splitMention(match string) (prefix, name, suffix string) {
+	at := strings.Index(match, "@")
+	name = strings.TrimRight(match[at+1:], ".-")
+	return match[:at], name, match[at+1+len(name):]
+}
+
+// parseCommentMentions returns the distinct usernames mentioned in text.
+func parseCommentMentions(text string) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, m := range commentMentionRe.FindAllString(text, -1) {
+		_, name, _ := splitMention(m)
+		key := strings.ToUpper(name)
+		if _, ok := seen[key]; name != "" && !ok {
+			seen[key] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// replaceCommentMentions rewrites each mention of a user in users, keyed by
+// the upper case username, into a markdown link to the user's page.
+func replaceCommentMentions(text string, users map[string]string) string {
+	if len(users) == 0 {
+		return text
+	}
+	return commentMentionRe.ReplaceAllStringFunc(text, func(match string) string {
+		prefix, name, suffix := splitMention(match)
+		username, ok := users[strings.ToUpper(name)]
+		if !ok {
+			return match
+		}
+		return prefix + "[@" + username + "](/users/" + url.PathEscape(username) + ")" + suffix
+	})
+}
+
+// linkCommentMentions rewrites @username mentions of existing users into
+// links to their page, returning the ids of the mentioned users.
+func linkCommentMentions(ctx context.Context, q *queries.Queries, text string) (string, []uuid.UUID, error) {
+	names := parseCommentMentions(text)
+	if len(names) == 0 {
+		return text, nil, nil
+	}
+
+	rows, err := q.FindUsersByNames(ctx, names)
+	if err != nil {
+		return text, nil, err
+	}
+
+	users := make(map[string]string, len(rows))
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		users[strings.ToUpper(row.Name)] = row.Name
+		ids = append(ids, row.ID)
+	}
+
+	return replaceCommentMentions(text, users), ids, nil
+}
@@ -0,0 +1,85 @@
+package edit
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseCommentMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"none", "no mentions here", nil},
+		{"bare", "thanks @alice", []string{"alice"}},
+		{"at start", "@alice see above", []string{"alice"}},
+		{"trailing punctuation", "ask @alice.", []string{"alice"}},
+		{"dots and dashes", "ask @a.b-c", []string{"a.b-c"}},
+		{"multiple", "@alice and @bob", []string{"alice", "bob"}},
+		{"deduplicated case insensitively", "@alice and @Alice", []string{"alice"}},
+		{"email skipped", "mail alice@example.com", nil},
+		{"inside markdown link skipped", "[@alice](/users/alice)", nil},
+		{"lone at skipped", "meet @ noon", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCommentMentions(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceCommentMentions(t *testing.T) {
+	users := map[string]string{
+		"ALICE":     "Alice",
+		"BOB.JONES": "bob.jones",
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "mention linked with the canonical name",
+			text: "thanks @alice",
+			want: "thanks [@Alice](/users/Alice)",
+		},
+		{
+			name: "mention at start linked without leading space",
+			text: "@alice see above",
+			want: "[@Alice](/users/Alice) see above",
+		},
+		{
+			name: "trailing punctuation preserved",
+			text: "ask @bob.jones.",
+			want: "ask [@bob.jones](/users/bob.jones).",
+		},
+		{
+			name: "unknown user left bare",
+			text: "ask @carol",
+			want: "ask @carol",
+		},
+		{
+			name: "email untouched",
+			text: "mail alice@example.com",
+			want: "mail alice@example.com",
+		},
+		{
+			name: "existing markdown link untouched",
+			text: "[@alice](/users/alice)",
+			want: "[@alice](/users/alice)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceCommentMentions(tt.text, users); got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
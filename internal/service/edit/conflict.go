@@ -0,0 +1,107 @@
+package edit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+// editsConflict returns whether two edits of the same entities change the
+// same fields. Merges and deletions change every field.
+func editsConflict(a, b *models.Edit) bool {
+	for _, edit := range []*models.Edit{a, b} {
+		if edit.Operation == models.OperationEnumDestroy.String() || edit.Operation == models.OperationEnumMerge.String() {
+			return true
+		}
+	}
+
+	fields := changedFields(b)
+	for _, field := range changedFields(a) {
+		if slices.Contains(fields, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateConflicts links the edit to the pending edits changing the same
+// fields of its target or merge sources, replacing its previous links.
+func (m *mutator) UpdateConflicts(input *models.EditInput) error {
+	if err := m.queries.DeleteEditConflicts(m.context, m.edit.ID); err != nil {
+		return err
+	}
+
+	var entityIDs []uuid.UUID
+	if input.ID != nil {
+		entityIDs = append(entityIDs, *input.ID)
+	}
+	entityIDs = append(entityIDs, input.MergeSourceIds...)
+	if len(entityIDs) == 0 {
+		return nil
+	}
+
+	pending, err := m.queries.FindPendingEditsForEntities(m.context, queries.FindPendingEditsForEntitiesParams{
+		EditID:    m.edit.ID,
+		EntityIds: entityIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	var conflicts []uuid.UUID
+	for _, edit := range pending {
+		if editsConflict(m.edit, converter.EditToModelPtr(edit)) {
+			conflicts = append(conflicts, edit.ID)
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return m.queries.CreateEditConflicts(m.context, queries.CreateEditConflictsParams{
+		EditID:             m.edit.ID,
+		ConflictingEditIds: conflicts,
+	})
+}
+
+func (s *Edit) GetConflictingEdits(ctx context.Context, editID uuid.UUID) ([]models.Edit, error) {
+	edits, err := s.queries.GetConflictingEdits(ctx, editID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.EditsToModels(edits), nil
+}
+
+// warnConflictingEdits comments on the pending edits conflicting with an
+// applied edit, so that voters know they may fail to apply.
+func (s *Edit) warnConflictingEdits(ctx context.Context, applied *models.Edit) error {
+	edits, err := s.queries.GetConflictingEdits(ctx, applied.ID)
+	if err != nil {
+		return err
+	}
+
+	modBotID := getModBot(ctx, s.queries)
+	text := fmt.Sprintf("###### Conflicting edit applied: ######\n[%s](/edits/%s), which changes the same fields, was applied. This edit may fail to apply unless it is updated.", applied.ID, applied.ID)
+	for _, edit := range edits {
+		if edit.Status != models.VoteStatusEnumPending.String() {
+			continue
+		}
+
+		commentID, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		comment := models.NewEditComment(commentID, modBotID, converter.EditToModelPtr(edit), text)
+		if _, err := s.queries.CreateEditComment(ctx, converter.EditCommentToCreateParams(*comment)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
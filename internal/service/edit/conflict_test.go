@@ -0,0 +1,44 @@
+package edit
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func TestEditsConflict(t *testing.T) {
+	edit := func(operation models.OperationEnum, details models.PerformerEdit) *models.Edit {
+		e := &models.Edit{Operation: operation.String()}
+		if err := e.SetData(models.PerformerEditData{New: &details}); err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+
+	name := "Name"
+	country := "Country"
+	rename := edit(models.OperationEnumModify, models.PerformerEdit{Name: &name})
+	addAlias := edit(models.OperationEnumModify, models.PerformerEdit{AddedAliases: []string{"alias"}})
+	removeAlias := edit(models.OperationEnumModify, models.PerformerEdit{RemovedAliases: []string{"other"}, Country: &country})
+	merge := edit(models.OperationEnumMerge, models.PerformerEdit{})
+
+	tests := []struct {
+		name string
+		a, b *models.Edit
+		want bool
+	}{
+		{"same field", rename, rename, true},
+		{"different fields", rename, addAlias, false},
+		{"added and removed", addAlias, removeAlias, true},
+		{"merge", merge, addAlias, true},
+		{"merged", rename, merge, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editsConflict(tt.a, tt.b); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
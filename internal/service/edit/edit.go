@@ -5,7 +5,9 @@ import (
 	"errors"
 
 	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
 
+	"github.com/stashapp/stash-box/internal/auth"
 	"github.com/stashapp/stash-box/internal/converter"
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/internal/queries"
@@ -46,6 +48,14 @@ func (m *mutator) CreateEdit() (*models.Edit, error) {
 }
 
 func (m *mutator) UpdateEdit() (*models.Edit, error) {
+	_, err := m.queries.FindEditGroupByEdit(m.context, m.edit.ID)
+	if err == nil {
+		return nil, ErrUpdateGroupedEdit
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
 	m.edit.UpdateCount++
 	updatedEdit, err := m.queries.UpdateEdit(m.context, converter.EditToUpdateParams(*m.edit))
 	if err != nil {
@@ -55,11 +65,18 @@ func (m *mutator) UpdateEdit() (*models.Edit, error) {
 	if err := m.queries.ResetVotes(m.context, m.edit.ID); err != nil {
 		return nil, err
 	}
+	// the updated edit has to pass the vote again
+	if err := m.queries.DequeueEditForModerator(m.context, m.edit.ID); err != nil {
+		return nil, err
+	}
 	return converter.EditToModelPtr(updatedEdit), nil
 }
 
 func (m *mutator) CreateComment(userID uuid.UUID, comment *string) error {
 	if comment != nil && len(*comment) > 0 {
+		if err := auth.ValidateComment(m.context); err != nil {
+			return err
+		}
 		text, err := linkCommentEntities(m.context, m.queries, *comment)
 		if err != nil {
 			return err
@@ -77,6 +94,23 @@ type editApplyer interface {
 	apply() error
 }
 
+func newEditApplyer(ctx context.Context, tx *queries.Queries, edit *models.Edit) editApplyer {
+	var targetType models.TargetTypeEnum
+	utils.ResolveEnumString(edit.TargetType, &targetType)
+
+	switch targetType {
+	case models.TargetTypeEnumTag:
+		return Tag(ctx, tx, edit)
+	case models.TargetTypeEnumPerformer:
+		return Performer(ctx, tx, edit)
+	case models.TargetTypeEnumStudio:
+		return Studio(ctx, tx, edit)
+	case models.TargetTypeEnumScene:
+		return Scene(ctx, tx, edit)
+	}
+	return nil
+}
+
 func urlCompare(subject []models.URL, against []models.URL) (added []models.URL, missing []models.URL) {
 	for _, s := range subject {
 		newMod := true
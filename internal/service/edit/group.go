@@ -0,0 +1,387 @@
+package edit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/errutil"
+)
+
+var ErrEmptyBulkChanges = errors.New("bulk edit must change at least one field")
+var ErrBulkEditLimit = errors.New("too many entities match the bulk edit filter")
+var ErrBulkAddedAndRemoved = errors.New("cannot both add and remove the same item")
+var ErrUpdateGroupedEdit = errors.New("edits of a bulk edit cannot be updated")
+
+// bulkChange is the edit generated by a bulk edit for a single entity
+type bulkChange struct {
+	entityID uuid.UUID
+	data     any
+}
+
+// groupEdits returns the edits applied, rejected or failed together with an
+// edit: the edit itself, followed by the other pending edits of its group.
+func groupEdits(ctx context.Context, tx *queries.Queries, edit *models.Edit) ([]*models.Edit, error) {
+	ret := []*models.Edit{edit}
+
+	group, err := tx.FindEditGroupByEdit(ctx, edit.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ret, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := tx.GetEditGroupEdits(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member.ID != edit.ID && member.Status == models.VoteStatusEnumPending.String() {
+			ret = append(ret, converter.EditToModelPtr(member))
+		}
+	}
+	return ret, nil
+}
+
+// matchBulkEdit returns the entities matching a bulk edit filter, up to the
+// bulk edit limit. One match more than the limit is fetched to tell whether
+// the limit is exceeded.
+func matchBulkEdit(find func(limit int) ([]uuid.UUID, error)) ([]uuid.UUID, error) {
+	limit := config.GetBulkEditLimit()
+	ids, err := find(limit + 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > limit {
+		return nil, fmt.Errorf("%w: more than %d matches", ErrBulkEditLimit, limit)
+	}
+	return ids, nil
+}
+
+// uniqueItems returns the items without duplicates, in their original order
+func uniqueItems[T comparable](items []T) []T {
+	var ret []T
+	for _, item := range items {
+		if !slices.Contains(ret, item) {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+func validateBulkLists[T comparable](added, removed []T) error {
+	for _, item := range added {
+		if slices.Contains(removed, item) {
+			return fmt.Errorf("%w: %v", ErrBulkAddedAndRemoved, item)
+		}
+	}
+	return nil
+}
+
+// bulkSceneChanges returns the edits changing the scenes matching a bulk
+// edit. Scenes the change makes no difference to are skipped.
+func (s *Edit) bulkSceneChanges(ctx context.Context, tx *queries.Queries, input models.BulkSceneEditInput) ([]bulkChange, []models.Scene, error) {
+	changes := *input.Changes
+	changes.AddedTags = uniqueItems(changes.AddedTags)
+	changes.RemovedTags = uniqueItems(changes.RemovedTags)
+	if changes.StudioID == nil && len(changes.AddedTags) == 0 && len(changes.RemovedTags) == 0 {
+		return nil, nil, ErrEmptyBulkChanges
+	}
+	if err := validateBulkLists(changes.AddedTags, changes.RemovedTags); err != nil {
+		return nil, nil, err
+	}
+
+	if changes.StudioID != nil {
+		if _, err := tx.FindStudio(ctx, *changes.StudioID); err != nil {
+			return nil, nil, fmt.Errorf("%w: studio %s", ErrEntityNotFound, *changes.StudioID)
+		}
+	}
+	tagIDs := slices.Concat(changes.AddedTags, changes.RemovedTags)
+	if tags, err := tx.FindTagsByIds(ctx, tagIDs); err != nil || len(tags) < len(tagIDs) {
+		return nil, nil, fmt.Errorf("%w: tag", ErrEntityNotFound)
+	}
+
+	currentUser := auth.GetCurrentUser(ctx)
+	ids, err := matchBulkEdit(func(limit int) ([]uuid.UUID, error) {
+		return s.scenes.FindMatching(ctx, *input.Filter, currentUser.ID, limit)
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, nil, err
+	}
+
+	sceneTags, err := tx.FindTagIdsBySceneIds(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	currentTags := make(map[uuid.UUID][]uuid.UUID)
+	for _, st := range sceneTags {
+		currentTags[st.SceneID] = append(currentTags[st.SceneID], st.TagID)
+	}
+
+	var ret []bulkChange
+	var scenes []models.Scene
+	for _, id := range ids {
+		dbScene, err := tx.FindScene(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		scene := converter.SceneToModel(dbScene)
+
+		newData := models.SceneEdit{}
+		oldData := models.SceneEdit{}
+		if changes.StudioID != nil && scene.StudioID != (uuid.NullUUID{UUID: *changes.StudioID, Valid: true}) {
+			newData.StudioID = changes.StudioID
+			if scene.StudioID.Valid {
+				oldData.StudioID = &scene.StudioID.UUID
+			}
+		}
+		for _, tagID := range changes.AddedTags {
+			if !slices.Contains(currentTags[id], tagID) {
+				newData.AddedTags = append(newData.AddedTags, tagID)
+			}
+		}
+		for _, tagID := range changes.RemovedTags {
+			if slices.Contains(currentTags[id], tagID) {
+				newData.RemovedTags = append(newData.RemovedTags, tagID)
+			}
+		}
+
+		if reflect.DeepEqual(newData, models.SceneEdit{}) {
+			continue
+		}
+
+		ret = append(ret, bulkChange{
+			entityID: id,
+			data:     models.SceneEditData{New: &newData, Old: &oldData},
+		})
+		scenes = append(scenes, scene)
+	}
+
+	return ret, scenes, nil
+}
+
+// bulkPerformerChanges returns the edits changing the performers matching a
+// bulk edit. Performers the change makes no difference to are skipped.
+func (s *Edit) bulkPerformerChanges(ctx context.Context, tx *queries.Queries, input models.BulkPerformerEditInput) ([]bulkChange, []models.Performer, error) {
+	changes := *input.Changes
+	changes.AddedAliases = uniqueItems(changes.AddedAliases)
+	changes.RemovedAliases = uniqueItems(changes.RemovedAliases)
+	if changes.Country == nil && len(changes.AddedAliases) == 0 && len(changes.RemovedAliases) == 0 {
+		return nil, nil, ErrEmptyBulkChanges
+	}
+	if err := validateBulkLists(changes.AddedAliases, changes.RemovedAliases); err != nil {
+		return nil, nil, err
+	}
+
+	currentUser := auth.GetCurrentUser(ctx)
+	ids, err := matchBulkEdit(func(limit int) ([]uuid.UUID, error) {
+		return s.performers.FindMatching(ctx, *input.Filter, currentUser.ID, limit)
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, nil, err
+	}
+
+	var ret []bulkChange
+	var performers []models.Performer
+	for _, id := range ids {
+		dbPerformer, err := tx.FindPerformer(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		performer := converter.PerformerToModel(dbPerformer)
+
+		aliases, err := tx.GetPerformerAliases(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		newData := models.PerformerEdit{}
+		oldData := models.PerformerEdit{}
+		if changes.Country != nil && (performer.Country == nil || *performer.Country != *changes.Country) {
+			newData.Country = changes.Country
+			oldData.Country = performer.Country
+		}
+		for _, alias := range changes.AddedAliases {
+			if alias != performer.Name && !slices.Contains(aliases, alias) {
+				newData.AddedAliases = append(newData.AddedAliases, alias)
+			}
+		}
+		for _, alias := range changes.RemovedAliases {
+			if slices.Contains(aliases, alias) {
+				newData.RemovedAliases = append(newData.RemovedAliases, alias)
+			}
+		}
+
+		if reflect.DeepEqual(newData, models.PerformerEdit{}) {
+			continue
+		}
+
+		ret = append(ret, bulkChange{
+			entityID: id,
+			data:     models.PerformerEditData{New: &newData, Old: &oldData},
+		})
+		performers = append(performers, performer)
+	}
+
+	return ret, performers, nil
+}
+
+// BulkSceneEditPreview returns the scenes a bulk edit would change
+func (s *Edit) BulkSceneEditPreview(ctx context.Context, input models.BulkSceneEditInput) ([]models.Scene, error) {
+	_, scenes, err := s.bulkSceneChanges(ctx, s.queries, input)
+	return scenes, err
+}
+
+// BulkPerformerEditPreview returns the performers a bulk edit would change
+func (s *Edit) BulkPerformerEditPreview(ctx context.Context, input models.BulkPerformerEditInput) ([]models.Performer, error) {
+	_, performers, err := s.bulkPerformerChanges(ctx, s.queries, input)
+	return performers, err
+}
+
+// CreateBulkSceneEdit creates an edit for every scene changed by a bulk edit,
+// grouped so that they are applied together.
+func (s *Edit) CreateBulkSceneEdit(ctx context.Context, input models.BulkSceneEditInput) (*models.EditGroup, error) {
+	return s.createEditGroup(ctx, models.TargetTypeEnumScene, input.Comment, input.Bot, func(tx *queries.Queries) ([]bulkChange, error) {
+		changes, _, err := s.bulkSceneChanges(ctx, tx, input)
+		return changes, err
+	})
+}
+
+// CreateBulkPerformerEdit creates an edit for every performer changed by a
+// bulk edit, grouped so that they are applied together.
+func (s *Edit) CreateBulkPerformerEdit(ctx context.Context, input models.BulkPerformerEditInput) (*models.EditGroup, error) {
+	return s.createEditGroup(ctx, models.TargetTypeEnumPerformer, input.Comment, input.Bot, func(tx *queries.Queries) ([]bulkChange, error) {
+		changes, _, err := s.bulkPerformerChanges(ctx, tx, input)
+		return changes, err
+	})
+}
+
+func (s *Edit) createEditGroup(ctx context.Context, targetType models.TargetTypeEnum, comment *string, bot *bool, bulkChanges func(tx *queries.Queries) ([]bulkChange, error)) (*models.EditGroup, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+	if err := validateBotEdit(ctx, &models.EditInput{Bot: bot}); err != nil {
+		return nil, err
+	}
+
+	var group queries.EditGroup
+	err := s.withTxn(func(tx *queries.Queries) error {
+		changes, err := bulkChanges(tx)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return ErrNoChanges
+		}
+
+		groupID, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		group, err = tx.CreateEditGroup(ctx, queries.CreateEditGroupParams{
+			ID:         groupID,
+			UserID:     uuid.NullUUID{UUID: currentUser.ID, Valid: true},
+			TargetType: targetType.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		var editIDs []uuid.UUID
+		for _, change := range changes {
+			id, err := uuid.NewV7()
+			if err != nil {
+				return err
+			}
+			editInput := &models.EditInput{
+				ID:        &change.entityID,
+				Operation: models.OperationEnumModify,
+				Bot:       bot,
+				Comment:   comment,
+			}
+			m := &mutator{
+				context: ctx,
+				queries: tx,
+				edit:    models.NewEdit(id, currentUser.ID, targetType, editInput),
+			}
+			if err := m.edit.SetData(change.data); err != nil {
+				return err
+			}
+
+			if err := validateEditLocks(ctx, tx, m.edit, editInput); err != nil {
+				return err
+			}
+
+			if _, err := m.CreateEdit(); err != nil {
+				return err
+			}
+			if err := m.createJoin(targetType, editInput); err != nil {
+				return err
+			}
+			if err := m.UpdateConflicts(editInput); err != nil {
+				return err
+			}
+			if err := m.CreateComment(currentUser.ID, comment); err != nil {
+				return err
+			}
+			editIDs = append(editIDs, id)
+		}
+
+		return tx.CreateEditGroupEdits(ctx, queries.CreateEditGroupEditsParams{
+			GroupID: group.ID,
+			EditIds: editIDs,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.EditGroupToModelPtr(group), nil
+}
+
+func (s *Edit) FindEditGroup(ctx context.Context, id uuid.UUID) (*models.EditGroup, error) {
+	group, err := s.queries.FindEditGroup(ctx, id)
+	if err != nil {
+		return nil, errutil.IgnoreNotFound(err)
+	}
+	return converter.EditGroupToModelPtr(group), nil
+}
+
+func (s *Edit) GetEditGroup(ctx context.Context, editID uuid.UUID) (*models.EditGroup, error) {
+	group, err := s.queries.FindEditGroupByEdit(ctx, editID)
+	if err != nil {
+		return nil, errutil.IgnoreNotFound(err)
+	}
+	return converter.EditGroupToModelPtr(group), nil
+}
+
+func (s *Edit) GetEditGroupEdits(ctx context.Context, groupID uuid.UUID) ([]models.Edit, error) {
+	edits, err := s.queries.GetEditGroupEdits(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.EditsToModels(edits), nil
+}
+
+func (m *mutator) createJoin(targetType models.TargetTypeEnum, input *models.EditInput) error {
+	switch targetType {
+	case models.TargetTypeEnumPerformer:
+		return Performer(m.context, m.queries, m.edit).CreateJoin(models.PerformerEditInput{Edit: input})
+	case models.TargetTypeEnumScene:
+		return Scene(m.context, m.queries, m.edit).CreateJoin(models.SceneEditInput{Edit: input})
+	case models.TargetTypeEnumStudio:
+		return Studio(m.context, m.queries, m.edit).CreateJoin(models.StudioEditInput{Edit: input})
+	case models.TargetTypeEnumTag:
+		return Tag(m.context, m.queries, m.edit).CreateJoin(models.TagEditInput{Edit: input})
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package edit
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/config"
+)
+
+func TestValidateBulkLists(t *testing.T) {
+	if err := validateBulkLists([]string{"a", "b"}, []string{"c"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateBulkLists(nil, []string{"c"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateBulkLists([]string{"a", "b"}, []string{"b"}); !errors.Is(err, ErrBulkAddedAndRemoved) {
+		t.Errorf("err = %v, want %v", err, ErrBulkAddedAndRemoved)
+	}
+}
+
+func TestMatchBulkEdit(t *testing.T) {
+	prevLimit := config.C.BulkEditLimit
+	config.C.BulkEditLimit = 2
+	defer func() { config.C.BulkEditLimit = prevLimit }()
+
+	ids := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+	find := func(matches int) func(limit int) ([]uuid.UUID, error) {
+		return func(limit int) ([]uuid.UUID, error) {
+			// one more than the limit is fetched
+			if limit != 3 {
+				t.Errorf("limit = %d, want 3", limit)
+			}
+			return ids[:min(matches, limit)], nil
+		}
+	}
+
+	matched, err := matchBulkEdit(find(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matched) != 2 {
+		t.Errorf("matched %d ids, want 2", len(matched))
+	}
+
+	_, err = matchBulkEdit(find(1000))
+	if !errors.Is(err, ErrBulkEditLimit) {
+		t.Errorf("err = %v, want %v", err, ErrBulkEditLimit)
+	}
+
+	queryErr := errors.New("query failed")
+	_, err = matchBulkEdit(func(int) ([]uuid.UUID, error) { return nil, queryErr })
+	if !errors.Is(err, queryErr) {
+		t.Errorf("err = %v, want %v", err, queryErr)
+	}
+}
+
+func TestUniqueItems(t *testing.T) {
+	a := uuid.Must(uuid.NewV4())
+	b := uuid.Must(uuid.NewV4())
+	if got := uniqueItems([]uuid.UUID{a, b, a, a}); !slices.Equal(got, []uuid.UUID{a, b}) {
+		t.Errorf("uniqueItems = %v, want [%s %s]", got, a, b)
+	}
+	if got := uniqueItems[string](nil); got != nil {
+		t.Errorf("uniqueItems(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,337 @@
+package edit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+	"github.com/stashapp/stash-box/pkg/utils"
+)
+
+var ErrEntityLocked = errors.New("entity is locked")
+var ErrEmptyLockReason = errors.New("lock reason is required")
+var ErrLockExpired = errors.New("lock expiry must be in the future")
+var ErrInvalidLockField = errors.New("invalid lock field")
+var ErrLockNotFound = errors.New("lock not found")
+
+// lockableFields are the fields of each entity type that can be locked,
+// named as in the edit data
+var lockableFields = map[models.TargetTypeEnum][]string{
+	models.TargetTypeEnumPerformer: editFields(models.PerformerEdit{}),
+	models.TargetTypeEnumScene:     editFields(models.SceneEdit{}),
+	models.TargetTypeEnumStudio:    editFields(models.StudioEdit{}),
+	models.TargetTypeEnumTag:       editFields(models.TagEdit{}),
+}
+
+// editFieldName strips the prefix of the added and removed lists of an edit,
+// so that both are named as the field they change
+func editFieldName(name string) string {
+	name = strings.TrimPrefix(name, "added_")
+	return strings.TrimPrefix(name, "removed_")
+}
+
+// editFields returns the fields of an edit details type
+func editFields(details any) []string {
+	var ret []string
+	t := reflect.TypeOf(details)
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || name == "draft_id" {
+			continue
+		}
+		name = editFieldName(name)
+		if !slices.Contains(ret, name) {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// changedFields returns the fields changed by an edit
+func changedFields(edit *models.Edit) []string {
+	var ret []string
+	data := edit.GetData()
+	if data == nil {
+		return ret
+	}
+
+	for _, details := range []*json.RawMessage{data.New, data.Old} {
+		if details == nil {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(*details, &fields); err != nil {
+			continue
+		}
+		for name, value := range fields {
+			if name == "draft_id" || string(value) == "null" {
+				continue
+			}
+			name = editFieldName(name)
+			if !slices.Contains(ret, name) {
+				ret = append(ret, name)
+			}
+		}
+	}
+	return ret
+}
+
+// lockCovers returns whether a lock applies to an edit changing the fields.
+// Merges and deletions change every field.
+func lockCovers(lock queries.EntityLock, operation models.OperationEnum, fields []string) bool {
+	if len(lock.Fields) == 0 || operation == models.OperationEnumDestroy || operation == models.OperationEnumMerge {
+		return true
+	}
+	for _, field := range fields {
+		if slices.Contains(lock.Fields, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// editLock returns the strictest active lock on the entities that applies to
+// the edit, or nil if the edit is not restricted.
+func editLock(ctx context.Context, tx *queries.Queries, edit *models.Edit, entityIDs []uuid.UUID) (*queries.EntityLock, error) {
+	if len(entityIDs) == 0 {
+		return nil, nil
+	}
+
+	locks, err := tx.GetActiveEntityLocks(ctx, entityIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var operation models.OperationEnum
+	utils.ResolveEnumString(edit.Operation, &operation)
+	fields := changedFields(edit)
+
+	var ret *queries.EntityLock
+	for i, lock := range locks {
+		if !lockCovers(lock, operation, fields) {
+			continue
+		}
+		if lock.Level == models.EntityLockLevelEnumLocked.String() {
+			return &locks[i], nil
+		}
+		if ret == nil {
+			ret = &locks[i]
+		}
+	}
+	return ret, nil
+}
+
+// requiresModerator returns whether an edit, or another edit of its bulk
+// edit, changes locked fields, and may therefore only be applied by a
+// moderator rather than by votes.
+func (s *Edit) requiresModerator(ctx context.Context, edit *models.Edit) (bool, error) {
+	edits, err := groupEdits(ctx, s.queries, edit)
+	if err != nil {
+		return false, err
+	}
+
+	for _, e := range edits {
+		if e.Operation == models.OperationEnumCreate.String() {
+			continue
+		}
+
+		target, err := s.queries.GetEditTargetID(ctx, e.ID)
+		if err != nil {
+			return false, err
+		}
+		entityIDs := []uuid.UUID{target.ID}
+		if data := e.GetData(); data != nil {
+			entityIDs = append(entityIDs, data.MergeSources...)
+		}
+
+		lock, err := editLock(ctx, s.queries, e, entityIDs)
+		if err != nil || lock != nil {
+			return lock != nil, err
+		}
+	}
+	return false, nil
+}
+
+// queueForModerator adds an edit which passed the vote but changes locked
+// fields, together with the other edits of its bulk edit, to the moderator
+// queue. The first time an edit is queued a comment is posted on it, which
+// notifies its submitter and voters.
+func (s *Edit) queueForModerator(ctx context.Context, edit *models.Edit) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		edits, err := groupEdits(ctx, tx, edit)
+		if err != nil {
+			return err
+		}
+
+		modBotID := getModBot(ctx, tx)
+		text := "###### Awaiting moderator: ######\nThis edit passed the vote, but changes locked fields and can only be applied by a moderator."
+		for _, e := range edits {
+			queued, err := tx.QueueEditForModerator(ctx, e.ID)
+			if err != nil {
+				return err
+			}
+			if queued == 0 {
+				continue
+			}
+
+			commentID, err := uuid.NewV7()
+			if err != nil {
+				return err
+			}
+			comment := models.NewEditComment(commentID, modBotID, e, text)
+			if _, err := tx.CreateEditComment(ctx, converter.EditCommentToCreateParams(*comment)); err != nil {
+				return err
+			}
+			if err := tx.TriggerEditCommentNotifications(ctx, commentID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type entityLockAuditState struct {
+	Fields  []string   `json:"fields"`
+	Level   string     `json:"level"`
+	Reason  string     `json:"reason"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+func newEntityLockAuditState(lock queries.EntityLock) *entityLockAuditState {
+	return &entityLockAuditState{
+		Fields:  lock.Fields,
+		Level:   lock.Level,
+		Reason:  lock.Reason,
+		Expires: lock.ExpiresAt,
+	}
+}
+
+func findLockTarget(ctx context.Context, tx *queries.Queries, targetType models.TargetTypeEnum, id uuid.UUID) error {
+	var err error
+	switch targetType {
+	case models.TargetTypeEnumPerformer:
+		_, err = tx.FindPerformer(ctx, id)
+	case models.TargetTypeEnumScene:
+		_, err = tx.FindScene(ctx, id)
+	case models.TargetTypeEnumStudio:
+		_, err = tx.FindStudio(ctx, id)
+	case models.TargetTypeEnumTag:
+		_, err = tx.FindTag(ctx, id)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s %s", ErrEntityNotFound, strings.ToLower(targetType.String()), id)
+	}
+	return err
+}
+
+// GetEntityLocks returns the active locks on an entity
+func (s *Edit) GetEntityLocks(ctx context.Context, id uuid.UUID) ([]models.EntityLock, error) {
+	locks, err := s.queries.GetActiveEntityLocks(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	return converter.EntityLocksToModels(locks), nil
+}
+
+// LockEntity protects an entity, or some of its fields, from edits
+func (s *Edit) LockEntity(ctx context.Context, input models.EntityLockInput) (*models.EntityLock, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, ErrEmptyLockReason
+	}
+	if input.Expires != nil && !input.Expires.After(time.Now()) {
+		return nil, ErrLockExpired
+	}
+
+	fields := []string{}
+	for _, field := range input.Fields {
+		if !slices.Contains(lockableFields[input.TargetType], field) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidLockField, field)
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	var createdBy uuid.NullUUID
+	if currentUser := auth.GetCurrentUser(ctx); currentUser != nil {
+		createdBy = uuid.NullUUID{UUID: currentUser.ID, Valid: true}
+	}
+
+	var lock queries.EntityLock
+	err := s.withTxn(func(tx *queries.Queries) error {
+		if err := findLockTarget(ctx, tx, input.TargetType, input.TargetID); err != nil {
+			return err
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		lock, err = tx.CreateEntityLock(ctx, queries.CreateEntityLockParams{
+			ID:         id,
+			TargetType: input.TargetType.String(),
+			TargetID:   input.TargetID,
+			Fields:     fields,
+			Level:      input.Level.String(),
+			Reason:     reason,
+			CreatedBy:  createdBy,
+			ExpiresAt:  input.Expires,
+		})
+		if err != nil {
+			return err
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionENTITYLOCK,
+			TargetID:   lock.TargetID,
+			TargetType: lock.TargetType,
+			After:      newEntityLockAuditState(lock),
+			Reason:     &reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.EntityLockToModelPtr(lock), nil
+}
+
+// UnlockEntity removes a lock from an entity
+func (s *Edit) UnlockEntity(ctx context.Context, input models.EntityUnlockInput) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		lock, err := tx.FindEntityLock(ctx, input.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrLockNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.DeleteEntityLock(ctx, lock.ID); err != nil {
+			return err
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionENTITYUNLOCK,
+			TargetID:   lock.TargetID,
+			TargetType: lock.TargetType,
+			Before:     newEntityLockAuditState(lock),
+			Reason:     input.Reason,
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package edit
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+func TestEditFields(t *testing.T) {
+	fields := editFields(models.PerformerEdit{})
+	for _, field := range []string{"name", "aliases", "urls", "images", "career_periods"} {
+		if !slices.Contains(fields, field) {
+			t.Errorf("missing field %s in %v", field, fields)
+		}
+	}
+	for _, field := range []string{"draft_id", "added_aliases", "removed_aliases"} {
+		if slices.Contains(fields, field) {
+			t.Errorf("unexpected field %s in %v", field, fields)
+		}
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	edit := &models.Edit{}
+	name := "New Name"
+	if err := edit.SetData(models.StudioEditData{
+		New: &models.StudioEdit{Name: &name, AddedAliases: []string{"alias"}},
+		Old: &models.StudioEdit{RemovedImages: nil},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := changedFields(edit)
+	slices.Sort(fields)
+	if !slices.Equal(fields, []string{"aliases", "name"}) {
+		t.Errorf("got %v", fields)
+	}
+}
+
+func TestLockCovers(t *testing.T) {
+	nameLock := queries.EntityLock{Fields: []string{"name"}}
+	entityLock := queries.EntityLock{Fields: []string{}}
+
+	tests := []struct {
+		name      string
+		lock      queries.EntityLock
+		operation models.OperationEnum
+		fields    []string
+		want      bool
+	}{
+		{"locked field", nameLock, models.OperationEnumModify, []string{"aliases", "name"}, true},
+		{"other field", nameLock, models.OperationEnumModify, []string{"aliases"}, false},
+		{"whole entity", entityLock, models.OperationEnumModify, []string{"aliases"}, true},
+		{"destroy", nameLock, models.OperationEnumDestroy, nil, true},
+		{"merge", nameLock, models.OperationEnumMerge, []string{"aliases"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lockCovers(tt.lock, tt.operation, tt.fields); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
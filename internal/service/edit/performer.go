@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/gofrs/uuid"
 
@@ -44,7 +45,11 @@ func (m *PerformerEditProcessor) Edit(input models.PerformerEditInput, inputArgs
 		err = m.createEdit(input, inputArgs)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return validateEditLocks(m.context, m.queries, m.edit, input.Edit)
 }
 
 func (m *PerformerEditProcessor) modifyEdit(input models.PerformerEditInput, inputArgs utils.ArgumentsQuery) error {
@@ -147,6 +152,8 @@ func (m *PerformerEditProcessor) createEdit(input models.PerformerEditInput, inp
 	performerEdit.New.AddedPiercings = converter.BodyModInputToModel(input.Details.Piercings)
 	performerEdit.New.AddedImages = input.Details.ImageIds
 	performerEdit.New.AddedUrls = input.Details.Urls
+	performerEdit.New.AddedRelationships = input.Details.Relationships
+	performerEdit.New.AddedCareerPeriods = converter.CareerPeriodInputToModel(input.Details.CareerPeriods)
 	performerEdit.New.DraftID = input.Details.DraftID
 
 	return m.edit.SetData(*performerEdit)
@@ -367,6 +374,18 @@ func (m *PerformerEditProcessor) diffRelationships(performerEdit *models.Perform
 		}
 	}
 
+	if input.Details.Relationships != nil || inputArgs.Field("relationships").IsNull() {
+		if err := m.diffPerformerRelationships(performerEdit, performerID, input.Details.Relationships); err != nil {
+			return err
+		}
+	}
+
+	if input.Details.CareerPeriods != nil || inputArgs.Field("career_periods").IsNull() {
+		if err := m.diffCareerPeriods(performerEdit, performerID, converter.CareerPeriodInputToModel(input.Details.CareerPeriods)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -456,6 +475,75 @@ func (m *PerformerEditProcessor) diffImages(performerEdit *models.PerformerEditD
 	return nil
 }
 
+func (m *PerformerEditProcessor) diffPerformerRelationships(performerEdit *models.PerformerEditData, performerID uuid.UUID, newRelationships []models.PerformerRelationshipInput) error {
+	dbRelationships, err := m.queries.GetPerformerRelationships(m.context, performerID)
+	if err != nil {
+		return err
+	}
+
+	var relationships []models.PerformerRelationshipInput
+	for _, rel := range dbRelationships {
+		relationships = append(relationships, models.PerformerRelationshipInput{
+			PerformerID: rel.RelatedPerformerID,
+			Type:        models.PerformerRelationshipTypeEnum(rel.Type),
+		})
+	}
+	performerEdit.New.AddedRelationships, performerEdit.New.RemovedRelationships = utils.SliceCompare(newRelationships, relationships)
+
+	return nil
+}
+
+func (m *PerformerEditProcessor) diffCareerPeriods(performerEdit *models.PerformerEditData, performerID uuid.UUID, newPeriods []models.PerformerCareerPeriod) error {
+	dbPeriods, err := m.queries.GetPerformerCareerPeriods(m.context, performerID)
+	if err != nil {
+		return err
+	}
+
+	var periods []models.PerformerCareerPeriod
+	for _, period := range dbPeriods {
+		periods = append(periods, converter.CareerPeriodToModel(period))
+	}
+	performerEdit.New.AddedCareerPeriods, performerEdit.New.RemovedCareerPeriods = careerPeriodCompare(newPeriods, periods)
+
+	return nil
+}
+
+func careerPeriodEqual(a, b models.PerformerCareerPeriod) bool {
+	return a.StartDate == b.StartDate &&
+		ptrEqual(a.EndDate, b.EndDate) &&
+		ptrEqual(a.StudioID, b.StudioID) &&
+		ptrEqual(a.Note, b.Note)
+}
+
+func ptrEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func careerPeriodCompare(subject []models.PerformerCareerPeriod, against []models.PerformerCareerPeriod) (added []models.PerformerCareerPeriod, missing []models.PerformerCareerPeriod) {
+	contains := func(periods []models.PerformerCareerPeriod, period models.PerformerCareerPeriod) bool {
+		return slices.ContainsFunc(periods, func(p models.PerformerCareerPeriod) bool {
+			return careerPeriodEqual(p, period)
+		})
+	}
+
+	for _, p := range subject {
+		if !contains(against, p) && !contains(added, p) {
+			added = append(added, p)
+		}
+	}
+
+	for _, p := range against {
+		if !contains(subject, p) && !contains(missing, p) {
+			missing = append(missing, p)
+		}
+	}
+
+	return
+}
+
 func (m *PerformerEditProcessor) SoftDelete(performer models.Performer) (*models.Performer, error) {
 	// Delete joins
 	if err := m.queries.DeletePerformerAliases(m.context, performer.ID); err != nil {
@@ -473,6 +561,12 @@ func (m *PerformerEditProcessor) SoftDelete(performer models.Performer) (*models
 	if err := m.queries.DeletePerformerImages(m.context, performer.ID); err != nil {
 		return nil, err
 	}
+	if err := m.queries.DeletePerformerRelationships(m.context, performer.ID); err != nil {
+		return nil, err
+	}
+	if err := m.queries.DeletePerformerCareerPeriods(m.context, performer.ID); err != nil {
+		return nil, err
+	}
 
 	ret, err := m.queries.SoftDeletePerformer(m.context, performer.ID)
 	return converter.PerformerToModelPtr(ret), err
@@ -532,6 +626,13 @@ func (m *PerformerEditProcessor) MergeInto(source *models.Performer, target *mod
 		return fmt.Errorf("merge target performer is deleted: %s", target.ID.String())
 	}
 
+	if err := m.queries.ReassignPerformerRelationships(m.context, queries.ReassignPerformerRelationshipsParams{
+		OldPerformerID: source.ID,
+		NewPerformerID: target.ID,
+	}); err != nil {
+		return err
+	}
+
 	if _, err := m.SoftDelete(*source); err != nil {
 		return err
 	}
@@ -592,6 +693,14 @@ func (m *PerformerEditProcessor) ApplyEdit(performer *models.Performer, create b
 		return err
 	}
 
+	if err := m.updateRelationshipsFromEdit(performer.ID, data); err != nil {
+		return err
+	}
+
+	if err := m.updateCareerPeriodsFromEdit(performer.ID, data); err != nil {
+		return err
+	}
+
 	if data.New.Name != nil && data.SetModifyAliases {
 		if err = m.UpdateScenePerformerAlias(performer.ID, *data.Old.Name, *data.New.Name); err != nil {
 			return err
@@ -720,3 +829,76 @@ func (m *PerformerEditProcessor) updateImagesFromEdit(performerID uuid.UUID, dat
 	_, err = m.queries.CreatePerformerImages(m.context, images)
 	return err
 }
+
+func (m *PerformerEditProcessor) updateRelationshipsFromEdit(performerID uuid.UUID, data *models.PerformerEditData) error {
+	for _, rel := range data.New.RemovedRelationships {
+		if err := m.queries.DeletePerformerRelationship(m.context, queries.DeletePerformerRelationshipParams{
+			PerformerID:        performerID,
+			RelatedPerformerID: rel.PerformerID,
+			Type:               rel.Type.String(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, rel := range data.New.AddedRelationships {
+		// the related performer may have been deleted or merged since the edit was submitted
+		if err := validateRelatedPerformer(m.context, m.queries, rel.PerformerID); err != nil {
+			return err
+		}
+		if rel.PerformerID == performerID {
+			return ErrSelfRelationship
+		}
+
+		if err := m.queries.CreatePerformerRelationship(m.context, queries.CreatePerformerRelationshipParams{
+			PerformerID:        performerID,
+			RelatedPerformerID: rel.PerformerID,
+			Type:               rel.Type.String(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *PerformerEditProcessor) updateCareerPeriodsFromEdit(performerID uuid.UUID, data *models.PerformerEditData) error {
+	rows, err := m.queries.GetEditPerformerCareerPeriods(m.context, m.edit.ID)
+	if err != nil {
+		return err
+	}
+
+	var periods []models.PerformerCareerPeriod
+	var periodParams []queries.CreatePerformerCareerPeriodsParams
+	for _, row := range rows {
+		periods = append(periods, converter.CareerPeriodToModel(queries.PerformerCareerPeriod{
+			StartDate: row.StartDate,
+			EndDate:   row.EndDate,
+			StudioID:  row.StudioID,
+			Note:      row.Note,
+		}))
+		periodParams = append(periodParams, queries.CreatePerformerCareerPeriodsParams{
+			PerformerID: performerID,
+			StartDate:   row.StartDate,
+			EndDate:     row.EndDate,
+			StudioID:    row.StudioID,
+			Note:        row.Note,
+		})
+	}
+
+	// other edits may have changed the career history since this edit was submitted
+	if err := validateCareerPeriods(m.context, m.queries, periods); err != nil {
+		return err
+	}
+
+	if err := m.queries.DeletePerformerCareerPeriods(m.context, performerID); err != nil {
+		return err
+	}
+
+	if len(periodParams) == 0 {
+		return nil
+	}
+
+	_, err = m.queries.CreatePerformerCareerPeriods(m.context, periodParams)
+	return err
+}
@@ -187,6 +187,13 @@ func (s *Edit) buildEditQuery(psql sq.StatementBuilderType, filter models.EditQu
 	if filter.IncludeUserSubmitted != nil && !*filter.IncludeUserSubmitted {
 		query = query.Where(sq.NotEq{"edits.user_id": userID})
 	}
+	if filter.AwaitingModerator != nil {
+		awaiting := "edits.id IN (SELECT edit_id FROM edit_moderator_queue) AND edits.status = 'PENDING'"
+		if !*filter.AwaitingModerator {
+			awaiting = "NOT (" + awaiting + ")"
+		}
+		query = query.Where(awaiting)
+	}
 
 	return query, nil
 }
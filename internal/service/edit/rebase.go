@@ -0,0 +1,722 @@
+package edit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/models/validator"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/errutil"
+	"github.com/stashapp/stash-box/pkg/utils"
+)
+
+var ErrUnauthorizedRebase = errors.New("only the creator can rebase edits")
+var ErrRebaseStatus = errors.New("only failed edits and pending edits with an applied conflicting edit can be rebased")
+var ErrRebaseOperation = errors.New("only modify edits can be rebased")
+var ErrAlreadyRebased = errors.New("edit has already been rebased")
+
+var rebasePermissions = map[models.TargetTypeEnum]models.PermissionEnum{
+	models.TargetTypeEnumPerformer: models.PermissionEnumEditPerformers,
+	models.TargetTypeEnumScene:     models.PermissionEnumEditScenes,
+	models.TargetTypeEnumStudio:    models.PermissionEnumEditStudios,
+	models.TargetTypeEnumTag:       models.PermissionEnumEditTags,
+}
+
+// editValidator checks the prerequisites of edit data against the current
+// state of the edit target
+type editValidator func(data []byte) error
+
+func targetValidator(ctx context.Context, tx *queries.Queries, targetType models.TargetTypeEnum, id uuid.UUID) (editValidator, error) {
+	var entity editEntity
+	var validate editValidator
+
+	switch targetType {
+	case models.TargetTypeEnumPerformer:
+		dbPerformer, err := tx.FindPerformer(ctx, id)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if err == nil {
+			performer := converter.PerformerToModel(dbPerformer)
+			entity = performer
+			validate = func(data []byte) error {
+				var editData models.PerformerEditData
+				if err := json.Unmarshal(data, &editData); err != nil {
+					return err
+				}
+				return performer.ValidateModifyEdit(editData)
+			}
+		}
+	case models.TargetTypeEnumScene:
+		dbScene, err := tx.FindScene(ctx, id)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if err == nil {
+			scene := converter.SceneToModel(dbScene)
+			entity = scene
+			validate = func(data []byte) error {
+				var editData models.SceneEditData
+				if err := json.Unmarshal(data, &editData); err != nil {
+					return err
+				}
+				return scene.ValidateModifyEdit(editData)
+			}
+		}
+	case models.TargetTypeEnumStudio:
+		dbStudio, err := tx.FindStudio(ctx, id)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if err == nil {
+			studio := converter.StudioToModel(dbStudio)
+			entity = studio
+			validate = func(data []byte) error {
+				var editData models.StudioEditData
+				if err := json.Unmarshal(data, &editData); err != nil {
+					return err
+				}
+				return studio.ValidateModifyEdit(editData)
+			}
+		}
+	case models.TargetTypeEnumTag:
+		dbTag, err := tx.FindTag(ctx, id)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if err == nil {
+			tag := converter.TagToModel(dbTag)
+			entity = tag
+			validate = func(data []byte) error {
+				var editData models.TagEditData
+				if err := json.Unmarshal(data, &editData); err != nil {
+					return err
+				}
+				return tag.ValidateModifyEdit(editData)
+			}
+		}
+	}
+
+	if entity == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrEntityNotFound, strings.ToLower(targetType.String()), id)
+	}
+	if err := validateEditEntity(&entity, id, strings.ToLower(targetType.String())); err != nil {
+		return nil, err
+	}
+	return validate, nil
+}
+
+// rebaseEditData removes the changes to fields whose previous value no longer
+// matches the current state of the target, returning the remaining data and
+// the removed fields. Added and removed list items are kept, since they are
+// merged with the current lists when the edit is applied.
+func rebaseEditData(data []byte, validate editValidator) (map[string]json.RawMessage, []string, error) {
+	var editData map[string]json.RawMessage
+	if err := json.Unmarshal(data, &editData); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse edit data: %w", err)
+	}
+
+	newData := map[string]json.RawMessage{}
+	oldData := map[string]json.RawMessage{}
+	if raw, ok := editData["new_data"]; ok {
+		if err := json.Unmarshal(raw, &newData); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse edit data: %w", err)
+		}
+	}
+	if raw, ok := editData["old_data"]; ok {
+		if err := json.Unmarshal(raw, &oldData); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse edit data: %w", err)
+		}
+	}
+
+	// the draft has been used by the original edit
+	delete(newData, "draft_id")
+
+	var fields []string
+	for field := range oldData {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	var dropped []string
+	for _, field := range fields {
+		check, err := json.Marshal(map[string]map[string]json.RawMessage{
+			"old_data": {field: oldData[field]},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = validate(check)
+		var prerequisiteErr *validator.ErrEditPrerequisiteFailed
+		if errors.As(err, &prerequisiteErr) {
+			delete(newData, field)
+			delete(oldData, field)
+			dropped = append(dropped, field)
+		} else if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(newData) == 0 && len(oldData) == 0 {
+		return nil, dropped, ErrNoChanges
+	}
+
+	var err error
+	if editData["new_data"], err = json.Marshal(newData); err != nil {
+		return nil, nil, err
+	}
+	if editData["old_data"], err = json.Marshal(oldData); err != nil {
+		return nil, nil, err
+	}
+	return editData, dropped, nil
+}
+
+// clearedFields returns the fields of rebased edit data whose value is
+// removed by the edit.
+func clearedFields(data map[string]json.RawMessage) ([]string, error) {
+	newData := map[string]json.RawMessage{}
+	oldData := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data["new_data"], &newData); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data["old_data"], &oldData); err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for field, value := range oldData {
+		if string(value) == "null" {
+			continue
+		}
+		if newValue, ok := newData[field]; !ok || string(newValue) == "null" {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+	return fields, nil
+}
+
+// rebaseList applies the added and removed items of an edit to the current
+// list of the target. Nil is returned if the edit doesn't change the list.
+func rebaseList[T any](added, removed []T, current func() ([]T, error)) ([]T, error) {
+	if len(added) == 0 && len(removed) == 0 {
+		return nil, nil
+	}
+
+	items, err := current()
+	if err != nil {
+		return nil, err
+	}
+
+	contains := func(list []T, item T) bool {
+		return slices.ContainsFunc(list, func(i T) bool {
+			return reflect.DeepEqual(i, item)
+		})
+	}
+
+	ret := []T{}
+	for _, item := range items {
+		if !contains(removed, item) {
+			ret = append(ret, item)
+		}
+	}
+	for _, item := range added {
+		if !contains(ret, item) {
+			ret = append(ret, item)
+		}
+	}
+	return ret, nil
+}
+
+// rebaseEdit converts the rebased edit data back into edit input and creates
+// the new edit through the edit processor of the target type, validating it
+// like any other edit against the current state of the target. Scalar values
+// are taken from the new data, and lists are the current lists of the target
+// with the added and removed items of the edit applied.
+func rebaseEdit(ctx context.Context, tx *queries.Queries, edit *models.Edit, targetType models.TargetTypeEnum, editInput *models.EditInput, data map[string]json.RawMessage) (*models.Edit, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cleared, err := clearedFields(data)
+	if err != nil {
+		return nil, err
+	}
+	nulls := map[string]interface{}{}
+	for _, field := range cleared {
+		nulls[field] = nil
+	}
+	inputArgs := utils.NewArguments(map[string]interface{}{"details": nulls})
+
+	switch targetType {
+	case models.TargetTypeEnumPerformer:
+		return rebasePerformerEdit(ctx, tx, edit, editInput, raw, inputArgs)
+	case models.TargetTypeEnumScene:
+		return rebaseSceneEdit(ctx, tx, edit, editInput, raw, inputArgs)
+	case models.TargetTypeEnumStudio:
+		return rebaseStudioEdit(ctx, tx, edit, editInput, raw, inputArgs)
+	case models.TargetTypeEnumTag:
+		return rebaseTagEdit(ctx, tx, edit, editInput, raw, inputArgs)
+	}
+	return nil, fmt.Errorf("unsupported target type: %s", targetType)
+}
+
+// rebaseDetails reads the scalar values of the new edit data into edit
+// details input, whose fields share the names of the edit data fields.
+func rebaseDetails(data []byte, details any) error {
+	var editData struct {
+		New json.RawMessage `json:"new_data"`
+	}
+	if err := json.Unmarshal(data, &editData); err != nil {
+		return err
+	}
+	return json.Unmarshal(editData.New, details)
+}
+
+func rebaseSceneEdit(ctx context.Context, tx *queries.Queries, edit *models.Edit, editInput *models.EditInput, data []byte, inputArgs utils.ArgumentsQuery) (*models.Edit, error) {
+	var editData models.SceneEditData
+	if err := json.Unmarshal(data, &editData); err != nil {
+		return nil, err
+	}
+	details := &models.SceneEditDetailsInput{}
+	if err := rebaseDetails(data, details); err != nil {
+		return nil, err
+	}
+
+	sceneID := *editInput.ID
+	var err error
+	details.Urls, err = rebaseList(editData.New.AddedUrls, editData.New.RemovedUrls, func() ([]models.URL, error) {
+		rows, err := tx.GetSceneURLs(ctx, sceneID)
+		var urls []models.URL
+		for _, row := range rows {
+			urls = append(urls, models.URL{URL: row.Url, SiteID: row.SiteID})
+		}
+		return urls, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.TagIds, err = rebaseList(editData.New.AddedTags, editData.New.RemovedTags, func() ([]uuid.UUID, error) {
+		tags, err := tx.FindTagsBySceneID(ctx, sceneID)
+		var ids []uuid.UUID
+		for _, tag := range tags {
+			ids = append(ids, tag.ID)
+		}
+		return ids, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.ImageIds, err = rebaseList(editData.New.AddedImages, editData.New.RemovedImages, func() ([]uuid.UUID, error) {
+		images, err := tx.FindImagesBySceneID(ctx, sceneID)
+		var ids []uuid.UUID
+		for _, image := range images {
+			ids = append(ids, image.ID)
+		}
+		return ids, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.Performers, err = rebaseList(editData.New.AddedPerformers, editData.New.RemovedPerformers, func() ([]models.PerformerAppearanceInput, error) {
+		rows, err := tx.GetScenePerformers(ctx, sceneID)
+		var performers []models.PerformerAppearanceInput
+		for _, row := range rows {
+			performers = append(performers, models.PerformerAppearanceInput{PerformerID: row.Performer.ID, As: row.As})
+		}
+		return performers, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	input := models.SceneEditInput{Edit: editInput, Details: details}
+	p := Scene(ctx, tx, edit)
+	if err := p.Edit(input, inputArgs, false); err != nil {
+		return nil, err
+	}
+	newEdit, err := p.CreateEdit()
+	if err != nil {
+		return nil, err
+	}
+	return newEdit, p.CreateJoin(input)
+}
+
+func rebasePerformerEdit(ctx context.Context, tx *queries.Queries, edit *models.Edit, editInput *models.EditInput, data []byte, inputArgs utils.ArgumentsQuery) (*models.Edit, error) {
+	var editData models.PerformerEditData
+	if err := json.Unmarshal(data, &editData); err != nil {
+		return nil, err
+	}
+	details := &models.PerformerEditDetailsInput{}
+	if err := rebaseDetails(data, details); err != nil {
+		return nil, err
+	}
+
+	performerID := *editInput.ID
+	var err error
+	details.Aliases, err = rebaseList(editData.New.AddedAliases, editData.New.RemovedAliases, func() ([]string, error) {
+		return tx.GetPerformerAliases(ctx, performerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.Urls, err = rebaseList(editData.New.AddedUrls, editData.New.RemovedUrls, func() ([]models.URL, error) {
+		rows, err := tx.GetPerformerURLs(ctx, performerID)
+		var urls []models.URL
+		for _, row := range rows {
+			urls = append(urls, models.URL{URL: row.Url, SiteID: row.SiteID})
+		}
+		return urls, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	tattoos, err := rebaseList(editData.New.AddedTattoos, editData.New.RemovedTattoos, func() ([]models.BodyModification, error) {
+		rows, err := tx.GetPerformerTattoos(ctx, performerID)
+		var mods []models.BodyModification
+		for _, row := range rows {
+			mods = append(mods, bodyMod(row.Location, row.Description))
+		}
+		return mods, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.Tattoos = bodyModsToInput(tattoos)
+	piercings, err := rebaseList(editData.New.AddedPiercings, editData.New.RemovedPiercings, func() ([]models.BodyModification, error) {
+		rows, err := tx.GetPerformerPiercings(ctx, performerID)
+		var mods []models.BodyModification
+		for _, row := range rows {
+			mods = append(mods, bodyMod(row.Location, row.Description))
+		}
+		return mods, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.Piercings = bodyModsToInput(piercings)
+	details.ImageIds, err = rebaseList(editData.New.AddedImages, editData.New.RemovedImages, func() ([]uuid.UUID, error) {
+		images, err := tx.GetPerformerImages(ctx, performerID)
+		var ids []uuid.UUID
+		for _, image := range images {
+			ids = append(ids, image.ID)
+		}
+		return ids, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.Relationships, err = rebaseList(editData.New.AddedRelationships, editData.New.RemovedRelationships, func() ([]models.PerformerRelationshipInput, error) {
+		rows, err := tx.GetPerformerRelationships(ctx, performerID)
+		var relationships []models.PerformerRelationshipInput
+		for _, row := range rows {
+			relationships = append(relationships, models.PerformerRelationshipInput{
+				PerformerID: row.RelatedPerformerID,
+				Type:        models.PerformerRelationshipTypeEnum(row.Type),
+			})
+		}
+		return relationships, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	periods, err := rebaseList(editData.New.AddedCareerPeriods, editData.New.RemovedCareerPeriods, func() ([]models.PerformerCareerPeriod, error) {
+		rows, err := tx.GetPerformerCareerPeriods(ctx, performerID)
+		var periods []models.PerformerCareerPeriod
+		for _, row := range rows {
+			periods = append(periods, converter.CareerPeriodToModel(row))
+		}
+		return periods, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if periods != nil {
+		details.CareerPeriods = []models.PerformerCareerPeriodInput{}
+		for _, period := range periods {
+			details.CareerPeriods = append(details.CareerPeriods, models.PerformerCareerPeriodInput(period))
+		}
+	}
+
+	input := models.PerformerEditInput{
+		Edit:    editInput,
+		Details: details,
+		Options: &models.PerformerEditOptionsInput{SetModifyAliases: &editData.SetModifyAliases},
+	}
+	p := Performer(ctx, tx, edit)
+	if err := p.Edit(input, inputArgs, false); err != nil {
+		return nil, err
+	}
+	newEdit, err := p.CreateEdit()
+	if err != nil {
+		return nil, err
+	}
+	return newEdit, p.CreateJoin(input)
+}
+
+func bodyMod(location, description *string) models.BodyModification {
+	mod := models.BodyModification{Description: description}
+	if location != nil {
+		mod.Location = *location
+	}
+	return mod
+}
+
+func bodyModsToInput(mods []models.BodyModification) []models.BodyModificationInput {
+	if mods == nil {
+		return nil
+	}
+	ret := []models.BodyModificationInput{}
+	for _, mod := range mods {
+		ret = append(ret, models.BodyModificationInput(mod))
+	}
+	return ret
+}
+
+func rebaseStudioEdit(ctx context.Context, tx *queries.Queries, edit *models.Edit, editInput *models.EditInput, data []byte, inputArgs utils.ArgumentsQuery) (*models.Edit, error) {
+	var editData models.StudioEditData
+	if err := json.Unmarshal(data, &editData); err != nil {
+		return nil, err
+	}
+	details := &models.StudioEditDetailsInput{}
+	if err := rebaseDetails(data, details); err != nil {
+		return nil, err
+	}
+
+	studioID := *editInput.ID
+	var err error
+	details.Urls, err = rebaseList(editData.New.AddedUrls, editData.New.RemovedUrls, func() ([]models.URL, error) {
+		rows, err := tx.GetStudioURLs(ctx, studioID)
+		var urls []models.URL
+		for _, row := range rows {
+			urls = append(urls, models.URL{URL: row.Url, SiteID: row.SiteID})
+		}
+		return urls, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.ImageIds, err = rebaseList(editData.New.AddedImages, editData.New.RemovedImages, func() ([]uuid.UUID, error) {
+		images, err := tx.FindImagesByStudioID(ctx, studioID)
+		var ids []uuid.UUID
+		for _, image := range images {
+			ids = append(ids, image.ID)
+		}
+		return ids, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	details.Aliases, err = rebaseList(editData.New.AddedAliases, editData.New.RemovedAliases, func() ([]string, error) {
+		return tx.GetStudioAliases(ctx, studioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	input := models.StudioEditInput{Edit: editInput, Details: details}
+	p := Studio(ctx, tx, edit)
+	if err := p.Edit(input, inputArgs); err != nil {
+		return nil, err
+	}
+	newEdit, err := p.CreateEdit()
+	if err != nil {
+		return nil, err
+	}
+	return newEdit, p.CreateJoin(input)
+}
+
+func rebaseTagEdit(ctx context.Context, tx *queries.Queries, edit *models.Edit, editInput *models.EditInput, data []byte, inputArgs utils.ArgumentsQuery) (*models.Edit, error) {
+	var editData models.TagEditData
+	if err := json.Unmarshal(data, &editData); err != nil {
+		return nil, err
+	}
+	details := &models.TagEditDetailsInput{}
+	if err := rebaseDetails(data, details); err != nil {
+		return nil, err
+	}
+
+	var err error
+	details.Aliases, err = rebaseList(editData.New.AddedAliases, editData.New.RemovedAliases, func() ([]string, error) {
+		return tx.GetTagAliases(ctx, *editInput.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	input := models.TagEditInput{Edit: editInput, Details: details}
+	p := Tag(ctx, tx, edit)
+	if err := p.Edit(input, inputArgs); err != nil {
+		return nil, err
+	}
+	newEdit, err := p.CreateEdit()
+	if err != nil {
+		return nil, err
+	}
+	return newEdit, p.CreateJoin(input)
+}
+
+func rebaseComment(original uuid.UUID, dropped []string) string {
+	text := fmt.Sprintf("###### Rebased edit: ######\nReopened from [%s](/edits/%s) against the current state of the target.", original, original)
+	if len(dropped) > 0 {
+		text += fmt.Sprintf(" Changes to fields that have since been changed were dropped: %s.", strings.Join(dropped, ", "))
+	}
+	return text
+}
+
+func validateRebase(ctx context.Context, tx *queries.Queries, edit *models.Edit, userID uuid.UUID) error {
+	if edit.UserID.UUID != userID {
+		return ErrUnauthorizedRebase
+	}
+
+	if edit.Operation != models.OperationEnumModify.String() {
+		return ErrRebaseOperation
+	}
+
+	switch edit.Status {
+	case models.VoteStatusEnumFailed.String():
+	case models.VoteStatusEnumPending.String():
+		conflicts, err := tx.GetConflictingEdits(ctx, edit.ID)
+		if err != nil {
+			return err
+		}
+		// until a conflicting edit is applied there is nothing to rebase onto
+		if !slices.ContainsFunc(conflicts, func(e queries.Edit) bool { return e.Applied }) {
+			return ErrRebaseStatus
+		}
+	default:
+		return ErrRebaseStatus
+	}
+
+	_, err := tx.GetEditRebasedTo(ctx, edit.ID)
+	if err == nil {
+		return ErrAlreadyRebased
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// RebaseEdit reopens a failed edit, or a pending edit conflicting with an
+// applied edit, as a new pending edit against the current state of its
+// target. The changes to fields that have been changed since the edit was
+// submitted are dropped, and the remaining changes are validated like a new
+// edit. A pending original is canceled.
+func (s *Edit) RebaseEdit(ctx context.Context, input models.RebaseEditInput) (*models.Edit, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+
+	var newEdit *models.Edit
+	err := s.withTxn(func(tx *queries.Queries) error {
+		dbEdit, err := tx.FindEdit(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		edit := converter.EditToModelPtr(dbEdit)
+
+		if err := validateRebase(ctx, tx, edit, currentUser.ID); err != nil {
+			return err
+		}
+
+		var targetType models.TargetTypeEnum
+		utils.ResolveEnumString(edit.TargetType, &targetType)
+		if err := auth.ValidatePermission(ctx, rebasePermissions[targetType]); err != nil {
+			return err
+		}
+
+		target, err := tx.GetEditTargetID(ctx, edit.ID)
+		if err != nil {
+			return err
+		}
+
+		validate, err := targetValidator(ctx, tx, targetType, target.ID)
+		if err != nil {
+			return err
+		}
+
+		data, dropped, err := rebaseEditData(edit.Data, validate)
+		if err != nil {
+			return err
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		editInput := &models.EditInput{
+			ID:        &target.ID,
+			Operation: models.OperationEnumModify,
+			Bot:       &edit.Bot,
+			Comment:   input.Comment,
+		}
+		newEdit, err = rebaseEdit(ctx, tx, models.NewEdit(id, currentUser.ID, targetType, editInput), targetType, editInput, data)
+		if err != nil {
+			return err
+		}
+		m := &mutator{
+			context: ctx,
+			queries: tx,
+			edit:    newEdit,
+		}
+
+		if err := tx.CreateEditRebase(ctx, queries.CreateEditRebaseParams{
+			EditID:        newEdit.ID,
+			RebasedFromID: edit.ID,
+		}); err != nil {
+			return err
+		}
+
+		if edit.Status == models.VoteStatusEnumPending.String() {
+			edit.Cancel()
+			if _, err := tx.UpdateEdit(ctx, converter.EditToUpdateParams(*edit)); err != nil {
+				return err
+			}
+		}
+
+		if err := m.UpdateConflicts(editInput); err != nil {
+			return err
+		}
+
+		commentID, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		comment := models.NewEditComment(commentID, getModBot(ctx, tx), newEdit, rebaseComment(edit.ID, dropped))
+		if _, err := tx.CreateEditComment(ctx, converter.EditCommentToCreateParams(*comment)); err != nil {
+			return err
+		}
+
+		return m.CreateComment(currentUser.ID, input.Comment)
+	})
+
+	return newEdit, err
+}
+
+func (s *Edit) GetRebasedFrom(ctx context.Context, editID uuid.UUID) (*models.Edit, error) {
+	edit, err := s.queries.GetEditRebasedFrom(ctx, editID)
+	if err != nil {
+		return nil, errutil.IgnoreNotFound(err)
+	}
+	return converter.EditToModelPtr(edit), nil
+}
+
+func (s *Edit) GetRebasedTo(ctx context.Context, editID uuid.UUID) (*models.Edit, error) {
+	edit, err := s.queries.GetEditRebasedTo(ctx, editID)
+	if err != nil {
+		return nil, errutil.IgnoreNotFound(err)
+	}
+	return converter.EditToModelPtr(edit), nil
+}
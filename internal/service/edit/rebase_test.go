@@ -0,0 +1,118 @@
+package edit
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func TestRebaseEditData(t *testing.T) {
+	country := "US"
+	performer := models.Performer{Name: "Current", Country: &country}
+	validate := func(data []byte) error {
+		var editData models.PerformerEditData
+		if err := json.Unmarshal(data, &editData); err != nil {
+			return err
+		}
+		return performer.ValidateModifyEdit(editData)
+	}
+
+	editData := func(newData, oldData models.PerformerEdit) []byte {
+		e := &models.Edit{}
+		if err := e.SetData(models.PerformerEditData{New: &newData, Old: &oldData}); err != nil {
+			t.Fatal(err)
+		}
+		return e.Data
+	}
+
+	oldName := "Old"
+	newName := "New"
+	newCountry := "DE"
+	draftID := uuid.Must(uuid.NewV4())
+
+	t.Run("drops changed fields", func(t *testing.T) {
+		data, dropped, err := rebaseEditData(editData(
+			models.PerformerEdit{Name: &newName, Country: &newCountry, AddedAliases: []string{"alias"}, DraftID: &draftID},
+			models.PerformerEdit{Name: &oldName, Country: &country},
+		), validate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dropped) != 1 || dropped[0] != "name" {
+			t.Errorf("dropped = %v, want [name]", dropped)
+		}
+
+		e := &models.Edit{}
+		if err := e.SetData(data); err != nil {
+			t.Fatal(err)
+		}
+		rebased, _ := e.GetPerformerData()
+		if rebased.New.Name != nil || rebased.Old.Name != nil {
+			t.Error("name change was kept")
+		}
+		if rebased.New.Country == nil || *rebased.New.Country != newCountry {
+			t.Error("country change was dropped")
+		}
+		if len(rebased.New.AddedAliases) != 1 {
+			t.Error("added aliases were dropped")
+		}
+		if rebased.New.DraftID != nil {
+			t.Error("draft id was kept")
+		}
+	})
+
+	t.Run("no remaining changes", func(t *testing.T) {
+		_, _, err := rebaseEditData(editData(
+			models.PerformerEdit{Name: &newName},
+			models.PerformerEdit{Name: &oldName},
+		), validate)
+		if !errors.Is(err, ErrNoChanges) {
+			t.Errorf("err = %v, want %v", err, ErrNoChanges)
+		}
+	})
+}
+
+func TestClearedFields(t *testing.T) {
+	data := map[string]json.RawMessage{
+		"new_data": json.RawMessage(`{"name": "New", "added_aliases": ["alias"]}`),
+		"old_data": json.RawMessage(`{"name": "Old", "country": "US", "disambiguation": null}`),
+	}
+	fields, err := clearedFields(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(fields, []string{"country"}) {
+		t.Errorf("clearedFields = %v, want [country]", fields)
+	}
+}
+
+func TestRebaseList(t *testing.T) {
+	current := func() ([]string, error) { return []string{"a", "b"}, nil }
+
+	list, err := rebaseList([]string{"c", "a"}, []string{"b"}, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(list, []string{"a", "c"}) {
+		t.Errorf("rebaseList = %v, want [a c]", list)
+	}
+
+	// removing every item gives an empty list rather than no change
+	list, _ = rebaseList(nil, []string{"a", "b"}, current)
+	if list == nil || len(list) != 0 {
+		t.Errorf("rebaseList = %#v, want empty list", list)
+	}
+
+	list, _ = rebaseList(nil, nil, func() ([]string, error) {
+		t.Error("current list loaded without changes")
+		return nil, nil
+	})
+	if list != nil {
+		t.Errorf("rebaseList = %v, want nil", list)
+	}
+}
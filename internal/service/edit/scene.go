@@ -46,7 +46,11 @@ func (m *SceneEditProcessor) Edit(input models.SceneEditInput, inputArgs utils.A
 		err = m.createEdit(input, inputArgs)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return validateEditLocks(m.context, m.queries, m.edit, input.Edit)
 }
 
 func (m *SceneEditProcessor) modifyEdit(input models.SceneEditInput, inputArgs utils.ArgumentsQuery) error {
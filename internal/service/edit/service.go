@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/stashapp/stash-box/internal/auth"
 	"github.com/stashapp/stash-box/internal/config"
@@ -17,6 +18,7 @@ import (
 	"github.com/stashapp/stash-box/internal/models/validator"
 	"github.com/stashapp/stash-box/internal/queries"
 	"github.com/stashapp/stash-box/internal/service/errutil"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
 	"github.com/stashapp/stash-box/pkg/logger"
 	"github.com/stashapp/stash-box/pkg/utils"
 )
@@ -32,18 +34,33 @@ var ErrAmendPendingEdit = fmt.Errorf("cannot amend pending edit - only closed ed
 var ErrNoChangesToAmend = fmt.Errorf("must specify at least one field or item to remove")
 var ErrAmendEmptyResult = fmt.Errorf("cannot remove all fields - edit must retain some content")
 var ErrHidePrimaryComment = fmt.Errorf("cannot hide the edit's primary comment")
+var ErrInvalidParentComment = fmt.Errorf("replies must be to a comment on the same edit")
+
+// SceneMatcher finds the scenes matching a filter
+type SceneMatcher interface {
+	FindMatching(ctx context.Context, input models.SceneQueryInput, userID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
+
+// PerformerMatcher finds the performers matching a filter
+type PerformerMatcher interface {
+	FindMatching(ctx context.Context, input models.PerformerQueryInput, userID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
 
 // Edit handles edit-related operations
 type Edit struct {
-	queries *queries.Queries
-	withTxn queries.WithTxnFunc
+	queries    *queries.Queries
+	withTxn    queries.WithTxnFunc
+	scenes     SceneMatcher
+	performers PerformerMatcher
 }
 
 // NewEdit creates a new edit service
-func NewEdit(queries *queries.Queries, withTxn queries.WithTxnFunc) *Edit {
+func NewEdit(queries *queries.Queries, withTxn queries.WithTxnFunc, scenes SceneMatcher, performers PerformerMatcher) *Edit {
 	return &Edit{
-		queries: queries,
-		withTxn: withTxn,
+		queries:    queries,
+		withTxn:    withTxn,
+		scenes:     scenes,
+		performers: performers,
 	}
 }
 
@@ -64,7 +81,7 @@ func (s *Edit) GetComments(ctx context.Context, editID uuid.UUID) ([]models.Edit
 	result := converter.EditCommentsToModels(comments)
 
 	// Hidden comments are only visible to moderators and the comment's author
-	if err := auth.ValidateRole(ctx, models.RoleEnumModerate); err != nil {
+	if err := auth.ValidatePermission(ctx, models.PermissionEnumModerateComments); err != nil {
 		currentUser := auth.GetCurrentUser(ctx)
 		result = slices.DeleteFunc(result, func(c models.EditComment) bool {
 			isOwner := currentUser != nil && c.UserID.Valid && c.UserID.UUID == currentUser.ID
@@ -110,45 +127,25 @@ func (s *Edit) DeleteWithAudit(ctx context.Context, input models.DeleteEditInput
 			return ErrPendingEdit
 		}
 
-		// Only create audit log if retention is enabled (> 0 days)
-		retentionDays := config.GetModAuditRetentionDays()
-		if retentionDays > 0 {
-			auditData := struct {
-				queries.Edit
-				Data      json.RawMessage `json:"data"`
-				DeletedBy uuid.UUID       `json:"deleted_by"`
-				DeletedAt time.Time       `json:"deleted_at"`
-			}{
-				Edit:      dbEdit,
-				Data:      dbEdit.Data,
-				DeletedBy: currentUser.ID,
-				DeletedAt: time.Now(),
-			}
-
-			// Marshal audit data to JSON
-			auditDataJSON, err := json.Marshal(auditData)
-			if err != nil {
-				return fmt.Errorf("failed to marshal audit data: %w", err)
-			}
-
-			// Create mod_audit record
-			auditID, err := uuid.NewV7()
-			if err != nil {
-				return fmt.Errorf("failed to generate audit ID: %w", err)
-			}
-
-			_, err = tx.CreateModAudit(ctx, queries.CreateModAuditParams{
-				ID:         auditID,
-				Action:     queries.ModAuditActionEDITDELETE,
-				UserID:     uuid.NullUUID{UUID: currentUser.ID, Valid: true},
-				TargetID:   dbEdit.ID,
-				TargetType: "EDIT",
-				Data:       auditDataJSON,
-				Reason:     &input.Reason,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to create audit record: %w", err)
-			}
+		auditData := struct {
+			queries.Edit
+			Data      json.RawMessage `json:"data"`
+			DeletedBy uuid.UUID       `json:"deleted_by"`
+			DeletedAt time.Time       `json:"deleted_at"`
+		}{
+			Edit:      dbEdit,
+			Data:      dbEdit.Data,
+			DeletedBy: currentUser.ID,
+			DeletedAt: time.Now(),
+		}
+		if err := mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionEDITDELETE,
+			TargetID:   dbEdit.ID,
+			TargetType: mod_audit.TargetEdit,
+			Data:       auditData,
+			Reason:     &input.Reason,
+		}); err != nil {
+			return err
 		}
 
 		if err := tx.DeleteNotificationsByEditComments(ctx, input.ID); err != nil {
@@ -225,10 +222,8 @@ func (s *Edit) AmendEdit(ctx context.Context, input models.AmendEditInput) (*mod
 			return fmt.Errorf("failed to marshal updated edit data: %w", err)
 		}
 
-		if config.GetModAuditRetentionDays() > 0 {
-			if err := s.createAmendAudit(ctx, tx, dbEdit.ID, currentUser.ID, input.Reason, removedData); err != nil {
-				return err
-			}
+		if err := s.createAmendAudit(ctx, tx, dbEdit.ID, currentUser.ID, input.Reason, removedData); err != nil {
+			return err
 		}
 
 		dbEdit, err = tx.UpdateEditData(ctx, queries.UpdateEditDataParams{
@@ -252,34 +247,18 @@ func (s *Edit) createAmendAudit(ctx context.Context, tx *queries.Queries, editID
 		return fmt.Errorf("failed to marshal removed data: %w", err)
 	}
 
-	auditDataJSON, err := json.Marshal(models.EditAmendmentAuditData{
-		EditID:      editID,
-		AmendedBy:   userID,
-		AmendedAt:   time.Now(),
-		RemovedData: removedDataJSON,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to marshal audit data: %w", err)
-	}
-
-	auditID, err := uuid.NewV7()
-	if err != nil {
-		return fmt.Errorf("failed to generate audit ID: %w", err)
-	}
-
-	_, err = tx.CreateModAudit(ctx, queries.CreateModAuditParams{
-		ID:         auditID,
+	return mod_audit.Record(ctx, tx, mod_audit.Entry{
 		Action:     queries.ModAuditActionEDITAMENDMENT,
-		UserID:     uuid.NullUUID{UUID: userID, Valid: true},
 		TargetID:   editID,
-		TargetType: "EDIT",
-		Data:       auditDataJSON,
-		Reason:     &reason,
+		TargetType: mod_audit.TargetEdit,
+		Data: models.EditAmendmentAuditData{
+			EditID:      editID,
+			AmendedBy:   userID,
+			AmendedAt:   time.Now(),
+			RemovedData: removedDataJSON,
+		},
+		Reason: &reason,
 	})
-	if err != nil {
-		return fmt.Errorf("failed to create audit record: %w", err)
-	}
-	return nil
 }
 
 // UpdateComment lets a moderator replace a comment's text, preserving the
@@ -297,20 +276,20 @@ func (s *Edit) UpdateComment(ctx context.Context, input models.UpdateEditComment
 			return fmt.Errorf("failed to find comment: %w", err)
 		}
 
-		if config.GetModAuditRetentionDays() > 0 {
-			auditData, err := json.Marshal(models.EditCommentUpdateAuditData{
+		if err := mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionEDITCOMMENTUPDATE,
+			TargetID:   comment.ID,
+			TargetType: mod_audit.TargetEditComment,
+			Data: models.EditCommentUpdateAuditData{
 				CommentID:    comment.ID,
 				EditID:       comment.EditID,
 				UpdatedBy:    currentUser.ID,
 				UpdatedAt:    time.Now(),
 				PreviousText: comment.Text,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to marshal audit data: %w", err)
-			}
-			if err := s.createCommentAudit(ctx, tx, queries.ModAuditActionEDITCOMMENTUPDATE, comment.ID, currentUser.ID, input.Reason, auditData); err != nil {
-				return err
-			}
+			},
+			Reason: input.Reason,
+		}); err != nil {
+			return err
 		}
 
 		dbComment, err := tx.UpdateEditCommentText(ctx, queries.UpdateEditCommentTextParams{
@@ -351,20 +330,20 @@ func (s *Edit) HideComment(ctx context.Context, input models.HideEditCommentInpu
 			return ErrHidePrimaryComment
 		}
 
-		if config.GetModAuditRetentionDays() > 0 {
-			auditData, err := json.Marshal(models.EditCommentHideAuditData{
+		if err := mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionEDITCOMMENTHIDE,
+			TargetID:   comment.ID,
+			TargetType: mod_audit.TargetEditComment,
+			Data: models.EditCommentHideAuditData{
 				CommentID: comment.ID,
 				EditID:    comment.EditID,
 				ChangedBy: currentUser.ID,
 				ChangedAt: time.Now(),
 				Hidden:    input.Hidden,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to marshal audit data: %w", err)
-			}
-			if err := s.createCommentAudit(ctx, tx, queries.ModAuditActionEDITCOMMENTHIDE, comment.ID, currentUser.ID, input.Reason, auditData); err != nil {
-				return err
-			}
+			},
+			Reason: input.Reason,
+		}); err != nil {
+			return err
 		}
 
 		dbComment, err := tx.SetEditCommentHidden(ctx, queries.SetEditCommentHiddenParams{
@@ -382,25 +361,83 @@ func (s *Edit) HideComment(ctx context.Context, input models.HideEditCommentInpu
 	return updated, err
 }
 
-func (s *Edit) createCommentAudit(ctx context.Context, tx *queries.Queries, action queries.ModAuditAction, commentID, userID uuid.UUID, reason *string, auditData []byte) error {
-	auditID, err := uuid.NewV7()
-	if err != nil {
-		return fmt.Errorf("failed to generate audit ID: %w", err)
+// GetCommentMentions returns the ids of the users mentioned in a comment.
+func (s *Edit) GetCommentMentions(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error) {
+	return s.queries.GetEditCommentMentions(ctx, commentID)
+}
+
+// GetCommentReactions returns the reactions to a comment, marking those the
+// current user reacted with.
+func (s *Edit) GetCommentReactions(ctx context.Context, commentID uuid.UUID) ([]models.EditCommentReaction, error) {
+	var userID uuid.UUID
+	if currentUser := auth.GetCurrentUser(ctx); currentUser != nil {
+		userID = currentUser.ID
 	}
 
-	_, err = tx.CreateModAudit(ctx, queries.CreateModAuditParams{
-		ID:         auditID,
-		Action:     action,
-		UserID:     uuid.NullUUID{UUID: userID, Valid: true},
-		TargetID:   commentID,
-		TargetType: "EDIT_COMMENT",
-		Data:       auditData,
-		Reason:     reason,
+	rows, err := s.queries.GetEditCommentReactions(ctx, queries.GetEditCommentReactionsParams{
+		UserID:    userID,
+		CommentID: commentID,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create audit record: %w", err)
+		return nil, err
 	}
-	return nil
+
+	var ret []models.EditCommentReaction
+	for _, row := range rows {
+		ret = append(ret, models.EditCommentReaction{
+			Reaction: models.EditCommentReactionEnum(row.Reaction),
+			Count:    row.Count,
+			Reacted:  row.Reacted,
+		})
+	}
+	return ret, nil
+}
+
+// AddCommentReaction reacts to a comment as the current user.
+func (s *Edit) AddCommentReaction(ctx context.Context, input models.EditCommentReactionInput) (*models.EditComment, error) {
+	return s.updateCommentReaction(ctx, input, func(tx *queries.Queries, userID uuid.UUID) error {
+		return tx.AddEditCommentReaction(ctx, queries.AddEditCommentReactionParams{
+			CommentID: input.ID,
+			UserID:    userID,
+			Reaction:  input.Reaction.String(),
+		})
+	})
+}
+
+// RemoveCommentReaction removes a reaction of the current user from a comment.
+func (s *Edit) RemoveCommentReaction(ctx context.Context, input models.EditCommentReactionInput) (*models.EditComment, error) {
+	return s.updateCommentReaction(ctx, input, func(tx *queries.Queries, userID uuid.UUID) error {
+		return tx.DeleteEditCommentReaction(ctx, queries.DeleteEditCommentReactionParams{
+			CommentID: input.ID,
+			UserID:    userID,
+			Reaction:  input.Reaction.String(),
+		})
+	})
+}
+
+func (s *Edit) updateCommentReaction(ctx context.Context, input models.EditCommentReactionInput, update func(tx *queries.Queries, userID uuid.UUID) error) (*models.EditComment, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return nil, fmt.Errorf("no authenticated user found")
+	}
+	if !input.Reaction.IsValid() {
+		return nil, fmt.Errorf("invalid reaction %s", input.Reaction)
+	}
+
+	var comment *models.EditComment
+	err := s.withTxn(func(tx *queries.Queries) error {
+		dbComment, err := tx.FindEditComment(ctx, input.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find comment: %w", err)
+		}
+		if err := update(tx, currentUser.ID); err != nil {
+			return err
+		}
+		comment = converter.EditCommentToModelPtr(dbComment)
+		return nil
+	})
+
+	return comment, err
 }
 
 func removeArrayItems(data map[string]interface{}, field string, indices []int, removed map[string]interface{}) {
@@ -616,6 +653,40 @@ func (s *Edit) GetMergedPerformers(ctx context.Context, id uuid.UUID) ([]models.
 	return result, nil
 }
 
+func (s *Edit) GetMergedPerformerCareerPeriods(ctx context.Context, id uuid.UUID) ([]models.PerformerCareerPeriod, error) {
+	rows, err := s.queries.GetEditPerformerCareerPeriods(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.PerformerCareerPeriod
+	for _, row := range rows {
+		result = append(result, converter.CareerPeriodToModel(queries.PerformerCareerPeriod{
+			StartDate: row.StartDate,
+			EndDate:   row.EndDate,
+			StudioID:  row.StudioID,
+			Note:      row.Note,
+		}))
+	}
+	return result, nil
+}
+
+func (s *Edit) GetMergedPerformerRelationships(ctx context.Context, id uuid.UUID) ([]models.PerformerRelationship, error) {
+	rows, err := s.queries.GetMergedPerformerRelationshipsForEdit(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.PerformerRelationship
+	for _, row := range rows {
+		result = append(result, models.PerformerRelationship{
+			Performer: converter.PerformerToModelPtr(row.Performer),
+			Type:      models.PerformerRelationshipTypeEnum(row.Type),
+		})
+	}
+	return result, nil
+}
+
 func (s *Edit) FindByPerformerID(ctx context.Context, performerID uuid.UUID) ([]models.Edit, error) {
 	edits, err := s.queries.GetEditsByPerformer(ctx, performerID)
 	if err != nil {
@@ -719,6 +790,10 @@ func (s *Edit) CreateSceneEdit(ctx context.Context, input models.SceneEditInput)
 			}
 		}
 
+		if err := p.UpdateConflicts(input.Edit); err != nil {
+			return err
+		}
+
 		return p.CreateComment(currentUser.ID, input.Edit.Comment)
 	})
 
@@ -751,6 +826,10 @@ func (s *Edit) UpdateSceneEdit(ctx context.Context, id uuid.UUID, input models.S
 			return err
 		}
 
+		if err := p.UpdateConflicts(input.Edit); err != nil {
+			return err
+		}
+
 		return p.CreateComment(currentUser.ID, input.Edit.Comment)
 	})
 
@@ -787,6 +866,10 @@ func (s *Edit) CreateStudioEdit(ctx context.Context, input models.StudioEditInpu
 			return err
 		}
 
+		if err := p.UpdateConflicts(input.Edit); err != nil {
+			return err
+		}
+
 		return p.CreateComment(currentUser.ID, input.Edit.Comment)
 	})
 
@@ -819,6 +902,10 @@ func (s *Edit) UpdateStudioEdit(ctx context.Context, id uuid.UUID, input models.
 			return err
 		}
 
+		if err := p.UpdateConflicts(input.Edit); err != nil {
+			return err
+		}
+
 		return p.CreateComment(currentUser.ID, input.Edit.Comment)
 	})
 
@@ -826,10 +913,8 @@ func (s *Edit) UpdateStudioEdit(ctx context.Context, id uuid.UUID, input models.
 }
 
 func (s *Edit) CreateTagEdit(ctx context.Context, input models.TagEditInput) (*models.Edit, error) {
-	if config.GetRequireTagRole() {
-		if err := auth.ValidateRole(ctx, models.RoleEnumEditTags); err != nil {
-			return nil, err
-		}
+	if err := auth.ValidatePermission(ctx, models.PermissionEnumEditTags); err != nil {
+		return nil, err
 	}
 
 	UUID, err := uuid.NewV7()
@@ -861,6 +946,10 @@ func (s *Edit) CreateTagEdit(ctx context.Context, input models.TagEditInput) (*m
 			return err
 		}
 
+		if err := p.UpdateConflicts(input.Edit); err != nil {
+			return err
+		}
+
 		return p.CreateComment(currentUser.ID, input.Edit.Comment)
 	})
 
@@ -893,6 +982,10 @@ func (s *Edit) UpdateTagEdit(ctx context.Context, id uuid.UUID, input models.Tag
 			return err
 		}
 
+		if err := p.UpdateConflicts(input.Edit); err != nil {
+			return err
+		}
+
 		return p.CreateComment(currentUser.ID, input.Edit.Comment)
 	})
 
@@ -935,6 +1028,10 @@ func (s *Edit) CreatePerformerEdit(ctx context.Context, input models.PerformerEd
 			}
 		}
 
+		if err := p.UpdateConflicts(input.Edit); err != nil {
+			return err
+		}
+
 		return p.CreateComment(currentUser.ID, input.Edit.Comment)
 	})
 
@@ -967,6 +1064,10 @@ func (s *Edit) UpdatePerformerEdit(ctx context.Context, id uuid.UUID, input mode
 			return err
 		}
 
+		if err := p.UpdateConflicts(input.Edit); err != nil {
+			return err
+		}
+
 		return p.CreateComment(currentUser.ID, input.Edit.Comment)
 	})
 
@@ -992,13 +1093,20 @@ func (s *Edit) CreateVote(ctx context.Context, input models.EditVoteInput) (*mod
 			return auth.ErrUnauthorized
 		}
 
-		if err := tx.CreateEditVote(ctx, queries.CreateEditVoteParams{
-			UserID: uuid.NullUUID{UUID: currentUser.ID, Valid: true},
-			EditID: voteEdit.ID,
-			Vote:   input.Vote.String(),
-		}); err != nil {
+		// a vote on an edit of a bulk edit counts for the whole group
+		edits, err := groupEdits(ctx, tx, voteEdit)
+		if err != nil {
 			return err
 		}
+		for _, edit := range edits {
+			if err := tx.CreateEditVote(ctx, queries.CreateEditVoteParams{
+				UserID: uuid.NullUUID{UUID: currentUser.ID, Valid: true},
+				EditID: edit.ID,
+				Vote:   input.Vote.String(),
+			}); err != nil {
+				return err
+			}
+		}
 
 		// Re-fetch the edit to get the updated vote_count from the database trigger
 		dbEdit, err = tx.FindEdit(ctx, input.ID)
@@ -1033,6 +1141,10 @@ func (s *Edit) CreateVote(ctx context.Context, input models.EditVoteInput) (*mod
 }
 
 func (s *Edit) CreateComment(ctx context.Context, input models.EditCommentInput) (*models.Edit, *models.EditComment, error) {
+	if err := auth.ValidateComment(ctx); err != nil {
+		return nil, nil, err
+	}
+
 	edit, err := s.queries.FindEdit(ctx, input.ID)
 	if err != nil {
 		return nil, nil, err
@@ -1045,14 +1157,36 @@ func (s *Edit) CreateComment(ctx context.Context, input models.EditCommentInput)
 		if err != nil {
 			return err
 		}
+		text, mentions, err := linkCommentMentions(ctx, tx, text)
+		if err != nil {
+			return err
+		}
 		params, err := converter.CreateEditCommentParams(edit.ID, currentUser.ID, text)
 		if err != nil {
 			return err
 		}
+		if input.ParentID != nil {
+			parent, err := tx.FindEditComment(ctx, *input.ParentID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+			if err != nil || parent.EditID != edit.ID {
+				return ErrInvalidParentComment
+			}
+			params.ParentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+		}
 		dbComment, err := tx.CreateEditComment(ctx, params)
 		if err != nil {
 			return err
 		}
+		if len(mentions) > 0 {
+			if err := tx.CreateEditCommentMentions(ctx, queries.CreateEditCommentMentionsParams{
+				CommentID: dbComment.ID,
+				UserIds:   mentions,
+			}); err != nil {
+				return err
+			}
+		}
 		comment = converter.EditCommentToModelPtr(dbComment)
 		return nil
 	})
@@ -1068,7 +1202,7 @@ func (s *Edit) Cancel(ctx context.Context, input models.CancelEditInput) (*model
 
 	if err = auth.ValidateOwner(ctx, e.UserID.UUID); err == nil {
 		return s.CloseEdit(ctx, input.ID, models.VoteStatusEnumCanceled)
-	} else if err = auth.ValidateRole(ctx, models.RoleEnumModerate); err == nil {
+	} else if err = auth.ValidatePermission(ctx, models.PermissionEnumModerateEdits); err == nil {
 		currentUser := auth.GetCurrentUser(ctx)
 
 		if err := s.queries.CreateEditVote(ctx, queries.CreateEditVoteParams{
@@ -1079,7 +1213,8 @@ func (s *Edit) Cancel(ctx context.Context, input models.CancelEditInput) (*model
 			return nil, err
 		}
 
-		return s.CloseEdit(ctx, input.ID, models.VoteStatusEnumImmediateRejected)
+		audit := queries.ModAuditActionEDITREJECT
+		return s.closeEdit(ctx, input.ID, models.VoteStatusEnumImmediateRejected, &audit)
 	}
 
 	return nil, err
@@ -1101,7 +1236,25 @@ func (s *Edit) Apply(ctx context.Context, input models.ApproveEditInput) (*model
 		return nil, err
 	}
 
-	return s.ApplyEdit(ctx, input.ID, true)
+	audit := queries.ModAuditActionEDITAPPROVE
+	return s.applyEdit(ctx, input.ID, true, &audit)
+}
+
+type editVoteAuditState struct {
+	Status  string `json:"status"`
+	Applied bool   `json:"applied"`
+}
+
+// recordVoteAudit logs a moderator overriding the voting process. It is
+// recorded in the transaction applying or closing the edit.
+func recordVoteAudit(ctx context.Context, tx *queries.Queries, action queries.ModAuditAction, before editVoteAuditState, after *models.Edit) error {
+	return mod_audit.Record(ctx, tx, mod_audit.Entry{
+		Action:     action,
+		TargetID:   after.ID,
+		TargetType: mod_audit.TargetEdit,
+		Before:     before,
+		After:      editVoteAuditState{Status: after.Status, Applied: after.Applied},
+	})
 }
 
 func validateBotEdit(ctx context.Context, input *models.EditInput) error {
@@ -1131,6 +1284,12 @@ func validateEditUpdate(edit models.Edit, userID uuid.UUID) error {
 }
 
 func (s *Edit) ApplyEdit(ctx context.Context, editID uuid.UUID, immediate bool) (*models.Edit, error) {
+	return s.applyEdit(ctx, editID, immediate, nil)
+}
+
+// applyEdit applies an edit, logging the moderator action with it if audit
+// is set.
+func (s *Edit) applyEdit(ctx context.Context, editID uuid.UUID, immediate bool, audit *queries.ModAuditAction) (*models.Edit, error) {
 	var updatedEdit *models.Edit
 	dbEdit, err := s.queries.FindEdit(ctx, editID)
 	if err != nil {
@@ -1138,6 +1297,7 @@ func (s *Edit) ApplyEdit(ctx context.Context, editID uuid.UUID, immediate bool)
 	}
 
 	edit := converter.EditToModelPtr(dbEdit)
+	before := editVoteAuditState{Status: edit.Status, Applied: edit.Applied}
 	if err := validateEditPresence(edit); err != nil {
 		return nil, err
 	}
@@ -1146,32 +1306,46 @@ func (s *Edit) ApplyEdit(ctx context.Context, editID uuid.UUID, immediate bool)
 		return nil, err
 	}
 
-	var operation models.OperationEnum
-	utils.ResolveEnumString(edit.Operation, &operation)
-	var targetType models.TargetTypeEnum
-	utils.ResolveEnumString(edit.TargetType, &targetType)
+	// the edits of a bulk edit are applied together, or not at all
+	edits, err := groupEdits(ctx, s.queries, edit)
+	if err != nil {
+		return nil, err
+	}
 
+	var updatedEdits []*models.Edit
 	err = s.withTxn(func(tx *queries.Queries) error {
-		var applyer editApplyer
-		switch targetType {
-		case models.TargetTypeEnumTag:
-			applyer = Tag(ctx, tx, edit)
-		case models.TargetTypeEnumPerformer:
-			applyer = Performer(ctx, tx, edit)
-		case models.TargetTypeEnumStudio:
-			applyer = Studio(ctx, tx, edit)
-		case models.TargetTypeEnumScene:
-			applyer = Scene(ctx, tx, edit)
-		}
-
-		return applyer.apply()
+		for _, e := range edits {
+			if err := newEditApplyer(ctx, tx, e).apply(); err != nil {
+				if len(edits) > 1 {
+					return fmt.Errorf("edit %s: %w", e.ID, err)
+				}
+				return err
+			}
+		}
+
+		for _, e := range edits {
+			if immediate {
+				e.ImmediateAccept()
+			} else {
+				e.Accept()
+			}
+			dbEdit, err := tx.UpdateEdit(ctx, converter.EditToUpdateParams(*e))
+			if err != nil {
+				return err
+			}
+			updatedEdits = append(updatedEdits, converter.EditToModelPtr(dbEdit))
+		}
+
+		if audit != nil {
+			return recordVoteAudit(ctx, tx, *audit, before, updatedEdits[0])
+		}
+		return nil
 	})
 
 	success := true
 	if err != nil {
 		// Failed apply, so we create a comment with error details
 		success = false
-		commentID, _ := uuid.NewV7()
 		text := "###### Edit application failed: ######\n"
 		if prereqErr := (*validator.ErrEditPrerequisiteFailed)(nil); errors.As(err, &prereqErr) {
 			text = fmt.Sprintf("%sPrerequisite failed: %v", text, err)
@@ -1180,29 +1354,36 @@ func (s *Edit) ApplyEdit(ctx context.Context, editID uuid.UUID, immediate bool)
 		}
 		modBotID := getModBot(ctx, s.queries)
 
-		comment := models.NewEditComment(commentID, modBotID, edit, text)
-		_, err = s.queries.CreateEditComment(ctx, converter.EditCommentToCreateParams(*comment))
-		if err != nil {
-			return nil, err
+		for _, e := range edits {
+			commentID, _ := uuid.NewV7()
+			comment := models.NewEditComment(commentID, modBotID, e, text)
+			if _, err := s.queries.CreateEditComment(ctx, converter.EditCommentToCreateParams(*comment)); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	switch {
-	case !success:
-		edit.Fail()
-	case immediate:
-		edit.ImmediateAccept()
-	default:
-		edit.Accept()
-	}
-	dbEdit, err = s.queries.UpdateEdit(ctx, converter.EditToUpdateParams(*edit))
-	if err != nil {
-		return nil, err
+	if !success {
+		updatedEdits = nil
+		for _, e := range edits {
+			e.Fail()
+			dbEdit, err := s.queries.UpdateEdit(ctx, converter.EditToUpdateParams(*e))
+			if err != nil {
+				return nil, err
+			}
+			updatedEdits = append(updatedEdits, converter.EditToModelPtr(dbEdit))
+		}
 	}
-	updatedEdit = converter.EditToModelPtr(dbEdit)
+	updatedEdit = updatedEdits[0]
 
-	// TODO: Maybe use cron instead
 	if success {
+		for _, e := range updatedEdits {
+			if err := s.warnConflictingEdits(ctx, e); err != nil {
+				logger.Errorf("Failed to warn about conflicting edits: %v", err)
+			}
+		}
+
+		// TODO: Maybe use cron instead
 		userPromotionThreshold := config.GetVotePromotionThreshold()
 		if userPromotionThreshold != nil && updatedEdit.UserID.Valid {
 			go func() {
@@ -1217,6 +1398,12 @@ func (s *Edit) ApplyEdit(ctx context.Context, editID uuid.UUID, immediate bool)
 }
 
 func (s *Edit) CloseEdit(ctx context.Context, editID uuid.UUID, status models.VoteStatusEnum) (*models.Edit, error) {
+	return s.closeEdit(ctx, editID, status, nil)
+}
+
+// closeEdit closes an edit, logging the moderator action with it if audit is
+// set.
+func (s *Edit) closeEdit(ctx context.Context, editID uuid.UUID, status models.VoteStatusEnum, audit *queries.ModAuditAction) (*models.Edit, error) {
 	var updatedEdit *models.Edit
 	err := s.withTxn(func(tx *queries.Queries) error {
 		dbEdit, err := tx.FindEdit(ctx, editID)
@@ -1225,6 +1412,7 @@ func (s *Edit) CloseEdit(ctx context.Context, editID uuid.UUID, status models.Vo
 		}
 
 		edit := converter.EditToModelPtr(dbEdit)
+		before := editVoteAuditState{Status: edit.Status, Applied: edit.Applied}
 		if err := validateEditPresence(edit); err != nil {
 			return err
 		}
@@ -1233,21 +1421,37 @@ func (s *Edit) CloseEdit(ctx context.Context, editID uuid.UUID, status models.Vo
 			return err
 		}
 
-		switch status {
-		case models.VoteStatusEnumImmediateRejected:
-			edit.ImmediateReject()
-		case models.VoteStatusEnumRejected:
-			edit.Reject()
-		case models.VoteStatusEnumCanceled:
-			edit.Cancel()
-		default:
-			return fmt.Errorf("tried to close with invalid status: %s", status)
+		// the edits of a bulk edit are closed together
+		edits, err := groupEdits(ctx, tx, edit)
+		if err != nil {
+			return err
 		}
 
-		dbEdit, err = tx.UpdateEdit(ctx, converter.EditToUpdateParams(*edit))
-		updatedEdit = converter.EditToModelPtr(dbEdit)
+		for _, e := range edits {
+			switch status {
+			case models.VoteStatusEnumImmediateRejected:
+				e.ImmediateReject()
+			case models.VoteStatusEnumRejected:
+				e.Reject()
+			case models.VoteStatusEnumCanceled:
+				e.Cancel()
+			default:
+				return fmt.Errorf("tried to close with invalid status: %s", status)
+			}
 
-		return err
+			dbEdit, err = tx.UpdateEdit(ctx, converter.EditToUpdateParams(*e))
+			if err != nil {
+				return err
+			}
+			if e == edit {
+				updatedEdit = converter.EditToModelPtr(dbEdit)
+			}
+		}
+
+		if audit != nil {
+			return recordVoteAudit(ctx, tx, *audit, before, updatedEdit)
+		}
+		return nil
 	})
 
 	return updatedEdit, err
@@ -1282,6 +1486,14 @@ func (s *Edit) ResolveVotingThreshold(ctx context.Context, edit *models.Edit) (m
 	}
 
 	if positive >= threshold && negative == 0 {
+		// edits changing locked fields are left for a moderator to approve
+		protected, err := s.requiresModerator(ctx, edit)
+		if err != nil {
+			return models.VoteStatusEnumPending, err
+		}
+		if protected {
+			return models.VoteStatusEnumPending, s.queueForModerator(ctx, edit)
+		}
 		return models.VoteStatusEnumAccepted, nil
 	} else if negative >= threshold && positive == 0 {
 		return models.VoteStatusEnumRejected, nil
@@ -1336,6 +1548,15 @@ func (s *Edit) CloseCompleted(ctx context.Context) ([]*models.Edit, error) {
 	logger.Debugf("Closing %d completed edits", len(edits))
 	var closedEdits []*models.Edit
 	for _, edit := range edits {
+		// edits of a bulk edit are closed together with the first of their group
+		current, findErr := s.queries.FindEdit(ctx, edit.ID)
+		if findErr != nil {
+			return closedEdits, findErr
+		}
+		if current.Status != models.VoteStatusEnumPending.String() {
+			continue
+		}
+
 		e := converter.EditToModel(edit)
 		voteThreshold := 0
 		if e.IsDestructive() {
@@ -1346,6 +1567,17 @@ func (s *Edit) CloseCompleted(ctx context.Context) ([]*models.Edit, error) {
 		var err error
 		var closedEdit *models.Edit
 		if e.VoteCount >= voteThreshold {
+			// edits changing locked fields are left for a moderator to approve
+			protected, lockErr := s.requiresModerator(ctx, &e)
+			if lockErr != nil {
+				return closedEdits, lockErr
+			}
+			if protected {
+				if err := s.queueForModerator(ctx, &e); err != nil {
+					return closedEdits, err
+				}
+				continue
+			}
 			closedEdit, err = s.ApplyEdit(ctx, e.ID, false)
 		} else {
 			closedEdit, err = s.CloseEdit(ctx, e.ID, models.VoteStatusEnumRejected)
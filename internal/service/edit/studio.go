@@ -45,7 +45,11 @@ func (m *StudioEditProcessor) Edit(input models.StudioEditInput, inputArgs utils
 		err = m.createEdit(input)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return validateEditLocks(m.context, m.queries, m.edit, input.Edit)
 }
 
 func (m *StudioEditProcessor) modifyEdit(input models.StudioEditInput, inputArgs utils.ArgumentsQuery) error {
@@ -332,6 +336,13 @@ func (m *StudioEditProcessor) applyModifyEdit(studio *models.Studio, data *model
 		return err
 	}
 
+	// the network may have changed since the edit was submitted
+	if data.New.ParentID != nil {
+		if err := validateStudioParent(m.context, m.queries, studio.ID, *data.New.ParentID); err != nil {
+			return err
+		}
+	}
+
 	studio.CopyFromStudioEdit(*data.New, data.Old)
 	updatedDbStudio, err := m.queries.UpdateStudio(m.context, converter.StudioToUpdateParams(*studio))
 	if err != nil {
@@ -41,7 +41,11 @@ func (m *TagEditProcessor) Edit(input models.TagEditInput, inputArgs utils.Argum
 		err = m.createEdit(input, inputArgs)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return validateEditLocks(m.context, m.queries, m.edit, input.Edit)
 }
 
 func (m *TagEditProcessor) modifyEdit(input models.TagEditInput, inputArgs utils.ArgumentsQuery) error {
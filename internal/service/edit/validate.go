@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/converter"
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/internal/queries"
 	"github.com/stashapp/stash-box/pkg/utils"
@@ -23,6 +26,10 @@ var ErrInvalidStudio = errors.New("invalid studio id")
 var ErrInvalidPerformer = errors.New("invalid performer id")
 var ErrInvalidTag = errors.New("invalid tag id")
 var ErrInvalidSite = errors.New("invalid url site id")
+var ErrStudioParentCycle = errors.New("studio cannot be a parent of itself or its ancestors")
+var ErrSelfRelationship = errors.New("performer cannot be related to itself")
+var ErrInvalidCareerPeriod = errors.New("career period ends before it starts")
+var ErrCareerPeriodOverlap = errors.New("career periods overlap")
 
 type editEntity interface {
 	IsDeleted() bool
@@ -61,6 +68,30 @@ func validateEditPrerequisites(edit *models.Edit) error {
 	return nil
 }
 
+// validateEditLocks rejects edits changing the fields of a locked entity,
+// unless submitted by a moderator.
+func validateEditLocks(ctx context.Context, queries *queries.Queries, edit *models.Edit, input *models.EditInput) error {
+	if auth.HasPermission(ctx, models.PermissionEnumModerateEdits) {
+		return nil
+	}
+
+	var entityIDs []uuid.UUID
+	if input.ID != nil {
+		entityIDs = append(entityIDs, *input.ID)
+	}
+	entityIDs = append(entityIDs, input.MergeSourceIds...)
+
+	lock, err := editLock(ctx, queries, edit, entityIDs)
+	if err != nil {
+		return err
+	}
+	if lock != nil && lock.Level == models.EntityLockLevelEnumLocked.String() {
+		return fmt.Errorf("%w: %s", ErrEntityLocked, lock.Reason)
+	}
+
+	return nil
+}
+
 func validateSceneEditInput(ctx context.Context, queries *queries.Queries, input models.SceneEditInput, edit *models.Edit, update bool) error {
 	if input.Details == nil {
 		return nil
@@ -122,9 +153,105 @@ func validatePerformerEditInput(ctx context.Context, queries *queries.Queries, i
 		}
 	}
 
+	if err := validatePerformerRelationships(ctx, queries, input); err != nil {
+		return err
+	}
+
+	if err := validateCareerPeriods(ctx, queries, converter.CareerPeriodInputToModel(input.Details.CareerPeriods)); err != nil {
+		return err
+	}
+
 	return validateURLs(ctx, queries, input.Details.Urls)
 }
 
+func validatePerformerRelationships(ctx context.Context, queries *queries.Queries, input models.PerformerEditInput) error {
+	for _, rel := range input.Details.Relationships {
+		if input.Edit.ID != nil && rel.PerformerID == *input.Edit.ID {
+			return ErrSelfRelationship
+		}
+		if slices.Contains(input.Edit.MergeSourceIds, rel.PerformerID) {
+			return fmt.Errorf("%w: %s is a merge source", ErrSelfRelationship, rel.PerformerID)
+		}
+
+		if err := validateRelatedPerformer(ctx, queries, rel.PerformerID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateRelatedPerformer(ctx context.Context, queries *queries.Queries, performerID uuid.UUID) error {
+	performer, err := queries.FindPerformer(ctx, performerID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPerformer, performerID)
+	}
+	if performer.Deleted {
+		return fmt.Errorf("%w: performer %s", ErrEntityDeleted, performerID)
+	}
+
+	return nil
+}
+
+// fuzzyDateBound expands a fuzzy date to the first day it covers, so that
+// fuzzy dates of differing precision can be compared as strings.
+func fuzzyDateBound(date string) string {
+	switch len(date) {
+	case 4:
+		return date + "-01-01"
+	case 7:
+		return date + "-01"
+	}
+	return date
+}
+
+// validateCareerPeriods checks that each period is well formed, and that
+// periods for the same studio, or periods without a studio, do not overlap.
+// Contracts with different studios may run concurrently.
+func validateCareerPeriods(ctx context.Context, queries *queries.Queries, periods []models.PerformerCareerPeriod) error {
+	type bounds struct {
+		start  string
+		end    string
+		studio uuid.UUID
+	}
+
+	var ranges []bounds
+	for _, period := range periods {
+		if err := models.ValidateFuzzyString(&period.StartDate); err != nil {
+			return fmt.Errorf("%w: %s", err, period.StartDate)
+		}
+		if err := models.ValidateFuzzyString(period.EndDate); err != nil {
+			return fmt.Errorf("%w: %s", err, *period.EndDate)
+		}
+
+		r := bounds{
+			start: fuzzyDateBound(period.StartDate),
+			end:   "9999-12-31",
+		}
+		if period.EndDate != nil {
+			r.end = fuzzyDateBound(*period.EndDate)
+			if r.end < r.start {
+				return fmt.Errorf("%w: %s - %s", ErrInvalidCareerPeriod, period.StartDate, *period.EndDate)
+			}
+		}
+		if period.StudioID != nil {
+			if _, err := queries.FindStudio(ctx, *period.StudioID); err != nil {
+				return fmt.Errorf("%w: %s", ErrInvalidStudio, *period.StudioID)
+			}
+			r.studio = *period.StudioID
+		}
+
+		for _, other := range ranges {
+			if other.studio == r.studio && r.start < other.end && other.start < r.end {
+				return fmt.Errorf("%w: period starting %s", ErrCareerPeriodOverlap, period.StartDate)
+			}
+		}
+		ranges = append(ranges, r)
+	}
+
+	return nil
+}
+
 func validateDraftID(ctx context.Context, queries *queries.Queries, draftID uuid.UUID, editID uuid.UUID, update bool) error {
 	if !update {
 		_, err := queries.FindDraft(ctx, draftID)
@@ -167,6 +294,12 @@ func validateStudioEditInput(ctx context.Context, queries *queries.Queries, inpu
 		if err != nil {
 			return fmt.Errorf("%w: %s", ErrInvalidStudio, *input.Details.ParentID)
 		}
+
+		if input.Edit.ID != nil {
+			if err := validateStudioParent(ctx, queries, *input.Edit.ID, *input.Details.ParentID); err != nil {
+				return err
+			}
+		}
 	}
 
 	if len(input.Details.ImageIds) > 0 {
@@ -179,6 +312,21 @@ func validateStudioEditInput(ctx context.Context, queries *queries.Queries, inpu
 	return validateURLs(ctx, queries, input.Details.Urls)
 }
 
+// validateStudioParent ensures that assigning parentID as the parent of
+// studioID does not introduce a cycle in the studio network.
+func validateStudioParent(ctx context.Context, queries *queries.Queries, studioID uuid.UUID, parentID uuid.UUID) error {
+	networkIDs, err := queries.GetStudioNetworkIDs(ctx, studioID)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(networkIDs, parentID) {
+		return fmt.Errorf("%w: %s", ErrStudioParentCycle, parentID)
+	}
+
+	return nil
+}
+
 func validateURLs(ctx context.Context, queries *queries.Queries, urls []models.URL) error {
 	if len(urls) == 0 {
 		return nil
@@ -29,7 +29,9 @@ import (
 	"github.com/stashapp/stash-box/internal/service/mod_audit"
 	"github.com/stashapp/stash-box/internal/service/notification"
 	"github.com/stashapp/stash-box/internal/service/performer"
+	"github.com/stashapp/stash-box/internal/service/savedsearch"
 	"github.com/stashapp/stash-box/internal/service/scene"
+	"github.com/stashapp/stash-box/internal/service/search"
 	"github.com/stashapp/stash-box/internal/service/site"
 	"github.com/stashapp/stash-box/internal/service/studio"
 	"github.com/stashapp/stash-box/internal/service/tag"
@@ -90,7 +92,7 @@ func (f *Factory) Site() *site.Site {
 
 // Edit returns an EditService instance
 func (f *Factory) Edit() *edit.Edit {
-	return edit.NewEdit(queries.New(f.db), f.withTxn)
+	return edit.NewEdit(queries.New(f.db), f.withTxn, f.Scene(), f.Performer())
 }
 
 // Image returns an ImageService instance
@@ -105,7 +107,7 @@ func (f *Factory) Draft() *draft.Draft {
 
 // Notification returns a NotificationService instance
 func (f *Factory) Notification() *notification.Notification {
-	return notification.NewNotification(queries.New(f.db), f.withTxn)
+	return notification.NewNotification(queries.New(f.db), f.withTxn, f.emailMgr)
 }
 
 func (f *Factory) Invite() *invite.Invite {
@@ -121,3 +123,13 @@ func (f *Factory) ModAudit() *mod_audit.ModAuditService {
 func (f *Factory) Fingerprint() *fingerprint.Fingerprint {
 	return fingerprint.New(queries.New(f.db))
 }
+
+// Search returns a SearchService instance
+func (f *Factory) Search() *search.Search {
+	return search.NewSearch(queries.New(f.db), f.withTxn)
+}
+
+// SavedSearch returns a SavedSearchService instance
+func (f *Factory) SavedSearch() *savedsearch.SavedSearch {
+	return savedsearch.NewSavedSearch(queries.New(f.db), f.withTxn, f.Scene(), f.Performer())
+}
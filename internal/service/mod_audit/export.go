@@ -0,0 +1,100 @@
+package mod_audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type exportEntry struct {
+	ID         string          `json:"id"`
+	Action     string          `json:"action"`
+	UserID     *string         `json:"user_id"`
+	TargetID   string          `json:"target_id"`
+	TargetType string          `json:"target_type"`
+	Data       json.RawMessage `json:"data"`
+	Reason     *string         `json:"reason"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func toExportEntry(audit models.ModAudit) exportEntry {
+	entry := exportEntry{
+		ID:         audit.ID.String(),
+		Action:     audit.Action,
+		TargetID:   audit.TargetID.String(),
+		TargetType: audit.TargetType,
+		Data:       json.RawMessage(audit.Data),
+		Reason:     audit.Reason,
+		CreatedAt:  audit.CreatedAt,
+	}
+	if audit.UserID.Valid {
+		userID := audit.UserID.UUID.String()
+		entry.UserID = &userID
+	}
+	return entry
+}
+
+func exportJSON(audits []models.ModAudit) (string, error) {
+	entries := make([]exportEntry, len(audits))
+	for i, audit := range audits {
+		entries[i] = toExportEntry(audit)
+	}
+
+	out, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+var csvHeader = []string{"id", "created_at", "action", "user_id", "target_type", "target_id", "reason", "data"}
+
+func exportCSV(audits []models.ModAudit) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(csvHeader); err != nil {
+		return "", err
+	}
+
+	for _, audit := range audits {
+		entry := toExportEntry(audit)
+		userID := ""
+		if entry.UserID != nil {
+			userID = *entry.UserID
+		}
+		reason := ""
+		if entry.Reason != nil {
+			reason = *entry.Reason
+		}
+
+		if err := w.Write([]string{
+			entry.ID,
+			entry.CreatedAt.UTC().Format(time.RFC3339),
+			entry.Action,
+			userID,
+			entry.TargetType,
+			entry.TargetID,
+			csvSafe(reason),
+			audit.Data,
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// csvSafe neutralizes values that spreadsheet applications would otherwise
+// evaluate as formulas.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
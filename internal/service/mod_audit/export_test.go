@@ -0,0 +1,107 @@
+package mod_audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func testAudits() []models.ModAudit {
+	reason := "=HYPERLINK(\"http://example.org\")"
+	return []models.ModAudit{
+		{
+			ID:         uuid.Must(uuid.NewV4()),
+			Action:     "USER_UPDATE",
+			UserID:     uuid.NullUUID{UUID: uuid.Must(uuid.NewV4()), Valid: true},
+			TargetID:   uuid.Must(uuid.NewV4()),
+			TargetType: TargetUser,
+			Data:       `{"before":{"roles":["READ"]},"after":{"roles":["ADMIN"]}}`,
+			Reason:     &reason,
+			CreatedAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			ID:         uuid.Must(uuid.NewV4()),
+			Action:     "SITE_DESTROY",
+			TargetID:   uuid.Must(uuid.NewV4()),
+			TargetType: TargetSite,
+			Data:       `{"before":{"name":"Site, Inc"},"after":null}`,
+			CreatedAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	audits := testAudits()
+	out, err := exportCSV(audits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("expected header and 2 records, got %d", len(records))
+	}
+	if strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+
+	first := records[1]
+	if first[1] != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected created_at: %s", first[1])
+	}
+	if first[3] != audits[0].UserID.UUID.String() {
+		t.Errorf("unexpected user_id: %s", first[3])
+	}
+	if !strings.HasPrefix(first[6], "'=") {
+		t.Errorf("formula in reason was not neutralized: %s", first[6])
+	}
+	if first[7] != audits[0].Data {
+		t.Errorf("unexpected data: %s", first[7])
+	}
+
+	if records[2][3] != "" {
+		t.Errorf("expected empty user_id for deleted user, got %s", records[2][3])
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	audits := testAudits()
+	out, err := exportJSON(audits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []struct {
+		ID     string          `json:"id"`
+		UserID *string         `json:"user_id"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].ID != audits[0].ID.String() {
+		t.Errorf("unexpected id: %s", entries[0].ID)
+	}
+	if entries[1].UserID != nil {
+		t.Errorf("expected null user_id, got %s", *entries[1].UserID)
+	}
+
+	// data is embedded as a JSON document rather than an escaped string
+	var data map[string]any
+	if err := json.Unmarshal(entries[1].Data, &data); err != nil {
+		t.Errorf("data is not a JSON object: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package mod_audit
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gofrs/uuid"
 	"github.com/stashapp/stash-box/internal/models"
@@ -20,24 +21,47 @@ func NewModAuditService(queries *queries.Queries) *ModAuditService {
 	}
 }
 
-// GetModAuditCount returns the total count of audits matching the filter
-func (s *ModAuditService) GetModAuditCount(ctx context.Context, filter models.ModAuditQueryInput) (int, error) {
-	var action queries.NullModAuditAction
+type auditFilter struct {
+	action     queries.NullModAuditAction
+	userID     uuid.NullUUID
+	targetID   uuid.NullUUID
+	targetType *string
+}
+
+func newAuditFilter(filter models.ModAuditQueryInput) auditFilter {
+	var f auditFilter
 	if filter.Action != nil {
-		action = queries.NullModAuditAction{
+		f.action = queries.NullModAuditAction{
 			ModAuditAction: queries.ModAuditAction(filter.Action.String()),
 			Valid:          true,
 		}
 	}
 
-	var userID uuid.NullUUID
 	if filter.UserID != nil {
-		userID = uuid.NullUUID{UUID: *filter.UserID, Valid: true}
+		f.userID = uuid.NullUUID{UUID: *filter.UserID, Valid: true}
+	}
+
+	if filter.TargetID != nil {
+		f.targetID = uuid.NullUUID{UUID: *filter.TargetID, Valid: true}
+	}
+
+	if filter.TargetType != nil && *filter.TargetType != "" {
+		f.targetType = filter.TargetType
 	}
 
+	return f
+}
+
+// GetModAuditCount returns the total count of audits matching the filter
+func (s *ModAuditService) GetModAuditCount(ctx context.Context, filter models.ModAuditQueryInput) (int, error) {
+	f := newAuditFilter(filter)
 	count, err := s.queries.GetModAuditCount(ctx, queries.GetModAuditCountParams{
-		Action: action,
-		UserID: userID,
+		Action:     f.action,
+		UserID:     f.userID,
+		TargetType: f.targetType,
+		TargetID:   f.targetID,
+		StartDate:  filter.StartDate,
+		EndDate:    filter.EndDate,
 	})
 	if err != nil {
 		return 0, err
@@ -48,27 +72,21 @@ func (s *ModAuditService) GetModAuditCount(ctx context.Context, filter models.Mo
 
 // QueryModAudits returns audits matching the filter with pagination
 func (s *ModAuditService) QueryModAudits(ctx context.Context, filter models.ModAuditQueryInput) ([]models.ModAudit, error) {
-	var action queries.NullModAuditAction
-	if filter.Action != nil {
-		action = queries.NullModAuditAction{
-			ModAuditAction: queries.ModAuditAction(filter.Action.String()),
-			Valid:          true,
-		}
-	}
-
-	var userID uuid.NullUUID
-	if filter.UserID != nil {
-		userID = uuid.NullUUID{UUID: *filter.UserID, Valid: true}
-	}
-
 	offset := (filter.Page - 1) * filter.PerPage
-	limit := filter.PerPage
+	return s.queryModAudits(ctx, filter, filter.PerPage, offset)
+}
 
+func (s *ModAuditService) queryModAudits(ctx context.Context, filter models.ModAuditQueryInput, limit, offset int) ([]models.ModAudit, error) {
+	f := newAuditFilter(filter)
 	dbAudits, err := s.queries.QueryModAudits(ctx, queries.QueryModAuditsParams{
-		Action: action,
-		UserID: userID,
-		Limit:  int32(limit),
-		Offset: int32(offset),
+		Action:     f.action,
+		UserID:     f.userID,
+		TargetType: f.targetType,
+		TargetID:   f.targetID,
+		StartDate:  filter.StartDate,
+		EndDate:    filter.EndDate,
+		Limit:      int32(limit),
+		Offset:     int32(offset),
 	})
 	if err != nil {
 		return nil, err
@@ -91,6 +109,31 @@ func (s *ModAuditService) QueryModAudits(ctx context.Context, filter models.ModA
 	return audits, nil
 }
 
+// maxExportPerPage limits the number of audits exported at once
+const maxExportPerPage = 10000
+
+var ErrExportPageSize = fmt.Errorf("per_page must be between 1 and %d", maxExportPerPage)
+
+// Export renders a page of the audits matching the filter in the requested
+// format. Pages hold at most maxExportPerPage audits.
+func (s *ModAuditService) Export(ctx context.Context, filter models.ModAuditQueryInput, format models.ModAuditExportFormatEnum) (string, error) {
+	if filter.PerPage < 1 || filter.PerPage > maxExportPerPage {
+		return "", ErrExportPageSize
+	}
+
+	audits, err := s.QueryModAudits(ctx, filter)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case models.ModAuditExportFormatEnumCSV:
+		return exportCSV(audits)
+	default:
+		return exportJSON(audits)
+	}
+}
+
 // DeleteExpired removes mod audit records older than the specified number of days
 func (s *ModAuditService) DeleteExpired(ctx context.Context, retentionDays int) error {
 	if retentionDays <= 0 {
@@ -0,0 +1,103 @@
+package mod_audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+// Target types recorded in the audit log
+const (
+	TargetEdit        = "EDIT"
+	TargetEditComment = "EDIT_COMMENT"
+	TargetUser        = "USER"
+	TargetScene       = "SCENE"
+	TargetSite        = "SITE"
+	TargetTagCategory = "TAG_CATEGORY"
+	TargetLockout     = "LOCKOUT"
+	TargetRole        = "ROLE"
+)
+
+// Entry describes a privileged action to be recorded in the audit log.
+type Entry struct {
+	Action     queries.ModAuditAction
+	TargetID   uuid.UUID
+	TargetType string
+	// Before and After hold the state of the target around the change and
+	// are stored as a models.ModAuditChangeData document.
+	Before any
+	After  any
+	// Data replaces the before/after document with an action specific payload.
+	Data   any
+	Reason *string
+}
+
+// Record writes an audit entry attributed to the current user. q should be
+// the transaction of the audited change, so that the audit entry is only
+// persisted if the change itself is. Nothing is recorded when audit log
+// retention is disabled.
+func Record(ctx context.Context, q *queries.Queries, entry Entry) error {
+	if config.GetModAuditRetentionDays() <= 0 {
+		return nil
+	}
+
+	data := entry.Data
+	if data == nil {
+		change, err := newChangeData(entry.Before, entry.After)
+		if err != nil {
+			return err
+		}
+		data = change
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit data: %w", err)
+	}
+
+	auditID, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate audit ID: %w", err)
+	}
+
+	var userID uuid.NullUUID
+	if currentUser := auth.GetCurrentUser(ctx); currentUser != nil {
+		userID = uuid.NullUUID{UUID: currentUser.ID, Valid: true}
+	}
+
+	_, err = q.CreateModAudit(ctx, queries.CreateModAuditParams{
+		ID:         auditID,
+		Action:     entry.Action,
+		UserID:     userID,
+		TargetID:   entry.TargetID,
+		TargetType: entry.TargetType,
+		Data:       dataJSON,
+		Reason:     entry.Reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create audit record: %w", err)
+	}
+	return nil
+}
+
+func newChangeData(before, after any) (*models.ModAuditChangeData, error) {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit data: %w", err)
+	}
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit data: %w", err)
+	}
+
+	return &models.ModAuditChangeData{
+		Before: beforeJSON,
+		After:  afterJSON,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/email"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+// digestPeriods is the minimum time between two emails of a frequency
+var digestPeriods = map[models.NotificationEmailFrequency]time.Duration{
+	models.NotificationEmailFrequencyImmediate: 0,
+	models.NotificationEmailFrequencyDaily:     24 * time.Hour,
+	models.NotificationEmailFrequencyWeekly:    7 * 24 * time.Hour,
+}
+
+// GetEmailPreferences returns the email frequency of every notification type
+func (s *Notification) GetEmailPreferences(ctx context.Context, userID uuid.UUID) ([]models.NotificationEmailPreference, error) {
+	rows, err := s.queries.GetUserNotificationEmailPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	frequencies := make(map[models.NotificationEnum]models.NotificationEmailFrequency)
+	for _, row := range rows {
+		frequencies[models.NotificationEnum(row.Type)] = models.NotificationEmailFrequency(row.Frequency)
+	}
+
+	var ret []models.NotificationEmailPreference
+	for _, t := range models.AllNotificationEnum {
+		frequency, found := frequencies[t]
+		if !found {
+			frequency = models.NotificationEmailFrequencyOff
+		}
+		ret = append(ret, models.NotificationEmailPreference{
+			Type:      t,
+			Frequency: frequency,
+		})
+	}
+	return ret, nil
+}
+
+// UpdateEmailPreferences sets the email frequency of the given notification types
+func (s *Notification) UpdateEmailPreferences(ctx context.Context, userID uuid.UUID, preferences []models.NotificationEmailPreferenceInput) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		for _, preference := range preferences {
+			err := tx.UpsertUserNotificationEmailPreference(ctx, queries.UpsertUserNotificationEmailPreferenceParams{
+				UserID:    userID,
+				Type:      queries.NotificationType(preference.Type),
+				Frequency: preference.Frequency.String(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UnsubscribeEmails turns off every notification email for the user
+func (s *Notification) UnsubscribeEmails(ctx context.Context, userID uuid.UUID) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		return tx.UnsubscribeUserNotificationEmails(ctx, userID)
+	})
+}
+
+// SendEmails emails users their unread notifications of the given frequency.
+// Digests are sent at most once per period. Notifications of a user whose
+// email fails are retried on the next run.
+func (s *Notification) SendEmails(ctx context.Context, frequency models.NotificationEmailFrequency) error {
+	period, found := digestPeriods[frequency]
+	if !found {
+		return fmt.Errorf("notification emails can not be sent with frequency %s", frequency)
+	}
+
+	recipients, err := s.queries.FindNotificationEmailRecipients(ctx, queries.FindNotificationEmailRecipientsParams{
+		Frequency:  frequency.String(),
+		SentBefore: time.Now().Add(-period),
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range recipients {
+		if err := s.sendEmail(ctx, userID, frequency); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", userID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Notification) sendEmail(ctx context.Context, userID uuid.UUID, frequency models.NotificationEmailFrequency) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		user, err := tx.FindUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.ClaimNotificationEmails(ctx, queries.ClaimNotificationEmailsParams{
+			UserID:    userID,
+			Frequency: frequency.String(),
+		})
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+
+		var items []email.NotificationItem
+		for _, row := range rows {
+			item := email.NotificationItem{
+				Type:     models.NotificationEnum(row.Type),
+				TargetID: row.ID,
+			}
+			if row.EditID.Valid {
+				item.EditID = &row.EditID.UUID
+			}
+			items = append(items, item)
+		}
+
+		err = tx.MarkNotificationDigestSent(ctx, queries.MarkNotificationDigestSentParams{
+			UserID:    userID,
+			Frequency: frequency.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		// sent last, so that the notifications are only marked as emailed
+		// once the email is accepted
+		return email.SendNotificationEmail(s.emailMgr, converter.UserToModel(user), frequency, items, email.UnsubscribeURL(userID))
+	})
+}
@@ -5,20 +5,23 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/email"
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/internal/queries"
 	"github.com/stashapp/stash-box/pkg/logger"
 )
 
 type Notification struct {
-	queries *queries.Queries
-	withTxn queries.WithTxnFunc
+	queries  *queries.Queries
+	withTxn  queries.WithTxnFunc
+	emailMgr *email.Manager
 }
 
-func NewNotification(queries *queries.Queries, withTxn queries.WithTxnFunc) *Notification {
+func NewNotification(queries *queries.Queries, withTxn queries.WithTxnFunc, emailMgr *email.Manager) *Notification {
 	return &Notification{
-		queries: queries,
-		withTxn: withTxn,
+		queries:  queries,
+		withTxn:  withTxn,
+		emailMgr: emailMgr,
 	}
 }
 
@@ -31,6 +34,12 @@ func (s *Notification) TriggerSceneCreationNotifications(ctx context.Context, sc
 	return s.queries.TriggerSceneCreationNotifications(ctx, sceneID)
 }
 
+// TriggerSceneReleaseNotifications notifies subscribers about scenes from
+// their favorite performers and studios that are released on releaseDate.
+func (s *Notification) TriggerSceneReleaseNotifications(ctx context.Context, releaseDate string) error {
+	return s.queries.TriggerSceneReleaseNotifications(ctx, releaseDate)
+}
+
 func (s *Notification) TriggerPerformerEditNotifications(ctx context.Context, editID uuid.UUID) error {
 	return s.queries.TriggerPerformerEditNotifications(ctx, editID)
 }
@@ -60,7 +69,8 @@ func (s *Notification) TriggerUpdatedEditNotifications(ctx context.Context, edit
 }
 
 // LevelFor classifies a notification type as either NORMAL or URGENT. URGENT covers
-// notifications about activity on the user's own (or voted/commented) edits.
+// notifications about activity on the user's own (or voted/commented) edits and
+// changes to the user's suspension.
 func LevelFor(t models.NotificationEnum) models.NotificationLevel {
 	switch t {
 	case models.NotificationEnumCommentOwnEdit,
@@ -68,7 +78,10 @@ func LevelFor(t models.NotificationEnum) models.NotificationLevel {
 		models.NotificationEnumFailedOwnEdit,
 		models.NotificationEnumCommentCommentedEdit,
 		models.NotificationEnumCommentVotedEdit,
-		models.NotificationEnumUpdatedEdit:
+		models.NotificationEnumUpdatedEdit,
+		models.NotificationEnumMentionedInComment,
+		models.NotificationEnumUserSuspended,
+		models.NotificationEnumUserSuspensionLifted:
 		return models.NotificationLevelUrgent
 	default:
 		return models.NotificationLevelNormal
@@ -0,0 +1,168 @@
+package performer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+// maxPathLength limits the number of co-appearance hops searched by FindPath
+const maxPathLength = 6
+
+// maxPathVisited limits the number of performers FindPath visits, and the
+// number of links it loads per layer. maxPathFrontier limits the number of
+// performers expanded in a single layer.
+const (
+	maxPathVisited  = 20000
+	maxPathFrontier = 5000
+)
+
+var ErrPathSearchTooLarge = errors.New("performers are too widely connected to find a path between them")
+
+// maxCostars limits the number of costars returned by Costars
+const maxCostars = 100
+
+// Costars returns the performers sharing scenes with the given performer,
+// optionally restricted to scenes from a single studio. The limit is clamped
+// to between 1 and maxCostars.
+func (s *Performer) Costars(ctx context.Context, performerID uuid.UUID, limit int, studioID *uuid.UUID) ([]models.PerformerCostar, error) {
+	params := queries.GetPerformerCostarsParams{
+		PerformerID: performerID,
+		Limit:       int32(min(max(limit, 1), maxCostars)),
+	}
+	if studioID != nil {
+		params.StudioID = uuid.NullUUID{UUID: *studioID, Valid: true}
+	}
+
+	rows, err := s.queries.GetPerformerCostars(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.PerformerCostar
+	for _, row := range rows {
+		result = append(result, models.PerformerCostar{
+			Performer:      converter.PerformerToModelPtr(row.Performer),
+			SceneCount:     int(row.SceneCount),
+			FirstSceneDate: row.FirstSceneDate,
+			LastSceneDate:  row.LastSceneDate,
+		})
+	}
+
+	return result, nil
+}
+
+type pathNode struct {
+	parent uuid.UUID
+	depth  int
+}
+
+// FindPath returns the shortest chain of co-appearances between two
+// performers, including both endpoints. It returns an empty slice if the
+// performers are not connected within maxPathLength hops, and
+// ErrPathSearchTooLarge if the search would exceed maxPathVisited or
+// maxPathFrontier performers. Deleted performers are not part of any path.
+//
+// The search is a bidirectional breadth-first search, expanding the smaller
+// frontier one layer at a time with a single query per layer.
+func (s *Performer) FindPath(ctx context.Context, from uuid.UUID, to uuid.UUID) ([]models.Performer, error) {
+	forward := map[uuid.UUID]pathNode{from: {}}
+	backward := map[uuid.UUID]pathNode{to: {}}
+	forwardFrontier := []uuid.UUID{from}
+	backwardFrontier := []uuid.UUID{to}
+
+	meeting := uuid.Nil
+	if from == to {
+		meeting = from
+	}
+
+	for hops := 0; meeting.IsNil() && hops < maxPathLength; hops++ {
+		if len(forwardFrontier) == 0 || len(backwardFrontier) == 0 {
+			break
+		}
+
+		frontier, visited, other := &forwardFrontier, forward, backward
+		if len(backwardFrontier) < len(forwardFrontier) {
+			frontier, visited, other = &backwardFrontier, backward, forward
+		}
+
+		if len(*frontier) > maxPathFrontier {
+			return nil, ErrPathSearchTooLarge
+		}
+
+		links, err := s.queries.GetPerformerCostarLinks(ctx, queries.GetPerformerCostarLinksParams{
+			PerformerIds: *frontier,
+			MaxLinks:     maxPathVisited + 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(links) > maxPathVisited {
+			return nil, ErrPathSearchTooLarge
+		}
+
+		var next []uuid.UUID
+		bestLength := -1
+		for _, link := range links {
+			if _, seen := visited[link.CostarID]; seen {
+				continue
+			}
+
+			node := pathNode{parent: link.PerformerID, depth: visited[link.PerformerID].depth + 1}
+			visited[link.CostarID] = node
+			next = append(next, link.CostarID)
+			if len(forward)+len(backward) > maxPathVisited {
+				return nil, ErrPathSearchTooLarge
+			}
+
+			// complete the layer, keeping the meeting point with the shortest total length
+			if otherNode, found := other[link.CostarID]; found {
+				length := node.depth + otherNode.depth
+				if bestLength < 0 || length < bestLength {
+					bestLength = length
+					meeting = link.CostarID
+				}
+			}
+		}
+		*frontier = next
+	}
+
+	if meeting.IsNil() {
+		return []models.Performer{}, nil
+	}
+
+	// walk back to each endpoint from the meeting point
+	var path []uuid.UUID
+	for id := meeting; ; id = forward[id].parent {
+		path = append([]uuid.UUID{id}, path...)
+		if id == from {
+			break
+		}
+	}
+	for id := meeting; id != to; {
+		id = backward[id].parent
+		path = append(path, id)
+	}
+
+	performers, err := s.queries.FindPerformersByIds(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[uuid.UUID]queries.Performer, len(performers))
+	for _, performer := range performers {
+		m[performer.ID] = performer
+	}
+
+	result := make([]models.Performer, len(path))
+	for i, id := range path {
+		result[i] = converter.PerformerToModel(m[id])
+	}
+
+	return result, nil
+}
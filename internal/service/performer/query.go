@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/gofrs/uuid"
@@ -38,6 +39,30 @@ func (s *Performer) QueryCount(ctx context.Context, input models.PerformerQueryI
 	return queryhelper.ExecuteCount(ctx, query, s.queries.DB(), "QueryPerformersCount")
 }
 
+// FindCreatedBetween returns the ids of performers matching input that were
+// created after since and no later than until. The favorite filter is
+// evaluated for userID.
+func (s *Performer) FindCreatedBetween(ctx context.Context, input models.PerformerQueryInput, userID uuid.UUID, since, until time.Time) ([]uuid.UUID, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	inner := s.buildPerformerQuery(psql, input, userID, false).
+		Where(sq.Gt{"performers.created_at": since}).
+		Where(sq.LtOrEq{"performers.created_at": until})
+
+	query := psql.Select("DISTINCT matches.id").FromSelect(inner, "matches")
+	return queryhelper.ExecuteIDs(ctx, query, s.queries.DB(), "FindPerformersCreatedBetween")
+}
+
+// FindMatching returns the ids of up to limit performers matching input,
+// ignoring pagination. The favorite filter is evaluated for userID.
+func (s *Performer) FindMatching(ctx context.Context, input models.PerformerQueryInput, userID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	inner := s.buildPerformerQuery(psql, input, userID, false)
+	query := psql.Select("DISTINCT matches.id").FromSelect(inner, "matches").Limit(uint64(limit))
+	return queryhelper.ExecuteIDs(ctx, query, s.queries.DB(), "FindMatchingPerformers")
+}
+
 func (s *Performer) buildPerformerQuery(psql sq.StatementBuilderType, input models.PerformerQueryInput, userID uuid.UUID, forCount bool) sq.SelectBuilder {
 	var query sq.SelectBuilder
 	needsStudioJoin := input.StudioID != nil
@@ -46,24 +71,14 @@ func (s *Performer) buildPerformerQuery(psql sq.StatementBuilderType, input mode
 	if forCount {
 		if needsStudioJoin {
 			query = psql.Select("COUNT(DISTINCT performers.id)").From("performers").
-				Join(`(
-					SELECT performer_id, MIN(date) as debut, MAX(date) AS last_scene, COUNT(*) as scene_count
-					FROM scene_performers
-					JOIN scenes ON scene_id = id AND studio_id = ?
-					GROUP BY performer_id
-				) D ON performers.id = D.performer_id`, input.StudioID)
+				Join(studioSceneJoin(input))
 		} else {
 			query = psql.Select("COUNT(*)").From("performers")
 		}
 	} else {
 		if needsStudioJoin {
 			query = psql.Select("performers.*").From("performers").
-				Join(`(
-					SELECT performer_id, MIN(date) as debut, MAX(date) AS last_scene, COUNT(*) as scene_count
-					FROM scene_performers
-					JOIN scenes ON scene_id = id AND studio_id = ?
-					GROUP BY performer_id
-				) D ON performers.id = D.performer_id`, input.StudioID)
+				Join(studioSceneJoin(input))
 		} else {
 			query = psql.Select("performers.*").From("performers")
 		}
@@ -155,6 +170,15 @@ func (s *Performer) buildPerformerQuery(psql sq.StatementBuilderType, input mode
 		query = query.Where(sq.Expr(subquery, input.PerformedWith, input.PerformedWith))
 	}
 
+	// Filter by active status
+	if input.Active != nil {
+		if *input.Active {
+			query = query.Where(activeCondition)
+		} else {
+			query = query.Where("NOT (" + activeCondition + ")")
+		}
+	}
+
 	// String criteria
 	if input.Disambiguation != nil {
 		query = queryhelper.ApplyStringCriterion(query, "disambiguation", input.Disambiguation)
@@ -169,6 +193,32 @@ func (s *Performer) buildPerformerQuery(psql sq.StatementBuilderType, input mode
 	return query
 }
 
+// activeCondition matches performers who are currently active. Career
+// periods take precedence when present, so that performers who retired and
+// later returned are treated as active; otherwise the career years are used.
+const activeCondition = `performers.deathdate IS NULL AND CASE
+		WHEN EXISTS (SELECT 1 FROM performer_career_periods CP WHERE CP.performer_id = performers.id)
+		THEN EXISTS (SELECT 1 FROM performer_career_periods CP WHERE CP.performer_id = performers.id AND CP.end_date IS NULL)
+		ELSE performers.career_start_year IS NOT NULL AND performers.career_end_year IS NULL
+	END`
+
+// studioSceneJoin builds the per-performer scene aggregate join used when
+// filtering by studio. If IncludeSubStudios is set, scenes from every studio
+// below the given studio in the network are included.
+func studioSceneJoin(input models.PerformerQueryInput) (string, interface{}) {
+	studioClause := "studio_id = ?"
+	if input.IncludeSubStudios != nil && *input.IncludeSubStudios {
+		studioClause = "studio_id IN (" + queryhelper.StudioNetworkSubquery + ")"
+	}
+
+	return `(
+		SELECT performer_id, MIN(date) as debut, MAX(date) AS last_scene, COUNT(*) as scene_count
+		FROM scene_performers
+		JOIN scenes ON scene_id = id AND ` + studioClause + `
+		GROUP BY performer_id
+	) D ON performers.id = D.performer_id`, *input.StudioID
+}
+
 func (s *Performer) applyPerformerSort(query sq.SelectBuilder, input models.PerformerQueryInput) sq.SelectBuilder {
 	sortField := "name"
 	sortDir := "ASC"
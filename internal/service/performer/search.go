@@ -0,0 +1,120 @@
+package performer
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/search"
+)
+
+// searcher runs the full text queries behind searchPerformers against the
+// configured search backend.
+type searcher interface {
+	search(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) ([]uuid.UUID, error)
+	count(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) (int, error)
+	facets(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) (*models.PerformerSearchFacets, error)
+}
+
+func newSearcher() searcher {
+	if config.GetSearchBackend() == config.PostgresSearch {
+		return postgresSearcher{}
+	}
+	return paradeDBSearcher{}
+}
+
+type paradeDBSearcher struct{}
+
+func (paradeDBSearcher) search(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) ([]uuid.UUID, error) {
+	return q.SearchPerformers(ctx, queries.SearchPerformersParams{
+		Term:         params.Term,
+		FilterGender: params.FilterGender,
+		Limit:        int32(params.Limit),
+		Offset:       int32(params.Offset),
+	})
+}
+
+func (paradeDBSearcher) count(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) (int, error) {
+	raw, err := q.CountPerformerSearchMatches(ctx, queries.CountPerformerSearchMatchesParams{
+		Term:         params.Term,
+		FilterGender: params.FilterGender,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return parseParadeDBCount(raw), nil
+}
+
+func (paradeDBSearcher) facets(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) (*models.PerformerSearchFacets, error) {
+	raw, err := q.GetPerformerSearchFacets(ctx, queries.GetPerformerSearchFacetsParams{
+		Term:         params.Term,
+		FilterGender: params.FilterGender,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePerformerFacets(raw), nil
+}
+
+// postgresSearcher matches any word of the term, like the BM25 match queries.
+type postgresSearcher struct{}
+
+func (postgresSearcher) search(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) ([]uuid.UUID, error) {
+	query := search.TSQuery(params.Term, "|", false)
+	if query == "" {
+		return []uuid.UUID{}, nil
+	}
+
+	return q.SearchPerformersPostgres(ctx, queries.SearchPerformersPostgresParams{
+		Query:        query,
+		FilterGender: params.FilterGender,
+		Term:         params.Term,
+		Limit:        int32(params.Limit),
+		Offset:       int32(params.Offset),
+	})
+}
+
+func (postgresSearcher) count(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) (int, error) {
+	query := search.TSQuery(params.Term, "|", false)
+	if query == "" {
+		return 0, nil
+	}
+
+	count, err := q.CountPerformerSearchMatchesPostgres(ctx, queries.CountPerformerSearchMatchesPostgresParams{
+		Query:        query,
+		FilterGender: params.FilterGender,
+	})
+	return int(count), err
+}
+
+func (postgresSearcher) facets(ctx context.Context, q *queries.Queries, params *models.PerformerSearchParams) (*models.PerformerSearchFacets, error) {
+	facets := &models.PerformerSearchFacets{}
+	query := search.TSQuery(params.Term, "|", false)
+	if query == "" {
+		return facets, nil
+	}
+
+	rows, err := q.GetPerformerSearchFacetsPostgres(ctx, queries.GetPerformerSearchFacetsPostgresParams{
+		Query:        query,
+		FilterGender: params.FilterGender,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if row.Gender == nil {
+			continue
+		}
+		if gender := models.GenderEnum(*row.Gender); gender.IsValid() {
+			facets.Genders = append(facets.Genders, models.GenderFacet{
+				Gender: gender,
+				Count:  int(row.Count),
+			})
+		}
+	}
+	return facets, nil
+}
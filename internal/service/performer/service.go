@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/gofrs/uuid"
@@ -325,6 +326,58 @@ func (s *Performer) GetURLs(ctx context.Context, performerID uuid.UUID) ([]model
 	return result, nil
 }
 
+// GetRelationships returns the performers related to the given performer, ordered by type and name
+func (s *Performer) GetRelationships(ctx context.Context, performerID uuid.UUID) ([]models.PerformerRelationship, error) {
+	relationships, err := s.queries.GetPerformerRelationships(ctx, performerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for _, rel := range relationships {
+		ids = append(ids, rel.RelatedPerformerID)
+	}
+
+	performers, errs := s.LoadByIds(ctx, ids)
+	if len(errs) > 0 && errs[0] != nil {
+		return nil, errs[0]
+	}
+
+	var result []models.PerformerRelationship
+	for i, rel := range relationships {
+		if performers[i] == nil || performers[i].Deleted {
+			continue
+		}
+		result = append(result, models.PerformerRelationship{
+			Performer: performers[i],
+			Type:      models.PerformerRelationshipTypeEnum(rel.Type),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		return result[i].Performer.Name < result[j].Performer.Name
+	})
+
+	return result, nil
+}
+
+// GetCareerPeriods returns the career periods of the given performer, ordered by start date
+func (s *Performer) GetCareerPeriods(ctx context.Context, performerID uuid.UUID) ([]models.PerformerCareerPeriod, error) {
+	periods, err := s.queries.GetPerformerCareerPeriods(ctx, performerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.PerformerCareerPeriod, len(periods))
+	for i, period := range periods {
+		result[i] = converter.CareerPeriodToModel(period)
+	}
+	return result, nil
+}
+
 // Mutations
 
 func (s *Performer) Create(ctx context.Context, input models.PerformerCreateInput) (*models.Performer, error) {
@@ -522,11 +575,11 @@ func (s *Performer) SearchPerformer(ctx context.Context, term string, limit *int
 	}, nil
 }
 
-// bm25Search wraps a ParadeDB BM25 query in a tx with plan_cache_mode=
+// searchTxn wraps a full text query in a tx with plan_cache_mode=
 // force_custom_plan. Without this the planner switches to a generic plan
-// after 5 prepared-statement executes and pdb.score() fails with
-// "Unsupported query shape".
-func bm25Search[T any](ctx context.Context, s *Performer, fn func(*queries.Queries) (T, error)) (T, error) {
+// after 5 prepared-statement executes and ParadeDB's pdb.score() fails with
+// "Unsupported query shape". The setting is harmless for the postgres backend.
+func searchTxn[T any](ctx context.Context, s *Performer, fn func(*queries.Queries) (T, error)) (T, error) {
 	var out T
 	err := s.withTxn(func(q *queries.Queries) error {
 		if _, err := q.DB().Exec(ctx, "SET LOCAL plan_cache_mode = force_custom_plan"); err != nil {
@@ -540,13 +593,8 @@ func bm25Search[T any](ctx context.Context, s *Performer, fn func(*queries.Queri
 }
 
 func (s *Performer) SearchPerformerPage(ctx context.Context, params *models.PerformerSearchParams) ([]models.Performer, error) {
-	ids, err := bm25Search(ctx, s, func(q *queries.Queries) ([]uuid.UUID, error) {
-		return q.SearchPerformers(ctx, queries.SearchPerformersParams{
-			Term:         params.Term,
-			FilterGender: params.FilterGender,
-			Limit:        int32(params.Limit),
-			Offset:       int32(params.Offset),
-		})
+	ids, err := searchTxn(ctx, s, func(q *queries.Queries) ([]uuid.UUID, error) {
+		return newSearcher().search(ctx, q, params)
 	})
 	if err != nil {
 		return nil, err
@@ -563,29 +611,15 @@ func (s *Performer) SearchPerformerPage(ctx context.Context, params *models.Perf
 }
 
 func (s *Performer) SearchPerformerCount(ctx context.Context, params *models.PerformerSearchParams) (int, error) {
-	raw, err := bm25Search(ctx, s, func(q *queries.Queries) (interface{}, error) {
-		return q.CountPerformerSearchMatches(ctx, queries.CountPerformerSearchMatchesParams{
-			Term:         params.Term,
-			FilterGender: params.FilterGender,
-		})
+	return searchTxn(ctx, s, func(q *queries.Queries) (int, error) {
+		return newSearcher().count(ctx, q, params)
 	})
-	if err != nil {
-		return 0, err
-	}
-	return parseParadeDBCount(raw), nil
 }
 
 func (s *Performer) SearchPerformerFacets(ctx context.Context, params *models.PerformerSearchParams) (*models.PerformerSearchFacets, error) {
-	raw, err := bm25Search(ctx, s, func(q *queries.Queries) (interface{}, error) {
-		return q.GetPerformerSearchFacets(ctx, queries.GetPerformerSearchFacetsParams{
-			Term:         params.Term,
-			FilterGender: params.FilterGender,
-		})
+	return searchTxn(ctx, s, func(q *queries.Queries) (*models.PerformerSearchFacets, error) {
+		return newSearcher().facets(ctx, q, params)
 	})
-	if err != nil {
-		return nil, err
-	}
-	return parsePerformerFacets(raw), nil
 }
 
 type paradeDBCountResult struct {
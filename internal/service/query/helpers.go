@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/gofrs/uuid"
 	"github.com/jackc/pgx/v5"
 
 	"github.com/stashapp/stash-box/internal/queries"
@@ -94,3 +95,34 @@ func ExecuteCount(ctx context.Context, query sq.SelectBuilder, db queries.DBTX,
 	err = db.QueryRow(ctx, sql, args...).Scan(&count)
 	return int(count), err
 }
+
+// ExecuteIDs executes a query selecting a single id column
+// If queryName is provided, it prepends a sqlc-style comment for better span naming in traces
+func ExecuteIDs(ctx context.Context, query sq.SelectBuilder, db queries.DBTX, queryName string) ([]uuid.UUID, error) {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepend query name comment for tracing if provided
+	if queryName != "" {
+		sql = fmt.Sprintf("-- name: %s\n%s", queryName, sql)
+	}
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+}
+
+// StudioNetworkSubquery selects the ids of a studio and all of its descendants,
+// at any depth. It takes the root studio id as its only argument.
+// UNION (rather than UNION ALL) guarantees termination should a cycle exist.
+const StudioNetworkSubquery = `WITH RECURSIVE network AS (
+	SELECT studios.id FROM studios WHERE studios.id = ?
+	UNION
+	SELECT S.id FROM studios S JOIN network N ON S.parent_studio_id = N.id
+)
+SELECT id FROM network`
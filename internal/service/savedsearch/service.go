@@ -0,0 +1,241 @@
+package savedsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/errutil"
+)
+
+var ErrInvalidFilter = errors.New("exactly one of scene_filter and performer_filter must be set")
+
+// maxNotifiedMatches caps the number of matches listed in a single
+// notification. The notification still carries the total count.
+const maxNotifiedMatches = 50
+
+// SceneMatcher finds scenes matching a filter that were created in a window
+type SceneMatcher interface {
+	FindCreatedBetween(ctx context.Context, input models.SceneQueryInput, userID uuid.UUID, since, until time.Time) ([]uuid.UUID, error)
+}
+
+// PerformerMatcher finds performers matching a filter that were created in a window
+type PerformerMatcher interface {
+	FindCreatedBetween(ctx context.Context, input models.PerformerQueryInput, userID uuid.UUID, since, until time.Time) ([]uuid.UUID, error)
+}
+
+// SavedSearch handles saved scene and performer queries and the
+// notifications raised for their new matches
+type SavedSearch struct {
+	queries    *queries.Queries
+	withTxn    queries.WithTxnFunc
+	scenes     SceneMatcher
+	performers PerformerMatcher
+}
+
+// NewSavedSearch creates a new saved search service
+func NewSavedSearch(queries *queries.Queries, withTxn queries.WithTxnFunc, scenes SceneMatcher, performers PerformerMatcher) *SavedSearch {
+	return &SavedSearch{
+		queries:    queries,
+		withTxn:    withTxn,
+		scenes:     scenes,
+		performers: performers,
+	}
+}
+
+func encodeFilter(sceneFilter *models.SceneQueryInput, performerFilter *models.PerformerQueryInput) (models.SavedSearchTypeEnum, json.RawMessage, error) {
+	switch {
+	case sceneFilter != nil && performerFilter == nil:
+		data, err := json.Marshal(sceneFilter)
+		return models.SavedSearchTypeEnumScene, data, err
+	case performerFilter != nil && sceneFilter == nil:
+		data, err := json.Marshal(performerFilter)
+		return models.SavedSearchTypeEnumPerformer, data, err
+	default:
+		return "", nil, ErrInvalidFilter
+	}
+}
+
+// Create saves a new search for the given user
+func (s *SavedSearch) Create(ctx context.Context, userID uuid.UUID, input models.SavedSearchCreateInput) (*models.SavedSearch, error) {
+	searchType, filter, err := encodeFilter(input.SceneFilter, input.PerformerFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	var savedSearch queries.SavedSearch
+	err = s.withTxn(func(tx *queries.Queries) error {
+		var err error
+		savedSearch, err = tx.CreateSavedSearch(ctx, queries.CreateSavedSearchParams{
+			ID:     id,
+			UserID: userID,
+			Name:   input.Name,
+			Type:   searchType.String(),
+			Filter: filter,
+			Notify: input.Notify,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.SavedSearchToModelPtr(savedSearch), nil
+}
+
+// Update changes a saved search owned by the given user
+func (s *SavedSearch) Update(ctx context.Context, userID uuid.UUID, input models.SavedSearchUpdateInput) (*models.SavedSearch, error) {
+	var savedSearch queries.SavedSearch
+	err := s.withTxn(func(tx *queries.Queries) error {
+		existing, err := tx.FindSavedSearch(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if existing.UserID != userID {
+			return auth.ErrUnauthorized
+		}
+
+		params := queries.UpdateSavedSearchParams{
+			ID:     existing.ID,
+			Name:   existing.Name,
+			Type:   existing.Type,
+			Filter: existing.Filter,
+			Notify: existing.Notify,
+		}
+		if input.Name != nil {
+			params.Name = *input.Name
+		}
+		if input.Notify != nil {
+			params.Notify = *input.Notify
+		}
+		if input.SceneFilter != nil || input.PerformerFilter != nil {
+			searchType, filter, err := encodeFilter(input.SceneFilter, input.PerformerFilter)
+			if err != nil {
+				return err
+			}
+			params.Type = searchType.String()
+			params.Filter = filter
+		}
+
+		savedSearch, err = tx.UpdateSavedSearch(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.SavedSearchToModelPtr(savedSearch), nil
+}
+
+// Destroy deletes a saved search owned by the given user, along with its
+// notifications
+func (s *SavedSearch) Destroy(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		existing, err := tx.FindSavedSearch(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing.UserID != userID {
+			return auth.ErrUnauthorized
+		}
+
+		if err := tx.DeleteNotificationsByTargetID(ctx, id); err != nil {
+			return err
+		}
+		return tx.DeleteSavedSearch(ctx, id)
+	})
+}
+
+// FindByID returns a saved search, or nil if it does not exist
+func (s *SavedSearch) FindByID(ctx context.Context, id uuid.UUID) (*models.SavedSearch, error) {
+	savedSearch, err := s.queries.FindSavedSearch(ctx, id)
+	if err != nil {
+		return nil, errutil.IgnoreNotFound(err)
+	}
+	return converter.SavedSearchToModelPtr(savedSearch), nil
+}
+
+// FindByUser returns the saved searches of a user, ordered by name
+func (s *SavedSearch) FindByUser(ctx context.Context, userID uuid.UUID) ([]models.SavedSearch, error) {
+	searches, err := s.queries.FindSavedSearchesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.SavedSearchesToModels(searches), nil
+}
+
+// CheckMatches notifies the owners of saved searches about matches created
+// since the previous check. A failing saved search does not hold up the
+// others; it is retried on the next run.
+func (s *SavedSearch) CheckMatches(ctx context.Context) error {
+	searches, err := s.queries.FindNotifiableSavedSearches(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, search := range searches {
+		if err := s.checkMatches(ctx, converter.SavedSearchToModel(search)); err != nil {
+			errs = append(errs, fmt.Errorf("saved search %s: %w", search.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *SavedSearch) checkMatches(ctx context.Context, search models.SavedSearch) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		window, err := tx.AdvanceSavedSearchCheck(ctx, search.ID)
+		if err != nil {
+			return err
+		}
+
+		ids, err := s.findMatches(ctx, search, window.Since, window.Until)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		count := len(ids)
+		if count > maxNotifiedMatches {
+			ids = ids[:maxNotifiedMatches]
+		}
+
+		return tx.CreateSavedSearchMatchNotification(ctx, queries.CreateSavedSearchMatchNotificationParams{
+			UserID:        search.UserID,
+			SavedSearchID: search.ID,
+			Ids:           ids,
+			Count:         count,
+		})
+	})
+}
+
+func (s *SavedSearch) findMatches(ctx context.Context, search models.SavedSearch, since, until time.Time) ([]uuid.UUID, error) {
+	switch search.Type {
+	case models.SavedSearchTypeEnumScene:
+		filter, err := search.SceneFilter()
+		if err != nil {
+			return nil, err
+		}
+		return s.scenes.FindCreatedBetween(ctx, filter, search.UserID, since, until)
+	case models.SavedSearchTypeEnumPerformer:
+		filter, err := search.PerformerFilter()
+		if err != nil {
+			return nil, err
+		}
+		return s.performers.FindCreatedBetween(ctx, filter, search.UserID, since, until)
+	default:
+		return nil, fmt.Errorf("unsupported saved search type %s", search.Type)
+	}
+}
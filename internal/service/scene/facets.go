@@ -0,0 +1,268 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	queryhelper "github.com/stashapp/stash-box/internal/service/query"
+)
+
+const defaultFacetLimit = 10
+
+// durationBuckets holds the range in seconds of each duration bucket. The
+// lower bound is inclusive, the upper bound exclusive, and a max of zero is
+// unbounded.
+var durationBuckets = []struct {
+	bucket models.SceneDurationBucketEnum
+	min    int
+	max    int
+}{
+	{models.SceneDurationBucketEnumUnder10Minutes, 0, 10 * 60},
+	{models.SceneDurationBucketEnumFrom10To30Minutes, 10 * 60, 30 * 60},
+	{models.SceneDurationBucketEnumFrom30To60Minutes, 30 * 60, 60 * 60},
+	{models.SceneDurationBucketEnumOver60Minutes, 60 * 60, 0},
+}
+
+// yearExpr extracts the release year of a scene. Fuzzy dates are stored as
+// YYYY or YYYY-MM, so the year is always the first four characters.
+func yearExpr(table string) string {
+	return fmt.Sprintf("LEFT(%s.date, 4)::INT", table)
+}
+
+func durationCondition(table string, buckets []models.SceneDurationBucketEnum) (sq.Or, error) {
+	column := table + ".duration"
+
+	var cond sq.Or
+	for _, bucket := range buckets {
+		found := false
+		for _, b := range durationBuckets {
+			if b.bucket != bucket {
+				continue
+			}
+			found = true
+			if b.max == 0 {
+				cond = append(cond, sq.GtOrEq{column: b.min})
+			} else {
+				cond = append(cond, sq.And{sq.GtOrEq{column: b.min}, sq.Lt{column: b.max}})
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unsupported duration bucket %s", bucket)
+		}
+	}
+	return cond, nil
+}
+
+// durationBucketExpr maps the duration of a scene to the name of its bucket
+func durationBucketExpr(table string) string {
+	column := table + ".duration"
+
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, bucket := range durationBuckets {
+		if bucket.max == 0 {
+			fmt.Fprintf(&b, " WHEN %s >= %d THEN '%s'", column, bucket.min, bucket.bucket)
+		} else {
+			fmt.Fprintf(&b, " WHEN %s >= %d AND %s < %d THEN '%s'", column, bucket.min, column, bucket.max, bucket.bucket)
+		}
+	}
+	b.WriteString(" END")
+	return b.String()
+}
+
+// QueryFacets returns the facets of every scene matching a queryScenes filter
+func (s *Scene) QueryFacets(ctx context.Context, input models.SceneQueryInput, limit int) (*models.SceneSearchFacets, error) {
+	user := auth.GetCurrentUser(ctx)
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	matches, err := s.buildSceneQuery(psql, input, user.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.facets(ctx, matches, limit)
+}
+
+// SearchFacets returns the facets of every scene matching a searchScenes term
+// and its drill-down filter
+func (s *Scene) SearchFacets(ctx context.Context, params models.SceneSearchParams, limit int) (*models.SceneSearchFacets, error) {
+	ids, err := s.searchMatches(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FacetsByIDs(ctx, ids, limit)
+}
+
+// FacetsByIDs returns the facets of the given scenes
+func (s *Scene) FacetsByIDs(ctx context.Context, ids []uuid.UUID, limit int) (*models.SceneSearchFacets, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	matches := psql.Select("scenes.*").
+		From("scenes").
+		Where("scenes.id = ANY(?)", ids).
+		Where(sq.Eq{"scenes.deleted": false})
+
+	return s.facets(ctx, matches, limit)
+}
+
+// searchMatches returns the id of every scene matching the search, in rank
+// order, narrowed down by the search filter.
+func (s *Scene) searchMatches(ctx context.Context, params models.SceneSearchParams) ([]uuid.UUID, error) {
+	ids, _, err := newSearcher().search(ctx, s.queries, params.Term, math.MaxInt32, 0)
+	if err != nil || params.Filter == nil || len(ids) == 0 {
+		return ids, err
+	}
+
+	user := auth.GetCurrentUser(ctx)
+	input := models.SceneQueryInput{
+		Studios:    params.Filter.Studios,
+		Tags:       params.Filter.Tags,
+		Performers: params.Filter.Performers,
+		Years:      params.Filter.Years,
+		Durations:  params.Filter.Durations,
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	inner, err := s.buildSceneQuery(psql, input, user.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	query := psql.Select("matches.id").FromSelect(inner.Where("scenes.id = ANY(?)", ids), "matches")
+
+	filtered, err := queryhelper.ExecuteIDs(ctx, query, s.queries.DB(), "FilterSceneSearchMatches")
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[uuid.UUID]bool)
+	for _, id := range filtered {
+		matched[id] = true
+	}
+
+	var ret []uuid.UUID
+	for _, id := range ids {
+		if matched[id] {
+			ret = append(ret, id)
+		}
+	}
+	return ret, nil
+}
+
+func (s *Scene) facets(ctx context.Context, matches sq.SelectBuilder, limit int) (*models.SceneSearchFacets, error) {
+	if limit <= 0 {
+		limit = defaultFacetLimit
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	db := s.queries.DB()
+	facets := &models.SceneSearchFacets{
+		Studios:    []models.SceneStudioFacet{},
+		Tags:       []models.SceneTagFacet{},
+		Performers: []models.ScenePerformerFacet{},
+		Years:      []models.SceneYearFacet{},
+		Durations:  []models.SceneDurationFacet{},
+	}
+
+	studios := psql.Select("matches.studio_id", "COUNT(*)").
+		FromSelect(matches, "matches").
+		Where("matches.studio_id IS NOT NULL").
+		GroupBy("matches.studio_id").
+		OrderBy("COUNT(*) DESC", "matches.studio_id").
+		Limit(uint64(limit))
+	err := queryFacet(ctx, db, studios, "SceneStudioFacets", func(id uuid.UUID, count int) {
+		facets.Studios = append(facets.Studios, models.SceneStudioFacet{StudioID: id, Count: count})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := psql.Select("ST.tag_id", "COUNT(*)").
+		FromSelect(matches, "matches").
+		Join("scene_tags ST ON ST.scene_id = matches.id").
+		GroupBy("ST.tag_id").
+		OrderBy("COUNT(*) DESC", "ST.tag_id").
+		Limit(uint64(limit))
+	err = queryFacet(ctx, db, tags, "SceneTagFacets", func(id uuid.UUID, count int) {
+		facets.Tags = append(facets.Tags, models.SceneTagFacet{TagID: id, Count: count})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	performers := psql.Select("SP.performer_id", "COUNT(*)").
+		FromSelect(matches, "matches").
+		Join("scene_performers SP ON SP.scene_id = matches.id").
+		GroupBy("SP.performer_id").
+		OrderBy("COUNT(*) DESC", "SP.performer_id").
+		Limit(uint64(limit))
+	err = queryFacet(ctx, db, performers, "ScenePerformerFacets", func(id uuid.UUID, count int) {
+		facets.Performers = append(facets.Performers, models.ScenePerformerFacet{PerformerID: id, Count: count})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	years := psql.Select(yearExpr("matches")+" AS year", "COUNT(*)").
+		FromSelect(matches, "matches").
+		Where("matches.date IS NOT NULL").
+		GroupBy("year").
+		OrderBy("year DESC")
+	err = queryFacet(ctx, db, years, "SceneYearFacets", func(year int32, count int) {
+		facets.Years = append(facets.Years, models.SceneYearFacet{Year: int(year), Count: count})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	durations := psql.Select(durationBucketExpr("matches")+" AS bucket", "COUNT(*)").
+		FromSelect(matches, "matches").
+		Where("matches.duration IS NOT NULL").
+		GroupBy("bucket")
+	durationCounts := make(map[models.SceneDurationBucketEnum]int)
+	err = queryFacet(ctx, db, durations, "SceneDurationFacets", func(bucket string, count int) {
+		durationCounts[models.SceneDurationBucketEnum(bucket)] = count
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range durationBuckets {
+		if count := durationCounts[b.bucket]; count > 0 {
+			facets.Durations = append(facets.Durations, models.SceneDurationFacet{Bucket: b.bucket, Count: count})
+		}
+	}
+
+	return facets, nil
+}
+
+// queryFacet runs a query selecting a facet value and its count
+func queryFacet[K any](ctx context.Context, db queries.DBTX, query sq.SelectBuilder, queryName string, add func(K, int)) error {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+	sql = fmt.Sprintf("-- name: %s\n%s", queryName, sql)
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key K
+		var count int64
+		if err := rows.Scan(&key, &count); err != nil {
+			return err
+		}
+		add(key, int(count))
+	}
+	return rows.Err()
+}
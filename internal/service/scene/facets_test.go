@@ -0,0 +1,36 @@
+package scene
+
+import (
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func TestDurationCondition(t *testing.T) {
+	cond, err := durationCondition("scenes", []models.SceneDurationBucketEnum{
+		models.SceneDurationBucketEnumUnder10Minutes,
+		models.SceneDurationBucketEnumOver60Minutes,
+	})
+	assert.NoError(t, err)
+
+	sql, args, err := sq.Select("*").From("scenes").Where(cond).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM scenes WHERE ((scenes.duration >= ? AND scenes.duration < ?) OR scenes.duration >= ?)", sql)
+	assert.Equal(t, []any{0, 600, 3600}, args)
+
+	_, err = durationCondition("scenes", []models.SceneDurationBucketEnum{"FOREVER"})
+	assert.Error(t, err)
+}
+
+func TestDurationBucketExpr(t *testing.T) {
+	expected := "CASE" +
+		" WHEN m.duration >= 0 AND m.duration < 600 THEN 'UNDER_10_MINUTES'" +
+		" WHEN m.duration >= 600 AND m.duration < 1800 THEN 'FROM_10_TO_30_MINUTES'" +
+		" WHEN m.duration >= 1800 AND m.duration < 3600 THEN 'FROM_30_TO_60_MINUTES'" +
+		" WHEN m.duration >= 3600 THEN 'OVER_60_MINUTES'" +
+		" END"
+	assert.Equal(t, expected, durationBucketExpr("m"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/gofrs/uuid"
@@ -41,6 +42,43 @@ func (s *Scene) QueryCount(ctx context.Context, input models.SceneQueryInput) (i
 	return queryhelper.ExecuteCount(ctx, countQuery, s.queries.DB(), "QueryScenesCount")
 }
 
+// FindCreatedBetween returns the ids of scenes matching input that were
+// created after since and no later than until. Favorite and fingerprint
+// submission filters are evaluated for userID.
+func (s *Scene) FindCreatedBetween(ctx context.Context, input models.SceneQueryInput, userID uuid.UUID, since, until time.Time) ([]uuid.UUID, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	// sorting by trending would limit matches to trending scenes
+	input.Sort = models.SceneSortEnumCreatedAt
+	inner, err := s.buildSceneQuery(psql, input, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	inner = inner.
+		Where(sq.Gt{"scenes.created_at": since}).
+		Where(sq.LtOrEq{"scenes.created_at": until})
+
+	query := psql.Select("DISTINCT matches.id").FromSelect(inner, "matches")
+	return queryhelper.ExecuteIDs(ctx, query, s.queries.DB(), "FindScenesCreatedBetween")
+}
+
+// FindMatching returns the ids of up to limit scenes matching input, ignoring
+// pagination. Favorite and fingerprint submission filters are evaluated for
+// userID.
+func (s *Scene) FindMatching(ctx context.Context, input models.SceneQueryInput, userID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	// sorting by trending would limit matches to trending scenes
+	input.Sort = models.SceneSortEnumCreatedAt
+	inner, err := s.buildSceneQuery(psql, input, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	query := psql.Select("DISTINCT matches.id").FromSelect(inner, "matches").Limit(uint64(limit))
+	return queryhelper.ExecuteIDs(ctx, query, s.queries.DB(), "FindMatchingScenes")
+}
+
 func (s *Scene) buildSceneQuery(psql sq.StatementBuilderType, input models.SceneQueryInput, userID uuid.UUID, forCount bool) (sq.SelectBuilder, error) {
 	query := psql.Select("scenes.*").From("scenes")
 
@@ -51,14 +89,9 @@ func (s *Scene) buildSceneQuery(psql sq.StatementBuilderType, input models.Scene
 			Where(sq.Eq{"scene_urls.url": *input.URL})
 	}
 
-	// Filter by parent studio
+	// Filter by parent studio, including all descendant studios in the network
 	if input.ParentStudio != nil {
-		query = query.
-			Join("studios ON scenes.studio_id = studios.id").
-			Where(sq.Or{
-				sq.Eq{"studios.parent_studio_id": *input.ParentStudio},
-				sq.Eq{"studios.id": *input.ParentStudio},
-			})
+		query = query.Where("scenes.studio_id IN ("+queryhelper.StudioNetworkSubquery+")", *input.ParentStudio)
 	}
 
 	// Filter by performers
@@ -162,6 +195,32 @@ func (s *Scene) buildSceneQuery(psql sq.StatementBuilderType, input models.Scene
 		}
 	}
 
+	// Filter by release year. Fuzzy dates are stored as YYYY or YYYY-MM, so
+	// the year is always the first four characters.
+	if len(input.Years) > 0 {
+		query = query.Where(sq.Eq{yearExpr("scenes"): input.Years})
+	}
+
+	// Filter by duration bucket
+	if len(input.Durations) > 0 {
+		cond, err := durationCondition("scenes", input.Durations)
+		if err != nil {
+			return query, err
+		}
+		query = query.Where(cond)
+	}
+
+	// Filter by release schedule. Fuzzy dates compare after any full date in
+	// the same period, so a scene dated only by the current month or year is
+	// not considered upcoming.
+	if input.Upcoming != nil {
+		if *input.Upcoming {
+			query = query.Where("scenes.date > to_char(CURRENT_DATE, 'YYYY-MM-DD')")
+		} else {
+			query = query.Where("scenes.date <= to_char(CURRENT_DATE, 'YYYY-MM-DD')")
+		}
+	}
+
 	// Filter by favorites
 	if input.Favorites != nil {
 		var clauses []string
@@ -217,7 +276,8 @@ func (s *Scene) buildSceneQuery(psql sq.StatementBuilderType, input models.Scene
 			(input.Title != nil && *input.Title != "") ||
 			(input.Studios != nil && len(input.Studios.Value) > 0) ||
 			input.Date != nil || input.Favorites != nil ||
-			input.Code != nil
+			input.Code != nil || input.Upcoming != nil ||
+			len(input.Years) > 0 || len(input.Durations) > 0
 
 		if !hasOtherFilters && !forCount {
 			// Optimize: limit the trending subquery directly
@@ -0,0 +1,85 @@
+package scene
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/search"
+)
+
+// searcher runs the full text query behind searchScenes against the
+// configured search backend, returning a page of scene ids and the total
+// number of matches.
+type searcher interface {
+	search(ctx context.Context, q *queries.Queries, term string, limit int, offset int) ([]uuid.UUID, int, error)
+}
+
+func newSearcher() searcher {
+	if config.GetSearchBackend() == config.PostgresSearch {
+		return postgresSearcher{}
+	}
+	return paradeDBSearcher{}
+}
+
+type paradeDBSearcher struct{}
+
+func (paradeDBSearcher) search(ctx context.Context, q *queries.Queries, term string, limit int, offset int) ([]uuid.UUID, int, error) {
+	// Tokenize on whitespace; each token is scored independently by SearchScenes.
+	tokens := strings.Fields(term)
+	if len(tokens) == 0 {
+		return nil, 0, nil
+	}
+
+	rows, err := q.SearchScenes(ctx, queries.SearchScenesParams{
+		Tokens: tokens,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.SceneID
+	}
+
+	count := 0
+	if len(rows) > 0 {
+		count = parseParadeDBCount(rows[0].TotalCount)
+	}
+	return ids, count, nil
+}
+
+type postgresSearcher struct{}
+
+func (postgresSearcher) search(ctx context.Context, q *queries.Queries, term string, limit int, offset int) ([]uuid.UUID, int, error) {
+	terms := search.TSQueryTerms(term, false)
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+
+	rows, err := q.SearchScenesPostgres(ctx, queries.SearchScenesPostgresParams{
+		Terms:  terms,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.SceneID
+	}
+
+	count := 0
+	if len(rows) > 0 {
+		count = int(rows[0].TotalCount)
+	}
+	return ids, count, nil
+}
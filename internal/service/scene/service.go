@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgx/v5"
@@ -16,6 +16,7 @@ import (
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/internal/queries"
 	"github.com/stashapp/stash-box/internal/service/errutil"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
 )
 
 // Scene handles scene-related operations
@@ -65,6 +66,18 @@ func (s *Scene) FindByURL(ctx context.Context, url string, limit int) ([]models.
 	return converter.ScenesToModels(scenes), err
 }
 
+// FindUpcomingFavorites returns scenes released after from and up to to that
+// feature one of the user's favorite performers or studios.
+func (s *Scene) FindUpcomingFavorites(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]models.Scene, error) {
+	scenes, err := s.queries.FindUpcomingFavoriteScenes(ctx, queries.FindUpcomingFavoriteScenesParams{
+		FromDate: from.Format(time.DateOnly),
+		ToDate:   to.Format(time.DateOnly),
+		UserID:   userID,
+		Limit:    int32(limit),
+	})
+	return converter.ScenesToModels(scenes), err
+}
+
 func (s *Scene) FindScenesBySceneFingerprints(ctx context.Context, sceneFingerprints [][]models.FingerprintQueryInput) ([][]*models.Scene, error) {
 	var fingerprints []models.FingerprintQueryInput
 	for _, scene := range sceneFingerprints {
@@ -133,29 +146,22 @@ func (s *Scene) FindScenesBySceneFingerprints(ctx context.Context, sceneFingerpr
 	return result, nil
 }
 
-func (s *Scene) SearchScenesWithCount(ctx context.Context, term string, limit int, offset int) (*models.SceneQuery, error) {
-	// Tokenize on whitespace; each token is scored independently by SearchScenes.
-	tokens := strings.Fields(term)
-	if len(tokens) == 0 {
-		return &models.SceneQuery{
-			SearchResults: &models.SceneSearchResults{Scenes: []models.Scene{}, Count: 0},
-		}, nil
+func (s *Scene) SearchScenesWithCount(ctx context.Context, params models.SceneSearchParams, limit int, offset int) (*models.SceneQuery, error) {
+	var ids []uuid.UUID
+	var count int
+	var err error
+	if params.Filter == nil {
+		ids, count, err = newSearcher().search(ctx, s.queries, params.Term, limit, offset)
+	} else {
+		// drill-down filters are applied to the full, ranked match set
+		ids, err = s.searchMatches(ctx, params)
+		count = len(ids)
+		ids = ids[min(offset, count):min(offset+limit, count)]
 	}
-
-	rows, err := s.queries.SearchScenes(ctx, queries.SearchScenesParams{
-		Tokens: tokens,
-		Limit:  int32(limit),
-		Offset: int32(offset),
-	})
 	if err != nil {
 		return nil, err
 	}
 
-	ids := make([]uuid.UUID, len(rows))
-	for i, row := range rows {
-		ids[i] = row.SceneID
-	}
-
 	scenePtrs, _ := s.LoadIds(ctx, ids)
 	scenes := make([]models.Scene, 0, len(scenePtrs))
 	for _, scene := range scenePtrs {
@@ -164,12 +170,8 @@ func (s *Scene) SearchScenesWithCount(ctx context.Context, term string, limit in
 		}
 	}
 
-	count := 0
-	if len(rows) > 0 {
-		count = parseParadeDBCount(rows[0].TotalCount)
-	}
-
 	return &models.SceneQuery{
+		Search: &params,
 		SearchResults: &models.SceneSearchResults{
 			Scenes: scenes,
 			Count:  count,
@@ -488,8 +490,8 @@ func (s *Scene) SubmitFingerprint(ctx context.Context, input models.FingerprintS
 	}
 
 	// if no user is set, or if the current user does not have the modify
-	// role, then set users to the current user
-	if len(input.Fingerprint.UserIds) == 0 || !auth.IsRole(ctx, models.RoleEnumModify) {
+	// permission, then set users to the current user
+	if len(input.Fingerprint.UserIds) == 0 || !auth.HasPermission(ctx, models.PermissionEnumModify) {
 		currentUserID := auth.GetCurrentUser(ctx).ID
 		input.Fingerprint.UserIds = []uuid.UUID{currentUserID}
 	}
@@ -731,7 +733,14 @@ func (s *Scene) MoveFingerprintSubmissions(ctx context.Context, input models.Mov
 			movedUsers[fp.Hash] = userIDs
 		}
 
-		return nil
+		fingerprints := fingerprintAuditStates(input.Fingerprints)
+		return mod_audit.Record(ctx, txnQueries, mod_audit.Entry{
+			Action:     queries.ModAuditActionFINGERPRINTMOVE,
+			TargetID:   input.SourceSceneID,
+			TargetType: mod_audit.TargetScene,
+			Before:     fingerprintAuditState{SceneID: input.SourceSceneID, Fingerprints: fingerprints},
+			After:      fingerprintAuditState{SceneID: input.TargetSceneID, Fingerprints: fingerprints},
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -766,10 +775,36 @@ func (s *Scene) DeleteFingerprintSubmissions(ctx context.Context, input models.D
 			}
 		}
 
-		return nil
+		return mod_audit.Record(ctx, txnQueries, mod_audit.Entry{
+			Action:     queries.ModAuditActionFINGERPRINTDELETE,
+			TargetID:   input.SceneID,
+			TargetType: mod_audit.TargetScene,
+			Before:     fingerprintAuditState{SceneID: input.SceneID, Fingerprints: fingerprintAuditStates(input.Fingerprints)},
+		})
 	})
 }
 
+type fingerprintAuditState struct {
+	SceneID      uuid.UUID              `json:"scene_id"`
+	Fingerprints []fingerprintAuditItem `json:"fingerprints"`
+}
+
+type fingerprintAuditItem struct {
+	Hash      string `json:"hash"`
+	Algorithm string `json:"algorithm"`
+}
+
+func fingerprintAuditStates(fingerprints []models.FingerprintQueryInput) []fingerprintAuditItem {
+	ret := make([]fingerprintAuditItem, len(fingerprints))
+	for i, fp := range fingerprints {
+		ret[i] = fingerprintAuditItem{
+			Hash:      fp.Hash.Hex(),
+			Algorithm: fp.Algorithm.String(),
+		}
+	}
+	return ret
+}
+
 func (s *Scene) FindExistingScenes(ctx context.Context, input models.QueryExistingSceneInput) ([]models.Scene, error) {
 	var hashes []int64
 	var studioID uuid.NullUUID
@@ -833,9 +868,9 @@ func createFingerprints(ctx context.Context, tx *queries.Queries, sceneID uuid.U
 		}
 
 		// if no user is set, or if the current user does not have the modify
-		// role, then set users to the current user
+		// permission, then set users to the current user
 		userIDs := fp.UserIds
-		if len(userIDs) == 0 || !auth.IsRole(ctx, models.RoleEnumModify) {
+		if len(userIDs) == 0 || !auth.HasPermission(ctx, models.PermissionEnumModify) {
 			userIDs = []uuid.UUID{user.ID}
 		}
 
@@ -0,0 +1,121 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+type field struct {
+	name  string
+	value string
+}
+
+type candidate struct {
+	hitType models.SearchTypeEnum
+	id      uuid.UUID
+	score   float64
+	fields  []field
+}
+
+func newCandidate(t models.SearchTypeEnum, id uuid.UUID, score float64) candidate {
+	return candidate{
+		hitType: t,
+		id:      id,
+		score:   score,
+	}
+}
+
+func (c *candidate) add(name string, value *string) {
+	if value != nil && *value != "" {
+		c.fields = append(c.fields, field{name: name, value: *value})
+	}
+}
+
+func (c *candidate) addAll(name string, values []string) {
+	for _, v := range values {
+		c.add(name, &v)
+	}
+}
+
+// highlight returns the fields that contain the search terms, best match
+// first, and the match quality between 0 and 1. An exact match of a whole
+// field scores 1; matching every term in one field scores 0.75. Otherwise
+// the quality is proportional to the share of terms found across all
+// fields, so that scenes matched on title, studio and performer together
+// still rank well.
+func (c candidate) highlight(terms []string) ([]models.SearchHighlight, float64) {
+	type match struct {
+		field   field
+		quality float64
+	}
+
+	if len(terms) == 0 {
+		return []models.SearchHighlight{}, 0
+	}
+
+	var matches []match
+	best := 0.0
+	found := make(map[string]bool)
+	for _, f := range c.fields {
+		tokens := tokenize(f.value)
+		matched := 0
+		for _, t := range terms {
+			if containsPrefix(tokens, t) {
+				matched++
+				found[t] = true
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+
+		var quality float64
+		switch {
+		case strings.Join(tokens, " ") == strings.Join(terms, " "):
+			quality = 1
+		case matched == len(terms):
+			quality = 0.75
+		default:
+			quality = 0.5 * float64(matched) / float64(len(terms))
+		}
+
+		matches = append(matches, match{field: f, quality: quality})
+		best = max(best, quality)
+	}
+
+	coverage := 0.5 * float64(len(found)) / float64(len(terms))
+	best = max(best, coverage)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].quality > matches[j].quality
+	})
+
+	ret := make([]models.SearchHighlight, len(matches))
+	for i, m := range matches {
+		ret[i] = models.SearchHighlight{
+			Field: m.field.name,
+			Value: m.field.value,
+		}
+	}
+	return ret, best
+}
+
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func containsPrefix(tokens []string, prefix string) bool {
+	for _, t := range tokens {
+		if strings.HasPrefix(t, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func testCandidate(t models.SearchTypeEnum, score float64, fields ...field) candidate {
+	c := newCandidate(t, uuid.Must(uuid.NewV4()), score)
+	c.fields = fields
+	return c
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name       string
+		term       string
+		fields     []field
+		highlights []models.SearchHighlight
+		quality    float64
+	}{
+		{
+			name:       "exact name",
+			term:       "Jane Doe",
+			fields:     []field{{"name", "Jane Doe"}, {"alias", "Janey"}},
+			highlights: []models.SearchHighlight{{Field: "name", Value: "Jane Doe"}, {Field: "alias", Value: "Janey"}},
+			quality:    1,
+		},
+		{
+			name:       "alias preferred over partial name",
+			term:       "jane smith",
+			fields:     []field{{"name", "Jane Doe"}, {"alias", "Jane Smith"}},
+			highlights: []models.SearchHighlight{{Field: "alias", Value: "Jane Smith"}, {Field: "name", Value: "Jane Doe"}},
+			quality:    1,
+		},
+		{
+			name:       "all terms in longer field",
+			term:       "jane",
+			fields:     []field{{"name", "Jane Doe"}},
+			highlights: []models.SearchHighlight{{Field: "name", Value: "Jane Doe"}},
+			quality:    0.75,
+		},
+		{
+			name:       "terms spread across fields",
+			term:       "beach studio",
+			fields:     []field{{"title", "Beach Day"}, {"studio", "Studio Name"}},
+			highlights: []models.SearchHighlight{{Field: "title", Value: "Beach Day"}, {Field: "studio", Value: "Studio Name"}},
+			quality:    0.5,
+		},
+		{
+			name:       "fuzzy match without highlight",
+			term:       "jame",
+			fields:     []field{{"name", "Jane"}},
+			highlights: []models.SearchHighlight{},
+			quality:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCandidate(models.SearchTypeEnumPerformer, 1, tt.fields...)
+			highlights, quality := c.highlight(tokenize(tt.term))
+			assert.Equal(t, tt.highlights, highlights)
+			assert.InDelta(t, tt.quality, quality, 0.0001)
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	// raw scores are only comparable within a type
+	performer := testCandidate(models.SearchTypeEnumPerformer, 20, field{"name", "Jane Doe"})
+	weakPerformer := testCandidate(models.SearchTypeEnumPerformer, 5, field{"name", "Jane Smith"})
+	scene := testCandidate(models.SearchTypeEnumScene, 20000, field{"title", "Jane Doe at the Beach"})
+
+	hits := rank("jane doe", []candidate{weakPerformer, scene, performer}, 10)
+	if assert.Len(t, hits, 3) {
+		assert.Equal(t, performer.id, hits[0].ID)
+		assert.InDelta(t, 1, hits[0].Score, 0.0001)
+		assert.Equal(t, scene.id, hits[1].ID)
+		assert.Equal(t, weakPerformer.id, hits[2].ID)
+	}
+
+	hits = rank("jane doe", []candidate{weakPerformer, scene, performer}, 1)
+	if assert.Len(t, hits, 1) {
+		assert.Equal(t, performer.id, hits[0].ID)
+	}
+}
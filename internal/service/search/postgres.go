@@ -0,0 +1,103 @@
+package search
+
+import (
+	"context"
+
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+// searchTypePostgres is searchType for search_backend: postgres. The tsquery
+// operators match those of the per-type searches.
+func searchTypePostgres(ctx context.Context, q *queries.Queries, t models.SearchTypeEnum, term string, limit int) ([]candidate, int, error) {
+	var ret []candidate
+	var total int64
+
+	switch t {
+	case models.SearchTypeEnumPerformer:
+		query := TSQuery(term, "|", false)
+		if query == "" {
+			return nil, 0, nil
+		}
+		rows, err := q.SearchPerformerHitsPostgres(ctx, queries.SearchPerformerHitsPostgresParams{
+			Query: query,
+			Term:  term,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range rows {
+			c := newCandidate(t, row.PerformerID, row.Score)
+			c.add("name", row.Name)
+			c.add("disambiguation", row.Disambiguation)
+			c.addAll("alias", row.Aliases)
+			ret = append(ret, c)
+			total = row.TotalCount
+		}
+	case models.SearchTypeEnumScene:
+		terms := TSQueryTerms(term, false)
+		if len(terms) == 0 {
+			return nil, 0, nil
+		}
+		rows, err := q.SearchSceneHitsPostgres(ctx, queries.SearchSceneHitsPostgresParams{
+			Terms: terms,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range rows {
+			c := newCandidate(t, row.SceneID, row.Score)
+			c.add("title", row.SceneTitle)
+			c.add("code", row.SceneCode)
+			c.addAll("performer", row.PerformerNames)
+			c.add("studio", row.StudioName)
+			ret = append(ret, c)
+			total = row.TotalCount
+		}
+	case models.SearchTypeEnumStudio:
+		query := TSQuery(term, "|", false)
+		if query == "" {
+			return nil, 0, nil
+		}
+		rows, err := q.SearchStudioHitsPostgres(ctx, queries.SearchStudioHitsPostgresParams{
+			Query: query,
+			Term:  term,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range rows {
+			c := newCandidate(t, row.StudioID, row.Score)
+			c.add("name", row.Name)
+			c.addAll("alias", row.Aliases)
+			c.add("network", row.Network)
+			ret = append(ret, c)
+			total = row.TotalCount
+		}
+	case models.SearchTypeEnumTag:
+		query := TSQuery(term, "&", true)
+		if query == "" {
+			return nil, 0, nil
+		}
+		rows, err := q.SearchTagHitsPostgres(ctx, queries.SearchTagHitsPostgresParams{
+			Query: query,
+			Term:  term,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range rows {
+			c := newCandidate(t, row.TagID, row.Score)
+			c.add("name", row.Name)
+			c.addAll("alias", row.Aliases)
+			ret = append(ret, c)
+			total = row.TotalCount
+		}
+	}
+
+	return ret, int(total), nil
+}
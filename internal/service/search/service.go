@@ -0,0 +1,237 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+const defaultLimit = 10
+const maxLimit = 100
+
+var allTypes = []models.SearchTypeEnum{
+	models.SearchTypeEnumPerformer,
+	models.SearchTypeEnumScene,
+	models.SearchTypeEnumStudio,
+	models.SearchTypeEnumTag,
+}
+
+// Search runs a single term against the performer, scene, studio and tag
+// search indexes and merges the results into one relevance ordering.
+type Search struct {
+	queries *queries.Queries
+	withTxn queries.WithTxnFunc
+}
+
+// NewSearch creates a new search service
+func NewSearch(queries *queries.Queries, withTxn queries.WithTxnFunc) *Search {
+	return &Search{
+		queries: queries,
+		withTxn: withTxn,
+	}
+}
+
+// Search returns up to limit hits across the requested types, along with the
+// total number of matches for each type. An empty types list searches all.
+// The limit is capped at maxLimit.
+func (s *Search) Search(ctx context.Context, term string, types []models.SearchTypeEnum, limit int) (*models.SearchResultType, error) {
+	term = strings.TrimSpace(term)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+	if len(types) == 0 {
+		types = allTypes
+	}
+
+	result := &models.SearchResultType{
+		Hits:   []models.SearchHit{},
+		Counts: []models.SearchTypeCount{},
+	}
+
+	var candidates []candidate
+	counts := make(map[models.SearchTypeEnum]int)
+	if term != "" {
+		// pdb.score requires a custom plan, see searchTxn in the performer service
+		err := s.withTxn(func(q *queries.Queries) error {
+			if _, err := q.DB().Exec(ctx, "SET LOCAL plan_cache_mode = force_custom_plan"); err != nil {
+				return err
+			}
+
+			for _, t := range uniqueTypes(types) {
+				found, count, err := searchType(ctx, q, t, term, limit)
+				if err != nil {
+					return err
+				}
+				candidates = append(candidates, found...)
+				counts[t] = count
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, t := range uniqueTypes(types) {
+		result.Counts = append(result.Counts, models.SearchTypeCount{
+			Type:  t,
+			Count: counts[t],
+		})
+	}
+
+	result.Hits = rank(term, candidates, limit)
+	return result, nil
+}
+
+func uniqueTypes(types []models.SearchTypeEnum) []models.SearchTypeEnum {
+	var ret []models.SearchTypeEnum
+	seen := make(map[models.SearchTypeEnum]bool)
+	for _, t := range types {
+		if !seen[t] {
+			seen[t] = true
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+func searchType(ctx context.Context, q *queries.Queries, t models.SearchTypeEnum, term string, limit int) ([]candidate, int, error) {
+	if config.GetSearchBackend() == config.PostgresSearch {
+		return searchTypePostgres(ctx, q, t, term, limit)
+	}
+
+	var ret []candidate
+	var total any
+
+	switch t {
+	case models.SearchTypeEnumPerformer:
+		rows, err := q.SearchPerformerHits(ctx, queries.SearchPerformerHitsParams{
+			Term:  term,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range rows {
+			c := newCandidate(t, row.PerformerID, row.Score)
+			c.add("name", row.Name)
+			c.add("disambiguation", row.Disambiguation)
+			c.addAll("alias", row.Aliases)
+			ret = append(ret, c)
+			total = row.TotalCount
+		}
+	case models.SearchTypeEnumScene:
+		rows, err := q.SearchSceneHits(ctx, queries.SearchSceneHitsParams{
+			Tokens: strings.Fields(term),
+			Limit:  int32(limit),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range rows {
+			c := newCandidate(t, row.SceneID, row.Score)
+			c.add("title", row.SceneTitle)
+			c.add("code", row.SceneCode)
+			c.addAll("performer", row.PerformerNames)
+			c.add("studio", row.StudioName)
+			ret = append(ret, c)
+			total = row.TotalCount
+		}
+	case models.SearchTypeEnumStudio:
+		rows, err := q.SearchStudioHits(ctx, queries.SearchStudioHitsParams{
+			Term:  term,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range rows {
+			c := newCandidate(t, row.StudioID, row.Score)
+			c.add("name", row.Name)
+			c.addAll("alias", row.Aliases)
+			c.add("network", row.Network)
+			ret = append(ret, c)
+			total = row.TotalCount
+		}
+	case models.SearchTypeEnumTag:
+		rows, err := q.SearchTagHits(ctx, queries.SearchTagHitsParams{
+			Term:  term,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range rows {
+			c := newCandidate(t, row.TagID, row.Score)
+			c.add("name", row.Name)
+			c.addAll("alias", row.Aliases)
+			ret = append(ret, c)
+			total = row.TotalCount
+		}
+	}
+
+	return ret, parseParadeDBCount(total), nil
+}
+
+type paradeDBCountResult struct {
+	Value float64 `json:"value"`
+}
+
+func parseParadeDBCount(raw any) int {
+	if raw == nil {
+		return 0
+	}
+	jsonBytes, err := json.Marshal(raw)
+	if err != nil {
+		return 0
+	}
+	var result paradeDBCountResult
+	if err := json.Unmarshal(jsonBytes, &result); err != nil {
+		return 0
+	}
+	return int(result.Value)
+}
+
+// rank normalizes the BM25 scores of each type against the best hit of that
+// type, so that scores from different indexes can be compared, and blends
+// them with how closely the highlighted fields match the term.
+func rank(term string, candidates []candidate, limit int) []models.SearchHit {
+	maxScore := make(map[models.SearchTypeEnum]float64)
+	for _, c := range candidates {
+		if c.score > maxScore[c.hitType] {
+			maxScore[c.hitType] = c.score
+		}
+	}
+
+	terms := tokenize(term)
+	hits := make([]models.SearchHit, len(candidates))
+	for i, c := range candidates {
+		relevance := 0.0
+		if maxScore[c.hitType] > 0 {
+			relevance = c.score / maxScore[c.hitType]
+		}
+		highlights, quality := c.highlight(terms)
+		hits[i] = models.SearchHit{
+			Type:       c.hitType,
+			ID:         c.id,
+			Score:      (relevance + quality) / 2,
+			Highlights: highlights,
+		}
+	}
+
+	// stable so that equal scores keep the index order of their type
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
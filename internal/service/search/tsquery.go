@@ -0,0 +1,24 @@
+package search
+
+import "strings"
+
+// TSQuery builds a to_tsquery('simple') expression from the words of term,
+// for the postgres search backend. Words are joined with op, "|" to match any
+// of them or "&" to match all of them. Returns an empty string if term has
+// no words, which to_tsquery does not accept.
+func TSQuery(term string, op string, prefix bool) string {
+	return strings.Join(TSQueryTerms(term, prefix), " "+op+" ")
+}
+
+// TSQueryTerms returns the words of term as to_tsquery terms. Only letters
+// and digits are kept, so the terms never contain tsquery operators. With
+// prefix set, each term also matches the words it is a prefix of.
+func TSQueryTerms(term string, prefix bool) []string {
+	words := tokenize(term)
+	if prefix {
+		for i := range words {
+			words[i] += ":*"
+		}
+	}
+	return words
+}
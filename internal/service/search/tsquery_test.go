@@ -0,0 +1,15 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTSQuery(t *testing.T) {
+	assert.Equal(t, "jane | doe", TSQuery("Jane Doe", "|", false))
+	assert.Equal(t, "o:* & brien:*", TSQuery("O'Brien", "&", true))
+	assert.Equal(t, "scene | 2019 | 02 | 03", TSQuery("scene 2019-02-03", "|", false))
+	assert.Equal(t, "", TSQuery(" !& | ", "|", false))
+	assert.Equal(t, []string{"jané"}, TSQueryTerms("(Jané)", false))
+}
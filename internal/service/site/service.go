@@ -9,6 +9,7 @@ import (
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/internal/queries"
 	"github.com/stashapp/stash-box/internal/service/errutil"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
 	"github.com/stashapp/stash-box/internal/storage"
 )
 
@@ -44,9 +45,17 @@ func (s *Site) Create(ctx context.Context, input models.SiteCreateInput) (*model
 	var site *models.Site
 	err = s.withTxn(func(tx *queries.Queries) error {
 		dbSite, err := tx.CreateSite(ctx, converter.SiteToCreateParams(newSite))
+		if err != nil {
+			return err
+		}
 		site = converter.SiteToModelPtr(dbSite)
 
-		return err
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionSITECREATE,
+			TargetID:   dbSite.ID,
+			TargetType: mod_audit.TargetSite,
+			After:      dbSite,
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -63,17 +72,26 @@ func (s *Site) Create(ctx context.Context, input models.SiteCreateInput) (*model
 func (s *Site) Update(ctx context.Context, input models.SiteUpdateInput) (*models.Site, error) {
 	var site *models.Site
 	err := s.withTxn(func(tx *queries.Queries) error {
-		dbSite, err := tx.GetSite(ctx, input.ID)
+		existingSite, err := tx.GetSite(ctx, input.ID)
 		if err != nil {
 			return err
 		}
-		updatedSite := converter.SiteToModel(dbSite)
+		updatedSite := converter.SiteToModel(existingSite)
 		converter.UpdateSiteFromUpdateInput(&updatedSite, input)
 
-		dbSite, err = tx.UpdateSite(ctx, converter.SiteToUpdateParams(updatedSite))
+		dbSite, err := tx.UpdateSite(ctx, converter.SiteToUpdateParams(updatedSite))
+		if err != nil {
+			return err
+		}
 		site = converter.SiteToModelPtr(dbSite)
 
-		return err
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionSITEUPDATE,
+			TargetID:   dbSite.ID,
+			TargetType: mod_audit.TargetSite,
+			Before:     existingSite,
+			After:      dbSite,
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -88,7 +106,24 @@ func (s *Site) Update(ctx context.Context, input models.SiteUpdateInput) (*model
 
 // Destroy deletes a site by ID
 func (s *Site) Destroy(ctx context.Context, id uuid.UUID) error {
-	if err := s.queries.DeleteSite(ctx, id); err != nil {
+	err := s.withTxn(func(tx *queries.Queries) error {
+		existingSite, err := tx.GetSite(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.DeleteSite(ctx, id); err != nil {
+			return err
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionSITEDESTROY,
+			TargetID:   existingSite.ID,
+			TargetType: mod_audit.TargetSite,
+			Before:     existingSite,
+		})
+	})
+	if err != nil {
 		return err
 	}
 	return storage.ClearSiteIcon(id)
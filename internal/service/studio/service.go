@@ -2,18 +2,24 @@ package studio
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/gofrs/uuid"
 
 	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
 	"github.com/stashapp/stash-box/internal/converter"
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/internal/queries"
 	"github.com/stashapp/stash-box/internal/service/errutil"
+	"github.com/stashapp/stash-box/internal/service/search"
 )
 
+var ErrParentCycle = errors.New("studio cannot be a parent of itself or its ancestors")
+
 // Studio handles studio-related operations
 type Studio struct {
 	queries *queries.Queries
@@ -67,6 +73,22 @@ func (s *Studio) FindByParentID(ctx context.Context, parentID uuid.UUID) ([]mode
 	return converter.StudiosToModels(studios), nil
 }
 
+// NetworkStats returns aggregate statistics across a studio and every studio below it
+func (s *Studio) NetworkStats(ctx context.Context, id uuid.UUID) (*models.StudioNetworkStats, error) {
+	row, err := s.queries.GetStudioNetworkStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StudioNetworkStats{
+		StudioCount:    int(row.StudioCount),
+		SceneCount:     int(row.SceneCount),
+		PerformerCount: int(row.PerformerCount),
+		FirstSceneDate: row.FirstSceneDate,
+		LastSceneDate:  row.LastSceneDate,
+	}, nil
+}
+
 func (s *Studio) CountByPerformer(ctx context.Context, performerID uuid.UUID, studioID *uuid.UUID) ([]models.PerformerStudio, error) {
 	var result []models.PerformerStudio
 
@@ -178,6 +200,16 @@ func (s *Studio) Update(ctx context.Context, input models.StudioUpdateInput) (*m
 			return err
 		}
 
+		if input.ParentID != nil {
+			networkIDs, err := tx.GetStudioNetworkIDs(ctx, input.ID)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(networkIDs, *input.ParentID) {
+				return ErrParentCycle
+			}
+		}
+
 		// Populate studio from the input
 		params := converter.UpdateStudioFromUpdateInput(existingStudio, input)
 		dbStudio, err := tx.UpdateStudio(ctx, params)
@@ -237,20 +269,11 @@ func (s *Studio) Favorite(ctx context.Context, id uuid.UUID, favorite bool) erro
 }
 
 func (s *Studio) Search(ctx context.Context, term string, limit int) ([]models.Studio, error) {
-	rows, err := s.queries.SearchStudios(ctx, queries.SearchStudiosParams{
-		Term:  &term,
-		Limit: int32(limit),
-	})
+	ids, err := s.searchIDs(ctx, term, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	// Extract studio IDs
-	ids := make([]uuid.UUID, len(rows))
-	for i, row := range rows {
-		ids[i] = row.StudioID
-	}
-
 	// Load full studios
 	studioPtrs, _ := s.LoadIds(ctx, ids)
 	studios := make([]models.Studio, 0, len(studioPtrs))
@@ -263,6 +286,34 @@ func (s *Studio) Search(ctx context.Context, term string, limit int) ([]models.S
 	return studios, nil
 }
 
+func (s *Studio) searchIDs(ctx context.Context, term string, limit int) ([]uuid.UUID, error) {
+	if config.GetSearchBackend() == config.PostgresSearch {
+		query := search.TSQuery(term, "|", false)
+		if query == "" {
+			return nil, nil
+		}
+		return s.queries.SearchStudiosPostgres(ctx, queries.SearchStudiosPostgresParams{
+			Query: query,
+			Term:  term,
+			Limit: int32(limit),
+		})
+	}
+
+	rows, err := s.queries.SearchStudios(ctx, queries.SearchStudiosParams{
+		Term:  &term,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.StudioID
+	}
+	return ids, nil
+}
+
 func createAliases(ctx context.Context, tx *queries.Queries, studioID uuid.UUID, aliases []string) error {
 	var params []queries.CreateStudioAliasesParams
 	for _, alias := range aliases {
@@ -6,10 +6,13 @@ import (
 
 	"github.com/gofrs/uuid"
 
+	"github.com/stashapp/stash-box/internal/config"
 	"github.com/stashapp/stash-box/internal/converter"
 	"github.com/stashapp/stash-box/internal/models"
 	"github.com/stashapp/stash-box/internal/queries"
 	"github.com/stashapp/stash-box/internal/service/errutil"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+	"github.com/stashapp/stash-box/internal/service/search"
 )
 
 // Service handles tag-related operations
@@ -199,7 +202,16 @@ func (s *Tag) CreateCategory(ctx context.Context, input models.TagCategoryCreate
 	var category queries.TagCategory
 	err = s.withTxn(func(tx *queries.Queries) error {
 		category, err = tx.CreateTagCategory(ctx, params)
-		return err
+		if err != nil {
+			return err
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionTAGCATEGORYCREATE,
+			TargetID:   category.ID,
+			TargetType: mod_audit.TargetTagCategory,
+			After:      category,
+		})
 	})
 
 	return converter.TagCategoryToModelPtr(category), err
@@ -215,8 +227,17 @@ func (s *Tag) UpdateCategory(ctx context.Context, input models.TagCategoryUpdate
 
 		updatedCategory := converter.UpdateTagCategoryFromUpdateInput(existingCategory, input)
 		category, err = tx.UpdateTagCategory(ctx, updatedCategory)
+		if err != nil {
+			return err
+		}
 
-		return err
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionTAGCATEGORYUPDATE,
+			TargetID:   category.ID,
+			TargetType: mod_audit.TargetTagCategory,
+			Before:     existingCategory,
+			After:      category,
+		})
 	})
 
 	return converter.TagCategoryToModelPtr(category), err
@@ -224,7 +245,21 @@ func (s *Tag) UpdateCategory(ctx context.Context, input models.TagCategoryUpdate
 
 func (s *Tag) DeleteCategory(ctx context.Context, input models.TagCategoryDestroyInput) error {
 	return s.withTxn(func(tx *queries.Queries) error {
-		return tx.DeleteTagCategory(ctx, input.ID)
+		existingCategory, err := tx.FindTagCategory(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.DeleteTagCategory(ctx, input.ID); err != nil {
+			return err
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionTAGCATEGORYDESTROY,
+			TargetID:   existingCategory.ID,
+			TargetType: mod_audit.TargetTagCategory,
+			Before:     existingCategory,
+		})
 	})
 }
 
@@ -234,6 +269,20 @@ func (s *Tag) QueryCategories(ctx context.Context) (int, []models.TagCategory, e
 }
 
 func (s *Tag) SearchTags(ctx context.Context, term string, limit int) ([]models.Tag, error) {
+	if config.GetSearchBackend() == config.PostgresSearch {
+		// all words must match, as prefixes, like the BM25 query
+		query := search.TSQuery(term, "&", true)
+		if query == "" {
+			return nil, nil
+		}
+		tags, err := s.queries.SearchTagsPostgres(ctx, queries.SearchTagsPostgresParams{
+			Query: query,
+			Term:  term,
+			Limit: int32(limit),
+		})
+		return converter.TagsToModels(tags), err
+	}
+
 	tags, err := s.queries.SearchTags(ctx, queries.SearchTagsParams{
 		Term:  &term,
 		Limit: int32(limit),
@@ -0,0 +1,486 @@
+package user
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/email"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+type exportProfile struct {
+	ID                        uuid.UUID     `json:"id"`
+	Name                      string        `json:"name"`
+	Email                     string        `json:"email"`
+	Roles                     []string      `json:"roles"`
+	InvitedBy                 uuid.NullUUID `json:"invited_by"`
+	InviteTokens              int           `json:"invite_tokens"`
+	APICalls                  *int          `json:"api_calls"`
+	LastAPICall               time.Time     `json:"last_api_call"`
+	NotificationSubscriptions []string      `json:"notification_subscriptions"`
+	Created                   time.Time     `json:"created"`
+	Updated                   time.Time     `json:"updated"`
+}
+
+type exportEdit struct {
+	ID         uuid.UUID       `json:"id"`
+	Operation  string          `json:"operation"`
+	TargetType string          `json:"target_type"`
+	Status     string          `json:"status"`
+	Applied    bool            `json:"applied"`
+	Votes      int             `json:"votes"`
+	Bot        bool            `json:"bot"`
+	Data       json.RawMessage `json:"data"`
+	Created    time.Time       `json:"created"`
+	Updated    *time.Time      `json:"updated"`
+	Closed     *time.Time      `json:"closed"`
+}
+
+type exportVote struct {
+	EditID  uuid.UUID `json:"edit_id"`
+	Vote    string    `json:"vote"`
+	Created time.Time `json:"created"`
+}
+
+type exportComment struct {
+	ID       uuid.UUID     `json:"id"`
+	EditID   uuid.UUID     `json:"edit_id"`
+	ParentID uuid.NullUUID `json:"parent_id"`
+	Text     string        `json:"text"`
+	Hidden   bool          `json:"hidden"`
+	Created  time.Time     `json:"created"`
+	Updated  *time.Time    `json:"updated"`
+}
+
+type exportFingerprint struct {
+	SceneID   uuid.UUID              `json:"scene_id"`
+	Hash      models.FingerprintHash `json:"hash"`
+	Algorithm string                 `json:"algorithm"`
+	Duration  int                    `json:"duration"`
+	Vote      int16                  `json:"vote"`
+	Created   time.Time              `json:"created"`
+}
+
+type exportFavorite struct {
+	ID      uuid.UUID  `json:"id"`
+	Created *time.Time `json:"created"`
+}
+
+type exportFavorites struct {
+	Performers []exportFavorite `json:"performers"`
+	Studios    []exportFavorite `json:"studios"`
+}
+
+type exportNotification struct {
+	ID      uuid.UUID        `json:"id"`
+	Type    string           `json:"type"`
+	Data    *json.RawMessage `json:"data"`
+	Created time.Time        `json:"created"`
+	Read    *time.Time       `json:"read"`
+}
+
+type exportSavedSearch struct {
+	ID      uuid.UUID       `json:"id"`
+	Name    string          `json:"name"`
+	Type    string          `json:"type"`
+	Filter  json.RawMessage `json:"filter"`
+	Notify  bool            `json:"notify"`
+	Created time.Time       `json:"created"`
+	Updated time.Time       `json:"updated"`
+}
+
+type exportDraft struct {
+	ID      uuid.UUID       `json:"id"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data"`
+	Created time.Time       `json:"created"`
+}
+
+type exportSession struct {
+	UserAgent string    `json:"user_agent"`
+	IPAddress string    `json:"ip_address"`
+	Created   time.Time `json:"created"`
+	LastSeen  time.Time `json:"last_seen"`
+	Expires   time.Time `json:"expires"`
+}
+
+type exportFile struct {
+	name string
+	data any
+}
+
+// exportFiles returns the contents of the files of a data export.
+func exportFiles(ctx context.Context, tx *queries.Queries, userID uuid.UUID) ([]exportFile, error) {
+	user, err := tx.FindUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := tx.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := tx.GetUserNotificationSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := exportProfile{
+		ID:                        user.ID,
+		Name:                      user.Name,
+		Email:                     user.Email,
+		Roles:                     roles,
+		InvitedBy:                 user.InvitedBy,
+		InviteTokens:              user.InviteTokens,
+		APICalls:                  user.ApiCalls,
+		LastAPICall:               user.LastApiCall,
+		NotificationSubscriptions: []string{},
+		Created:                   user.CreatedAt,
+		Updated:                   user.UpdatedAt,
+	}
+	for _, s := range subscriptions {
+		profile.NotificationSubscriptions = append(profile.NotificationSubscriptions, string(s))
+	}
+
+	nullUserID := uuid.NullUUID{UUID: userID, Valid: true}
+
+	edits, err := tx.ExportUserEdits(ctx, nullUserID)
+	if err != nil {
+		return nil, err
+	}
+	exportEdits := []exportEdit{}
+	for _, e := range edits {
+		exportEdits = append(exportEdits, exportEdit{
+			ID:         e.ID,
+			Operation:  e.Operation,
+			TargetType: e.TargetType,
+			Status:     e.Status,
+			Applied:    e.Applied,
+			Votes:      e.Votes,
+			Bot:        e.Bot,
+			Data:       e.Data,
+			Created:    e.CreatedAt,
+			Updated:    e.UpdatedAt,
+			Closed:     e.ClosedAt,
+		})
+	}
+
+	votes, err := tx.ExportUserEditVotes(ctx, nullUserID)
+	if err != nil {
+		return nil, err
+	}
+	exportVotes := []exportVote{}
+	for _, v := range votes {
+		exportVotes = append(exportVotes, exportVote{
+			EditID:  v.EditID,
+			Vote:    v.Vote,
+			Created: v.CreatedAt,
+		})
+	}
+
+	comments, err := tx.ExportUserEditComments(ctx, nullUserID)
+	if err != nil {
+		return nil, err
+	}
+	exportComments := []exportComment{}
+	for _, c := range comments {
+		exportComments = append(exportComments, exportComment{
+			ID:       c.ID,
+			EditID:   c.EditID,
+			ParentID: c.ParentID,
+			Text:     c.Text,
+			Hidden:   c.IsHidden,
+			Created:  c.CreatedAt,
+			Updated:  c.UpdatedAt,
+		})
+	}
+
+	fingerprints, err := tx.ExportUserSceneFingerprints(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exportFingerprints := []exportFingerprint{}
+	for _, f := range fingerprints {
+		exportFingerprints = append(exportFingerprints, exportFingerprint{
+			SceneID:   f.SceneID,
+			Hash:      models.FingerprintHash(f.Hash),
+			Algorithm: f.Algorithm,
+			Duration:  f.Duration,
+			Vote:      f.Vote,
+			Created:   f.CreatedAt,
+		})
+	}
+
+	performerFavorites, err := tx.ExportUserPerformerFavorites(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	studioFavorites, err := tx.ExportUserStudioFavorites(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	favorites := exportFavorites{
+		Performers: []exportFavorite{},
+		Studios:    []exportFavorite{},
+	}
+	for _, f := range performerFavorites {
+		favorites.Performers = append(favorites.Performers, exportFavorite{ID: f.PerformerID, Created: f.CreatedAt})
+	}
+	for _, f := range studioFavorites {
+		favorites.Studios = append(favorites.Studios, exportFavorite{ID: f.StudioID, Created: f.CreatedAt})
+	}
+
+	notifications, err := tx.ExportUserNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exportNotifications := []exportNotification{}
+	for _, n := range notifications {
+		exportNotifications = append(exportNotifications, exportNotification{
+			ID:      n.ID,
+			Type:    string(n.Type),
+			Data:    n.Data,
+			Created: n.CreatedAt,
+			Read:    n.ReadAt,
+		})
+	}
+
+	savedSearches, err := tx.FindSavedSearchesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exportSavedSearches := []exportSavedSearch{}
+	for _, s := range savedSearches {
+		exportSavedSearches = append(exportSavedSearches, exportSavedSearch{
+			ID:      s.ID,
+			Name:    s.Name,
+			Type:    s.Type,
+			Filter:  s.Filter,
+			Notify:  s.Notify,
+			Created: s.CreatedAt,
+			Updated: s.UpdatedAt,
+		})
+	}
+
+	drafts, err := tx.FindDraftsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exportDrafts := []exportDraft{}
+	for _, d := range drafts {
+		exportDrafts = append(exportDrafts, exportDraft{
+			ID:      d.ID,
+			Type:    d.Type,
+			Data:    d.Data,
+			Created: d.CreatedAt,
+		})
+	}
+
+	sessions, err := tx.GetUserSessions(ctx, nullUserID)
+	if err != nil {
+		return nil, err
+	}
+	exportSessions := []exportSession{}
+	for _, s := range sessions {
+		exportSessions = append(exportSessions, exportSession{
+			UserAgent: s.UserAgent,
+			IPAddress: s.IpAddress,
+			Created:   s.CreatedAt,
+			LastSeen:  s.LastSeenAt,
+			Expires:   s.ExpiresAt,
+		})
+	}
+
+	return []exportFile{
+		{"profile.json", profile},
+		{"edits.json", exportEdits},
+		{"votes.json", exportVotes},
+		{"comments.json", exportComments},
+		{"fingerprints.json", exportFingerprints},
+		{"favorites.json", favorites},
+		{"notifications.json", exportNotifications},
+		{"saved_searches.json", exportSavedSearches},
+		{"drafts.json", exportDrafts},
+		{"sessions.json", exportSessions},
+	}, nil
+}
+
+func writeExportArchive(w io.Writer, files []exportFile) error {
+	zw := zip.NewWriter(w)
+	for _, file := range files {
+		f, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// Data export links are usable once, within this time of being requested
+const dataExportExpiry = 15 * time.Minute
+
+// RequestDataExport returns a link the current user can download a zip
+// archive of the data held about them from.
+func (s *User) RequestDataExport(ctx context.Context) (*models.UserDataExport, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return nil, auth.ErrUnauthorized
+	}
+
+	param, err := converter.CreateUserTokenParamsFromData(models.UserTokenTypeDataExport, models.UserTokenData{
+		UserID: currentUser.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	param.ExpiresAt = param.CreatedAt.Add(dataExportExpiry)
+
+	token, err := s.queries.CreateUserToken(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserDataExport{
+		URL:     fmt.Sprintf("%s/export/%s", config.GetHostURL(), token.ID),
+		Expires: token.ExpiresAt,
+	}, nil
+}
+
+// ExportData writes a zip archive of the data held about the user that
+// requested the export token to w, using up the token. The data is read
+// before anything is written, so errors returned before the archive is
+// started leave w untouched.
+func (s *User) ExportData(ctx context.Context, tokenID uuid.UUID, w io.Writer) error {
+	var files []exportFile
+	err := s.withTxn(func(tx *queries.Queries) error {
+		token, err := tx.FindUserToken(ctx, tokenID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidActivationKey
+		}
+		if err != nil {
+			return err
+		}
+		if token.Type != models.UserTokenTypeDataExport || token.ExpiresAt.Before(time.Now()) {
+			return ErrInvalidActivationKey
+		}
+
+		data, err := getUserTokenData(token)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteUserToken(ctx, token.ID); err != nil {
+			return err
+		}
+
+		files, err = exportFiles(ctx, tx, data.UserID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeExportArchive(w, files)
+}
+
+// RequestDeleteAccount emails the current user a link to confirm the
+// deletion of their account.
+func (s *User) RequestDeleteAccount(ctx context.Context) error {
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return auth.ErrUnauthorized
+	}
+
+	return s.withTxn(func(tx *queries.Queries) error {
+		user, err := tx.FindUser(ctx, currentUser.ID)
+		if err != nil {
+			return err
+		}
+		if err := validateDelete(user); err != nil {
+			return err
+		}
+
+		param, err := converter.CreateUserTokenParamsFromData(models.UserTokenTypeDeleteAccount, models.UserTokenData{
+			UserID: user.ID,
+		})
+		if err != nil {
+			return err
+		}
+		token, err := tx.CreateUserToken(ctx, param)
+		if err != nil {
+			return err
+		}
+
+		return email.SendDeleteAccountEmail(user, token.ID, s.emailMgr)
+	})
+}
+
+// ConfirmDeleteAccount deletes the account of the current user. Their edits,
+// comments and votes are kept without the user.
+func (s *User) ConfirmDeleteAccount(ctx context.Context, tokenID uuid.UUID) error {
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return auth.ErrUnauthorized
+	}
+
+	err := s.withTxn(func(tx *queries.Queries) error {
+		token, err := tx.FindUserToken(ctx, tokenID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidActivationKey
+		}
+		if err != nil {
+			return err
+		}
+		if token.Type != models.UserTokenTypeDeleteAccount || token.ExpiresAt.Before(time.Now()) {
+			return ErrInvalidActivationKey
+		}
+
+		data, err := getUserTokenData(token)
+		if err != nil {
+			return err
+		}
+		if data.UserID != currentUser.ID {
+			return ErrInvalidActivationKey
+		}
+
+		user, err := tx.FindUser(ctx, data.UserID)
+		if err != nil {
+			return err
+		}
+		if err := validateDelete(user); err != nil {
+			return err
+		}
+
+		// the audit entry keeps no personal data of the user
+		if err := recordUserAudit(ctx, tx, queries.ModAuditActionUSERDESTROY, user.ID, nil, nil); err != nil {
+			return err
+		}
+
+		if err := deleteUser(ctx, tx, user.ID); err != nil {
+			return err
+		}
+
+		return tx.DeleteUserToken(ctx, token.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	auth.CacheInvalidate(currentUser.ID)
+	return nil
+}
@@ -82,5 +82,12 @@ func activateResetPassword(ctx context.Context, tx *queries.Queries, id uuid.UUI
 		return err
 	}
 
+	if _, err := revokeSessions(ctx, tx, user.ID); err != nil {
+		return err
+	}
+	if err := clearLoginLockout(ctx, tx, user.Name); err != nil {
+		return err
+	}
+
 	return tx.DeleteUserToken(ctx, id)
 }
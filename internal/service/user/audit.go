@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+)
+
+// userAuditState is the audited representation of a user. Credentials and
+// the email address are deliberately excluded, since the log outlives the
+// account.
+type userAuditState struct {
+	Name         string        `json:"name"`
+	Roles        []string      `json:"roles"`
+	CustomRoles  []string      `json:"custom_roles"`
+	InvitedBy    uuid.NullUUID `json:"invited_by"`
+	InviteTokens int           `json:"invite_tokens"`
+}
+
+type inviteAuditState struct {
+	InviteTokens int `json:"invite_tokens"`
+}
+
+func getUserAuditState(ctx context.Context, tx *queries.Queries, u queries.User) (*userAuditState, error) {
+	roles, err := tx.GetUserRoles(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	customRoles, err := tx.GetUserCustomRoles(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	customRoleNames := make([]string, len(customRoles))
+	for i, role := range customRoles {
+		customRoleNames[i] = role.Name
+	}
+
+	return &userAuditState{
+		Name:         u.Name,
+		Roles:        roles,
+		CustomRoles:  customRoleNames,
+		InvitedBy:    u.InvitedBy,
+		InviteTokens: u.InviteTokens,
+	}, nil
+}
+
+func recordUserAudit(ctx context.Context, tx *queries.Queries, action queries.ModAuditAction, userID uuid.UUID, before, after *userAuditState) error {
+	return mod_audit.Record(ctx, tx, mod_audit.Entry{
+		Action:     action,
+		TargetID:   userID,
+		TargetType: mod_audit.TargetUser,
+		Before:     before,
+		After:      after,
+	})
+}
+
+func recordInviteAudit(ctx context.Context, tx *queries.Queries, action queries.ModAuditAction, userID uuid.UUID, before, after int) error {
+	return mod_audit.Record(ctx, tx, mod_audit.Entry{
+		Action:     action,
+		TargetID:   userID,
+		TargetType: mod_audit.TargetUser,
+		Before:     inviteAuditState{InviteTokens: before},
+		After:      inviteAuditState{InviteTokens: after},
+	})
+}
@@ -0,0 +1,26 @@
+package user
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+// CalendarToken returns the token authorizing access to the user's release
+// calendar feed. The token is derived from the user's API key, so
+// regenerating the API key revokes any previously shared feed URL.
+func CalendarToken(user *models.User) string {
+	mac := hmac.New(sha256.New, config.GetJWTSignKey())
+	mac.Write([]byte(user.ID.String()))
+	mac.Write([]byte(user.APIKey))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateCalendarToken reports whether token grants access to the user's
+// release calendar feed.
+func ValidateCalendarToken(user *models.User, token string) bool {
+	return hmac.Equal([]byte(CalendarToken(user)), []byte(token))
+}
@@ -0,0 +1,181 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+)
+
+var ErrEmptyInviteTreeAction = errors.New("select users to suspend and/or invites to revoke")
+
+type inviteTreeAuditData struct {
+	Users         []uuid.UUID `json:"users"`
+	Suspended     []uuid.UUID `json:"suspended"`
+	Expires       *time.Time  `json:"expires,omitempty"`
+	RevokedTokens int         `json:"revoked_tokens"`
+	RevokedCodes  int         `json:"revoked_codes"`
+}
+
+func addEditStats(dst *models.InviteTreeEditStats, src models.InviteTreeEditStats) {
+	dst.Accepted += src.Accepted
+	dst.Rejected += src.Rejected
+	dst.Pending += src.Pending
+}
+
+// buildInviteTree converts the depth-first rows of an invite tree to nodes,
+// summing the edits and descendants of each branch.
+func buildInviteTree(rows []queries.GetInviteTreeRow) []models.InviteTreeNode {
+	nodes := make([]models.InviteTreeNode, len(rows))
+	index := make(map[uuid.UUID]int, len(rows))
+	for i, row := range rows {
+		edits := models.InviteTreeEditStats{
+			Accepted: row.AcceptedEdits,
+			Rejected: row.RejectedEdits,
+			Pending:  row.PendingEdits,
+		}
+		nodes[i] = models.InviteTreeNode{
+			UserID:      row.UserID,
+			InvitedByID: row.InvitedBy,
+			Depth:       row.Depth,
+			Edits:       edits,
+			BranchEdits: edits,
+		}
+		index[row.UserID] = i
+	}
+
+	// children always follow their parent, so walking backwards completes
+	// each branch before it is added to its parent
+	for i := len(nodes) - 1; i > 0; i-- {
+		parent, ok := index[nodes[i].InvitedByID.UUID]
+		if !nodes[i].InvitedByID.Valid || !ok {
+			continue
+		}
+		addEditStats(&nodes[parent].BranchEdits, nodes[i].BranchEdits)
+		nodes[parent].Descendants += nodes[i].Descendants + 1
+	}
+
+	return nodes
+}
+
+// GetInviteTree returns the user and everyone invited by them, directly or
+// indirectly, in depth-first order.
+func (s *User) GetInviteTree(ctx context.Context, userID uuid.UUID) ([]models.InviteTreeNode, error) {
+	if _, err := s.queries.FindUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetInviteTree(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildInviteTree(rows), nil
+}
+
+// RevokeInviteTree suspends and/or removes the invite tokens and pending
+// invite codes of everyone invited by a user, directly or indirectly. Admins
+// and the current user are not suspended.
+func (s *User) RevokeInviteTree(ctx context.Context, input models.InviteTreeRevokeInput) (*models.InviteTreeRevokeResult, error) {
+	if !input.Suspend && !input.RevokeInvites {
+		return nil, ErrEmptyInviteTreeAction
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, ErrEmptySuspensionReason
+	}
+	if input.Suspend && input.Expires != nil && !input.Expires.After(time.Now()) {
+		return nil, ErrSuspensionExpired
+	}
+
+	var createdBy uuid.NullUUID
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser != nil {
+		createdBy = uuid.NullUUID{UUID: currentUser.ID, Valid: true}
+	}
+
+	audit := inviteTreeAuditData{
+		Users:     []uuid.UUID{},
+		Suspended: []uuid.UUID{},
+	}
+	if input.Suspend {
+		audit.Expires = input.Expires
+	}
+
+	err := s.withTxn(func(tx *queries.Queries) error {
+		if _, err := tx.FindUser(ctx, input.UserID); err != nil {
+			return err
+		}
+
+		rows, err := tx.GetInviteTree(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if row.UserID == input.UserID && !input.IncludeRoot {
+				continue
+			}
+			audit.Users = append(audit.Users, row.UserID)
+
+			if input.RevokeInvites {
+				u, err := tx.FindUser(ctx, row.UserID)
+				if err != nil {
+					return err
+				}
+				if _, err := repealInviteTokens(ctx, tx, row.UserID, u.InviteTokens); err != nil {
+					return err
+				}
+				audit.RevokedTokens += u.InviteTokens
+			}
+
+			if input.Suspend && (currentUser == nil || currentUser.ID != row.UserID) {
+				_, err := suspendUser(ctx, tx, row.UserID, reason, models.SuspensionRestrictionEnumReadOnly, input.Expires, createdBy)
+				if errors.Is(err, ErrSuspendAdmin) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				audit.Suspended = append(audit.Suspended, row.UserID)
+			}
+		}
+
+		if input.RevokeInvites && len(audit.Users) > 0 {
+			codes, err := tx.DeleteInviteKeysForUsers(ctx, audit.Users)
+			if err != nil {
+				return err
+			}
+			audit.RevokedCodes = int(codes)
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionINVITETREEREVOKE,
+			TargetID:   input.UserID,
+			TargetType: mod_audit.TargetUser,
+			Data:       audit,
+			Reason:     &reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range audit.Suspended {
+		auth.CacheInvalidate(id)
+	}
+
+	return &models.InviteTreeRevokeResult{
+		Users:         len(audit.Users),
+		Suspended:     len(audit.Suspended),
+		RevokedTokens: audit.RevokedTokens,
+		RevokedCodes:  audit.RevokedCodes,
+	}, nil
+}
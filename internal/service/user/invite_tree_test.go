@@ -0,0 +1,50 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+func TestBuildInviteTree(t *testing.T) {
+	root, child, grandchild, sibling := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	invitedBy := func(id uuid.UUID) uuid.NullUUID {
+		return uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	// depth-first order, as returned by the query
+	nodes := buildInviteTree([]queries.GetInviteTreeRow{
+		{UserID: root, Depth: 0, AcceptedEdits: 10},
+		{UserID: child, InvitedBy: invitedBy(root), Depth: 1, AcceptedEdits: 1, RejectedEdits: 4},
+		{UserID: grandchild, InvitedBy: invitedBy(child), Depth: 2, RejectedEdits: 6, PendingEdits: 2},
+		{UserID: sibling, InvitedBy: invitedBy(root), Depth: 1, AcceptedEdits: 3},
+	})
+
+	assert.Len(t, nodes, 4)
+	assert.Equal(t, models.InviteTreeEditStats{Accepted: 14, Rejected: 10, Pending: 2}, nodes[0].BranchEdits)
+	assert.Equal(t, models.InviteTreeEditStats{Accepted: 10}, nodes[0].Edits)
+	assert.Equal(t, 3, nodes[0].Descendants)
+
+	assert.Equal(t, models.InviteTreeEditStats{Accepted: 1, Rejected: 10, Pending: 2}, nodes[1].BranchEdits)
+	assert.Equal(t, 1, nodes[1].Descendants)
+
+	assert.Equal(t, models.InviteTreeEditStats{Rejected: 6, Pending: 2}, nodes[2].BranchEdits)
+	assert.Equal(t, 0, nodes[2].Descendants)
+	assert.Equal(t, models.InviteTreeEditStats{Accepted: 3}, nodes[3].BranchEdits)
+}
+
+func TestBuildInviteTreeInvitedRoot(t *testing.T) {
+	// the root of the tree may itself have been invited by someone outside it
+	root, child := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	nodes := buildInviteTree([]queries.GetInviteTreeRow{
+		{UserID: root, InvitedBy: uuid.NullUUID{UUID: uuid.Must(uuid.NewV4()), Valid: true}, PendingEdits: 1},
+		{UserID: child, InvitedBy: uuid.NullUUID{UUID: root, Valid: true}, Depth: 1, PendingEdits: 1},
+	})
+
+	assert.Equal(t, models.InviteTreeEditStats{Pending: 2}, nodes[0].BranchEdits)
+	assert.Equal(t, 1, nodes[0].Descendants)
+}
@@ -0,0 +1,225 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/email"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+	"github.com/stashapp/stash-box/pkg/logger"
+)
+
+var ErrLockedOut = errors.New("too many attempts")
+
+// Attempt counters restart after a day without attempts
+const lockoutWindow = 24 * time.Hour
+
+type lockoutAuditData struct {
+	Scope    models.LockoutScopeEnum `json:"scope"`
+	Key      string                  `json:"key"`
+	Attempts int                     `json:"attempts"`
+}
+
+type lockoutCounter struct {
+	scope models.LockoutScopeEnum
+	key   string
+}
+
+// lockoutDuration returns how long further attempts are locked out after the
+// given number of attempts. The lockout starts once the limit is reached and
+// doubles with every further attempt, up to maxDuration.
+func lockoutDuration(attempts, limit int, duration, maxDuration time.Duration) time.Duration {
+	if limit <= 0 || attempts < limit {
+		return 0
+	}
+	for i := limit; i < attempts && duration < maxDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, maxDuration)
+}
+
+func lockoutLimit(scope models.LockoutScopeEnum) int {
+	switch scope {
+	case models.LockoutScopeEnumLoginIP, models.LockoutScopeEnumResetPasswordIP, models.LockoutScopeEnumNewUserIP:
+		return config.GetLockoutIPAttempts()
+	default:
+		return config.GetLockoutAttempts()
+	}
+}
+
+// lockoutCounters returns the counters of an attempt for an account or email
+// address, followed by the counter for the IP address of the request.
+func lockoutCounters(ctx context.Context, scope, ipScope models.LockoutScopeEnum, key string) []lockoutCounter {
+	counters := []lockoutCounter{{
+		scope: scope,
+		key:   strings.ToLower(strings.TrimSpace(key)),
+	}}
+	if ip := auth.GetClientIP(ctx); ip != "" {
+		counters = append(counters, lockoutCounter{scope: ipScope, key: ip})
+	}
+	return counters
+}
+
+// checkLockouts returns ErrLockedOut if any of the counters is locked out.
+func checkLockouts(ctx context.Context, tx *queries.Queries, counters []lockoutCounter) error {
+	for _, c := range counters {
+		lockout, err := tx.FindLockout(ctx, queries.FindLockoutParams{
+			Scope: c.scope.String(),
+			Key:   c.key,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if lockout.LockedUntil != nil && lockout.LockedUntil.After(time.Now()) {
+			return fmt.Errorf("%w, try again after %s", ErrLockedOut, lockout.LockedUntil.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// recordAttempts counts an attempt for each of the counters, locking out
+// further attempts of counters that reached their limit.
+func recordAttempts(ctx context.Context, tx *queries.Queries, counters []lockoutCounter) ([]queries.Lockout, error) {
+	var ret []queries.Lockout
+	for _, c := range counters {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		lockout, err := tx.RecordLockoutAttempt(ctx, queries.RecordLockoutAttemptParams{
+			ID:          id,
+			Scope:       c.scope.String(),
+			Key:         c.key,
+			WindowStart: time.Now().Add(-lockoutWindow),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		duration := lockoutDuration(lockout.Attempts, lockoutLimit(c.scope), config.GetLockoutDuration(), config.GetLockoutMaxDuration())
+		if duration > 0 {
+			until := time.Now().Add(duration)
+			lockout.LockedUntil = &until
+			if err := tx.SetLockoutUntil(ctx, queries.SetLockoutUntilParams{
+				Scope:       lockout.Scope,
+				Key:         lockout.Key,
+				LockedUntil: lockout.LockedUntil,
+			}); err != nil {
+				return nil, err
+			}
+		}
+
+		ret = append(ret, lockout)
+	}
+	return ret, nil
+}
+
+// limitAttempts rejects the attempt if it is locked out, and counts it for
+// the account or email address otherwise. The IP address counter is only
+// incremented by recordFailedAttempt, so that the requests of users sharing
+// an address don't lock each other out.
+func limitAttempts(ctx context.Context, tx *queries.Queries, counters []lockoutCounter) error {
+	if err := checkLockouts(ctx, tx, counters); err != nil {
+		return err
+	}
+	_, err := recordAttempts(ctx, tx, counters[:1])
+	return err
+}
+
+// recordFailedAttempt counts a failed attempt for the IP address of the
+// request.
+func recordFailedAttempt(ctx context.Context, tx *queries.Queries, counters []lockoutCounter) error {
+	_, err := recordAttempts(ctx, tx, counters[1:])
+	return err
+}
+
+func clearLoginLockout(ctx context.Context, tx *queries.Queries, username string) error {
+	return tx.DeleteLockout(ctx, queries.DeleteLockoutParams{
+		Scope: models.LockoutScopeEnumLoginAccount.String(),
+		Key:   strings.ToLower(username),
+	})
+}
+
+// recordFailedLogin counts a failed login, and notifies the owner of the
+// account when it is first locked out.
+func (s *User) recordFailedLogin(ctx context.Context, counters []lockoutCounter, user *queries.User) error {
+	lockouts, err := recordAttempts(ctx, s.queries, counters)
+	if err != nil {
+		return err
+	}
+
+	if user != nil && lockouts[0].Attempts == config.GetLockoutAttempts() {
+		// the lockout applies whether or not the owner could be notified
+		if err := email.SendLockoutEmail(*user, s.emailMgr); err != nil {
+			logger.Errorf("Error sending lockout email: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// QueryLockouts returns the attempt counters of the last day, most recent
+// first.
+func (s *User) QueryLockouts(ctx context.Context, lockedOnly bool) ([]models.Lockout, error) {
+	lockouts, err := s.queries.QueryLockouts(ctx, queries.QueryLockoutsParams{
+		WindowStart: time.Now().Add(-lockoutWindow),
+		LockedOnly:  lockedOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return converter.LockoutsToModels(lockouts), nil
+}
+
+// ClearLockout resets an attempt counter, lifting its lockout.
+func (s *User) ClearLockout(ctx context.Context, input models.ClearLockoutInput) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		lockout, err := tx.FindLockout(ctx, queries.FindLockoutParams{
+			Scope: input.Scope.String(),
+			Key:   input.Key,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.DeleteLockout(ctx, queries.DeleteLockoutParams{
+			Scope: lockout.Scope,
+			Key:   lockout.Key,
+		}); err != nil {
+			return err
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionLOCKOUTCLEAR,
+			TargetID:   lockout.ID,
+			TargetType: mod_audit.TargetLockout,
+			Data: lockoutAuditData{
+				Scope:    input.Scope,
+				Key:      lockout.Key,
+				Attempts: lockout.Attempts,
+			},
+		})
+	})
+}
+
+// DeleteExpiredLockouts removes attempt counters that have restarted and are
+// not locked out.
+func (s *User) DeleteExpiredLockouts(ctx context.Context) error {
+	return s.queries.DeleteExpiredLockouts(ctx, time.Now().Add(-lockoutWindow))
+}
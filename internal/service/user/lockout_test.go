@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	duration := func(attempts int) time.Duration {
+		return lockoutDuration(attempts, 5, time.Minute, time.Hour)
+	}
+
+	assert.Equal(t, time.Duration(0), duration(1))
+	assert.Equal(t, time.Duration(0), duration(4))
+	assert.Equal(t, time.Minute, duration(5))
+	assert.Equal(t, 2*time.Minute, duration(6))
+	assert.Equal(t, 8*time.Minute, duration(8))
+	assert.Equal(t, time.Hour, duration(12))
+	assert.Equal(t, time.Hour, duration(1000))
+
+	// a limit of zero disables the lockout
+	assert.Equal(t, time.Duration(0), lockoutDuration(1000, 0, time.Minute, time.Hour))
+}
+
+func TestLockoutCounters(t *testing.T) {
+	counters := lockoutCounters(context.Background(), models.LockoutScopeEnumLoginAccount, models.LockoutScopeEnumLoginIP, " User ")
+	assert.Equal(t, []lockoutCounter{{scope: models.LockoutScopeEnumLoginAccount, key: "user"}}, counters)
+
+	ctx := context.WithValue(context.Background(), auth.ContextClientIP, "192.0.2.1")
+	counters = lockoutCounters(ctx, models.LockoutScopeEnumNewUserEmail, models.LockoutScopeEnumNewUserIP, "user@example.com")
+	assert.Equal(t, []lockoutCounter{
+		{scope: models.LockoutScopeEnumNewUserEmail, key: "user@example.com"},
+		{scope: models.LockoutScopeEnumNewUserIP, key: "192.0.2.1"},
+	}, counters)
+}
@@ -0,0 +1,223 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+)
+
+// Weights of the reputation components. Edit acceptance weighs the most, as
+// it is the most direct measure of the quality of a user's contributions.
+const (
+	editWeight        = 5
+	voteWeight        = 3
+	fingerprintWeight = 2
+)
+
+// Roles that reputation rules may not grant or remove
+var unmanagedRoles = []models.RoleEnum{
+	models.RoleEnumRead,
+	models.RoleEnumReadOnly,
+	models.RoleEnumModerate,
+	models.RoleEnumAdmin,
+	models.RoleEnumBot,
+}
+
+// Users holding one of these roles are never changed by reputation rules
+var staffRoles = []models.RoleEnum{
+	models.RoleEnumModerate,
+	models.RoleEnumAdmin,
+}
+
+// ratio returns the share of good outcomes, pulled towards one half so that
+// a handful of outcomes does not swing the score to either extreme.
+func ratio(good, bad int) float64 {
+	return float64(good+1) / float64(good+bad+2)
+}
+
+func reputationScore(r models.UserReputation) int {
+	total := editWeight*ratio(r.AcceptedEdits, r.RejectedEdits) +
+		voteWeight*ratio(r.AgreeingVotes, r.DisagreeingVotes) +
+		fingerprintWeight*ratio(r.ConfirmedReports, r.DisputedReports)
+	return int(math.Round(100 * total / (editWeight + voteWeight + fingerprintWeight)))
+}
+
+func toReputation(row queries.GetUserReputationStatsRow) models.UserReputation {
+	ret := models.UserReputation{
+		AcceptedEdits:    row.AcceptedEdits,
+		RejectedEdits:    row.RejectedEdits,
+		AgreeingVotes:    row.AgreeingVotes,
+		DisagreeingVotes: row.DisagreeingVotes,
+		ConfirmedReports: row.ConfirmedReports,
+		DisputedReports:  row.DisputedReports,
+	}
+	ret.Score = reputationScore(ret)
+	return ret
+}
+
+// GetReputation returns the reputation of a user
+func (s *User) GetReputation(ctx context.Context, userID uuid.UUID) (*models.UserReputation, error) {
+	rows, err := s.queries.GetUserReputationStats(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret models.UserReputation
+	if len(rows) > 0 {
+		ret = toReputation(rows[0])
+	} else {
+		ret.Score = reputationScore(ret)
+	}
+	return &ret, nil
+}
+
+func validateReputationRules(rules []config.ReputationRule) error {
+	seen := make(map[models.RoleEnum]bool)
+	for _, rule := range rules {
+		role := models.RoleEnum(rule.Role)
+		if !role.IsValid() || slices.Contains(unmanagedRoles, role) {
+			return fmt.Errorf("reputation rules can not manage role %q", rule.Role)
+		}
+		if seen[role] {
+			return fmt.Errorf("multiple reputation rules for role %s", role)
+		}
+		seen[role] = true
+
+		if rule.MinScore < 0 || rule.MinScore > 100 || rule.RemoveBelow < 0 || rule.RemoveBelow > rule.MinScore {
+			return fmt.Errorf("reputation rule for role %s: min_score must be between remove_below and 100", role)
+		}
+	}
+	return nil
+}
+
+type roleChange struct {
+	role    models.RoleEnum
+	granted bool
+	reason  string
+}
+
+// reputationRoleChanges returns the roles to grant to or remove from a user
+// with the given roles and reputation.
+func reputationRoleChanges(roles []models.RoleEnum, reputation models.UserReputation, rules []config.ReputationRule) []roleChange {
+	for _, role := range roles {
+		if slices.Contains(staffRoles, role) {
+			return nil
+		}
+	}
+	readOnly := slices.Contains(roles, models.RoleEnumReadOnly)
+
+	var changes []roleChange
+	for _, rule := range rules {
+		role := models.RoleEnum(rule.Role)
+		hasRole := slices.Contains(roles, role)
+
+		switch {
+		case !hasRole && !readOnly && reputation.Score >= rule.MinScore && reputation.AcceptedEdits >= rule.MinEdits:
+			changes = append(changes, roleChange{
+				role:    role,
+				granted: true,
+				reason:  fmt.Sprintf("granted %s: reputation %d reached %d with %d accepted edits", role, reputation.Score, rule.MinScore, reputation.AcceptedEdits),
+			})
+		case hasRole && rule.RemoveBelow > 0 && reputation.Score < rule.RemoveBelow:
+			changes = append(changes, roleChange{
+				role:    role,
+				granted: false,
+				reason:  fmt.Sprintf("removed %s: reputation %d fell below %d", role, reputation.Score, rule.RemoveBelow),
+			})
+		}
+	}
+	return changes
+}
+
+// ApplyReputationRules grants and removes roles of every active user
+// according to the configured reputation rules. Each change is recorded in
+// the mod audit log.
+func (s *User) ApplyReputationRules(ctx context.Context) error {
+	rules := config.GetReputationRules()
+	if err := validateReputationRules(rules); err != nil || len(rules) == 0 {
+		return err
+	}
+
+	rows, err := s.queries.GetUserReputationStats(ctx, uuid.NullUUID{})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, row := range rows {
+		reputation := toReputation(row)
+		if len(reputationRoleChanges(converter.StringsToRoleEnums(row.Roles), reputation, rules)) == 0 {
+			continue
+		}
+		if err := s.applyReputationRules(ctx, row.UserID, reputation, rules); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", row.UserID, err))
+			continue
+		}
+		auth.CacheInvalidate(row.UserID)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *User) applyReputationRules(ctx context.Context, userID uuid.UUID, reputation models.UserReputation, rules []config.ReputationRule) error {
+	return s.withTxn(func(tx *queries.Queries) error {
+		user, err := tx.FindUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		before, err := getUserAuditState(ctx, tx, user)
+		if err != nil {
+			return err
+		}
+
+		// the roles may have changed since the reputation was computed
+		roles := converter.StringsToRoleEnums(before.Roles)
+		changes := reputationRoleChanges(roles, reputation, rules)
+		if len(changes) == 0 {
+			return nil
+		}
+
+		var reasons []string
+		for _, change := range changes {
+			if change.granted {
+				roles = append(roles, change.role)
+			} else {
+				roles = slices.DeleteFunc(roles, func(r models.RoleEnum) bool {
+					return r == change.role
+				})
+			}
+			reasons = append(reasons, change.reason)
+		}
+
+		if err := updateRoles(ctx, tx, userID, roles); err != nil {
+			return err
+		}
+
+		after, err := getUserAuditState(ctx, tx, user)
+		if err != nil {
+			return err
+		}
+
+		reason := strings.Join(reasons, "; ")
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionUSERREPUTATIONUPDATE,
+			TargetID:   userID,
+			TargetType: mod_audit.TargetUser,
+			Before:     before,
+			After:      after,
+			Reason:     &reason,
+		})
+	})
+}
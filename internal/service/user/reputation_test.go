@@ -0,0 +1,77 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+)
+
+func TestReputationScore(t *testing.T) {
+	assert.Equal(t, 50, reputationScore(models.UserReputation{}))
+
+	good := reputationScore(models.UserReputation{AcceptedEdits: 20, AgreeingVotes: 20, ConfirmedReports: 2})
+	bad := reputationScore(models.UserReputation{RejectedEdits: 20, DisagreeingVotes: 20, DisputedReports: 2})
+	assert.Greater(t, good, 90)
+	assert.Less(t, bad, 10)
+
+	// a single rejected edit does not sink a new user
+	assert.Equal(t, 42, reputationScore(models.UserReputation{RejectedEdits: 1}))
+}
+
+func TestReputationRoleChanges(t *testing.T) {
+	rules := []config.ReputationRule{
+		{Role: "VOTE", MinScore: 60, MinEdits: 10, RemoveBelow: 40},
+		{Role: "EDIT_TAGS", MinScore: 80, MinEdits: 50},
+	}
+
+	roles := func(changes []roleChange) map[models.RoleEnum]bool {
+		ret := make(map[models.RoleEnum]bool)
+		for _, c := range changes {
+			ret[c.role] = c.granted
+		}
+		return ret
+	}
+
+	trusted := models.UserReputation{Score: 85, AcceptedEdits: 60}
+	assert.Equal(t, map[models.RoleEnum]bool{
+		models.RoleEnumVote:     true,
+		models.RoleEnumEditTags: true,
+	}, roles(reputationRoleChanges([]models.RoleEnum{models.RoleEnumEdit}, trusted, rules)))
+
+	// enough score but not enough accepted edits
+	newcomer := models.UserReputation{Score: 85, AcceptedEdits: 5}
+	assert.Empty(t, reputationRoleChanges([]models.RoleEnum{models.RoleEnumEdit}, newcomer, rules))
+
+	// only rules with remove_below take roles away
+	poor := models.UserReputation{Score: 30, AcceptedEdits: 60}
+	assert.Equal(t, map[models.RoleEnum]bool{
+		models.RoleEnumVote: false,
+	}, roles(reputationRoleChanges([]models.RoleEnum{models.RoleEnumVote, models.RoleEnumEditTags}, poor, rules)))
+
+	// between remove_below and min_score nothing changes
+	middling := models.UserReputation{Score: 50, AcceptedEdits: 60}
+	assert.Empty(t, reputationRoleChanges([]models.RoleEnum{models.RoleEnumVote}, middling, rules))
+	assert.Empty(t, reputationRoleChanges([]models.RoleEnum{models.RoleEnumEdit}, middling, rules))
+
+	// staff and read only users are left alone
+	assert.Empty(t, reputationRoleChanges([]models.RoleEnum{models.RoleEnumModerate, models.RoleEnumVote}, poor, rules))
+	assert.Empty(t, reputationRoleChanges([]models.RoleEnum{models.RoleEnumReadOnly}, trusted, rules))
+}
+
+func TestValidateReputationRules(t *testing.T) {
+	assert.NoError(t, validateReputationRules(nil))
+	assert.NoError(t, validateReputationRules([]config.ReputationRule{{Role: "VOTE", MinScore: 60, RemoveBelow: 40}}))
+
+	for _, rules := range [][]config.ReputationRule{
+		{{Role: "NOT_A_ROLE", MinScore: 60}},
+		{{Role: "ADMIN", MinScore: 60}},
+		{{Role: "VOTE", MinScore: 60}, {Role: "VOTE", MinScore: 70}},
+		{{Role: "VOTE", MinScore: 101}},
+		{{Role: "VOTE", MinScore: 40, RemoveBelow: 60}},
+	} {
+		assert.Error(t, validateReputationRules(rules), rules)
+	}
+}
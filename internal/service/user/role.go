@@ -0,0 +1,377 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/errutil"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+)
+
+var (
+	ErrEmptyRoleName        = errors.New("role name is required")
+	ErrRoleNameTaken        = errors.New("a role with that name already exists")
+	ErrBuiltinRoleName      = errors.New("role name is reserved for a built-in role")
+	ErrEmptyRolePermissions = errors.New("role must grant at least one permission")
+	ErrRoleNotFound         = errors.New("role not found")
+)
+
+type roleAuditState struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func newRoleAuditState(r *queries.Role) *roleAuditState {
+	if r == nil {
+		return nil
+	}
+	return &roleAuditState{
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: r.Permissions,
+	}
+}
+
+func recordRoleAudit(ctx context.Context, tx *queries.Queries, action queries.ModAuditAction, id uuid.UUID, before, after *queries.Role) error {
+	return mod_audit.Record(ctx, tx, mod_audit.Entry{
+		Action:     action,
+		TargetID:   id,
+		TargetType: mod_audit.TargetRole,
+		Before:     newRoleAuditState(before),
+		After:      newRoleAuditState(after),
+	})
+}
+
+// validateRoleName returns the trimmed role name, checking that it is not
+// used by the built-in roles or another custom role.
+func validateRoleName(ctx context.Context, tx *queries.Queries, name string, id *uuid.UUID) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrEmptyRoleName
+	}
+
+	for _, role := range models.AllRoleEnum {
+		if strings.EqualFold(name, role.String()) {
+			return "", ErrBuiltinRoleName
+		}
+	}
+
+	existing, err := tx.FindRoleByName(ctx, name)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+	if err == nil && (id == nil || existing.ID != *id) {
+		return "", ErrRoleNameTaken
+	}
+
+	return name, nil
+}
+
+// rolePermissions returns the distinct permissions, in declaration order
+func rolePermissions(permissions []models.PermissionEnum) ([]string, error) {
+	var ret []string
+	for _, permission := range models.AllPermissionEnum {
+		if slices.Contains(permissions, permission) {
+			ret = append(ret, permission.String())
+		}
+	}
+	if len(ret) == 0 {
+		return nil, ErrEmptyRolePermissions
+	}
+	return ret, nil
+}
+
+// addedPermissions returns the permissions that are not already granted
+func addedPermissions(permissions, existing []string) []models.PermissionEnum {
+	var ret []models.PermissionEnum
+	for _, permission := range permissions {
+		if !slices.Contains(existing, permission) {
+			ret = append(ret, models.PermissionEnum(permission))
+		}
+	}
+	return ret
+}
+
+// validateGrantedRoles checks that the current user holds every permission
+// of the built-in and custom roles a user is given. Roles the user already
+// holds are not checked.
+func validateGrantedRoles(ctx context.Context, tx *queries.Queries, userID *uuid.UUID, roles []models.RoleEnum, customRoleIDs []uuid.UUID) error {
+	var heldRoles []string
+	var heldCustomRoles []queries.Role
+	if userID != nil {
+		var err error
+		if heldRoles, err = tx.GetUserRoles(ctx, *userID); err != nil {
+			return err
+		}
+		if heldCustomRoles, err = tx.GetUserCustomRoles(ctx, *userID); err != nil {
+			return err
+		}
+	}
+
+	var permissions []models.PermissionEnum
+	for _, role := range roles {
+		if !slices.Contains(heldRoles, role.String()) {
+			permissions = append(permissions, role.Permissions()...)
+		}
+	}
+
+	var added []uuid.UUID
+	for _, id := range customRoleIDs {
+		if !slices.ContainsFunc(heldCustomRoles, func(r queries.Role) bool { return r.ID == id }) {
+			added = append(added, id)
+		}
+	}
+	if len(added) > 0 {
+		customRoles, err := tx.GetRolesByIds(ctx, added)
+		if err != nil {
+			return err
+		}
+		for _, role := range customRoles {
+			permissions = append(permissions, converter.StringsToPermissionEnums(role.Permissions)...)
+		}
+	}
+
+	return auth.ValidateGrant(ctx, permissions)
+}
+
+// invalidateRoleUsers clears the cached permissions of the users holding a role
+func invalidateRoleUsers(userIDs []uuid.UUID) {
+	for _, id := range userIDs {
+		auth.CacheInvalidate(id)
+	}
+}
+
+// RolePresets returns the permissions granted by each built-in role
+func (s *User) RolePresets() []models.RolePreset {
+	ret := make([]models.RolePreset, len(models.AllRoleEnum))
+	for i, role := range models.AllRoleEnum {
+		ret[i] = models.RolePreset{
+			Role:        role,
+			Permissions: role.Permissions(),
+		}
+	}
+	return ret
+}
+
+func (s *User) GetAllRoles(ctx context.Context) ([]models.Role, error) {
+	roles, err := s.queries.GetAllRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return converter.RolesToModels(roles), nil
+}
+
+func (s *User) FindRole(ctx context.Context, id uuid.UUID) (*models.Role, error) {
+	role, err := s.queries.FindRole(ctx, id)
+	if err != nil {
+		return nil, errutil.IgnoreNotFound(err)
+	}
+	return converter.RoleToModelPtr(role), nil
+}
+
+func (s *User) CountRoleUsers(ctx context.Context, id uuid.UUID) (int, error) {
+	count, err := s.queries.CountRoleUsers(ctx, id)
+	return int(count), err
+}
+
+func (s *User) CreateRole(ctx context.Context, input models.RoleCreateInput) (*models.Role, error) {
+	permissions, err := rolePermissions(input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.ValidateGrant(ctx, input.Permissions); err != nil {
+		return nil, err
+	}
+
+	var role queries.Role
+	err = s.withTxn(func(tx *queries.Queries) error {
+		name, err := validateRoleName(ctx, tx, input.Name, nil)
+		if err != nil {
+			return err
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		var description string
+		if input.Description != nil {
+			description = strings.TrimSpace(*input.Description)
+		}
+
+		role, err = tx.CreateRole(ctx, queries.CreateRoleParams{
+			ID:          id,
+			Name:        name,
+			Description: description,
+			Permissions: permissions,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordRoleAudit(ctx, tx, queries.ModAuditActionROLECREATE, role.ID, nil, &role)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.RoleToModelPtr(role), nil
+}
+
+func (s *User) UpdateRole(ctx context.Context, input models.RoleUpdateInput) (*models.Role, error) {
+	var role queries.Role
+	var userIDs []uuid.UUID
+	err := s.withTxn(func(tx *queries.Queries) error {
+		existing, err := tx.FindRole(ctx, input.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		params := queries.UpdateRoleParams{
+			ID:          existing.ID,
+			Name:        existing.Name,
+			Description: existing.Description,
+			Permissions: existing.Permissions,
+		}
+		if input.Name != nil {
+			if params.Name, err = validateRoleName(ctx, tx, *input.Name, &existing.ID); err != nil {
+				return err
+			}
+		}
+		if input.Description != nil {
+			params.Description = strings.TrimSpace(*input.Description)
+		}
+		if input.Permissions != nil {
+			if params.Permissions, err = rolePermissions(input.Permissions); err != nil {
+				return err
+			}
+			if err := auth.ValidateGrant(ctx, addedPermissions(params.Permissions, existing.Permissions)); err != nil {
+				return err
+			}
+		}
+
+		role, err = tx.UpdateRole(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		userIDs, err = tx.GetRoleUserIDs(ctx, role.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordRoleAudit(ctx, tx, queries.ModAuditActionROLEUPDATE, role.ID, &existing, &role)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateRoleUsers(userIDs)
+	return converter.RoleToModelPtr(role), nil
+}
+
+// DestroyRole deletes a role, removing it from every user holding it
+func (s *User) DestroyRole(ctx context.Context, id uuid.UUID) error {
+	var userIDs []uuid.UUID
+	err := s.withTxn(func(tx *queries.Queries) error {
+		existing, err := tx.FindRole(ctx, id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		userIDs, err = tx.GetRoleUserIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.DeleteRole(ctx, id); err != nil {
+			return err
+		}
+
+		return recordRoleAudit(ctx, tx, queries.ModAuditActionROLEDESTROY, id, &existing, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	invalidateRoleUsers(userIDs)
+	return nil
+}
+
+func (s *User) GetCustomRoles(ctx context.Context, userID uuid.UUID) ([]models.Role, error) {
+	roles, err := s.queries.GetUserCustomRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.RolesToModels(roles), nil
+}
+
+// GetCustomPermissions returns the permissions granted to a user by their
+// custom roles.
+func (s *User) GetCustomPermissions(ctx context.Context, userID uuid.UUID) ([]models.PermissionEnum, error) {
+	permissions, err := s.queries.GetUserCustomPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.StringsToPermissionEnums(permissions), nil
+}
+
+// GetPermissions returns the permissions granted to a user by their built-in
+// and custom roles.
+func (s *User) GetPermissions(ctx context.Context, userID uuid.UUID) ([]models.PermissionEnum, error) {
+	roles, err := s.GetRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := s.GetCustomPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return auth.GrantedPermissions(roles, custom), nil
+}
+
+// updateCustomRoles replaces the custom roles of a user
+func updateCustomRoles(ctx context.Context, tx *queries.Queries, userID uuid.UUID, roleIDs []uuid.UUID) error {
+	roleIDs = slices.Clone(roleIDs)
+	slices.SortFunc(roleIDs, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	roleIDs = slices.Compact(roleIDs)
+
+	roles, err := tx.GetRolesByIds(ctx, roleIDs)
+	if err != nil {
+		return err
+	}
+	if len(roles) != len(roleIDs) {
+		return ErrRoleNotFound
+	}
+
+	if err := tx.DeleteUserCustomRoles(ctx, userID); err != nil {
+		return err
+	}
+	if len(roleIDs) == 0 {
+		return nil
+	}
+	return tx.CreateUserCustomRoles(ctx, queries.CreateUserCustomRolesParams{
+		UserID:  userID,
+		RoleIds: roleIDs,
+	})
+}
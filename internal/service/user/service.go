@@ -209,11 +209,20 @@ func (s *User) GetRoles(ctx context.Context, userID uuid.UUID) ([]models.RoleEnu
 // NewUser registers a new user. It returns the activation key only if
 // email verification is not required, otherwise it returns nil.
 func (s *User) NewUser(ctx context.Context, emailAddr string, inviteKey *uuid.UUID) (*uuid.UUID, error) {
+	if config.GetDisableLocalRegistration() {
+		return nil, ErrLocalRegistrationDisabled
+	}
+
 	// ensure user or pending activation with email does not already exist
 	if err := validateUserEmail(emailAddr); err != nil {
 		return nil, err
 	}
 
+	counters := lockoutCounters(ctx, models.LockoutScopeEnumNewUserEmail, models.LockoutScopeEnumNewUserIP, emailAddr)
+	if err := limitAttempts(ctx, s.queries, counters); err != nil {
+		return nil, err
+	}
+
 	var err error
 	var activationKey *uuid.UUID
 	err = s.withTxn(func(tx *queries.Queries) error {
@@ -242,17 +251,33 @@ func (s *User) NewUser(ctx context.Context, emailAddr string, inviteKey *uuid.UU
 		return email.SendNewUserEmail(emailAddr, activationToken.ID, s.emailMgr)
 	})
 
+	if err != nil {
+		if err := recordFailedAttempt(ctx, s.queries, counters); err != nil {
+			return nil, err
+		}
+	}
+
 	return activationKey, err
 }
 
 func (s *User) Create(ctx context.Context, input models.UserCreateInput) (*models.User, error) {
 	var user *models.User
 	err := s.withTxn(func(tx *queries.Queries) error {
+		if err := validateGrantedRoles(ctx, tx, nil, input.Roles, input.CustomRoleIds); err != nil {
+			return err
+		}
+
 		createdUser, err := createUser(ctx, tx, input, true)
-		if createdUser != nil {
-			user = converter.UserToModelPtr(*createdUser)
+		if err != nil {
+			return err
 		}
-		return err
+		user = converter.UserToModelPtr(*createdUser)
+
+		after, err := getUserAuditState(ctx, tx, *createdUser)
+		if err != nil {
+			return err
+		}
+		return recordUserAudit(ctx, tx, queries.ModAuditActionUSERCREATE, createdUser.ID, nil, after)
 	})
 
 	return user, err
@@ -270,6 +295,14 @@ func (s *User) Update(ctx context.Context, input models.UserUpdateInput) (*model
 		if err := validateUpdate(ctx, input, existingUser); err != nil {
 			return err
 		}
+		if err := validateGrantedRoles(ctx, tx, &existingUser.ID, input.Roles, input.CustomRoleIds); err != nil {
+			return err
+		}
+
+		before, err := getUserAuditState(ctx, tx, existingUser)
+		if err != nil {
+			return err
+		}
 
 		hash := existingUser.PasswordHash
 		if input.Password != nil {
@@ -287,7 +320,29 @@ func (s *User) Update(ctx context.Context, input models.UserUpdateInput) (*model
 
 		// Update roles
 		// TODO - only do this if provided
-		return updateRoles(ctx, tx, user.ID, input.Roles)
+		if err := updateRoles(ctx, tx, user.ID, input.Roles); err != nil {
+			return err
+		}
+
+		if input.CustomRoleIds != nil {
+			if err := updateCustomRoles(ctx, tx, user.ID, input.CustomRoleIds); err != nil {
+				return err
+			}
+		}
+
+		after, err := getUserAuditState(ctx, tx, user)
+		if err != nil {
+			return err
+		}
+
+		// log the user out everywhere when their credentials or permissions change
+		if input.Password != nil || !sameRoles(before.Roles, after.Roles) || !sameRoles(before.CustomRoles, after.CustomRoles) {
+			if _, err := revokeSessions(ctx, tx, user.ID); err != nil {
+				return err
+			}
+		}
+
+		return recordUserAudit(ctx, tx, queries.ModAuditActionUSERUPDATE, user.ID, before, after)
 	})
 
 	if err == nil {
@@ -308,23 +363,15 @@ func (s *User) Delete(ctx context.Context, input models.UserDestroyInput) error
 			return err
 		}
 
-		// Retain the user's unique fingerprints by reassigning them to the sentinel user
-		deletedUser, err := tx.FindUserByName(ctx, deletedUserName)
+		before, err := getUserAuditState(ctx, tx, existingUser)
 		if err != nil {
 			return err
 		}
-		if err := tx.ReassignUniqueSceneFingerprints(ctx, queries.ReassignUniqueSceneFingerprintsParams{
-			TargetUserID: deletedUser.ID,
-			SourceUserID: input.ID,
-		}); err != nil {
-			return err
-		}
-
-		if err := tx.DeleteUser(ctx, input.ID); err != nil {
+		if err := recordUserAudit(ctx, tx, queries.ModAuditActionUSERDESTROY, existingUser.ID, before, nil); err != nil {
 			return err
 		}
 
-		return tx.CancelUserEdits(ctx, uuid.NullUUID{UUID: input.ID, Valid: true})
+		return deleteUser(ctx, tx, input.ID)
 	})
 
 	if err == nil {
@@ -340,8 +387,8 @@ func (s *User) RegenerateAPIKey(ctx context.Context, userID *uuid.UUID) (string,
 	if userID != nil {
 		if currentUser.ID != *userID {
 			// changing another user api key
-			// must be admin
-			if err := auth.ValidateAdmin(ctx); err != nil {
+			// must be able to manage users
+			if err := auth.ValidateManageUsers(ctx); err != nil {
 				return "", err
 			}
 		}
@@ -376,7 +423,13 @@ func (s *User) RegenerateAPIKey(ctx context.Context, userID *uuid.UUID) (string,
 }
 
 func (s *User) ResetPassword(ctx context.Context, input models.ResetPasswordInput) error {
-	return s.withTxn(func(tx *queries.Queries) error {
+	counters := lockoutCounters(ctx, models.LockoutScopeEnumResetPasswordEmail, models.LockoutScopeEnumResetPasswordIP, input.Email)
+	if err := limitAttempts(ctx, s.queries, counters); err != nil {
+		return err
+	}
+
+	unknownEmail := false
+	err := s.withTxn(func(tx *queries.Queries) error {
 		u, err := tx.FindUserByEmail(ctx, input.Email)
 
 		// Sleep between 500-1500ms to avoid leaking email presence
@@ -384,6 +437,7 @@ func (s *User) ResetPassword(ctx context.Context, input models.ResetPasswordInpu
 		time.Sleep(time.Duration(500+n) * time.Millisecond)
 
 		if errors.Is(err, pgx.ErrNoRows) {
+			unknownEmail = true
 			return nil
 		} else if err != nil {
 			return err
@@ -397,6 +451,12 @@ func (s *User) ResetPassword(ctx context.Context, input models.ResetPasswordInpu
 
 		return email.SendResetPasswordEmail(u, *key, s.emailMgr)
 	})
+
+	// guessing email addresses counts against the IP address
+	if unknownEmail {
+		return recordFailedAttempt(ctx, s.queries, counters)
+	}
+	return err
 }
 
 func (s *User) ChangePassword(ctx context.Context, input models.UserChangePasswordInput) error {
@@ -423,6 +483,10 @@ func (s *User) ChangePassword(ctx context.Context, input models.UserChangePasswo
 }
 
 func (s *User) ActivateNewUser(ctx context.Context, input models.ActivateNewUserInput) (*models.User, error) {
+	if config.GetDisableLocalRegistration() {
+		return nil, ErrLocalRegistrationDisabled
+	}
+
 	var user queries.User
 	err := s.withTxn(func(tx *queries.Queries) error {
 		token, err := tx.FindUserToken(ctx, input.ActivationKey)
@@ -605,10 +669,18 @@ func (s *User) GrantInvite(ctx context.Context, input models.GrantInviteInput) (
 
 	var ret int
 	err := s.withTxn(func(tx *queries.Queries) error {
+		u, err := tx.FindUser(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+
 		count, err := grantInviteTokens(ctx, tx, input.UserID, input.Amount)
+		if err != nil {
+			return err
+		}
 		ret = count
 
-		return err
+		return recordInviteAudit(ctx, tx, queries.ModAuditActionINVITEGRANT, input.UserID, u.InviteTokens, count)
 	})
 
 	return ret, err
@@ -621,10 +693,18 @@ func (s *User) RevokeInvite(ctx context.Context, input models.RevokeInviteInput)
 
 	var ret int
 	err := s.withTxn(func(tx *queries.Queries) error {
+		u, err := tx.FindUser(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+
 		count, err := repealInviteTokens(ctx, tx, input.UserID, input.Amount)
+		if err != nil {
+			return err
+		}
 		ret = count
 
-		return err
+		return recordInviteAudit(ctx, tx, queries.ModAuditActionINVITEREVOKE, input.UserID, u.InviteTokens, count)
 	})
 
 	return ret, err
@@ -802,16 +882,44 @@ func (s *User) CreateSystemUsers(ctx context.Context) {
 }
 
 // Authenticate validates the provided username and password. If correct, it
-// returns the id of the user.
+// returns the id of the user. The failed logins of the account are reset
+// once the login is complete, which for users with two-factor
+// authentication is in VerifyTwoFactor.
 func (s *User) Authenticate(ctx context.Context, username string, password string) (string, error) {
+	counters := lockoutCounters(ctx, models.LockoutScopeEnumLoginAccount, models.LockoutScopeEnumLoginIP, username)
+	if err := checkLockouts(ctx, s.queries, counters); err != nil {
+		return "", err
+	}
+
 	user, err := s.queries.FindUserByName(ctx, username)
-	if err != nil {
+	if errors.Is(err, pgx.ErrNoRows) {
+		if err := s.recordFailedLogin(ctx, counters, nil); err != nil {
+			return "", err
+		}
+		return "", ErrAccessDenied
+	} else if err != nil {
 		return "", err
 	}
 
 	if !isPasswordCorrect(user.PasswordHash, password) {
+		if err := s.recordFailedLogin(ctx, counters, &user); err != nil {
+			return "", err
+		}
 		return "", ErrAccessDenied
 	}
 
+	// users with two-factor authentication keep their failures until the
+	// second step succeeds, so that repeating the password does not reset
+	// the count of wrong codes
+	totp, err := findTOTP(ctx, s.queries, user.ID)
+	if err != nil {
+		return "", err
+	}
+	if totp == nil || totp.EnabledAt == nil {
+		if err := clearLoginLockout(ctx, s.queries, user.Name); err != nil {
+			return "", err
+		}
+	}
+
 	return user.ID.String(), nil
 }
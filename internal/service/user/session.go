@@ -0,0 +1,153 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type revokeSessionsAuditData struct {
+	Sessions int `json:"sessions"`
+}
+
+// revokeSessions logs out all sessions of a user, except the session making
+// the request so that users are not logged out by their own changes.
+func revokeSessions(ctx context.Context, tx *queries.Queries, userID uuid.UUID) (int, error) {
+	params := queries.DeleteUserSessionsParams{
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+	}
+	if currentUser := auth.GetCurrentUser(ctx); currentUser != nil && currentUser.SessionID != nil {
+		params.ExceptID = uuid.NullUUID{UUID: *currentUser.SessionID, Valid: true}
+	}
+
+	count, err := tx.DeleteUserSessions(ctx, params)
+	return int(count), err
+}
+
+// FindSession returns an unexpired session, or nil if it does not exist.
+func (s *User) FindSession(ctx context.Context, id uuid.UUID) (*models.UserSession, error) {
+	session, err := s.queries.FindUserSession(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ret := converter.UserSessionToModel(session)
+	return &ret, nil
+}
+
+func (s *User) CreateSession(ctx context.Context, session models.UserSession) error {
+	return s.queries.CreateUserSession(ctx, queries.CreateUserSessionParams{
+		ID:        session.ID,
+		UserID:    session.UserID,
+		Data:      session.Data,
+		UserAgent: session.UserAgent,
+		IpAddress: session.IPAddress,
+		ExpiresAt: session.Expires,
+	})
+}
+
+// UpdateSession stores the state of an existing session and marks it as
+// used. A session that has been revoked is not recreated.
+func (s *User) UpdateSession(ctx context.Context, session models.UserSession) error {
+	_, err := s.queries.UpdateUserSession(ctx, queries.UpdateUserSessionParams{
+		ID:        session.ID,
+		UserID:    session.UserID,
+		Data:      session.Data,
+		UserAgent: session.UserAgent,
+		IpAddress: session.IPAddress,
+		ExpiresAt: session.Expires,
+	})
+	return err
+}
+
+func (s *User) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	return s.queries.DeleteUserSession(ctx, id)
+}
+
+// GetSessions returns the unexpired sessions of a user, most recently used
+// first.
+func (s *User) GetSessions(ctx context.Context, userID uuid.UUID) ([]models.UserSession, error) {
+	sessions, err := s.queries.GetUserSessions(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return converter.UserSessionsToModels(sessions), nil
+}
+
+// RevokeSession logs out a session of the current user.
+func (s *User) RevokeSession(ctx context.Context, id uuid.UUID) error {
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return auth.ErrUnauthorized
+	}
+
+	return s.withTxn(func(tx *queries.Queries) error {
+		session, err := tx.FindUserSession(ctx, id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSessionNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !session.UserID.Valid || session.UserID.UUID != currentUser.ID {
+			return ErrSessionNotFound
+		}
+
+		return tx.DeleteUserSession(ctx, id)
+	})
+}
+
+// RevokeOtherSessions logs out all sessions of the current user, except the
+// one making the request. Returns the number of sessions logged out.
+func (s *User) RevokeOtherSessions(ctx context.Context) (int, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return 0, auth.ErrUnauthorized
+	}
+
+	return revokeSessions(ctx, s.queries, currentUser.ID)
+}
+
+// RevokeUserSessions logs out all sessions of a user. Returns the number of
+// sessions logged out.
+func (s *User) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := s.withTxn(func(tx *queries.Queries) error {
+		if _, err := tx.FindUser(ctx, userID); err != nil {
+			return err
+		}
+
+		var err error
+		count, err = revokeSessions(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionUSERREVOKESESSIONS,
+			TargetID:   userID,
+			TargetType: mod_audit.TargetUser,
+			Data:       revokeSessionsAuditData{Sessions: count},
+		})
+	})
+
+	return count, err
+}
+
+// DeleteExpiredSessions removes sessions that can no longer be used.
+func (s *User) DeleteExpiredSessions(ctx context.Context) error {
+	return s.queries.DeleteExpiredUserSessions(ctx)
+}
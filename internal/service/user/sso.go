@@ -0,0 +1,246 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/oidc"
+	"github.com/stashapp/stash-box/internal/queries"
+)
+
+var (
+	ErrLocalRegistrationDisabled = errors.New("registration is only available through single sign-on")
+	ErrSSOMissingEmail           = errors.New("single sign-on account has no email address")
+	ErrSSOEmailNotVerified       = errors.New("single sign-on email address is not verified")
+	ErrSSOUsernameTaken          = errors.New("username of single sign-on account is already used")
+	ErrSSOLinkRequiresLogin      = errors.New("log in to link the single sign-on account to the existing account with its email address")
+	ErrSSOLinkProtected          = errors.New("accounts with two-factor authentication or staff roles can't be linked to single sign-on")
+)
+
+// mappedRoles returns the roles granted by the role mappings for the roles
+// claim, and all roles managed by the role mappings.
+func mappedRoles(mappings []config.OIDCRoleMapping, claimed []string) (granted, managed []models.RoleEnum) {
+	for _, mapping := range mappings {
+		matched := slices.Contains(claimed, mapping.Value)
+		for _, r := range mapping.Roles {
+			role := models.RoleEnum(strings.ToUpper(r))
+			if !role.IsValid() {
+				continue
+			}
+			if !slices.Contains(managed, role) {
+				managed = append(managed, role)
+			}
+			if matched && !slices.Contains(granted, role) {
+				granted = append(granted, role)
+			}
+		}
+	}
+	return granted, managed
+}
+
+// syncRoles replaces the roles managed by the role mappings with the roles
+// granted for the roles claim, keeping all other roles.
+func syncRoles(current []models.RoleEnum, granted, managed []models.RoleEnum) []models.RoleEnum {
+	ret := []models.RoleEnum{}
+	for _, role := range current {
+		if !slices.Contains(managed, role) {
+			ret = append(ret, role)
+		}
+	}
+	for _, role := range granted {
+		if !slices.Contains(ret, role) {
+			ret = append(ret, role)
+		}
+	}
+	return ret
+}
+
+// ssoUserName returns the name for a user created with single sign-on,
+// falling back to the local part of their email address.
+func ssoUserName(claims oidc.Claims) string {
+	name := strings.TrimSpace(claims.Username)
+	if name == "" || name == claims.Email {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+	return name
+}
+
+// LoginSSO returns the id of the user signing in with a single sign-on
+// account. On the first login, the account is linked to the user with the
+// same verified email address if that user started the login from their
+// session, or a new user is created for it. The roles of the user are
+// updated according to the role mappings on each login.
+func (s *User) LoginSSO(ctx context.Context, claims oidc.Claims, sessionUserID *uuid.UUID) (uuid.UUID, error) {
+	var mappings []config.OIDCRoleMapping
+	if c := config.GetOIDCConfig(); c != nil {
+		mappings = c.RoleMappings
+	}
+	granted, managed := mappedRoles(mappings, claims.Roles)
+
+	var userID uuid.UUID
+	err := s.withTxn(func(tx *queries.Queries) error {
+		identity, err := tx.FindUserIdentity(ctx, queries.FindUserIdentityParams{
+			Issuer:  claims.Issuer,
+			Subject: claims.Subject,
+		})
+		if err == nil {
+			userID = identity.UserID
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		} else {
+			userID, err = linkSSOUser(ctx, tx, claims, sessionUserID, granted, managed)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(managed) == 0 {
+			return nil
+		}
+
+		user, err := tx.FindUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		before, err := getUserAuditState(ctx, tx, user)
+		if err != nil {
+			return err
+		}
+
+		var current []models.RoleEnum
+		for _, role := range before.Roles {
+			current = append(current, models.RoleEnum(role))
+		}
+		roles := syncRoles(current, granted, managed)
+
+		after := *before
+		after.Roles = nil
+		for _, role := range roles {
+			after.Roles = append(after.Roles, role.String())
+		}
+		if sameRoles(before.Roles, after.Roles) {
+			return nil
+		}
+
+		if err := updateRoles(ctx, tx, userID, roles); err != nil {
+			return err
+		}
+		return recordUserAudit(ctx, tx, queries.ModAuditActionUSERUPDATE, userID, before, &after)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	auth.CacheInvalidate(userID)
+	return userID, nil
+}
+
+// validateSSOLink returns an error unless the single sign-on account may be
+// linked to an existing user. Logins with the provider skip two-factor
+// authentication, so users with two-factor authentication or staff roles are
+// never linked. Other users have to be logged in, so that whoever controls
+// an address at the provider can't take over the account.
+func validateSSOLink(ctx context.Context, tx *queries.Queries, userID uuid.UUID, sessionUserID *uuid.UUID) error {
+	totp, err := findTOTP(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	if totp != nil && totp.EnabledAt != nil {
+		return ErrSSOLinkProtected
+	}
+
+	userRoles, err := tx.GetUserRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+	var roles []models.RoleEnum
+	for _, role := range userRoles {
+		roles = append(roles, models.RoleEnum(role))
+	}
+	custom, err := tx.GetUserCustomPermissions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(roles, func(role models.RoleEnum) bool {
+		return slices.Contains(staffRoles, role)
+	}) || TwoFactorRequired(roles, converter.StringsToPermissionEnums(custom)) {
+		return ErrSSOLinkProtected
+	}
+
+	if sessionUserID == nil || *sessionUserID != userID {
+		return ErrSSOLinkRequiresLogin
+	}
+	return nil
+}
+
+// linkSSOUser links a single sign-on account to the user with its email
+// address, creating the user if there is none.
+func linkSSOUser(ctx context.Context, tx *queries.Queries, claims oidc.Claims, sessionUserID *uuid.UUID, granted, managed []models.RoleEnum) (uuid.UUID, error) {
+	if claims.Email == "" {
+		return uuid.Nil, ErrSSOMissingEmail
+	}
+	// an unverified address could belong to anyone
+	if !claims.EmailVerified {
+		return uuid.Nil, ErrSSOEmailNotVerified
+	}
+
+	var userID uuid.UUID
+	user, err := tx.FindUserByEmail(ctx, claims.Email)
+	if err == nil {
+		if err := validateSSOLink(ctx, tx, user.ID, sessionUserID); err != nil {
+			return uuid.Nil, err
+		}
+		userID = user.ID
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, err
+	} else {
+		name := ssoUserName(claims)
+		if _, err := tx.FindUserByName(ctx, name); !errors.Is(err, pgx.ErrNoRows) {
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return uuid.Nil, ErrSSOUsernameTaken
+		}
+
+		// the user logs in with single sign-on, and can set a password
+		// through a password reset
+		password, err := oidc.RandomString()
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		createdUser, err := createUser(ctx, tx, models.UserCreateInput{
+			Name:     name,
+			Email:    claims.Email,
+			Password: password,
+			Roles:    syncRoles(getDefaultUserRoles(), granted, managed),
+		}, true)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		after, err := getUserAuditState(ctx, tx, *createdUser)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if err := recordUserAudit(ctx, tx, queries.ModAuditActionUSERCREATE, createdUser.ID, nil, after); err != nil {
+			return uuid.Nil, err
+		}
+		userID = createdUser.ID
+	}
+
+	return userID, tx.CreateUserIdentity(ctx, queries.CreateUserIdentityParams{
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		UserID:  userID,
+	})
+}
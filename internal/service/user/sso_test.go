@@ -0,0 +1,51 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/oidc"
+)
+
+func TestMappedRoles(t *testing.T) {
+	mappings := []config.OIDCRoleMapping{
+		{Value: "editors", Roles: []string{"EDIT", "vote"}},
+		{Value: "moderators", Roles: []string{"MODERATE", "EDIT"}},
+		{Value: "invalid", Roles: []string{"NOT_A_ROLE"}},
+	}
+
+	granted, managed := mappedRoles(mappings, []string{"editors", "other"})
+	assert.Equal(t, []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumVote}, granted)
+	assert.Equal(t, []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumVote, models.RoleEnumModerate}, managed)
+
+	granted, _ = mappedRoles(mappings, nil)
+	assert.Empty(t, granted)
+
+	granted, managed = mappedRoles(nil, []string{"editors"})
+	assert.Empty(t, granted)
+	assert.Empty(t, managed)
+}
+
+func TestSyncRoles(t *testing.T) {
+	managed := []models.RoleEnum{models.RoleEnumEdit, models.RoleEnumModerate}
+
+	// unmanaged roles are kept, managed roles follow the claim
+	assert.ElementsMatch(t,
+		[]models.RoleEnum{models.RoleEnumRead, models.RoleEnumVote, models.RoleEnumEdit},
+		syncRoles([]models.RoleEnum{models.RoleEnumRead, models.RoleEnumVote, models.RoleEnumModerate}, []models.RoleEnum{models.RoleEnumEdit}, managed),
+	)
+	assert.ElementsMatch(t,
+		[]models.RoleEnum{models.RoleEnumRead},
+		syncRoles([]models.RoleEnum{models.RoleEnumRead, models.RoleEnumEdit}, nil, managed),
+	)
+}
+
+func TestSSOUserName(t *testing.T) {
+	assert.Equal(t, "user", ssoUserName(oidc.Claims{Username: "user", Email: "other@example.com"}))
+	assert.Equal(t, "other", ssoUserName(oidc.Claims{Email: "other@example.com"}))
+	// the username must not match the email address
+	assert.Equal(t, "other", ssoUserName(oidc.Claims{Username: "other@example.com", Email: "other@example.com"}))
+}
@@ -0,0 +1,269 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/converter"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/errutil"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+)
+
+var (
+	ErrSuspendSelf           = errors.New("cannot suspend yourself")
+	ErrSuspendAdmin          = errors.New("admins cannot be suspended")
+	ErrEmptySuspensionReason = errors.New("suspension reason is required")
+	ErrSuspensionExpired     = errors.New("suspension must expire in the future")
+	ErrNotSuspended          = errors.New("user is not suspended")
+)
+
+const expiredSuspensionReason = "suspension expired"
+
+type suspensionAuditState struct {
+	Reason      string     `json:"reason"`
+	Restriction string     `json:"restriction"`
+	Expires     *time.Time `json:"expires"`
+}
+
+func newSuspensionAuditState(s *queries.UserSuspension) *suspensionAuditState {
+	if s == nil {
+		return nil
+	}
+	return &suspensionAuditState{
+		Reason:      s.Reason,
+		Restriction: s.Restriction,
+		Expires:     s.ExpiresAt,
+	}
+}
+
+func findActiveSuspension(ctx context.Context, tx *queries.Queries, userID uuid.UUID) (*queries.UserSuspension, error) {
+	suspension, err := tx.FindActiveUserSuspension(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &suspension, nil
+}
+
+// FindActiveSuspension returns the suspension currently restricting a user,
+// or nil if the user is not suspended.
+func (s *User) FindActiveSuspension(ctx context.Context, userID uuid.UUID) (*models.UserSuspension, error) {
+	suspension, err := findActiveSuspension(ctx, s.queries, userID)
+	if err != nil || suspension == nil {
+		return nil, err
+	}
+	return converter.UserSuspensionToModelPtr(*suspension), nil
+}
+
+func (s *User) FindSuspensionByID(ctx context.Context, id uuid.UUID) (*models.UserSuspension, error) {
+	suspension, err := s.queries.FindUserSuspension(ctx, id)
+	if err != nil {
+		return nil, errutil.IgnoreNotFound(err)
+	}
+	return converter.UserSuspensionToModelPtr(suspension), nil
+}
+
+// GetSuspensions returns the suspensions of a user, most recent first
+func (s *User) GetSuspensions(ctx context.Context, userID uuid.UUID) ([]models.UserSuspension, error) {
+	suspensions, err := s.queries.GetUserSuspensions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.UserSuspensionsToModels(suspensions), nil
+}
+
+// suspendUser replaces the active suspension of a user with a new one,
+// notifying the user and recording it in the audit log.
+func suspendUser(ctx context.Context, tx *queries.Queries, userID uuid.UUID, reason string, restriction models.SuspensionRestrictionEnum, expires *time.Time, createdBy uuid.NullUUID) (queries.UserSuspension, error) {
+	if _, err := tx.FindUser(ctx, userID); err != nil {
+		return queries.UserSuspension{}, err
+	}
+
+	roles, err := tx.GetUserRoles(ctx, userID)
+	if err != nil {
+		return queries.UserSuspension{}, err
+	}
+	if slices.Contains(roles, string(models.RoleEnumAdmin)) {
+		return queries.UserSuspension{}, ErrSuspendAdmin
+	}
+
+	previous, err := findActiveSuspension(ctx, tx, userID)
+	if err != nil {
+		return queries.UserSuspension{}, err
+	}
+	if _, err := tx.LiftUserSuspensions(ctx, queries.LiftUserSuspensionsParams{
+		LiftedBy: createdBy,
+		UserID:   userID,
+	}); err != nil {
+		return queries.UserSuspension{}, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return queries.UserSuspension{}, err
+	}
+	suspension, err := tx.CreateUserSuspension(ctx, queries.CreateUserSuspensionParams{
+		ID:          id,
+		UserID:      userID,
+		Reason:      reason,
+		CreatedBy:   createdBy,
+		ExpiresAt:   expires,
+		Restriction: restriction.String(),
+	})
+	if err != nil {
+		return queries.UserSuspension{}, err
+	}
+
+	if err := tx.CreateUserSuspensionNotification(ctx, queries.CreateUserSuspensionNotificationParams{
+		UserID:       userID,
+		Type:         queries.NotificationTypeUSERSUSPENDED,
+		SuspensionID: suspension.ID,
+	}); err != nil {
+		return queries.UserSuspension{}, err
+	}
+
+	err = mod_audit.Record(ctx, tx, mod_audit.Entry{
+		Action:     queries.ModAuditActionUSERSUSPEND,
+		TargetID:   userID,
+		TargetType: mod_audit.TargetUser,
+		Before:     newSuspensionAuditState(previous),
+		After:      newSuspensionAuditState(&suspension),
+		Reason:     &reason,
+	})
+	return suspension, err
+}
+
+// Suspend restricts a user to read-only access, or withholds voting or
+// commenting, until the suspension expires or is lifted. An active suspension
+// of the user is replaced.
+func (s *User) Suspend(ctx context.Context, input models.UserSuspendInput) (*models.UserSuspension, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+	if currentUser != nil && currentUser.ID == input.UserID {
+		return nil, ErrSuspendSelf
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, ErrEmptySuspensionReason
+	}
+	if input.Expires != nil && !input.Expires.After(time.Now()) {
+		return nil, ErrSuspensionExpired
+	}
+	// suspensions restrict to read-only access unless specified otherwise
+	restriction := input.Restriction
+	if restriction == "" {
+		restriction = models.SuspensionRestrictionEnumReadOnly
+	}
+
+	var createdBy uuid.NullUUID
+	if currentUser != nil {
+		createdBy = uuid.NullUUID{UUID: currentUser.ID, Valid: true}
+	}
+
+	var suspension queries.UserSuspension
+	err := s.withTxn(func(tx *queries.Queries) error {
+		var err error
+		suspension, err = suspendUser(ctx, tx, input.UserID, reason, restriction, input.Expires, createdBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auth.CacheInvalidate(input.UserID)
+	return converter.UserSuspensionToModelPtr(suspension), nil
+}
+
+// LiftSuspension restores the access of a suspended user
+func (s *User) LiftSuspension(ctx context.Context, input models.UserLiftSuspensionInput) error {
+	var liftedBy uuid.NullUUID
+	if currentUser := auth.GetCurrentUser(ctx); currentUser != nil {
+		liftedBy = uuid.NullUUID{UUID: currentUser.ID, Valid: true}
+	}
+
+	err := s.withTxn(func(tx *queries.Queries) error {
+		active, err := findActiveSuspension(ctx, tx, input.UserID)
+		if err != nil {
+			return err
+		}
+		if active == nil {
+			return ErrNotSuspended
+		}
+
+		if _, err := tx.LiftUserSuspensions(ctx, queries.LiftUserSuspensionsParams{
+			LiftedBy: liftedBy,
+			UserID:   input.UserID,
+		}); err != nil {
+			return err
+		}
+
+		return liftedSuspension(ctx, tx, *active, input.Reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	auth.CacheInvalidate(input.UserID)
+	return nil
+}
+
+// LiftExpiredSuspensions marks suspensions past their expiry as lifted and
+// notifies the affected users.
+func (s *User) LiftExpiredSuspensions(ctx context.Context) error {
+	var lifted []queries.UserSuspension
+	err := s.withTxn(func(tx *queries.Queries) error {
+		var err error
+		lifted, err = tx.LiftExpiredUserSuspensions(ctx)
+		if err != nil {
+			return err
+		}
+
+		reason := expiredSuspensionReason
+		for _, suspension := range lifted {
+			if err := liftedSuspension(ctx, tx, suspension, &reason); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, suspension := range lifted {
+		auth.CacheInvalidate(suspension.UserID)
+	}
+	return nil
+}
+
+// liftedSuspension notifies the user that a suspension has been lifted and
+// records it in the audit log.
+func liftedSuspension(ctx context.Context, tx *queries.Queries, suspension queries.UserSuspension, reason *string) error {
+	if err := tx.CreateUserSuspensionNotification(ctx, queries.CreateUserSuspensionNotificationParams{
+		UserID:       suspension.UserID,
+		Type:         queries.NotificationTypeUSERSUSPENSIONLIFTED,
+		SuspensionID: suspension.ID,
+	}); err != nil {
+		return err
+	}
+
+	return mod_audit.Record(ctx, tx, mod_audit.Entry{
+		Action:     queries.ModAuditActionUSERLIFTSUSPENSION,
+		TargetID:   suspension.UserID,
+		TargetType: mod_audit.TargetUser,
+		Before:     newSuspensionAuditState(&suspension),
+		After:      nil,
+		Reason:     reason,
+	})
+}
@@ -0,0 +1,102 @@
+package user
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpDigits = 6
+	totpPeriod = 30
+	// Number of periods before and after the current one in which a code is
+	// still accepted, to allow for clock drift
+	totpSkew = 1
+
+	recoveryCodeCount = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func generateTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// totpURI returns the otpauth URI authenticator apps import the secret from,
+// usually shown as a QR code.
+func totpURI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func totpCode(key []byte, step int64) string {
+	mac := hmac.New(sha1.New, key)
+	_ = binary.Write(mac, binary.BigEndian, step)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// validateTOTP checks a code against the secret at time t, returning the
+// time step the code belongs to.
+func validateTOTP(secret, code string, t time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := t.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// generateRecoveryCodes returns single use codes in the form xxxxx-xxxxx
+func generateRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(b)
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode normalizes and hashes a recovery code for storage. The
+// codes are random, so a plain hash is sufficient.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 appendix B test secret for SHA1
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 test vectors, truncated to six digits
+	for unix, expected := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		assert.Equal(t, expected, totpCode([]byte("12345678901234567890"), unix/totpPeriod), unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	step, ok := validateTOTP(rfcSecret, "050471", now)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1111111111/totpPeriod), step)
+
+	// codes of the neighboring periods are accepted
+	_, ok = validateTOTP(rfcSecret, "050471", now.Add(totpPeriod*time.Second))
+	assert.True(t, ok)
+	_, ok = validateTOTP(rfcSecret, "050471", now.Add(-totpPeriod*time.Second))
+	assert.True(t, ok)
+
+	_, ok = validateTOTP(rfcSecret, "050471", now.Add(3*totpPeriod*time.Second))
+	assert.False(t, ok)
+	_, ok = validateTOTP(rfcSecret, "050 471", now)
+	assert.True(t, ok)
+	_, ok = validateTOTP(rfcSecret, "05047", now)
+	assert.False(t, ok)
+	_, ok = validateTOTP("not base32!", "050471", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri, err := url.Parse(totpURI("Stash-Box", "some user", "ABC"))
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Stash-Box:some user", uri.Path)
+	assert.Equal(t, "ABC", uri.Query().Get("secret"))
+	assert.Equal(t, "Stash-Box", uri.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := generateRecoveryCodes()
+	assert.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		assert.Regexp(t, `^[0-9a-f]{5}-[0-9a-f]{5}$`, code)
+		seen[hashRecoveryCode(code)] = true
+	}
+	assert.Len(t, seen, recoveryCodeCount)
+
+	// formatting is not significant
+	assert.Equal(t, hashRecoveryCode(codes[0]), hashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))+" "))
+}
@@ -0,0 +1,377 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/stashapp/stash-box/internal/auth"
+	"github.com/stashapp/stash-box/internal/config"
+	"github.com/stashapp/stash-box/internal/models"
+	"github.com/stashapp/stash-box/internal/queries"
+	"github.com/stashapp/stash-box/internal/service/mod_audit"
+)
+
+var (
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled  = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotSetUp    = errors.New("two-factor authentication has not been set up")
+	ErrTwoFactorRequired    = errors.New("two-factor authentication is required for your roles")
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor authentication code")
+)
+
+// TwoFactorRequired returns whether the built-in roles or the custom role
+// permissions of a user require two-factor authentication. It is required
+// when the user holds any permission, other than read access, of one of the
+// required roles, so that custom roles can't sidestep the requirement.
+func TwoFactorRequired(roles []models.RoleEnum, custom []models.PermissionEnum) bool {
+	held := make(map[models.PermissionEnum]bool)
+	for _, role := range roles {
+		for _, permission := range role.Permissions() {
+			held[permission] = true
+		}
+	}
+	for _, permission := range custom {
+		held[permission] = true
+	}
+
+	for _, required := range config.GetTwoFactorRequiredRoles() {
+		for _, permission := range models.RoleEnum(required).Permissions() {
+			if permission != models.PermissionEnumRead && held[permission] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// twoFactorRequired returns whether the user is required to use two-factor
+// authentication
+func (s *User) twoFactorRequired(ctx context.Context, userID uuid.UUID) (bool, error) {
+	roles, err := s.GetRoles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	custom, err := s.GetCustomPermissions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return TwoFactorRequired(roles, custom), nil
+}
+
+func findTOTP(ctx context.Context, tx *queries.Queries, userID uuid.UUID) (*queries.UserTotp, error) {
+	totp, err := tx.FindUserTOTP(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &totp, nil
+}
+
+func findEnabledTOTP(ctx context.Context, tx *queries.Queries, userID uuid.UUID) (*queries.UserTotp, error) {
+	totp, err := findTOTP(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if totp == nil || totp.EnabledAt == nil {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	return totp, nil
+}
+
+// useTOTPCode validates a TOTP code, making sure it can not be used again
+func useTOTPCode(ctx context.Context, tx *queries.Queries, totp queries.UserTotp, code string) error {
+	step, ok := validateTOTP(totp.Secret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	n, err := tx.UseUserTOTPStep(ctx, queries.UseUserTOTPStepParams{
+		Step:   step,
+		UserID: totp.UserID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// useTwoFactorCode validates either a TOTP code or an unused recovery code
+func useTwoFactorCode(ctx context.Context, tx *queries.Queries, totp queries.UserTotp, code string) error {
+	if err := useTOTPCode(ctx, tx, totp, code); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		return err
+	}
+
+	n, err := tx.UseUserRecoveryCode(ctx, queries.UseUserRecoveryCodeParams{
+		UserID:   totp.UserID,
+		CodeHash: hashRecoveryCode(code),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *queries.Queries, userID uuid.UUID) ([]string, error) {
+	codes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = hashRecoveryCode(code)
+	}
+
+	if err := tx.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := tx.CreateUserRecoveryCodes(ctx, queries.CreateUserRecoveryCodesParams{
+		UserID:     userID,
+		CodeHashes: hashes,
+	}); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// IsTwoFactorEnabled returns whether the user has confirmed a TOTP secret
+func (s *User) IsTwoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	totp, err := findTOTP(ctx, s.queries, userID)
+	if err != nil {
+		return false, err
+	}
+	return totp != nil && totp.EnabledAt != nil, nil
+}
+
+func (s *User) GetTwoFactorStatus(ctx context.Context, userID uuid.UUID) (*models.TwoFactorStatus, error) {
+	enabled, err := s.IsTwoFactorEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.twoFactorRequired(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.TwoFactorStatus{
+		Enabled:  enabled,
+		Required: required,
+	}
+
+	if enabled {
+		count, err := s.queries.CountUserRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		ret.RecoveryCodesRemaining = int(count)
+	}
+
+	return ret, nil
+}
+
+// SetupTwoFactor generates a new TOTP secret for the current user. It is
+// only used once confirmed with EnableTwoFactor.
+func (s *User) SetupTwoFactor(ctx context.Context) (*models.TwoFactorSetup, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+
+	var ret *models.TwoFactorSetup
+	err := s.withTxn(func(tx *queries.Queries) error {
+		existing, err := findTOTP(ctx, tx, currentUser.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.EnabledAt != nil {
+			return ErrTwoFactorEnabled
+		}
+
+		secret, err := generateTOTPSecret()
+		if err != nil {
+			return err
+		}
+
+		if err := tx.CreateUserTOTP(ctx, queries.CreateUserTOTPParams{
+			UserID: currentUser.ID,
+			Secret: secret,
+		}); err != nil {
+			return err
+		}
+
+		ret = &models.TwoFactorSetup{
+			Secret: secret,
+			URI:    totpURI(config.GetTitle(), currentUser.Name, secret),
+		}
+		return nil
+	})
+
+	return ret, err
+}
+
+// EnableTwoFactor confirms the secret from SetupTwoFactor with a code,
+// returning the recovery codes of the current user.
+func (s *User) EnableTwoFactor(ctx context.Context, code string) ([]string, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+
+	var codes []string
+	err := s.withTxn(func(tx *queries.Queries) error {
+		totp, err := findTOTP(ctx, tx, currentUser.ID)
+		if err != nil {
+			return err
+		}
+		if totp == nil {
+			return ErrTwoFactorNotSetUp
+		}
+		if totp.EnabledAt != nil {
+			return ErrTwoFactorEnabled
+		}
+
+		if err := useTOTPCode(ctx, tx, *totp, code); err != nil {
+			return err
+		}
+		if err := tx.EnableUserTOTP(ctx, currentUser.ID); err != nil {
+			return err
+		}
+
+		codes, err = replaceRecoveryCodes(ctx, tx, currentUser.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auth.CacheInvalidate(currentUser.ID)
+	return codes, nil
+}
+
+// DisableTwoFactor removes two-factor authentication of the current user,
+// unless it is required for their roles.
+func (s *User) DisableTwoFactor(ctx context.Context, code string) error {
+	currentUser := auth.GetCurrentUser(ctx)
+
+	required, err := s.twoFactorRequired(ctx, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrTwoFactorRequired
+	}
+
+	err = s.withTxn(func(tx *queries.Queries) error {
+		totp, err := findEnabledTOTP(ctx, tx, currentUser.ID)
+		if err != nil {
+			return err
+		}
+		if err := useTwoFactorCode(ctx, tx, *totp, code); err != nil {
+			return err
+		}
+		return removeTwoFactor(ctx, tx, currentUser.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	auth.CacheInvalidate(currentUser.ID)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user
+func (s *User) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	currentUser := auth.GetCurrentUser(ctx)
+
+	var codes []string
+	err := s.withTxn(func(tx *queries.Queries) error {
+		totp, err := findEnabledTOTP(ctx, tx, currentUser.ID)
+		if err != nil {
+			return err
+		}
+		if err := useTwoFactorCode(ctx, tx, *totp, code); err != nil {
+			return err
+		}
+
+		codes, err = replaceRecoveryCodes(ctx, tx, currentUser.ID)
+		return err
+	})
+
+	return codes, err
+}
+
+// ResetTwoFactor removes two-factor authentication from a user who has lost
+// both their authenticator and recovery codes.
+func (s *User) ResetTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	err := s.withTxn(func(tx *queries.Queries) error {
+		if _, err := findEnabledTOTP(ctx, tx, userID); err != nil {
+			return err
+		}
+		if err := removeTwoFactor(ctx, tx, userID); err != nil {
+			return err
+		}
+		if _, err := revokeSessions(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		return mod_audit.Record(ctx, tx, mod_audit.Entry{
+			Action:     queries.ModAuditActionUSERTWOFACTORRESET,
+			TargetID:   userID,
+			TargetType: mod_audit.TargetUser,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	auth.CacheInvalidate(userID)
+	return nil
+}
+
+func removeTwoFactor(ctx context.Context, tx *queries.Queries, userID uuid.UUID) error {
+	if err := tx.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+	return tx.DeleteUserTOTP(ctx, userID)
+}
+
+// VerifyTwoFactor checks the second login step of a user with two-factor
+// authentication, accepting a TOTP code or an unused recovery code.
+// Wrong codes count as failed logins of the account, which are reset once a
+// code is accepted.
+func (s *User) VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := s.queries.FindUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	counters := lockoutCounters(ctx, models.LockoutScopeEnumLoginAccount, models.LockoutScopeEnumLoginIP, user.Name)
+	if err := checkLockouts(ctx, s.queries, counters); err != nil {
+		return err
+	}
+
+	err = s.withTxn(func(tx *queries.Queries) error {
+		totp, err := findEnabledTOTP(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := useTwoFactorCode(ctx, tx, *totp, code); err != nil {
+			return err
+		}
+		return clearLoginLockout(ctx, tx, user.Name)
+	})
+	if errors.Is(err, ErrInvalidTwoFactorCode) {
+		if err := s.recordFailedLogin(ctx, counters, &user); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
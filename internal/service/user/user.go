@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/gofrs/uuid"
 	"github.com/stashapp/stash-box/internal/config"
@@ -87,12 +88,40 @@ func createUser(ctx context.Context, tx *queries.Queries, input models.UserCreat
 		return nil, err
 	}
 
+	if input.CustomRoleIds != nil {
+		if err := updateCustomRoles(ctx, tx, id, input.CustomRoleIds); err != nil {
+			return nil, err
+		}
+	}
+
 	if defaultNotifications {
 		err = createNotificationSubscriptions(ctx, tx, id, models.GetDefaultNotificationSubscriptions())
 	}
 	return &user, err
 }
 
+// deleteUser deletes a user. Their edits, comments and votes are kept
+// without the user, as are fingerprints no other user submitted.
+func deleteUser(ctx context.Context, tx *queries.Queries, userID uuid.UUID) error {
+	// Retain the user's unique fingerprints by reassigning them to the sentinel user
+	deletedUser, err := tx.FindUserByName(ctx, deletedUserName)
+	if err != nil {
+		return err
+	}
+	if err := tx.ReassignUniqueSceneFingerprints(ctx, queries.ReassignUniqueSceneFingerprintsParams{
+		TargetUserID: deletedUser.ID,
+		SourceUserID: userID,
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return tx.CancelUserEdits(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+}
+
 func changePassword(ctx context.Context, tx *queries.Queries, userID uuid.UUID, currentPassword string, newPassword string) error {
 	user, err := tx.FindUser(ctx, userID)
 	if err != nil {
@@ -113,10 +142,23 @@ func changePassword(ctx context.Context, tx *queries.Queries, userID uuid.UUID,
 		return err
 	}
 
-	return tx.UpdateUserPassword(ctx, queries.UpdateUserPasswordParams{
+	if err := tx.UpdateUserPassword(ctx, queries.UpdateUserPasswordParams{
 		ID:           user.ID,
 		PasswordHash: hash,
-	})
+	}); err != nil {
+		return err
+	}
+
+	_, err = revokeSessions(ctx, tx, user.ID)
+	return err
+}
+
+func sameRoles(a, b []string) bool {
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
 }
 
 func getDefaultUserRoles() []models.RoleEnum {
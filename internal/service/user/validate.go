@@ -86,7 +86,7 @@ func validateUpdate(ctx context.Context, input models.UserUpdateInput, current q
 	}
 
 	if input.Name != nil && *input.Name != current.Name {
-		if err := auth.ValidateAdmin(ctx); err != nil {
+		if err := auth.ValidateManageUsers(ctx); err != nil {
 			return err
 		}
 	}
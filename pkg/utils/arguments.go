@@ -86,3 +86,8 @@ func Arguments(ctx context.Context) (ret ArgumentsQuery) {
 	ret.args = fc.Field.ArgumentMap(oc.Variables)
 	return
 }
+
+// NewArguments returns a query on the given argument values.
+func NewArguments(args map[string]interface{}) ArgumentsQuery {
+	return ArgumentsQuery{args: args}
+}